@@ -0,0 +1,328 @@
+package vcloud
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+const (
+	apiLogClientRequestIdHeader = "X-Vmware-Vcloud-Client-Request-Id"
+	apiLogVcdRequestIdHeader    = "X-Vmware-Vcloud-Request-Id"
+	apiLogRedacted              = "***"
+)
+
+// apiLogDefaultRedactedFields are the body fields (JSON keys, XML elements and attributes) that are always redacted
+var apiLogDefaultRedactedFields = []string{
+	"password", "adminPassword", "domainUserPassword", "joinDomainPassword", "bindPassword",
+	"secret", "clientSecret", "token", "apiToken", "refresh_token", "access_token",
+	"privateKey", "passphrase",
+}
+
+// apiLogDefaultRedactedHeaders are the HTTP headers that are always redacted
+var apiLogDefaultRedactedHeaders = []string{
+	"Authorization", govcd.AuthorizationHeader, govcd.BearerTokenHeader, "Cookie", "Set-Cookie",
+}
+
+// apiLogConfig holds the settings of the 'api_logging' block of the provider
+type apiLogConfig struct {
+	fileName       string
+	includeBodies  bool
+	maxBodySize    int
+	redactedFields []string
+	// redactedHeaders holds canonical header names
+	redactedHeaders map[string]bool
+}
+
+// String returns a representation of the settings, used to tell apart the cached connections
+func (c *apiLogConfig) String() string {
+	if c == nil {
+		return ""
+	}
+	return fmt.Sprintf("%+v", *c)
+}
+
+// apiLogEntry is a line of the API log
+type apiLogEntry struct {
+	Time            string            `json:"time"`
+	RequestId       string            `json:"request_id"`
+	VcdRequestId    string            `json:"vcd_request_id,omitempty"`
+	ResourceType    string            `json:"resource_type,omitempty"`
+	DataSource      bool              `json:"data_source,omitempty"`
+	Operation       string            `json:"operation,omitempty"`
+	ResourceId      string            `json:"resource_id,omitempty"`
+	Method          string            `json:"method"`
+	Url             string            `json:"url"`
+	Status          int               `json:"status,omitempty"`
+	DurationMs      int64             `json:"duration_ms"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	RequestBody     string            `json:"request_body,omitempty"`
+	ResponseBody    string            `json:"response_body,omitempty"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// apiLogTransport writes a JSON line for each HTTP request sent to VCD, with sensitive headers and body fields
+// redacted
+type apiLogTransport struct {
+	base     http.RoundTripper
+	config   *apiLogConfig
+	writer   *apiLogWriter
+	redactor *apiLogRedactor
+}
+
+// apiLogWriter serializes the writes of all the providers logging to the same file
+type apiLogWriter struct {
+	sync.Mutex
+	file *os.File
+}
+
+var (
+	apiLogWriters      = make(map[string]*apiLogWriter)
+	apiLogWritersMutex sync.Mutex
+)
+
+// enableApiLog wraps the HTTP transport of the client with the structured API log
+func enableApiLog(client *govcd.VCDClient, config *apiLogConfig) error {
+	writer, err := getApiLogWriter(config.fileName)
+	if err != nil {
+		return err
+	}
+	client.Client.Http.Transport = &apiLogTransport{
+		base:     client.Client.Http.Transport,
+		config:   config,
+		writer:   writer,
+		redactor: newApiLogRedactor(config.redactedFields),
+	}
+	return nil
+}
+
+func getApiLogWriter(fileName string) (*apiLogWriter, error) {
+	apiLogWritersMutex.Lock()
+	defer apiLogWritersMutex.Unlock()
+	fileName = filepath.Clean(fileName)
+	if writer, ok := apiLogWriters[fileName]; ok {
+		return writer, nil
+	}
+	file, err := os.OpenFile(fileName, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("error opening API log file %s: %s", fileName, err)
+	}
+	writer := &apiLogWriter{file: file}
+	apiLogWriters[fileName] = writer
+	return writer, nil
+}
+
+func (w *apiLogWriter) write(entry apiLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	w.Lock()
+	defer w.Unlock()
+	_, _ = w.file.Write(append(line, '\n'))
+}
+
+func (t *apiLogTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	requestId := request.Header.Get(apiLogClientRequestIdHeader)
+	if requestId == "" {
+		request = request.Clone(request.Context())
+		requestId = govcd.VcloudRequestIdBuilderFunc()
+		request.Header.Set(apiLogClientRequestIdHeader, requestId)
+	}
+	entry := apiLogEntry{
+		RequestId:      requestId,
+		Method:         request.Method,
+		Url:            request.URL.String(),
+		RequestHeaders: t.headers(request.Header),
+	}
+	// The operation travels with the request, as go-vcloud-director and the resources may send the requests of an
+	// operation from different goroutines
+	if operation := requestCrudOperation(request); operation != nil {
+		entry.ResourceType = operation.resourceType
+		entry.Operation = operation.operation
+		entry.DataSource = operation.dataSource
+		// The address of the resource in the configuration is not known to providers. The ID is empty until a
+		// creation sets it
+		if operation.d != nil {
+			entry.ResourceId = operation.d.Id()
+		}
+	}
+	if t.config.includeBodies && request.GetBody != nil && isTextContent(request.Header.Get("Content-Type")) {
+		body, err := request.GetBody()
+		if err == nil {
+			contents, _ := io.ReadAll(body)
+			_ = body.Close()
+			entry.RequestBody = t.body(contents)
+		}
+	}
+
+	start := time.Now()
+	response, err := t.base.RoundTrip(request)
+	entry.Time = start.UTC().Format(time.RFC3339Nano)
+	entry.DurationMs = time.Since(start).Milliseconds()
+	if err != nil {
+		entry.Error = err.Error()
+		t.writer.write(entry)
+		return response, err
+	}
+
+	entry.Status = response.StatusCode
+	entry.VcdRequestId = response.Header.Get(apiLogVcdRequestIdHeader)
+	entry.ResponseHeaders = t.headers(response.Header)
+	if t.config.includeBodies && response.Body != nil && isTextContent(response.Header.Get("Content-Type")) {
+		contents, readErr := io.ReadAll(response.Body)
+		_ = response.Body.Close()
+		response.Body = io.NopCloser(bytes.NewReader(contents))
+		if readErr != nil {
+			entry.Error = readErr.Error()
+		}
+		entry.ResponseBody = t.body(contents)
+	}
+	t.writer.write(entry)
+	return response, nil
+}
+
+func (t *apiLogTransport) headers(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for name, values := range header {
+		if t.config.redactedHeaders[http.CanonicalHeaderKey(name)] {
+			result[name] = apiLogRedacted
+			continue
+		}
+		result[name] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+func (t *apiLogTransport) body(contents []byte) string {
+	text := t.redactor.redact(string(contents))
+	if t.config.maxBodySize > 0 && len(text) > t.config.maxBodySize {
+		text = text[:t.config.maxBodySize] + fmt.Sprintf("... [%d bytes truncated]", len(text)-t.config.maxBodySize)
+	}
+	return text
+}
+
+// isTextContent returns true for the content types whose body can be logged. Uploads of files are skipped
+func isTextContent(contentType string) bool {
+	contentType = strings.ToLower(contentType)
+	return strings.Contains(contentType, "xml") || strings.Contains(contentType, "json") || strings.HasPrefix(contentType, "text/")
+}
+
+// apiLogRedactor replaces the values of sensitive fields in XML and JSON bodies
+type apiLogRedactor struct {
+	jsonField    *regexp.Regexp
+	xmlElement   *regexp.Regexp
+	xmlAttribute *regexp.Regexp
+}
+
+func newApiLogRedactor(fields []string) *apiLogRedactor {
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	names := "(?i:" + strings.Join(quoted, "|") + ")"
+	return &apiLogRedactor{
+		jsonField:    regexp.MustCompile(`("` + names + `"\s*:\s*)"(?:[^"\\]|\\.)*"`),
+		xmlElement:   regexp.MustCompile(`(<(?:[\w-]+:)?` + names + `(?:\s[^>]*)?>)[^<]*(</)`),
+		xmlAttribute: regexp.MustCompile(`(\s(?:[\w-]+:)?` + names + `=")[^"]*(")`),
+	}
+}
+
+func (r *apiLogRedactor) redact(text string) string {
+	text = r.jsonField.ReplaceAllString(text, `${1}"`+apiLogRedacted+`"`)
+	text = r.xmlElement.ReplaceAllString(text, `${1}`+apiLogRedacted+`${2}`)
+	return r.xmlAttribute.ReplaceAllString(text, `${1}`+apiLogRedacted+`${2}`)
+}
+
+// apiLogSchema defines the 'api_logging' block of the provider
+func apiLogSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Writes every API request to a file in JSON lines format, with sensitive data redacted",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"file": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "The file where the API log is appended",
+				},
+				"include_bodies": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "Whether to log the XML and JSON bodies of requests and responses (defaults to true)",
+				},
+				"max_body_size": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      16384,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Maximum number of characters logged for each body. 0 means no limit (defaults to 16384)",
+				},
+				"redact_fields": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Additional JSON keys and XML elements or attributes whose values are redacted",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"redact_headers": {
+					Type:        schema.TypeSet,
+					Optional:    true,
+					Description: "Additional HTTP headers whose values are redacted",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+			},
+		},
+	}
+}
+
+// getApiLogConfig converts the 'api_logging' block of the provider. It returns nil when the block is not set
+func getApiLogConfig(d *schema.ResourceData) *apiLogConfig {
+	blocks := d.Get("api_logging").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	fields := append([]string{}, apiLogDefaultRedactedFields...)
+	for _, field := range block["redact_fields"].(*schema.Set).List() {
+		fields = append(fields, field.(string))
+	}
+	sort.Strings(fields)
+
+	headers := make(map[string]bool)
+	for _, header := range apiLogDefaultRedactedHeaders {
+		headers[http.CanonicalHeaderKey(header)] = true
+	}
+	for _, header := range block["redact_headers"].(*schema.Set).List() {
+		headers[http.CanonicalHeaderKey(header.(string))] = true
+	}
+
+	return &apiLogConfig{
+		fileName:        block["file"].(string),
+		includeBodies:   block["include_bodies"].(bool),
+		maxBodySize:     block["max_body_size"].(int),
+		redactedFields:  fields,
+		redactedHeaders: headers,
+	}
+}
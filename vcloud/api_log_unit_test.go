@@ -0,0 +1,133 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestMockVcdApiLog checks that every request is logged as a JSON line, with credentials redacted and the
+// Terraform operation that triggered it
+func TestMockVcdApiLog(t *testing.T) {
+	mock := newMockVcd(defaultMockVcdFixtures())
+	t.Cleanup(mock.Close)
+
+	logFile := filepath.Join(t.TempDir(), "api.log")
+	d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{
+		"api_logging": []interface{}{
+			map[string]interface{}{
+				"file":           logFile,
+				"redact_headers": []interface{}{"x-vmware-vcloud-client-request-id"},
+			},
+		},
+	})
+	config := Config{
+		User:            mock.fixtures.User,
+		Password:        mock.fixtures.Password,
+		SysOrg:          mock.fixtures.SysOrg,
+		Org:             "tf_org",
+		Vdc:             "tf_vdc",
+		Href:            mock.URL(),
+		MaxRetryTimeout: 5,
+		InsecureFlag:    true,
+		ApiLog:          getApiLogConfig(d),
+	}
+	vcdClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("error connecting to mock VCD: %s", err)
+	}
+	readMockDataSource(t, vcdClient, "vcloud_org", map[string]interface{}{"name": "tf_org"})
+
+	// A request sent from another goroutine is attributed to the operation of the client that sends it
+	catalogData := schema.TestResourceDataRaw(t, Provider().ResourcesMap["vcloud_catalog"].Schema, map[string]interface{}{})
+	catalogData.SetId("urn:vcloud:catalog:1")
+	operationClient := vcdClient.withCrudOperation(&crudOperation{
+		ctx:          context.Background(),
+		resourceType: "vcloud_catalog",
+		operation:    "update",
+		d:            catalogData,
+	})
+	done := make(chan error)
+	go func() {
+		_, err := operationClient.GetAdminOrgByName("tf_org")
+		done <- err
+	}()
+	if err = <-done; err != nil {
+		t.Fatalf("error retrieving Org: %s", err)
+	}
+
+	file, err := os.Open(filepath.Clean(logFile))
+	if err != nil {
+		t.Fatalf("error opening API log: %s", err)
+	}
+	defer file.Close()
+
+	var entries []apiLogEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 1024*1024), 1024*1024)
+	for scanner.Scan() {
+		var entry apiLogEntry
+		err = json.Unmarshal(scanner.Bytes(), &entry)
+		if err != nil {
+			t.Fatalf("invalid API log line '%s': %s", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		t.Fatalf("no API log entries written")
+	}
+
+	foundOperation := false
+	foundGoroutineOperation := false
+	for _, entry := range entries {
+		if entry.RequestId == "" || entry.Method == "" || entry.Url == "" || entry.Time == "" {
+			t.Errorf("incomplete API log entry: %+v", entry)
+		}
+		for name, value := range entry.RequestHeaders {
+			if strings.Contains(value, mock.fixtures.Password) || (strings.EqualFold(name, "Authorization") && value != apiLogRedacted) {
+				t.Errorf("header %s not redacted in %s %s: %s", name, entry.Method, entry.Url, value)
+			}
+		}
+		if value, ok := entry.RequestHeaders["X-Vmware-Vcloud-Client-Request-Id"]; ok && value != apiLogRedacted {
+			t.Errorf("additional header not redacted: %s", value)
+		}
+		if entry.ResourceType == "vcloud_org" && entry.Operation == "read" && entry.DataSource {
+			foundOperation = true
+		}
+		if entry.ResourceType == "vcloud_catalog" && entry.Operation == "update" && entry.ResourceId == "urn:vcloud:catalog:1" {
+			foundGoroutineOperation = true
+		}
+	}
+	if !foundOperation {
+		t.Errorf("no API log entry attributed to the read of data source vcloud_org")
+	}
+	if !foundGoroutineOperation {
+		t.Errorf("no API log entry attributed to the update of vcloud_catalog")
+	}
+}
+
+func Test_apiLogRedactor(t *testing.T) {
+	redactor := newApiLogRedactor(append(apiLogDefaultRedactedFields, "customSecret"))
+	tests := map[string]string{
+		`{"name":"user1","password":"p\"ss","nested":{"clientSecret": "abc"}}`: `{"name":"user1","password":"***","nested":{"clientSecret": "***"}}`,
+		`<User><Name>user1</Name><Password>pass</Password></User>`:             `<User><Name>user1</Name><Password>***</Password></User>`,
+		`<vcloud:AdminPassword type="x">pass</vcloud:AdminPassword>`:           `<vcloud:AdminPassword type="x">***</vcloud:AdminPassword>`,
+		`<Settings customSecret="abc" name="s1"/>`:                             `<Settings customSecret="***" name="s1"/>`,
+		`<PasswordPolicy><PasswordLength>8</PasswordLength></PasswordPolicy>`:  `<PasswordPolicy><PasswordLength>8</PasswordLength></PasswordPolicy>`,
+		`{"tokenType":"Bearer","token":"abc"}`:                                 `{"tokenType":"Bearer","token":"***"}`,
+	}
+	for input, want := range tests {
+		got := redactor.redact(input)
+		if got != want {
+			t.Errorf("redact(%s): got %s, want %s", input, got, want)
+		}
+	}
+}
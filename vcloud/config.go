@@ -8,12 +8,14 @@ import (
 	"path/filepath"
 	"reflect"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
 )
 
@@ -53,6 +55,32 @@ type Config struct {
 	// IgnoredMetadata allows to configure a set of metadata entries that should be ignored by all the
 	// API operations related to metadata.
 	IgnoredMetadata []govcd.IgnoredMetadata
+
+	// DefaultMetadata contains the metadata entries that are added to every resource supporting `metadata_entry`
+	DefaultMetadata map[string]types.MetadataValue
+
+	// RetryPolicy defines how CRUD operations failing with transient errors are retried
+	RetryPolicy *retryPolicy
+
+	// ProxyUrl is the HTTP(S) proxy used to reach VCD. When empty, the proxy is taken from the environment
+	ProxyUrl string
+	// NoProxy is a comma separated list of hosts that are reached without proxy
+	NoProxy string
+	// CaFile and CaPem add certificate authorities to the system ones, to verify the VCD certificate
+	CaFile string
+	CaPem  string
+	// ClientCertFile and ClientKeyFile, or ClientCertPem and ClientKeyPem, define the certificate used for
+	// TLS client authentication
+	ClientCertFile string
+	ClientKeyFile  string
+	ClientCertPem  string
+	ClientKeyPem   string
+
+	// ConnectionCacheTtl is how long a cached connection is reused. When zero, maxConnectionValidity is used
+	ConnectionCacheTtl time.Duration
+
+	// ApiLog enables the structured API log, when not nil
+	ApiLog *apiLogConfig
 }
 
 type VCDClient struct {
@@ -62,6 +90,9 @@ type VCDClient struct {
 	Vdc             string // name of default VDC
 	MaxRetryTimeout int
 	InsecureFlag    bool
+	RetryPolicy     *retryPolicy
+	ApiLog          *apiLogConfig
+	DefaultMetadata map[string]types.MetadataValue
 }
 
 // StringMap type is used to simplify reading resource definitions
@@ -123,7 +154,9 @@ var (
 	// Cached VDC authenticated connection
 	cachedVCDClients = &cacheStorage{conMap: make(map[string]cachedConnection)}
 
-	// Invalidates the cache after a given time (connection tokens usually expire after 20 to 30 minutes)
+	// Invalidates the cache after a given time (connection tokens usually expire after 20 to 30 minutes).
+	// It can be changed with the provider property "connection_cache_ttl". Expired sessions are renewed
+	// automatically (see sessionRefreshTransport), so this value limits only the reuse of the connection
 	maxConnectionValidity = 20 * time.Minute
 
 	enableDebug = os.Getenv("GOVCD_DEBUG") != ""
@@ -718,7 +751,20 @@ func (c *Config) Client() (*VCDClient, error) {
 		c.ServiceAccountTokenFile + "#" +
 		c.SysOrg + "#" +
 		c.Vdc + "#" +
-		c.Href
+		c.Href + "#" +
+		c.ProxyUrl + "#" +
+		c.NoProxy + "#" +
+		c.CaFile + "#" +
+		c.CaPem + "#" +
+		c.ClientCertFile + "#" +
+		c.ClientKeyFile + "#" +
+		c.ClientCertPem + "#" +
+		c.ClientKeyPem + "#" +
+		c.ApiLog.String() + "#" +
+		defaultMetadataString(c.DefaultMetadata) + "#" +
+		strconv.Itoa(c.MaxRetryTimeout) + "#" +
+		c.RetryPolicy.String() + "#" +
+		c.ConnectionCacheTtl.String()
 	checksum := fmt.Sprintf("%x", sha256.Sum256([]byte(rawData)))
 
 	// The cached connection is served only if the variable VCD_CACHE is set
@@ -730,9 +776,13 @@ func (c *Config) Client() (*VCDClient, error) {
 		cachedVCDClients.cacheClientServedCount += 1
 		cachedVCDClients.Unlock()
 		// debugPrintf("[%s] cached connection served %d times (size:%d)\n",
+		connectionValidity := maxConnectionValidity
+		if c.ConnectionCacheTtl > 0 {
+			connectionValidity = c.ConnectionCacheTtl
+		}
 		elapsed := time.Since(client.initTime)
-		if elapsed > maxConnectionValidity {
-			debugPrintf("cached connection invalidated after %2.0f minutes \n", maxConnectionValidity.Minutes())
+		if elapsed > connectionValidity {
+			debugPrintf("cached connection invalidated after %2.0f minutes \n", connectionValidity.Minutes())
 			cachedVCDClients.Lock()
 			delete(cachedVCDClients.conMap, checksum)
 			cachedVCDClients.Unlock()
@@ -759,12 +809,33 @@ func (c *Config) Client() (*VCDClient, error) {
 		Org:             c.Org,
 		Vdc:             c.Vdc,
 		MaxRetryTimeout: c.MaxRetryTimeout,
-		InsecureFlag:    c.InsecureFlag}
+		InsecureFlag:    c.InsecureFlag,
+		RetryPolicy:     c.RetryPolicy,
+		ApiLog:          c.ApiLog,
+		DefaultMetadata: c.DefaultMetadata}
+
+	err = c.configureHttpTransport(vcdClient.VCDClient)
+	if err != nil {
+		return nil, fmt.Errorf("error configuring the connection to VCD: %s", err)
+	}
+	if c.ApiLog != nil {
+		err = enableApiLog(vcdClient.VCDClient, c.ApiLog)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	err = ProviderAuthenticate(vcdClient.VCDClient, c.User, c.Password, c.Token, c.SysOrg, c.ApiToken, c.ApiTokenFile, c.ServiceAccountTokenFile)
 	if err != nil {
 		return nil, fmt.Errorf("something went wrong during authentication: %s", err)
 	}
+	// A session opened with a bearer token given by the user can't be renewed: a new token is needed.
+	// All the other methods (user and password, SAML, API token, service account) can open a new session
+	if c.Token == "" {
+		enableSessionRefresh(vcdClient.VCDClient, func(session *govcd.VCDClient) error {
+			return ProviderAuthenticate(session, c.User, c.Password, c.Token, c.SysOrg, c.ApiToken, c.ApiTokenFile, c.ServiceAccountTokenFile)
+		})
+	}
 	cachedVCDClients.Lock()
 	cachedVCDClients.conMap[checksum] = cachedConnection{initTime: time.Now(), connection: vcdClient}
 	cachedVCDClients.Unlock()
@@ -0,0 +1,176 @@
+package vcloud
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// sessionRefreshTransport re-authenticates the client when VCD answers 401 (Unauthorized) because the session
+// has expired, and then repeats the request with the new token. This allows long operations, which hold the
+// same connection for hours, to survive the session timeout.
+//
+// go-vcloud-director reads the token of the client without locks while building every request, so the new
+// session is opened on a copy of the client, and the token of the live client is never changed: the transport
+// replaces the expired token in the requests instead
+type sessionRefreshTransport struct {
+	base   http.RoundTripper
+	client *govcd.VCDClient
+	// authenticate opens a new session with the credentials given to the provider, on a copy of the client
+	authenticate func(session *govcd.VCDClient) error
+
+	// renewal serializes the renewals, so that concurrent requests failing with the same expired token open only
+	// one new session
+	renewal sync.Mutex
+
+	// sessionMutex protects the fields below, which describe the current session
+	sessionMutex sync.RWMutex
+	// authHeader and token are the ones of the last session opened by the transport. They are empty until the
+	// first renewal, while the requests use the token of the client
+	authHeader string
+	token      string
+	// expiredTokens holds the tokens of the sessions replaced so far
+	expiredTokens map[string]bool
+}
+
+// enableSessionRefresh wraps the HTTP transport of the client, so that expired sessions are renewed with the
+// given authentication function
+func enableSessionRefresh(client *govcd.VCDClient, authenticate func(session *govcd.VCDClient) error) {
+	client.Client.Http.Transport = &sessionRefreshTransport{
+		base:          client.Client.Http.Transport,
+		client:        client,
+		authenticate:  authenticate,
+		expiredTokens: make(map[string]bool),
+	}
+}
+
+func (t *sessionRefreshTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	if isAuthenticationRequest(request) {
+		return t.base.RoundTrip(request)
+	}
+	request = t.withCurrentSession(request)
+	response, err := t.base.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusUnauthorized {
+		return response, err
+	}
+	// The request can only be repeated when its body can be read again
+	if request.Body != nil && request.GetBody == nil {
+		return response, err
+	}
+
+	authHeader, token, refreshErr := t.refresh(requestAuthenticationToken(request))
+	if refreshErr != nil {
+		log.Printf("[WARN] VCD session could not be renewed: %s", refreshErr)
+		return response, err
+	}
+
+	retry := request.Clone(request.Context())
+	if request.GetBody != nil {
+		retry.Body, err = request.GetBody()
+		if err != nil {
+			return response, nil
+		}
+	}
+	setAuthenticationHeaders(retry, authHeader, token)
+	_ = response.Body.Close()
+	return t.base.RoundTrip(retry)
+}
+
+// withCurrentSession returns the request with the token of the current session, when it was built with the token
+// of a session that has already been replaced
+func (t *sessionRefreshTransport) withCurrentSession(request *http.Request) *http.Request {
+	requestToken := requestAuthenticationToken(request)
+	t.sessionMutex.RLock()
+	expired := requestToken != "" && t.expiredTokens[requestToken]
+	authHeader, token := t.authHeader, t.token
+	t.sessionMutex.RUnlock()
+	if !expired {
+		return request
+	}
+	request = request.Clone(request.Context())
+	setAuthenticationHeaders(request, authHeader, token)
+	return request
+}
+
+// refresh opens a new session, unless another request has already replaced the expired token, and returns the
+// authentication header and token to use. A request sent without token is repeated with the token of the last
+// session, after waiting for the renewal in progress, if any
+func (t *sessionRefreshTransport) refresh(expiredToken string) (string, string, error) {
+	t.renewal.Lock()
+	defer t.renewal.Unlock()
+
+	t.sessionMutex.RLock()
+	authHeader, token := t.authHeader, t.token
+	if token == "" {
+		authHeader, token = t.client.Client.VCDAuthHeader, t.client.Client.VCDToken
+	}
+	replaced := t.expiredTokens[expiredToken]
+	t.sessionMutex.RUnlock()
+	if expiredToken == "" || replaced {
+		if token == "" {
+			return "", "", fmt.Errorf("no valid session available")
+		}
+		return authHeader, token, nil
+	}
+	if token != expiredToken {
+		return authHeader, token, nil
+	}
+
+	log.Printf("[INFO] VCD session expired. Authenticating again")
+	session := *t.client
+	session.Client.VCDToken = ""
+	session.Client.VCDAuthHeader = ""
+	session.Client.Http.Transport = t.base
+	err := t.authenticate(&session)
+	if err != nil {
+		return "", "", fmt.Errorf("error authenticating: %s", err)
+	}
+
+	t.sessionMutex.Lock()
+	defer t.sessionMutex.Unlock()
+	t.expiredTokens[expiredToken] = true
+	t.authHeader = session.Client.VCDAuthHeader
+	t.token = session.Client.VCDToken
+	return t.authHeader, t.token, nil
+}
+
+// requestAuthenticationToken returns the session token used by a request
+func requestAuthenticationToken(request *http.Request) string {
+	for _, header := range []string{govcd.BearerTokenHeader, govcd.AuthorizationHeader} {
+		if token := request.Header.Get(header); token != "" {
+			return token
+		}
+	}
+	return strings.TrimPrefix(request.Header.Get("Authorization"), "bearer ")
+}
+
+// isAuthenticationRequest returns true for the requests that open a session. Their failures are never retried
+func isAuthenticationRequest(request *http.Request) bool {
+	path := strings.ToLower(request.URL.Path)
+	return strings.HasSuffix(path, "/sessions") ||
+		strings.HasSuffix(path, "/sessions/provider") ||
+		strings.HasSuffix(path, "/versions") ||
+		strings.Contains(path, "/oauth/") ||
+		strings.Contains(path, "/adfs/")
+}
+
+// setAuthenticationHeaders replaces the authentication headers of a request with the ones of the current
+// session, following the same rules used by go-vcloud-director when creating requests
+func setAuthenticationHeaders(request *http.Request, authHeader, token string) {
+	for _, header := range []string{govcd.AuthorizationHeader, govcd.BearerTokenHeader, "Authorization", "X-Vmware-Vcloud-Token-Type"} {
+		request.Header.Del(header)
+	}
+	if authHeader == "" || token == "" {
+		return
+	}
+	request.Header.Set(authHeader, token)
+	// The deprecated authorization token is 32 characters long, while the bearer token is much longer
+	if len(token) > 32 {
+		request.Header.Set("X-Vmware-Vcloud-Token-Type", "Bearer")
+		request.Header.Set("Authorization", "bearer "+token)
+	}
+}
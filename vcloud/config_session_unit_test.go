@@ -0,0 +1,167 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// TestMockVcdSessionRefresh checks that requests made after the session has expired open a new session
+func TestMockVcdSessionRefresh(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	oldToken := vcdClient.Client.VCDToken
+
+	mock.expireSessions()
+	org, err := vcdClient.GetAdminOrgByName("tf_org")
+	if err != nil {
+		t.Fatalf("error retrieving Org after session expiry: %s", err)
+	}
+	// The live client keeps its token, which the transport replaces in the requests
+	if vcdClient.Client.VCDToken != oldToken {
+		t.Errorf("expected the token of the client not to change")
+	}
+	if len(mock.sessions) != 1 || mock.sessions[oldToken] != nil {
+		t.Errorf("expected one new session, found %d", len(mock.sessions))
+	}
+
+	// OpenAPI requests with a body are repeated too
+	mock.expireSessions()
+	_, err = org.CreateCatalog("tf_refresh_catalog", "created after session expiry")
+	if err != nil {
+		t.Fatalf("error creating catalog after session expiry: %s", err)
+	}
+	if len(mock.sessions) != 1 {
+		t.Errorf("expected one open session, found %d", len(mock.sessions))
+	}
+}
+
+// TestMockVcdSessionRefreshConcurrent checks that concurrent requests failing with the same expired token open
+// a single new session, and that all of them succeed
+func TestMockVcdSessionRefreshConcurrent(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+
+	mock.expireSessions()
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := vcdClient.GetAdminOrgByName("tf_org")
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Errorf("error retrieving Org after session expiry: %s", err)
+		}
+	}
+	if len(mock.sessions) != 1 {
+		t.Errorf("expected one open session, found %d", len(mock.sessions))
+	}
+}
+
+// TestMockVcdSessionRefreshFailure checks that the original error is returned when a new session can't be opened
+func TestMockVcdSessionRefreshFailure(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	mock.Lock()
+	mock.fixtures.Password = "changed-password"
+	mock.Unlock()
+
+	mock.expireSessions()
+	_, err := vcdClient.GetAdminOrgByName("tf_org")
+	if err == nil {
+		t.Fatalf("expected an error when the session can't be renewed")
+	}
+	if len(mock.sessions) != 0 {
+		t.Errorf("expected no open sessions, found %d", len(mock.sessions))
+	}
+}
+
+func Test_isAuthenticationRequest(t *testing.T) {
+	tests := map[string]bool{
+		"https://vcd.example.com/api/versions":                                     true,
+		"https://vcd.example.com/cloudapi/1.0.0/sessions":                          true,
+		"https://vcd.example.com/cloudapi/1.0.0/sessions/provider":                 true,
+		"https://vcd.example.com/oauth/tenant/org1/token":                          true,
+		"https://adfs.example.com/adfs/services/trust/13/usernamemixed":            true,
+		"https://vcd.example.com/cloudapi/1.0.0/sessions/current":                  false,
+		"https://vcd.example.com/api/org/0a1b2c3d-0000-4000-8000-abcdef012345":     false,
+		"https://vcd.example.com/cloudapi/1.0.0/edgeGateways/urn:vcloud:gateway:1": false,
+	}
+	for requestUrl, want := range tests {
+		request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+		if err != nil {
+			t.Fatalf("error creating request: %s", err)
+		}
+		if got := isAuthenticationRequest(request); got != want {
+			t.Errorf("isAuthenticationRequest(%s) = %t, want %t", requestUrl, got, want)
+		}
+	}
+}
+
+func Test_setAuthenticationHeaders(t *testing.T) {
+	request, err := http.NewRequest(http.MethodGet, "https://vcd.example.com/api/org", nil)
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	request.Header.Set(govcd.BearerTokenHeader, "old")
+	request.Header.Set("Authorization", "bearer old")
+
+	newToken := strings.Repeat("x", 64)
+	setAuthenticationHeaders(request, govcd.BearerTokenHeader, newToken)
+	if request.Header.Get(govcd.BearerTokenHeader) != newToken || request.Header.Get("Authorization") != "bearer "+newToken {
+		t.Errorf("unexpected headers: %v", request.Header)
+	}
+}
+
+// roundTripFunc adapts a function to http.RoundTripper
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(request *http.Request) (*http.Response, error) {
+	return f(request)
+}
+
+// Test_sessionRefreshTransportReplacedTokens checks that requests built with the token of a replaced session, or
+// without token, are sent with the token of the current session
+func Test_sessionRefreshTransportReplacedTokens(t *testing.T) {
+	var sentTokens []string
+	transport := &sessionRefreshTransport{
+		base: roundTripFunc(func(request *http.Request) (*http.Response, error) {
+			token := requestAuthenticationToken(request)
+			sentTokens = append(sentTokens, token)
+			status := http.StatusOK
+			if token != "new-token" {
+				status = http.StatusUnauthorized
+			}
+			return &http.Response{StatusCode: status, Body: http.NoBody}, nil
+		}),
+		client:        &govcd.VCDClient{},
+		authHeader:    govcd.AuthorizationHeader,
+		token:         "new-token",
+		expiredTokens: map[string]bool{"old-token": true},
+	}
+
+	request, _ := http.NewRequest(http.MethodGet, "https://vcd.example.com/api/org", nil)
+	request.Header.Set(govcd.AuthorizationHeader, "old-token")
+	response, err := transport.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Errorf("expected the replaced token to be updated before sending: %v %v", response, err)
+	}
+
+	request, _ = http.NewRequest(http.MethodGet, "https://vcd.example.com/api/org", nil)
+	response, err = transport.RoundTrip(request)
+	if err != nil || response.StatusCode != http.StatusOK {
+		t.Errorf("expected the request without token to be repeated with the new token: %v %v", response, err)
+	}
+	if strings.Join(sentTokens, ",") != "new-token,,new-token" {
+		t.Errorf("unexpected tokens sent: %v", sentTokens)
+	}
+}
@@ -49,6 +49,7 @@ func init() {
 	setBoolFlag(&vcdTestVerbose, "vcd-verbose", "TEST_VERBOSE", "enables verbose output")
 	setBoolFlag(&enableTrace, "vcd-trace", "GOVCD_TRACE", "enables function calls tracing")
 	setBoolFlag(&vcdShortTest, "vcd-short", "VCD_SHORT_TEST", "runs short test")
+	setBoolFlag(&vcdMockServer, "vcd-mock-server", "VCD_MOCK_SERVER", "runs tests against an in-process mock VCD")
 	setBoolFlag(&vcdAddProvider, "vcd-add-provider", envVcdAddProvider, "add provider to test scripts")
 	setBoolFlag(&vcdSkipTemplateWriting, "vcd-skip-template-write", envVcdSkipTemplateWriting, "Skip writing templates to file")
 	setBoolFlag(&vcdRemoveOrgVdcFromTemplate, "vcd-remove-org-vdc-from-template", envVcdRemoveOrgVdcFromTemplate, "Remove org and VDC from template")
@@ -98,6 +99,11 @@ type TestConfig struct {
 		TerraformAcceptanceTests bool   `json:"tfAcceptanceTests"`
 		UseVcdConnectionCache    bool   `json:"useVcdConnectionCache"`
 		MaxRetryTimeout          int    `json:"maxRetryTimeout"`
+
+		// UseMockServer replaces the VCD defined by `Url` with an in-process mock (see mock_vcd_server_test.go).
+		// Credentials and URL are taken from the mock fixtures, which are read from `MockFixtures` when defined.
+		UseMockServer bool   `json:"useMockServer,omitempty"`
+		MockFixtures  string `json:"mockFixtures,omitempty"`
 	} `json:"provider"`
 	VCD struct {
 		Org         string `json:"org"`
@@ -370,6 +376,12 @@ var (
 	// Enables the short test (used by "make test")
 	vcdShortTest = os.Getenv("VCD_SHORT_TEST") != ""
 
+	// Runs the tests against an in-process mock VCD instead of the one defined in the configuration file
+	vcdMockServer = false
+
+	// The mock VCD started when either vcdMockServer or testConfig.Provider.UseMockServer are set
+	testMockVcd *mockVcd
+
 	// Keeps track of test artifact names, to avoid duplicates
 	testArtifactNames = make(map[string]string)
 )
@@ -670,6 +682,9 @@ func getConfigStruct(config string) TestConfig {
 	if configStruct.Provider.SysOrg == "" {
 		configStruct.Provider.SysOrg = configStruct.VCD.Org
 	}
+	if configStruct.Provider.UseMockServer || vcdMockServer {
+		startTestMockVcd(&configStruct)
+	}
 
 	if vcdTestOrgUser {
 		user := configStruct.TestEnvBuild.OrgUser
@@ -932,7 +947,10 @@ func TestMain(m *testing.M) {
 		fmt.Printf("Pass: %5d - Skip: %5d - Fail: %5d\n", vcdPassCount, vcdSkipCount, vcdFailCount)
 	}
 
-	if skipLeftoversRemoval || vcdShortTest {
+	if testMockVcd != nil {
+		testMockVcd.Close()
+	}
+	if skipLeftoversRemoval || vcdShortTest || testMockVcd != nil {
 		os.Exit(exitCode)
 	}
 	govcdClient, err := getTestVCDFromJson(testConfig)
@@ -954,6 +972,33 @@ func TestMain(m *testing.M) {
 	os.Exit(exitCode)
 }
 
+// startTestMockVcd starts the mock VCD and points the test configuration to it.
+// Like getConfigStruct, it panics on failure, as the test suite can't run without a VCD
+func startTestMockVcd(configStruct *TestConfig) {
+	fixtures := defaultMockVcdFixtures()
+	if configStruct.Provider.MockFixtures != "" {
+		var err error
+		fixtures, err = loadMockVcdFixtures(configStruct.Provider.MockFixtures)
+		if err != nil {
+			panic(err)
+		}
+	}
+	testMockVcd = newMockVcd(fixtures)
+	configStruct.Provider.Url = testMockVcd.URL()
+	configStruct.Provider.User = fixtures.User
+	configStruct.Provider.Password = fixtures.Password
+	configStruct.Provider.SysOrg = fixtures.SysOrg
+	configStruct.Provider.Token = ""
+	configStruct.Provider.ApiToken = ""
+	configStruct.Provider.ApiTokenFile = ""
+	configStruct.Provider.ServiceAccountTokenFile = ""
+	configStruct.Provider.UseSamlAdfs = false
+	configStruct.Provider.AllowInsecure = true
+	configStruct.Provider.VcdVersion = fixtures.VcdVersion
+	configStruct.Provider.ApiVersion = mockVcdApiVersions[len(mockVcdApiVersions)-1]
+	fmt.Printf("Using mock VCD at %s\n", configStruct.Provider.Url)
+}
+
 // Creates a VCDClient based on the endpoint given in the TestConfig argument.
 // TestConfig struct can be obtained by calling GetConfigStruct. Throws an error
 // if endpoint given is not a valid url.
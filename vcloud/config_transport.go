@@ -0,0 +1,124 @@
+package vcloud
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// configureHttpTransport applies the proxy and TLS settings of the provider to the HTTP transport created by
+// govcd.NewVCDClient. The transport keeps its defaults (proxy from the environment, system CA pool) for the
+// settings that are not given
+func (c *Config) configureHttpTransport(client *govcd.VCDClient) error {
+	transport, ok := client.Client.Http.Transport.(*http.Transport)
+	if !ok {
+		return fmt.Errorf("unexpected HTTP transport type %T", client.Client.Http.Transport)
+	}
+
+	if c.ProxyUrl != "" || c.NoProxy != "" {
+		proxy, err := proxyFunc(c.ProxyUrl, c.NoProxy)
+		if err != nil {
+			return err
+		}
+		transport.Proxy = proxy
+	}
+
+	if transport.TLSClientConfig == nil {
+		transport.TLSClientConfig = &tls.Config{} // #nosec G402 -- MinVersion is left to the Go default
+	}
+	if c.CaFile != "" || c.CaPem != "" {
+		rootCAs, err := caCertPool(c.CaFile, c.CaPem)
+		if err != nil {
+			return err
+		}
+		transport.TLSClientConfig.RootCAs = rootCAs
+	}
+	if c.ClientCertFile != "" || c.ClientCertPem != "" {
+		certificate, err := clientCertificate(c.ClientCertFile, c.ClientKeyFile, c.ClientCertPem, c.ClientKeyPem)
+		if err != nil {
+			return err
+		}
+		transport.TLSClientConfig.Certificates = []tls.Certificate{certificate}
+	}
+	return nil
+}
+
+// proxyFunc returns the proxy function for the HTTP transport. When proxyUrl is empty, the proxy is taken
+// from the environment (HTTPS_PROXY, HTTP_PROXY). When noProxy is empty, NO_PROXY is taken from the environment
+func proxyFunc(proxyUrl, noProxy string) (func(*http.Request) (*url.URL, error), error) {
+	proxyConfig := httpproxy.FromEnvironment()
+	if proxyUrl != "" {
+		parsedUrl, err := url.Parse(proxyUrl)
+		if err != nil {
+			return nil, fmt.Errorf("invalid 'proxy_url' '%s': %s", proxyUrl, err)
+		}
+		switch parsedUrl.Scheme {
+		case "http", "https", "socks5":
+		default:
+			return nil, fmt.Errorf("invalid 'proxy_url' '%s': the scheme must be one of 'http', 'https', 'socks5'", proxyUrl)
+		}
+		if parsedUrl.Host == "" {
+			return nil, fmt.Errorf("invalid 'proxy_url' '%s': missing host", proxyUrl)
+		}
+		proxyConfig.HTTPProxy = proxyUrl
+		proxyConfig.HTTPSProxy = proxyUrl
+	}
+	if noProxy != "" {
+		proxyConfig.NoProxy = noProxy
+	}
+
+	proxyForUrl := proxyConfig.ProxyFunc()
+	return func(request *http.Request) (*url.URL, error) {
+		return proxyForUrl(request.URL)
+	}, nil
+}
+
+// caCertPool returns the system CA pool, extended with the certificates from the given file and PEM text
+func caCertPool(caFile, caPem string) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil || pool == nil {
+		pool = x509.NewCertPool()
+	}
+	if caFile != "" {
+		contents, err := os.ReadFile(filepath.Clean(caFile))
+		if err != nil {
+			return nil, fmt.Errorf("error reading 'ca_file': %s", err)
+		}
+		if !pool.AppendCertsFromPEM(contents) {
+			return nil, fmt.Errorf("no valid PEM certificate found in 'ca_file' %s", caFile)
+		}
+	}
+	if caPem != "" && !pool.AppendCertsFromPEM([]byte(caPem)) {
+		return nil, fmt.Errorf("no valid PEM certificate found in 'ca_pem'")
+	}
+	return pool, nil
+}
+
+// clientCertificate loads the client certificate and its key, either from files or from PEM text
+func clientCertificate(certFile, keyFile, certPem, keyPem string) (tls.Certificate, error) {
+	if certFile != "" {
+		if keyFile == "" {
+			return tls.Certificate{}, fmt.Errorf("'client_key_file' is required with 'client_cert_file'")
+		}
+		certificate, err := tls.LoadX509KeyPair(filepath.Clean(certFile), filepath.Clean(keyFile))
+		if err != nil {
+			return tls.Certificate{}, fmt.Errorf("error loading client certificate from %s: %s", certFile, err)
+		}
+		return certificate, nil
+	}
+	if keyPem == "" {
+		return tls.Certificate{}, fmt.Errorf("'client_key_pem' is required with 'client_cert_pem'")
+	}
+	certificate, err := tls.X509KeyPair([]byte(certPem), []byte(keyPem))
+	if err != nil {
+		return tls.Certificate{}, fmt.Errorf("error loading client certificate from 'client_cert_pem': %s", err)
+	}
+	return certificate, nil
+}
@@ -0,0 +1,192 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// testClientCertificate creates a self-signed certificate for TLS client authentication, returning the
+// certificate and its key in PEM format
+func testClientCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("error generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	certDer, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("error creating certificate: %s", err)
+	}
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("error encoding key: %s", err)
+	}
+	return string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDer})),
+		string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+}
+
+// testTransportClient returns a VCD client for the given URL, with the transport configured by config
+func testTransportClient(t *testing.T, serverUrl string, config Config) (*govcd.VCDClient, error) {
+	parsedUrl, err := url.Parse(serverUrl)
+	if err != nil {
+		t.Fatalf("error parsing URL: %s", err)
+	}
+	client := govcd.NewVCDClient(*parsedUrl, false)
+	return client, config.configureHttpTransport(client)
+}
+
+// TestConfigTransportTls checks that a VCD with a private CA and client authentication can be reached only with
+// the 'ca_pem' and client certificate settings
+func TestConfigTransportTls(t *testing.T) {
+	clientCertPem, clientKeyPem := testClientCertificate(t)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AppendCertsFromPEM([]byte(clientCertPem))
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAndVerifyClientCert, ClientCAs: clientCAs} // #nosec G402 -- test server
+	server.StartTLS()
+	defer server.Close()
+	serverCaPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	keyFile := filepath.Join(t.TempDir(), "client.key")
+	certFile := filepath.Join(t.TempDir(), "client.crt")
+	caFile := filepath.Join(t.TempDir(), "ca.pem")
+	for fileName, contents := range map[string]string{keyFile: clientKeyPem, certFile: clientCertPem, caFile: serverCaPem} {
+		err := os.WriteFile(fileName, []byte(contents), 0600)
+		if err != nil {
+			t.Fatalf("error writing %s: %s", fileName, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		config    Config
+		wantError bool
+	}{
+		{
+			name:      "no CA",
+			config:    Config{ClientCertPem: clientCertPem, ClientKeyPem: clientKeyPem},
+			wantError: true,
+		},
+		{
+			name:      "no client certificate",
+			config:    Config{CaPem: serverCaPem},
+			wantError: true,
+		},
+		{
+			name:   "PEM settings",
+			config: Config{CaPem: serverCaPem, ClientCertPem: clientCertPem, ClientKeyPem: clientKeyPem},
+		},
+		{
+			name:   "file settings",
+			config: Config{CaFile: caFile, ClientCertFile: certFile, ClientKeyFile: keyFile},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := testTransportClient(t, server.URL, tt.config)
+			if err != nil {
+				t.Fatalf("error configuring transport: %s", err)
+			}
+			response, err := client.Client.Http.Get(server.URL)
+			if err == nil {
+				_ = response.Body.Close()
+			}
+			if (err != nil) != tt.wantError {
+				t.Errorf("got error %v, want error %t", err, tt.wantError)
+			}
+		})
+	}
+
+	_, err := testTransportClient(t, server.URL, Config{CaPem: "not a certificate"})
+	if err == nil {
+		t.Errorf("expected error with invalid 'ca_pem'")
+	}
+	_, err = testTransportClient(t, server.URL, Config{ClientCertPem: clientCertPem})
+	if err == nil {
+		t.Errorf("expected error with 'client_cert_pem' without key")
+	}
+}
+
+// TestConfigTransportProxy checks the selection of the proxy for a request
+func TestConfigTransportProxy(t *testing.T) {
+	proxy, err := proxyFunc("http://proxy.example.com:3128", "internal.example.com,10.0.0.0/8")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	tests := map[string]string{
+		"https://vcd.example.com/api":      "http://proxy.example.com:3128",
+		"https://internal.example.com/api": "",
+		"https://vcd.internal.example.com": "",
+		"https://10.1.2.3/api":             "",
+	}
+	for requestUrl, want := range tests {
+		request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+		if err != nil {
+			t.Fatalf("error creating request: %s", err)
+		}
+		got, err := proxy(request)
+		if err != nil {
+			t.Fatalf("unexpected error for %s: %s", requestUrl, err)
+		}
+		gotUrl := ""
+		if got != nil {
+			gotUrl = got.String()
+		}
+		if gotUrl != want {
+			t.Errorf("proxy for %s: got '%s', want '%s'", requestUrl, gotUrl, want)
+		}
+	}
+
+	// Without 'no_proxy', the hosts excluded by NO_PROXY in the environment are still reached directly
+	t.Setenv("NO_PROXY", "internal.example.com")
+	t.Setenv("no_proxy", "")
+	proxy, err = proxyFunc("http://proxy.example.com:3128", "")
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	request, err := http.NewRequest(http.MethodGet, "https://internal.example.com/api", nil)
+	if err != nil {
+		t.Fatalf("error creating request: %s", err)
+	}
+	got, err := proxy(request)
+	if err != nil || got != nil {
+		t.Errorf("expected no proxy for a host in NO_PROXY, got '%v' (error: %v)", got, err)
+	}
+
+	for _, invalidUrl := range []string{"ftp://proxy.example.com", "http://", "://proxy"} {
+		_, err = proxyFunc(invalidUrl, "")
+		if err == nil {
+			t.Errorf("expected error for proxy URL '%s'", invalidUrl)
+		}
+	}
+}
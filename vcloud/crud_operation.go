@@ -0,0 +1,150 @@
+package vcloud
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// crudOperation is the Terraform operation (create, read, update, delete or import of a resource, or read of a
+// data source) that sends a request to VCD. go-vcloud-director doesn't accept a context, so the operation reaches
+// the HTTP transport through a copy of the client made for each call, which attaches it to every request
+type crudOperation struct {
+	// ctx is the context of the Terraform operation. It bounds the retries of the requests, but is not given to
+	// the requests themselves, so that a cancelled operation doesn't interrupt a request that VCD is processing
+	ctx          context.Context
+	resourceType string
+	operation    string
+	dataSource   bool
+	d            *schema.ResourceData
+	// retry is the retry policy of the resource type
+	retry *retryPolicy
+}
+
+type crudOperationKey struct{}
+
+// requestCrudOperation returns the operation that sent a request, or nil when the request was sent outside of
+// the CRUD functions, such as when the provider is configured
+func requestCrudOperation(request *http.Request) *crudOperation {
+	operation, _ := request.Context().Value(crudOperationKey{}).(*crudOperation)
+	return operation
+}
+
+// crudOperationTransport attaches the operation to the requests of a client, and repeats the ones that fail with
+// transient errors according to the retry policy
+type crudOperationTransport struct {
+	base      http.RoundTripper
+	operation *crudOperation
+}
+
+func (t *crudOperationTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	request = request.WithContext(context.WithValue(request.Context(), crudOperationKey{}, t.operation))
+	return t.operation.roundTrip(t.base, request)
+}
+
+// withCrudOperation returns a copy of the client whose requests carry the given operation. The copy shares the
+// HTTP transport, and therefore the connections and the session, with the original client
+func (cli *VCDClient) withCrudOperation(operation *crudOperation) *VCDClient {
+	govcdClient := *cli.VCDClient
+	govcdClient.Client.Http.Transport = &crudOperationTransport{base: cli.Client.Http.Transport, operation: operation}
+	operationClient := *cli
+	operationClient.VCDClient = &govcdClient
+	return &operationClient
+}
+
+// crudOperationMeta returns the client given to a CRUD function, bound to the operation it runs
+func crudOperationMeta(ctx context.Context, meta interface{}, resourceType, operation string, dataSource bool, d *schema.ResourceData) interface{} {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil || vcdClient.VCDClient == nil {
+		return meta
+	}
+	return vcdClient.withCrudOperation(&crudOperation{
+		ctx:          ctx,
+		resourceType: resourceType,
+		operation:    operation,
+		dataSource:   dataSource,
+		d:            d,
+		retry:        vcdClient.RetryPolicy.forResource(resourceType),
+	})
+}
+
+// crudOperationTimeout returns the timeout of a legacy CRUD function, which doesn't receive a context
+func crudOperationTimeout(d *schema.ResourceData, operation string) time.Duration {
+	switch operation {
+	case "create":
+		return d.Timeout(schema.TimeoutCreate)
+	case "read":
+		return d.Timeout(schema.TimeoutRead)
+	case "update":
+		return d.Timeout(schema.TimeoutUpdate)
+	case "delete":
+		return d.Timeout(schema.TimeoutDelete)
+	}
+	return d.Timeout(schema.TimeoutDefault)
+}
+
+// withResourceOperations returns a copy of the given resources in which the CRUD and import functions receive a
+// client bound to the operation they run
+func withResourceOperations(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	return withCrudOperations(resources, false)
+}
+
+// withDataSourceOperations returns a copy of the given data sources in which the read functions receive a client
+// bound to the operation they run
+func withDataSourceOperations(dataSources map[string]*schema.Resource) map[string]*schema.Resource {
+	return withCrudOperations(dataSources, true)
+}
+
+func withCrudOperations(resources map[string]*schema.Resource, dataSource bool) map[string]*schema.Resource {
+	wrapped := make(map[string]*schema.Resource, len(resources))
+	for resourceType, resource := range resources {
+		r := *resource
+		r.CreateContext = crudOperationContext(resourceType, "create", dataSource, r.CreateContext)
+		r.ReadContext = crudOperationContext(resourceType, "read", dataSource, r.ReadContext)
+		r.UpdateContext = crudOperationContext(resourceType, "update", dataSource, r.UpdateContext)
+		r.DeleteContext = crudOperationContext(resourceType, "delete", dataSource, r.DeleteContext)
+		r.Create = crudOperationLegacy(resourceType, "create", dataSource, r.Create) //nolint:staticcheck
+		r.Read = crudOperationLegacy(resourceType, "read", dataSource, r.Read)       //nolint:staticcheck
+		r.Update = crudOperationLegacy(resourceType, "update", dataSource, r.Update) //nolint:staticcheck
+		r.Delete = crudOperationLegacy(resourceType, "delete", dataSource, r.Delete) //nolint:staticcheck
+		if r.Importer != nil {
+			importer := *r.Importer
+			if stateContext := importer.StateContext; stateContext != nil {
+				importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+					return stateContext(ctx, d, crudOperationMeta(ctx, meta, resourceType, "import", dataSource, d))
+				}
+			}
+			if state := importer.State; state != nil { //nolint:staticcheck
+				importer.State = func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) { //nolint:staticcheck
+					return state(d, crudOperationMeta(context.Background(), meta, resourceType, "import", dataSource, d))
+				}
+			}
+			r.Importer = &importer
+		}
+		wrapped[resourceType] = &r
+	}
+	return wrapped
+}
+
+func crudOperationContext(resourceType, operation string, dataSource bool, f crudContextFunc) crudContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(ctx, d, crudOperationMeta(ctx, meta, resourceType, operation, dataSource, d))
+	}
+}
+
+func crudOperationLegacy(resourceType, operation string, dataSource bool, f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		ctx, cancel := context.WithTimeout(context.Background(), crudOperationTimeout(d, operation))
+		defer cancel()
+		return f(d, crudOperationMeta(ctx, meta, resourceType, operation, dataSource, d))
+	}
+}
@@ -172,6 +172,9 @@ func testSpecificDataSourceNotFound(dataSourceName string, vcdClient *VCDClient)
 		case dataSourceName == "vcd_resource_list" || dataSourceName == "vcd_resource_schema" ||
 			dataSourceName == "vcd_nsxv_application_finder":
 			t.Skip(`not a real data source`)
+		// The data sources returning a list of entities return an empty list when nothing is found
+		case contains([]string{"vcd_vms", "vcd_vapps", "vcd_org_vdc_networks", "vcd_nsxt_edgegateways"}, dataSourceName):
+			t.Skip(`returns a list of entities`)
 		}
 
 		// Get list of mandatory fields in schema for a particular data source
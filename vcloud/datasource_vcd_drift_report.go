@@ -0,0 +1,562 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// driftReportSeparator separates name and ID in the lists retrieved through vcd_resource_list.
+// It can't be part of an entity name
+const driftReportSeparator = "\x00"
+
+// driftAttributeReader retrieves the name and the key attributes of a live entity. The attribute names are
+// the same used by the corresponding resource
+type driftAttributeReader func(vcdClient *VCDClient, org *govcd.Org, vdc *govcd.Vdc, id string) (string, map[string]string, error)
+
+// driftAttributeReaders lists the resource types whose key attributes can be compared by vcloud_drift_report
+var driftAttributeReaders = map[string]driftAttributeReader{
+	"vcd_vapp":                driftVappAttributes,
+	"vcd_vapp_vm":             driftVmAttributes,
+	"vcd_vm":                  driftVmAttributes,
+	"vcd_independent_disk":    driftDiskAttributes,
+	"vcd_nsxt_edgegateway":    driftNsxtEdgeGatewayAttributes,
+	"vcd_nsxt_firewall":       driftNsxtFirewallAttributes,
+	"vcd_network_routed_v2":   driftNetworkV2Attributes,
+	"vcd_network_isolated_v2": driftNetworkV2Attributes,
+}
+
+type driftReportEntity struct {
+	ResourceType string `json:"resource_type"`
+	Name         string `json:"name,omitempty"`
+	Id           string `json:"id"`
+}
+
+type driftReportDifference struct {
+	Attribute string `json:"attribute"`
+	Expected  string `json:"expected"`
+	Actual    string `json:"actual"`
+}
+
+type driftReportDriftedEntity struct {
+	driftReportEntity
+	Differences []driftReportDifference `json:"differences"`
+}
+
+type driftReportSummary struct {
+	Unmanaged int `json:"unmanaged"`
+	Drifted   int `json:"drifted"`
+	Missing   int `json:"missing"`
+}
+
+// driftReport is the structure of the 'report' attribute of vcloud_drift_report
+type driftReport struct {
+	Org       string                     `json:"org"`
+	Vdc       string                     `json:"vdc"`
+	Unmanaged []driftReportEntity        `json:"unmanaged"`
+	Drifted   []driftReportDriftedEntity `json:"drifted"`
+	Missing   []driftReportEntity        `json:"missing"`
+	Summary   driftReportSummary         `json:"summary"`
+}
+
+func datasourceVcdDriftReport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdDriftReportRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"parent": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the parent of the listed entities, as used by 'vcloud_resource_list' (e.g. the vApp for 'vcd_vapp_vm')",
+			},
+			"resource_types": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Resource types, as accepted by 'vcloud_resource_list', whose live entities are checked for unmanaged ones",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"managed_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "IDs of the entities managed by Terraform",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"managed_resource": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Entities managed by Terraform, with the key attributes expected for them",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The type of the resource (e.g. 'vcloud_vm')",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The ID of the entity",
+						},
+						"attributes": {
+							Type:        schema.TypeMap,
+							Optional:    true,
+							Description: "Expected values of the key attributes of the entity",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"unmanaged": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Live entities that are not referenced by 'managed_ids' or 'managed_resource'",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the entity",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entity",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the entity",
+						},
+					},
+				},
+			},
+			"drifted": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Key attributes of managed entities whose live value differs from the expected one",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the entity",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entity",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the entity",
+						},
+						"attribute": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The attribute that differs",
+						},
+						"expected": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value given in 'managed_resource'",
+						},
+						"actual": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The value found in VCD",
+						},
+					},
+				},
+			},
+			"missing": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Entities given in 'managed_resource' that don't exist anymore",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The type of the entity",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the entity",
+						},
+					},
+				},
+			},
+			"has_drift": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "True when there is at least one unmanaged, drifted, or missing entity",
+			},
+			"report": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The whole report, in JSON format",
+			},
+		},
+	}
+}
+
+func datasourceVcdDriftReportRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, vdc, err := vcdClient.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return diag.Errorf("[drift report] %s", err)
+	}
+	report := driftReport{
+		Org:       org.Org.Name,
+		Vdc:       vdc.Vdc.Name,
+		Unmanaged: []driftReportEntity{},
+		Drifted:   []driftReportDriftedEntity{},
+		Missing:   []driftReportEntity{},
+	}
+
+	managedIds := make(map[string]bool)
+	for _, id := range d.Get("managed_ids").(*schema.Set).List() {
+		managedIds[driftReportIdKey(id.(string))] = true
+	}
+	for _, item := range d.Get("managed_resource").([]interface{}) {
+		managedIds[driftReportIdKey(item.(map[string]interface{})["id"].(string))] = true
+	}
+
+	for _, item := range d.Get("resource_types").([]interface{}) {
+		resourceType := driftReportListType(item.(string))
+		live, err := driftReportLiveEntities(d, meta, org.Org.Name, vdc.Vdc.Name, resourceType)
+		if err != nil {
+			return diag.Errorf("[drift report] %s", err)
+		}
+		for _, entity := range live {
+			if !managedIds[driftReportIdKey(entity.Id)] {
+				report.Unmanaged = append(report.Unmanaged, entity)
+			}
+		}
+	}
+
+	for _, item := range d.Get("managed_resource").([]interface{}) {
+		managed := item.(map[string]interface{})
+		resourceType := driftReportListType(managed["resource_type"].(string))
+		id := managed["id"].(string)
+		reader, ok := driftAttributeReaders[resourceType]
+		if !ok {
+			return diag.Errorf("[drift report] resource type '%s' is not supported in 'managed_resource'. Supported types: %s",
+				managed["resource_type"].(string), strings.Join(driftReportSupportedTypes(), ", "))
+		}
+		name, attributes, err := reader(vcdClient, org, vdc, id)
+		if err != nil {
+			if govcd.ContainsNotFound(err) {
+				report.Missing = append(report.Missing, driftReportEntity{ResourceType: providerResourceType(resourceType), Id: id})
+				continue
+			}
+			return diag.Errorf("[drift report] error retrieving %s '%s': %s", resourceType, id, err)
+		}
+		differences, err := driftReportCompare(resourceType, managed["attributes"].(map[string]interface{}), attributes)
+		if err != nil {
+			return diag.Errorf("[drift report] %s", err)
+		}
+		if len(differences) > 0 {
+			report.Drifted = append(report.Drifted, driftReportDriftedEntity{
+				driftReportEntity: driftReportEntity{ResourceType: providerResourceType(resourceType), Name: name, Id: id},
+				Differences:       differences,
+			})
+		}
+	}
+
+	report.Summary = driftReportSummary{
+		Unmanaged: len(report.Unmanaged),
+		Drifted:   len(report.Drifted),
+		Missing:   len(report.Missing),
+	}
+	err = setDriftReportData(d, report)
+	if err != nil {
+		return diag.Errorf("[drift report] %s", err)
+	}
+	d.SetId(report.Org + ImportSeparator + report.Vdc)
+	return nil
+}
+
+// driftReportLiveEntities lists the live entities of a resource type, using the same functions of vcd_resource_list
+func driftReportLiveEntities(d *schema.ResourceData, meta interface{}, orgName, vdcName, resourceType string) ([]driftReportEntity, error) {
+	if strings.HasSuffix(resourceType, "_hierarchy") || resourceType == "resource" || resourceType == "resources" {
+		return nil, fmt.Errorf("resource type '%s' can't be used in a drift report", resourceType)
+	}
+	listData := datasourceVcdResourceList().Data(nil)
+	for field, value := range map[string]string{
+		"org":               orgName,
+		"vdc":               vdcName,
+		"parent":            d.Get("parent").(string),
+		"name":              "drift_report",
+		"resource_type":     resourceType,
+		"list_mode":         "name_id",
+		"name_id_separator": driftReportSeparator,
+	} {
+		err := listData.Set(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("error setting field '%s' for resource type '%s': %s", field, resourceType, err)
+		}
+	}
+	list, err := resourceListByType(listData, meta, resourceType)
+	if err != nil {
+		return nil, fmt.Errorf("error listing resource type '%s': %s", resourceType, err)
+	}
+	var entities []driftReportEntity
+	for _, item := range list {
+		name, id, _ := strings.Cut(item, driftReportSeparator)
+		if id == "" {
+			// Some entities (e.g. NSX-V rules) are identified by name
+			id = name
+		}
+		entities = append(entities, driftReportEntity{ResourceType: providerResourceType(resourceType), Name: name, Id: id})
+	}
+	return entities, nil
+}
+
+// driftReportCompare compares the expected attributes with the live ones, and returns the differences sorted by
+// attribute name
+func driftReportCompare(resourceType string, expected map[string]interface{}, actual map[string]string) ([]driftReportDifference, error) {
+	var differences []driftReportDifference
+	for attribute, expectedValue := range expected {
+		actualValue, ok := actual[attribute]
+		if !ok {
+			var supported []string
+			for key := range actual {
+				supported = append(supported, key)
+			}
+			sort.Strings(supported)
+			return nil, fmt.Errorf("attribute '%s' is not supported for resource type '%s'. Supported attributes: %s",
+				attribute, resourceType, strings.Join(supported, ", "))
+		}
+		if expectedValue.(string) != actualValue {
+			differences = append(differences, driftReportDifference{
+				Attribute: attribute,
+				Expected:  expectedValue.(string),
+				Actual:    actualValue,
+			})
+		}
+	}
+	sort.Slice(differences, func(i, j int) bool {
+		return differences[i].Attribute < differences[j].Attribute
+	})
+	return differences, nil
+}
+
+func setDriftReportData(d *schema.ResourceData, report driftReport) error {
+	var unmanaged, drifted, missing []map[string]interface{}
+	for _, entity := range report.Unmanaged {
+		unmanaged = append(unmanaged, map[string]interface{}{
+			"resource_type": entity.ResourceType,
+			"name":          entity.Name,
+			"id":            entity.Id,
+		})
+	}
+	for _, entity := range report.Drifted {
+		for _, difference := range entity.Differences {
+			drifted = append(drifted, map[string]interface{}{
+				"resource_type": entity.ResourceType,
+				"name":          entity.Name,
+				"id":            entity.Id,
+				"attribute":     difference.Attribute,
+				"expected":      difference.Expected,
+				"actual":        difference.Actual,
+			})
+		}
+	}
+	for _, entity := range report.Missing {
+		missing = append(missing, map[string]interface{}{
+			"resource_type": entity.ResourceType,
+			"id":            entity.Id,
+		})
+	}
+	for field, value := range map[string]interface{}{"unmanaged": unmanaged, "drifted": drifted, "missing": missing} {
+		err := d.Set(field, value)
+		if err != nil {
+			return fmt.Errorf("error setting '%s': %s", field, err)
+		}
+	}
+
+	contents, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding report: %s", err)
+	}
+	dSet(d, "report", string(contents))
+	dSet(d, "has_drift", len(unmanaged)+len(drifted)+len(missing) > 0)
+	return nil
+}
+
+// driftReportListType converts the provider resource names (e.g. "vcloud_vm") into the types used by
+// vcd_resource_list (e.g. "vcd_vm"). Other values are returned unchanged
+func driftReportListType(resourceType string) string {
+	if strings.HasPrefix(resourceType, "vcloud_") {
+		return "vcd_" + strings.TrimPrefix(resourceType, "vcloud_")
+	}
+	return resourceType
+}
+
+// driftReportIdKey normalizes an ID, so that a URN and the plain UUID of the same entity match
+func driftReportIdKey(id string) string {
+	if uuid := extractUuid(strings.ToLower(id)); uuid != "" {
+		return uuid
+	}
+	return id
+}
+
+func driftReportSupportedTypes() []string {
+	var supported []string
+	for resourceType := range driftAttributeReaders {
+		supported = append(supported, providerResourceType(resourceType))
+	}
+	sort.Strings(supported)
+	return supported
+}
+
+func driftVappAttributes(_ *VCDClient, _ *govcd.Org, vdc *govcd.Vdc, id string) (string, map[string]string, error) {
+	vapp, err := vdc.GetVAppById(id, true)
+	if err != nil {
+		return "", nil, err
+	}
+	statusText, err := vapp.GetStatus()
+	if err != nil {
+		statusText = vAppUnknownStatus
+	}
+	return vapp.VApp.Name, map[string]string{
+		"name":        vapp.VApp.Name,
+		"description": vapp.VApp.Description,
+		"status_text": statusText,
+	}, nil
+}
+
+func driftVmAttributes(_ *VCDClient, _ *govcd.Org, vdc *govcd.Vdc, id string) (string, map[string]string, error) {
+	vm, err := vdc.QueryVmById(id)
+	if err != nil {
+		return "", nil, err
+	}
+	statusText, err := vm.GetStatus()
+	if err != nil {
+		statusText = vAppUnknownStatus
+	}
+	attributes := map[string]string{
+		"name":            vm.VM.Name,
+		"description":     vm.VM.Description,
+		"status_text":     statusText,
+		"memory":          "",
+		"cpus":            "",
+		"cpu_cores":       "",
+		"storage_profile": "",
+		"computer_name":   "",
+	}
+	if spec := vm.VM.VmSpecSection; spec != nil {
+		if spec.MemoryResourceMb != nil {
+			attributes["memory"] = strconv.FormatInt(spec.MemoryResourceMb.Configured, 10)
+		}
+		if spec.NumCpus != nil {
+			attributes["cpus"] = strconv.Itoa(*spec.NumCpus)
+		}
+		if spec.NumCoresPerSocket != nil {
+			attributes["cpu_cores"] = strconv.Itoa(*spec.NumCoresPerSocket)
+		}
+	}
+	if vm.VM.StorageProfile != nil {
+		attributes["storage_profile"] = vm.VM.StorageProfile.Name
+	}
+	if vm.VM.GuestCustomizationSection != nil {
+		attributes["computer_name"] = vm.VM.GuestCustomizationSection.ComputerName
+	}
+	return vm.VM.Name, attributes, nil
+}
+
+func driftDiskAttributes(_ *VCDClient, _ *govcd.Org, vdc *govcd.Vdc, id string) (string, map[string]string, error) {
+	disk, err := vdc.GetDiskById(id, true)
+	if err != nil {
+		return "", nil, err
+	}
+	attributes := map[string]string{
+		"name":            disk.Disk.Name,
+		"description":     disk.Disk.Description,
+		"size_in_mb":      strconv.FormatInt(disk.Disk.SizeMb, 10),
+		"sharing_type":    disk.Disk.SharingType,
+		"storage_profile": "",
+	}
+	if disk.Disk.StorageProfile != nil {
+		attributes["storage_profile"] = disk.Disk.StorageProfile.Name
+	}
+	return disk.Disk.Name, attributes, nil
+}
+
+func driftNsxtEdgeGatewayAttributes(_ *VCDClient, org *govcd.Org, _ *govcd.Vdc, id string) (string, map[string]string, error) {
+	egw, err := org.GetNsxtEdgeGatewayById(id)
+	if err != nil {
+		return "", nil, err
+	}
+	return egw.EdgeGateway.Name, map[string]string{
+		"name":        egw.EdgeGateway.Name,
+		"description": egw.EdgeGateway.Description,
+	}, nil
+}
+
+// driftNsxtFirewallAttributes reads the firewall of an NSX-T Edge Gateway. As for vcd_nsxt_firewall, the ID is
+// the one of the Edge Gateway. The rules are summarized by their number and by their names, in order
+func driftNsxtFirewallAttributes(_ *VCDClient, org *govcd.Org, _ *govcd.Vdc, id string) (string, map[string]string, error) {
+	egw, err := org.GetNsxtEdgeGatewayById(id)
+	if err != nil {
+		return "", nil, err
+	}
+	firewall, err := egw.GetNsxtFirewall()
+	if err != nil {
+		return "", nil, err
+	}
+	var ruleNames []string
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	return egw.EdgeGateway.Name, map[string]string{
+		"rule_count": strconv.Itoa(len(ruleNames)),
+		"rule_names": strings.Join(ruleNames, ","),
+	}, nil
+}
+
+func driftNetworkV2Attributes(_ *VCDClient, org *govcd.Org, _ *govcd.Vdc, id string) (string, map[string]string, error) {
+	network, err := org.GetOpenApiOrgVdcNetworkById(id)
+	if err != nil {
+		return "", nil, err
+	}
+	attributes := map[string]string{
+		"name":          network.OpenApiOrgVdcNetwork.Name,
+		"description":   network.OpenApiOrgVdcNetwork.Description,
+		"gateway":       "",
+		"prefix_length": "",
+	}
+	if len(network.OpenApiOrgVdcNetwork.Subnets.Values) > 0 {
+		subnet := network.OpenApiOrgVdcNetwork.Subnets.Values[0]
+		attributes["gateway"] = subnet.Gateway
+		attributes["prefix_length"] = strconv.Itoa(subnet.PrefixLength)
+	}
+	return network.OpenApiOrgVdcNetwork.Name, attributes, nil
+}
@@ -0,0 +1,102 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func Test_driftReportIdKey(t *testing.T) {
+	urn := driftReportIdKey("urn:vcloud:vm:0A1B2C3D-0000-4000-8000-ABCDEF012345")
+	plain := driftReportIdKey("0a1b2c3d-0000-4000-8000-abcdef012345")
+	if urn != plain {
+		t.Errorf("URN and UUID of the same entity don't match: '%s' - '%s'", urn, plain)
+	}
+	if got := driftReportIdKey("rule-1"); got != "rule-1" {
+		t.Errorf("IDs without UUID should be unchanged: got '%s'", got)
+	}
+}
+
+func Test_driftReportCompare(t *testing.T) {
+	actual := map[string]string{"memory": "1024", "cpus": "2", "name": "vm1"}
+	differences, err := driftReportCompare("vcd_vm", map[string]interface{}{"name": "vm1", "memory": "2048", "cpus": "1"}, actual)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []driftReportDifference{
+		{Attribute: "cpus", Expected: "1", Actual: "2"},
+		{Attribute: "memory", Expected: "2048", Actual: "1024"},
+	}
+	if !reflect.DeepEqual(differences, want) {
+		t.Errorf("got differences %v, want %v", differences, want)
+	}
+
+	_, err = driftReportCompare("vcd_vm", map[string]interface{}{"hostname": "vm1"}, actual)
+	if err == nil {
+		t.Errorf("expected an error for an unsupported attribute")
+	}
+}
+
+// TestMockVcdDriftReport checks the detection of unmanaged, drifted, and missing entities
+func TestMockVcdDriftReport(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	vdc := mock.fixtures.Orgs[0].Vdcs[0]
+	vm1, vm2 := vdc.VApps[0].Vms[0], vdc.VApps[0].Vms[1]
+	edgeGatewayId := "urn:vcloud:gateway:" + vdc.EdgeGateways[0].ID
+	missingId := "urn:vcloud:vm:" + mockUuid()
+
+	d := readMockDataSource(t, vcdClient, "vcloud_drift_report", map[string]interface{}{
+		"resource_types": []interface{}{"vcloud_vapp_vm", "vcd_nsxt_edgegateway"},
+		// the plain UUID must match the URN returned by VCD
+		"managed_ids": []interface{}{vm1.ID},
+		"managed_resource": []interface{}{
+			map[string]interface{}{
+				"resource_type": "vcloud_vapp_vm",
+				"id":            "urn:vcloud:vm:" + vm2.ID,
+				"attributes":    map[string]interface{}{"memory": "2048", "cpus": "1", "name": vm2.Name},
+			},
+			map[string]interface{}{
+				"resource_type": "vcloud_vm",
+				"id":            missingId,
+			},
+		},
+	})
+
+	var report driftReport
+	err := json.Unmarshal([]byte(d.Get("report").(string)), &report)
+	if err != nil {
+		t.Fatalf("error decoding report: %s", err)
+	}
+	wantUnmanaged := []driftReportEntity{{ResourceType: "vcloud_nsxt_edgegateway", Name: vdc.EdgeGateways[0].Name, Id: edgeGatewayId}}
+	if !reflect.DeepEqual(report.Unmanaged, wantUnmanaged) {
+		t.Errorf("got unmanaged %v, want %v", report.Unmanaged, wantUnmanaged)
+	}
+	if len(report.Drifted) != 1 || report.Drifted[0].Name != vm2.Name ||
+		!reflect.DeepEqual(report.Drifted[0].Differences, []driftReportDifference{{Attribute: "memory", Expected: "2048", Actual: "1024"}}) {
+		t.Errorf("unexpected drifted entities: %v", report.Drifted)
+	}
+	if len(report.Missing) != 1 || report.Missing[0].Id != missingId {
+		t.Errorf("unexpected missing entities: %v", report.Missing)
+	}
+	if !d.Get("has_drift").(bool) {
+		t.Errorf("expected 'has_drift' to be true")
+	}
+	if d.Get("drifted.0.attribute").(string) != "memory" || d.Get("unmanaged.#").(int) != 1 {
+		t.Errorf("unexpected drift attributes: drifted.0.attribute=%v unmanaged.#=%v", d.Get("drifted.0.attribute"), d.Get("unmanaged.#"))
+	}
+
+	d = readMockDataSource(t, vcdClient, "vcloud_drift_report", map[string]interface{}{
+		"managed_resource": []interface{}{
+			map[string]interface{}{
+				"resource_type": "vcloud_nsxt_firewall",
+				"id":            edgeGatewayId,
+				"attributes":    map[string]interface{}{"rule_count": "1", "rule_names": "allow-outbound"},
+			},
+		},
+	})
+	if d.Get("has_drift").(bool) {
+		t.Errorf("expected no drift for the firewall, got report %s", d.Get("report"))
+	}
+}
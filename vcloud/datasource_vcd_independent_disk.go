@@ -34,6 +34,20 @@ func datasourceVcIndependentDisk() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 			},
+			"filter": {
+				Type:          schema.TypeList,
+				MaxItems:      1,
+				MinItems:      1,
+				Optional:      true,
+				ConflictsWith: []string{"id", "name"},
+				Description:   "Criteria for retrieving an independent disk by various attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
 			"description": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -121,13 +135,21 @@ func dataSourceVcdIndependentDiskRead(_ context.Context, d *schema.ResourceData,
 	idValue := d.Get("id").(string)
 	nameValue := d.Get("name").(string)
 
-	if idValue == "" && nameValue == "" {
-		return diag.Errorf("`id` and `name` are empty. At least one is needed")
+	filter, hasFilter := d.GetOk("filter")
+
+	if idValue == "" && nameValue == "" && !hasFilter {
+		return diag.Errorf("`id`, `name` and `filter` are empty. At least one is needed")
 	}
 
 	identifier := idValue
 	var disk *govcd.Disk
-	if identifier != "" {
+	if hasFilter {
+		disk, err = getIndependentDiskByFilter(vdc, filter)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		identifier = disk.Disk.Name
+	} else if identifier != "" {
 		disk, err = vdc.GetDiskById(identifier, true)
 		if govcd.IsNotFound(err) {
 			log.Printf("unable to find disk with ID %s: %s. Removing from state", identifier, err)
@@ -3,6 +3,7 @@ package vcloud
 import (
 	"context"
 	"log"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -20,10 +21,13 @@ func datasourceVcdIpSpace() *schema.Resource {
 				Description: "Org ID for 'SHARED' IP spaces",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of IP space",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "metadata_entry_filter"},
+				Description:  "Name of IP space (optional if 'metadata_entry_filter' is used)",
 			},
+			"metadata_entry_filter": openApiMetadataEntryFilterSchema("IP Space", []string{"name", "metadata_entry_filter"}),
 			"type": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -139,6 +143,7 @@ func datasourceVcdIpSpace() *schema.Resource {
 				Computed:    true,
 				Description: "Flag whether SNAT rule creation should be enabled (VCD 10.5.0+)",
 			},
+			"metadata_entry": openApiMetadataEntryDatasourceSchema("IP Space"),
 		},
 	}
 }
@@ -153,12 +158,35 @@ func datasourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta
 	var ipSpace *govcd.IpSpace
 	var err error
 
-	if orgId != "" { // in case org_id is provided (PRIVATE IP Space)
+	switch {
+	case ipSpaceName == "": // lookup by metadata, within the Org if org_id is provided
+		queryParams := url.Values{}
+		if orgId != "" {
+			queryParams.Add("filter", "orgRef.id=="+orgId)
+		}
+		var candidates []*govcd.IpSpace
+		candidates, err = vcdClient.GetAllIpSpaceSummaries(queryParams)
+		if err != nil {
+			return diag.Errorf("error retrieving IP Spaces: %s", err)
+		}
+		ipSpace, err = getOpenApiEntityByMetadataFilter(d, "metadata_entry_filter", "IP Space", candidates,
+			func(candidate *govcd.IpSpace) openApiMetadataHandler {
+				return ipSpaceMetadata(vcdClient, candidate.IpSpace)
+			})
+		if err != nil {
+			return diag.Errorf("error retrieving IP Space: %s", err)
+		}
+		// Summaries don't contain all the IP Space details
+		ipSpace, err = vcdClient.GetIpSpaceById(ipSpace.IpSpace.ID)
+		if err != nil {
+			return diag.Errorf("error retrieving IP Space: %s", err)
+		}
+	case orgId != "": // in case org_id is provided (PRIVATE IP Space)
 		ipSpace, err = vcdClient.GetIpSpaceByNameAndOrgId(ipSpaceName, orgId)
 		if err != nil {
 			return diag.Errorf("error retrieving IP Space '%s' in Org ID '%s': %s", ipSpaceName, orgId, err)
 		}
-	} else {
+	default:
 		ipSpace, err = vcdClient.GetIpSpaceByName(ipSpaceName)
 		if err != nil {
 			return diag.Errorf("error retrieving IP Space '%s': %s", ipSpaceName, err)
@@ -172,5 +200,5 @@ func datasourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(ipSpace.IpSpace.ID)
 
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_ip_space", ipSpaceMetadata(vcdClient, ipSpace.IpSpace))
 }
@@ -2,6 +2,8 @@ package vcloud
 
 import (
 	"context"
+	"fmt"
+	"net/url"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -38,20 +40,21 @@ func datasourceVcdNetworkIsolatedV2() *schema.Resource {
 			"name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ExactlyOneOf: []string{"name", "filter"},
-				Description:  "A unique name for this network (optional if 'filter' is used)",
+				ExactlyOneOf: []string{"name", "filter", "openapi_metadata_entry_filter"},
+				Description:  "A unique name for this network (optional if 'filter' or 'openapi_metadata_entry_filter' are used)",
 			},
 			"filter": {
 				Type:         schema.TypeList,
 				MaxItems:     1,
 				MinItems:     1,
 				Optional:     true,
-				ExactlyOneOf: []string{"name", "filter"},
+				ExactlyOneOf: []string{"name", "filter", "openapi_metadata_entry_filter"},
 				Description:  "Criteria for retrieving a network by various attributes",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name_regex": elementNameRegex,
 						"ip":         elementIp,
+						"metadata":   elementMetadata,
 					},
 				},
 			},
@@ -128,7 +131,9 @@ func datasourceVcdNetworkIsolatedV2() *schema.Resource {
 				Description: "Key value map of metadata assigned to this network. Key and value can be any string",
 				Deprecated:  "Use metadata_entry instead",
 			},
-			"metadata_entry": metadataEntryDatasourceSchema("Network"),
+			"metadata_entry":                metadataEntryDatasourceSchema("Network"),
+			"openapi_metadata_entry":        openApiMetadataEntryDatasourceSchema("Network"),
+			"openapi_metadata_entry_filter": openApiMetadataEntryFilterSchema("Network", []string{"name", "filter", "openapi_metadata_entry_filter"}),
 		},
 	}
 }
@@ -146,7 +151,8 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 	vdcField := d.Get("vdc").(string)
 	ownerIdField := d.Get("owner_id").(string)
 
-	if !nameOrFilterIsSet(d) {
+	_, hasMetadataFilter := d.GetOk("openapi_metadata_entry_filter")
+	if !nameOrFilterIsSet(d) && !hasMetadataFilter {
 		return diag.Errorf(noNameOrFilterError, "vcd_network_isolated_v2")
 	}
 
@@ -156,6 +162,25 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 	var network *govcd.OpenApiOrgVdcNetwork
 	filter, hasFilter := d.GetOk("filter")
 	switch {
+	// User supplied `openapi_metadata_entry_filter`, search in `owner_id` or in the `vdc` (in data source or inherited)
+	case hasMetadataFilter:
+		network, err = getOpenApiOrgVdcNetworkByMetadataFilter(d, vcdClient, org, ownerIdField, "vcd_network_isolated_v2",
+			func(candidate *govcd.OpenApiOrgVdcNetwork) bool {
+				return candidate.IsIsolated()
+			})
+		if err != nil {
+			return diag.Errorf("[isolated network read v2] error getting Org VDC network: %s", err)
+		}
+	// User supplied `filter` and `owner_id`, search in the VDC or VDC Group. The query service does not return
+	// the networks of VDC Groups
+	case hasFilter && networkName == "" && ownerIdField != "":
+		network, err = getOpenApiOrgVdcNetworkByFilterInOwner(vcdClient, org, ownerIdField, "vcd_network_isolated_v2", filter,
+			func(candidate *govcd.OpenApiOrgVdcNetwork) bool {
+				return candidate.IsIsolated()
+			})
+		if err != nil {
+			return diag.FromErr(err)
+		}
 	// User supplied `filter`, search in the `vdc` (in data source or inherited)
 	case hasFilter && networkName == "" && (vdcField != "" || inheritedVdcField != ""):
 		_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -166,13 +191,6 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 		if err != nil {
 			return diag.FromErr(err)
 		}
-	// TODO - XML Query based API does not support VDC Group networks (does not return them)
-	// User supplied `filter` and `edge_gateway_id` (search scope can be detected - VDC or VDC Group)
-	// case hasFilter && edgeGatewayId != "":
-	// 	network, err = getOpenApiOrgVdcNetworkByFilter(vdc, filter, "isolated")
-	// 	if err != nil {
-	// 		return diag.FromErr(err)
-	// 	}
 	// User supplied `name` and also `owner_id`
 	case ownerIdField != "" && networkName != "":
 		network, err = org.GetOpenApiOrgVdcNetworkByNameAndOwnerId(networkName, ownerIdField)
@@ -214,6 +232,12 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 		}
 	}
 
+	diags = append(diags, updateOpenApiMetadataAttributeInState(d, vcdClient, "vcd_network_isolated_v2", "openapi_metadata_entry",
+		openApiOrgVdcNetworkMetadata(vcdClient, "vcd_network_isolated_v2", network.OpenApiOrgVdcNetwork))...)
+	if diags != nil && diags.HasError() {
+		return diags
+	}
+
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
 	if len(diags) > 0 {
 		return diags
@@ -221,3 +245,36 @@ func datasourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceDat
 
 	return nil
 }
+
+// getOpenApiOrgVdcNetworkByMetadataFilter finds the only network of the given owner (VDC or VDC Group) that is wanted and
+// matches the 'openapi_metadata_entry_filter' of the data source. When the owner is empty, the networks of the VDC
+// in the data source or in the provider are searched
+func getOpenApiOrgVdcNetworkByMetadataFilter(d *schema.ResourceData, vcdClient *VCDClient, org *govcd.Org, ownerId, resourceType string, wanted func(*govcd.OpenApiOrgVdcNetwork) bool) (*govcd.OpenApiOrgVdcNetwork, error) {
+	var allNetworks []*govcd.OpenApiOrgVdcNetwork
+	var err error
+	if ownerId != "" {
+		queryParameters := url.Values{}
+		queryParameters.Add("filter", "ownerRef.id=="+ownerId)
+		allNetworks, err = org.GetAllOpenApiOrgVdcNetworks(queryParameters)
+	} else {
+		_, vdc, vdcErr := vcdClient.GetOrgAndVdcFromResource(d)
+		if vdcErr != nil {
+			return nil, fmt.Errorf("error getting VDC: %s", vdcErr)
+		}
+		allNetworks, err = vdc.GetAllOpenApiOrgVdcNetworks(nil)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving Org VDC networks: %s", err)
+	}
+
+	var candidates []*govcd.OpenApiOrgVdcNetwork
+	for _, network := range allNetworks {
+		if wanted(network) {
+			candidates = append(candidates, network)
+		}
+	}
+	return getOpenApiEntityByMetadataFilter(d, "openapi_metadata_entry_filter", "Org VDC network", candidates,
+		func(candidate *govcd.OpenApiOrgVdcNetwork) openApiMetadataHandler {
+			return openApiOrgVdcNetworkMetadata(vcdClient, resourceType, candidate.OpenApiOrgVdcNetwork)
+		})
+}
@@ -38,20 +38,21 @@ func datasourceVcdNetworkRoutedV2() *schema.Resource {
 			"name": {
 				Type:         schema.TypeString,
 				Optional:     true,
-				ExactlyOneOf: []string{"name", "filter"},
-				Description:  "A unique name for this network (optional if 'filter' is used)",
+				ExactlyOneOf: []string{"name", "filter", "openapi_metadata_entry_filter"},
+				Description:  "A unique name for this network (optional if 'filter' or 'openapi_metadata_entry_filter' are used)",
 			},
 			"filter": {
 				Type:         schema.TypeList,
 				MaxItems:     1,
 				MinItems:     1,
 				Optional:     true,
-				ExactlyOneOf: []string{"name", "filter"},
+				ExactlyOneOf: []string{"name", "filter", "openapi_metadata_entry_filter"},
 				Description:  "Criteria for retrieving a network by various attributes",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"name_regex": elementNameRegex,
 						"ip":         elementIp,
+						"metadata":   elementMetadata,
 					},
 				},
 			},
@@ -133,7 +134,9 @@ func datasourceVcdNetworkRoutedV2() *schema.Resource {
 				Description: "Key value map of metadata assigned to this network. Key and value can be any string",
 				Deprecated:  "Use metadata_entry instead",
 			},
-			"metadata_entry": metadataEntryDatasourceSchema("Network"),
+			"metadata_entry":                metadataEntryDatasourceSchema("Network"),
+			"openapi_metadata_entry":        openApiMetadataEntryDatasourceSchema("Network"),
+			"openapi_metadata_entry_filter": openApiMetadataEntryFilterSchema("Network", []string{"name", "filter", "openapi_metadata_entry_filter"}),
 			"route_advertisement_enabled": {
 				Type:        schema.TypeBool,
 				Computed:    true,
@@ -170,7 +173,8 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 	networkName := d.Get("name").(string)
 	edgeGatewayId := d.Get("edge_gateway_id").(string)
 
-	if !nameOrFilterIsSet(d) {
+	_, hasMetadataFilter := d.GetOk("openapi_metadata_entry_filter")
+	if !nameOrFilterIsSet(d) && !hasMetadataFilter {
 		return diag.Errorf(noNameOrFilterError, "vcd_network_routed_v2")
 	}
 
@@ -179,6 +183,25 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 	filter, hasFilter := d.GetOk("filter")
 
 	switch {
+	// User supplied `openapi_metadata_entry_filter`, search in the VDC or VDC Group of the Edge Gateway when
+	// `edge_gateway_id` is present, or in the `vdc` (in data source or inherited)
+	case hasMetadataFilter:
+		parentVdcOrVdcGroupId := ""
+		if edgeGatewayId != "" {
+			anyEdgeGateway, err := org.GetAnyTypeEdgeGatewayById(edgeGatewayId)
+			if err != nil {
+				return diag.Errorf("error retrieving Edge Gateway structure: %s", err)
+			}
+			parentVdcOrVdcGroupId = anyEdgeGateway.EdgeGateway.OwnerRef.ID
+		}
+		network, err = getOpenApiOrgVdcNetworkByMetadataFilter(d, vcdClient, org, parentVdcOrVdcGroupId, "vcd_network_routed_v2",
+			func(candidate *govcd.OpenApiOrgVdcNetwork) bool {
+				return candidate.IsRouted() && (edgeGatewayId == "" ||
+					candidate.OpenApiOrgVdcNetwork.Connection != nil && candidate.OpenApiOrgVdcNetwork.Connection.RouterRef.ID == edgeGatewayId)
+			})
+		if err != nil {
+			return diag.Errorf("[routed network read v2] error getting Org VDC network: %s", err)
+		}
 	// User supplied `filter` and also `edge_gateway_id` is present, search in the `vdc` (in data
 	// source or inherited)
 	case hasFilter && networkName == "" && edgeGatewayId != "":
@@ -190,8 +213,17 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 		}
 		parentVdcOrVdcGroupId := anyEdgeGateway.EdgeGateway.OwnerRef.ID
 
+		// The query service does not return the networks of VDC Groups
 		if govcd.OwnerIsVdcGroup(parentVdcOrVdcGroupId) {
-			return diag.Errorf("filters do not support VDC Groups yet")
+			network, err = getOpenApiOrgVdcNetworkByFilterInOwner(vcdClient, org, parentVdcOrVdcGroupId, "vcd_network_routed_v2", filter,
+				func(candidate *govcd.OpenApiOrgVdcNetwork) bool {
+					return candidate.IsRouted() && candidate.OpenApiOrgVdcNetwork.Connection != nil &&
+						candidate.OpenApiOrgVdcNetwork.Connection.RouterRef.ID == edgeGatewayId
+				})
+			if err != nil {
+				return diag.FromErr(err)
+			}
+			break
 		}
 
 		vdc, err := org.GetVDCById(parentVdcOrVdcGroupId, false)
@@ -215,13 +247,6 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 		if err != nil {
 			return diag.FromErr(err)
 		}
-	// TODO - XML Query based API does not support VDC Group networks (does not return them)
-	// User supplied `filter` and `edge_gateway_id` (search scope can be detected - VDC or VDC Group)
-	// case hasFilter && edgeGatewayId != "":
-	// 	network, err = getOpenApiOrgVdcNetworkByFilter(vdc, filter, "routed")
-	// 	if err != nil {
-	// 		return diag.FromErr(err)
-	// 	}
 	// User supplied `name` and also `edge_gateway_id`
 	case edgeGatewayId != "" && networkName != "":
 		// Lookup Edge Gateway to know parent VDC or VDC Group (routed networks always exists in the
@@ -271,6 +296,12 @@ func datasourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData,
 		}
 	}
 
+	diags = append(diags, updateOpenApiMetadataAttributeInState(d, vcdClient, "vcd_network_routed_v2", "openapi_metadata_entry",
+		openApiOrgVdcNetworkMetadata(vcdClient, "vcd_network_routed_v2", network.OpenApiOrgVdcNetwork))...)
+	if diags != nil && diags.HasError() {
+		return diags
+	}
+
 	// This must be checked at the end as updateMetadataInStateDeprecated can throw Warning diagnostics
 	if len(diags) > 0 {
 		return diags
@@ -5,6 +5,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
 )
 
 func datasourceVcdAlbVirtualService() *schema.Resource {
@@ -33,10 +34,13 @@ func datasourceVcdAlbVirtualService() *schema.Resource {
 				Description: "Edge gateway ID in which ALB Virtual Service is",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Name of ALB Virtual Service",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "metadata_entry_filter"},
+				Description:  "Name of ALB Virtual Service (optional if 'metadata_entry_filter' is used)",
 			},
+			"metadata_entry_filter": openApiMetadataEntryFilterSchema("NSX-T ALB Virtual Service", []string{"name", "metadata_entry_filter"}),
 			"description": {
 				Type:        schema.TypeString,
 				Computed:    true,
@@ -110,6 +114,7 @@ func datasourceVcdAlbVirtualService() *schema.Resource {
 				Computed:    true,
 				Description: "Preserves Client IP on a Virtual Service when enabled (VCD 10.4.1+)",
 			},
+			"metadata_entry": openApiMetadataEntryDatasourceSchema("NSX-T ALB Virtual Service"),
 		},
 	}
 }
@@ -127,9 +132,29 @@ func datasourceVcdAlbVirtualServiceRead(_ context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not retrieve NSX-T Edge Gateway with ID '%s': %s", d.Id(), err)
 	}
 
-	albVirtualService, err := vcdClient.GetAlbVirtualServiceByName(nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
-	if err != nil {
-		return diag.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", d.Get("name").(string), err)
+	var albVirtualService *govcd.NsxtAlbVirtualService
+	if d.Get("name").(string) == "" {
+		candidates, err := vcdClient.GetAllAlbVirtualServiceSummaries(nsxtEdge.EdgeGateway.ID, nil)
+		if err != nil {
+			return diag.Errorf("could not retrieve NSX-T ALB Virtual Services: %s", err)
+		}
+		albVirtualService, err = getOpenApiEntityByMetadataFilter(d, "metadata_entry_filter", "NSX-T ALB Virtual Service", candidates,
+			func(candidate *govcd.NsxtAlbVirtualService) openApiMetadataHandler {
+				return nsxtAlbVirtualServiceMetadata(vcdClient, candidate.NsxtAlbVirtualService)
+			})
+		if err != nil {
+			return diag.Errorf("could not retrieve NSX-T ALB Virtual Service: %s", err)
+		}
+		// Summaries don't contain all the Virtual Service details
+		albVirtualService, err = vcdClient.GetAlbVirtualServiceById(albVirtualService.NsxtAlbVirtualService.ID)
+		if err != nil {
+			return diag.Errorf("could not retrieve NSX-T ALB Virtual Service: %s", err)
+		}
+	} else {
+		albVirtualService, err = vcdClient.GetAlbVirtualServiceByName(nsxtEdge.EdgeGateway.ID, d.Get("name").(string))
+		if err != nil {
+			return diag.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", d.Get("name").(string), err)
+		}
 	}
 
 	err = setNsxtAlbVirtualServiceData(d, albVirtualService.NsxtAlbVirtualService)
@@ -138,5 +163,5 @@ func datasourceVcdAlbVirtualServiceRead(_ context.Context, d *schema.ResourceDat
 	}
 	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
 
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_nsxt_alb_virtual_service", nsxtAlbVirtualServiceMetadata(vcdClient, albVirtualService.NsxtAlbVirtualService))
 }
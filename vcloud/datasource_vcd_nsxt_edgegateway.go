@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/url"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 
@@ -31,9 +32,27 @@ func datasourceVcdNsxtEdgeGateway() *schema.Resource {
 				Deprecated:    "This field is deprecated in favor of 'owner_id' which supports both - VDC and VDC Group IDs",
 			},
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "Edge Gateway name",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "metadata_entry_filter", "filter"},
+				Description:  "Edge Gateway name (optional if 'filter' or 'metadata_entry_filter' are used)",
+			},
+			"metadata_entry_filter": openApiMetadataEntryFilterSchema("NSX-T Edge Gateway", []string{"name", "metadata_entry_filter", "filter"}),
+			"filter": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				MinItems:     1,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "metadata_entry_filter", "filter"},
+				Description:  "Criteria for retrieving an Edge Gateway by various attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"ip":         elementIp,
+						"metadata":   elementMetadata,
+					},
+				},
 			},
 			"owner_id": {
 				Type:          schema.TypeString,
@@ -192,6 +211,7 @@ func datasourceVcdNsxtEdgeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Total number of IPs allocated for this Gateway from NSX-T Segment backed External Network uplinks",
 			},
+			"metadata_entry": openApiMetadataEntryDatasourceSchema("NSX-T Edge Gateway"),
 		},
 	}
 }
@@ -248,6 +268,33 @@ func datasourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData,
 	var edge *govcd.NsxtEdgeGateway
 	edgeGatewayName := d.Get("name").(string)
 	switch {
+	case edgeGatewayName == "":
+		var candidates []*govcd.NsxtEdgeGateway
+		if ownerIdField != "" {
+			queryParameters := url.Values{}
+			queryParameters.Add("filter", "ownerRef.id=="+ownerIdField)
+			candidates, err = org.GetAllNsxtEdgeGateways(queryParameters)
+		} else {
+			_, vdc, vdcErr := pickVdcIdByPriority(org, inheritedVdcField, vdcField, ownerIdField)
+			if vdcErr != nil {
+				return diag.Errorf("error getting VDC ID: %s", vdcErr)
+			}
+			candidates, err = vdc.GetAllNsxtEdgeGateways(nil)
+		}
+		if err != nil {
+			return diag.Errorf("error retrieving NSX-T Edge Gateways: %s", err)
+		}
+		if filter, hasFilter := d.GetOk("filter"); hasFilter {
+			edge, err = getNsxtEdgeGatewayByFilter(vcdClient, candidates, filter)
+		} else {
+			edge, err = getOpenApiEntityByMetadataFilter(d, "metadata_entry_filter", "NSX-T Edge Gateway", candidates,
+				func(candidate *govcd.NsxtEdgeGateway) openApiMetadataHandler {
+					return nsxtEdgeGatewayMetadata(vcdClient, candidate.EdgeGateway)
+				})
+		}
+		if err != nil {
+			return diag.Errorf("error getting NSX-T Edge Gateway: %s", err)
+		}
 	case ownerIdField != "":
 		edge, err = org.GetNsxtEdgeGatewayByNameAndOwnerId(edgeGatewayName, ownerIdField)
 		if err != nil {
@@ -274,5 +321,5 @@ func datasourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData,
 
 	d.SetId(edge.EdgeGateway.ID)
 
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_nsxt_edgegateway", nsxtEdgeGatewayMetadata(vcdClient, edge.EdgeGateway))
 }
@@ -0,0 +1,78 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdNsxtEdgeGatewayConfig() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgeGatewayConfigRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "ID of the Edge Gateway to export",
+			},
+			"redact_secrets": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Leave the pre-shared keys of IPsec VPN tunnels out of the document",
+			},
+			"document": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Configuration of the Edge Gateway, in JSON format",
+			},
+			"sections": {
+				Type:        schema.TypeSet,
+				Computed:    true,
+				Description: "Sections included in the document",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxtEdgeGatewayConfigRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config export] error retrieving Edge Gateway: %s", err)
+	}
+
+	document, err := exportNsxtEdgeConfig(vcdClient, nsxtEdge.EdgeGateway, d.Get("redact_secrets").(bool))
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config export] %s", err)
+	}
+	contents, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config export] error encoding document: %s", err)
+	}
+	dSet(d, "document", string(contents))
+
+	var sections []string
+	for name := range document.Sections {
+		sections = append(sections, name)
+	}
+	err = d.Set("sections", convertStringsToTypeSet(sections))
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config export] error setting 'sections': %s", err)
+	}
+
+	d.SetId(nsxtEdge.EdgeGateway.ID)
+	return nil
+}
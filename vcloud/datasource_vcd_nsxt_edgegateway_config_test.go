@@ -0,0 +1,54 @@
+//go:build network || nsxt || ALL || functional
+
+package vcloud
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdNsxtEdgeGatewayConfigDS exports the configuration of the test Edge Gateway and checks the document
+func TestAccVcdNsxtEdgeGatewayConfigDS(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":     testConfig.VCD.Org,
+		"NsxtVdc": testConfig.Nsxt.Vdc,
+		"EdgeGw":  testConfig.Nsxt.EdgeGateway,
+		"Tags":    "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdNsxtEdgeGatewayConfigDS, params)
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_edgegateway_config.export", "id", "data.vcd_nsxt_edgegateway.testing", "id"),
+					resource.TestMatchResourceAttr("data.vcd_nsxt_edgegateway_config.export", "document", regexp.MustCompile(`"format": "vcloud_nsxt_edgegateway_config"`)),
+					resource.TestCheckTypeSetElemAttr("data.vcd_nsxt_edgegateway_config.export", "sections.*", "firewall"),
+				),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtEdgeGatewayConfigDS = testAccVcdNsxtFirewallPrereqs + `
+data "vcd_nsxt_edgegateway_config" "export" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+  redact_secrets  = true
+}
+`
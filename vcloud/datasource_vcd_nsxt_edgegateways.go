@@ -0,0 +1,117 @@
+package vcloud
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// nsxtEdgeGatewaysItemSchema returns the attributes of every NSX-T Edge Gateway returned by vcd_nsxt_edgegateways,
+// which are the same as vcd_nsxt_edgegateway
+func nsxtEdgeGatewaysItemSchema() map[string]*schema.Schema {
+	itemSchema := computedSchemaMap(datasourceVcdNsxtEdgeGateway().Schema, "filter", "metadata_entry_filter", "org")
+	itemSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the NSX-T Edge Gateway",
+	}
+	return itemSchema
+}
+
+func datasourceVcdNsxtEdgeGateways() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxtEdgeGatewaysRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of VDC or VDC Group. When not set, the Edge Gateways of all the VDCs and VDC Groups of the Org are returned",
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Criteria for retrieving the Edge Gateways. When not set, all the Edge Gateways are returned",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"ip":         elementIp,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
+			"edge_gateways": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "NSX-T Edge Gateways matching the filter, with the same attributes as the vcd_nsxt_edgegateway data source",
+				Elem: &schema.Resource{
+					Schema: nsxtEdgeGatewaysItemSchema(),
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxtEdgeGatewaysRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("error retrieving Org: %s", err)
+	}
+
+	// Edge Gateways are not restricted to the Org by the API, hence the Org filter when there is no owner
+	ownerId := d.Get("owner_id").(string)
+	queryParameters := url.Values{}
+	if ownerId != "" {
+		queryParameters.Add("filter", "ownerRef.id=="+ownerId)
+	} else {
+		queryParameters.Add("filter", "orgRef.id=="+org.Org.ID)
+	}
+	candidates, err := org.GetAllNsxtEdgeGateways(queryParameters)
+	if err != nil {
+		return diag.Errorf("error retrieving NSX-T Edge Gateways: %s", err)
+	}
+
+	edgeGateways, err := getAllNsxtEdgeGatewaysByFilter(vcdClient, candidates, d.Get("filter"))
+	if err != nil {
+		return diag.Errorf("error retrieving NSX-T Edge Gateways: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	itemSchema := nsxtEdgeGatewaysItemSchema()
+	items := make([]interface{}, len(edgeGateways))
+	for i, edgeGateway := range edgeGateways {
+		// The Edge Gateways were already retrieved, and only need to be stored as vcd_nsxt_edgegateway does
+		edgeGatewayData := datasourceVcdNsxtEdgeGateway().Data(nil)
+		err = setNsxtEdgeGatewayData(vcdClient, edgeGateway, edgeGatewayData)
+		if err != nil {
+			return diag.Errorf("error reading NSX-T Edge Gateway %s: %s", edgeGateway.EdgeGateway.Name, err)
+		}
+		edgeGatewayData.SetId(edgeGateway.EdgeGateway.ID)
+		diags = append(diags, updateOpenApiMetadataInState(edgeGatewayData, vcdClient, "vcd_nsxt_edgegateway",
+			nsxtEdgeGatewayMetadata(vcdClient, edgeGateway.EdgeGateway))...)
+		if diags.HasError() {
+			return diags
+		}
+		items[i] = resourceDataToMap(edgeGatewayData, itemSchema)
+	}
+
+	err = d.Set("edge_gateways", items)
+	if err != nil {
+		return diag.Errorf("error setting NSX-T Edge Gateways: %s", err)
+	}
+	if ownerId != "" {
+		d.SetId(ownerId)
+	} else {
+		d.SetId(org.Org.ID)
+	}
+	return diags
+}
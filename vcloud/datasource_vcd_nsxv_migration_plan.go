@@ -0,0 +1,962 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// nsxvMigrationEdge is the configuration of an NSX-V edge gateway translated by vcloud_nsxv_migration_plan
+type nsxvMigrationEdge struct {
+	Id                    string
+	Name                  string
+	FirewallRules         []*types.EdgeFirewallRule
+	FirewallDefaultAction string
+	IpSets                []*types.EdgeIpSet
+	NatRules              []*types.EdgeNatRule
+	LbMonitors            []*types.LbMonitor
+	LbPools               []*types.LbPool
+	LbAppProfiles         []*types.LbAppProfile
+	LbAppRules            []*types.LbAppRule
+	LbVirtualServers      []*types.LbVirtualServer
+	IpsecTunnels          []*types.GatewayIpsecVpnTunnel
+}
+
+// nsxvMigrationIssue is a part of the NSX-V configuration that could not be translated exactly
+type nsxvMigrationIssue struct {
+	SourceType string `json:"source_type"`
+	Name       string `json:"name,omitempty"`
+	Id         string `json:"id,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// nsxvMigrationReport is the structure of the 'report' attribute of vcloud_nsxv_migration_plan
+type nsxvMigrationReport struct {
+	Org          string               `json:"org"`
+	Vdc          string               `json:"vdc"`
+	EdgeGateway  string               `json:"edge_gateway"`
+	Resources    map[string]int       `json:"resources"`
+	Untranslated []nsxvMigrationIssue `json:"untranslated"`
+}
+
+type nsxvMigrationVariable struct {
+	name         string
+	description  string
+	defaultValue string
+	sensitive    bool
+}
+
+type nsxvMigrationResource struct {
+	resourceType string
+	address      string
+	values       map[string]interface{}
+}
+
+// nsxvMigrationPlan collects the NSX-T resources, and the variables they need, generated from an NSX-V edge gateway
+type nsxvMigrationPlan struct {
+	org           string
+	edgeGatewayId hclExpression
+	contextId     hclExpression
+	variables     []nsxvMigrationVariable
+	resources     []nsxvMigrationResource
+	usedNames     map[string]bool
+	ipSets        map[string]hclExpression
+	report        nsxvMigrationReport
+}
+
+func datasourceVcdNsxvMigrationPlan() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdNsxvMigrationPlanRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of the NSX-V VDC to use, optional if defined at provider level",
+			},
+			"edge_gateway": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the NSX-V edge gateway to translate",
+			},
+			"target_edge_gateway_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of the NSX-T edge gateway that receives the configuration. When empty, the generated configuration requires it as a variable",
+			},
+			"output_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Name of the file where the generated configuration is written",
+			},
+			"hcl": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The generated NSX-T configuration",
+			},
+			"untranslated": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Parts of the NSX-V configuration that could not be translated exactly",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"source_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The NSX-V resource type of the entity (e.g. 'vcloud_nsxv_firewall_rule')",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the entity",
+						},
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the entity",
+						},
+						"reason": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "What could not be translated, and how the generated configuration deals with it",
+						},
+					},
+				},
+			},
+			"report": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The count of generated resources and the untranslated features, in JSON format",
+			},
+		},
+	}
+}
+
+func datasourceVcdNsxvMigrationPlanRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	org, vdc, err := vcdClient.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+	if err != nil {
+		return diag.Errorf("[nsxv migration plan] %s", err)
+	}
+	if vdc.IsNsxt() {
+		return diag.Errorf("[nsxv migration plan] VDC '%s' is backed by NSX-T: the source edge gateway must be in an NSX-V VDC", vdc.Vdc.Name)
+	}
+	edge, err := readNsxvMigrationEdge(vdc, d.Get("edge_gateway").(string))
+	if err != nil {
+		return diag.Errorf("[nsxv migration plan] %s", err)
+	}
+
+	targetId := d.Get("target_edge_gateway_id").(string)
+	contextId := ""
+	if targetId != "" {
+		target, err := org.GetNsxtEdgeGatewayById(targetId)
+		if err != nil {
+			return diag.Errorf("[nsxv migration plan] error retrieving NSX-T edge gateway '%s': %s", targetId, err)
+		}
+		if target.EdgeGateway.OwnerRef != nil {
+			contextId = target.EdgeGateway.OwnerRef.ID
+		}
+	}
+
+	plan := newNsxvMigrationPlan(org.Org.Name, vdc.Vdc.Name, edge.Name, targetId, contextId)
+	plan.translate(edge)
+	hcl := plan.hcl()
+
+	fileName := d.Get("output_file").(string)
+	if fileName != "" {
+		err = os.WriteFile(fileName, []byte(hcl), 0600)
+		if err != nil {
+			return diag.Errorf("[nsxv migration plan] error writing file '%s': %s", fileName, err)
+		}
+	}
+	err = setNsxvMigrationPlanData(d, hcl, plan.report)
+	if err != nil {
+		return diag.Errorf("[nsxv migration plan] %s", err)
+	}
+	d.SetId(edge.Id)
+	return nil
+}
+
+// readNsxvMigrationEdge retrieves the firewall, NAT, load balancer, and IPsec VPN configuration of an NSX-V edge
+// gateway, with the IP sets of its VDC
+func readNsxvMigrationEdge(vdc *govcd.Vdc, edgeGatewayName string) (*nsxvMigrationEdge, error) {
+	egw, err := vdc.GetEdgeGatewayByName(edgeGatewayName, true)
+	if err != nil {
+		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+	}
+	if !egw.HasAdvancedNetworking() {
+		return nil, fmt.Errorf("edge gateway '%s' does not have advanced networking enabled", edgeGatewayName)
+	}
+	edge := &nsxvMigrationEdge{Id: egw.EdgeGateway.ID, Name: egw.EdgeGateway.Name}
+
+	edge.FirewallRules, err = egw.GetAllNsxvFirewallRules()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving firewall rules: %s", err)
+	}
+	firewallConfig, err := egw.GetFirewallConfig()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving firewall configuration: %s", err)
+	}
+	if firewallConfig.Enabled {
+		edge.FirewallDefaultAction = firewallConfig.DefaultPolicy.Action
+	} else {
+		// A disabled firewall lets all traffic through
+		edge.FirewallDefaultAction = "accept"
+	}
+	edge.IpSets, err = vdc.GetAllNsxvIpSets()
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return nil, fmt.Errorf("error retrieving IP sets: %s", err)
+	}
+	edge.NatRules, err = egw.GetNsxvNatRules()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NAT rules: %s", err)
+	}
+
+	edge.LbMonitors, err = egw.GetLbServiceMonitors()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer service monitors: %s", err)
+	}
+	edge.LbPools, err = egw.GetLbServerPools()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer server pools: %s", err)
+	}
+	edge.LbAppProfiles, err = egw.GetLbAppProfiles()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer application profiles: %s", err)
+	}
+	edge.LbAppRules, err = egw.GetLbAppRules()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer application rules: %s", err)
+	}
+	edge.LbVirtualServers, err = egw.GetLbVirtualServers()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving load balancer virtual servers: %s", err)
+	}
+
+	configuration := egw.EdgeGateway.Configuration
+	if configuration != nil && configuration.EdgeGatewayServiceConfiguration != nil &&
+		configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService != nil {
+		edge.IpsecTunnels = configuration.EdgeGatewayServiceConfiguration.GatewayIpsecVpnService.Tunnel
+	}
+	return edge, nil
+}
+
+// newNsxvMigrationPlan creates an empty plan. The NSX-T edge gateway and its owner (used as context of the
+// application port profiles) are variables of the generated configuration, with the given IDs as default values
+func newNsxvMigrationPlan(orgName, vdcName, edgeGatewayName, targetEdgeGatewayId, targetContextId string) *nsxvMigrationPlan {
+	plan := &nsxvMigrationPlan{
+		org:       orgName,
+		usedNames: make(map[string]bool),
+		ipSets:    make(map[string]hclExpression),
+		report: nsxvMigrationReport{
+			Org:          orgName,
+			Vdc:          vdcName,
+			EdgeGateway:  edgeGatewayName,
+			Resources:    make(map[string]int),
+			Untranslated: []nsxvMigrationIssue{},
+		},
+	}
+	plan.edgeGatewayId = plan.addVariable("nsxt_edge_gateway_id", "ID of the NSX-T edge gateway", targetEdgeGatewayId, false)
+	plan.contextId = plan.addVariable("nsxt_context_id", "ID of the VDC or VDC group that owns the NSX-T edge gateway", targetContextId, false)
+	return plan
+}
+
+// addVariable declares a variable of the generated configuration, unless it exists already, and returns a
+// reference to it
+func (plan *nsxvMigrationPlan) addVariable(name, description, defaultValue string, sensitive bool) hclExpression {
+	for _, variable := range plan.variables {
+		if variable.name == name {
+			return hclExpression("var." + name)
+		}
+	}
+	plan.variables = append(plan.variables, nsxvMigrationVariable{
+		name:         name,
+		description:  description,
+		defaultValue: defaultValue,
+		sensitive:    sensitive,
+	})
+	return hclExpression("var." + name)
+}
+
+// uniqueName returns the given name, or the name with a numeric suffix when it was already used for the same
+// resource type. NSX-T requires unique names for most of the generated entities
+func (plan *nsxvMigrationPlan) uniqueName(resourceType, name string) string {
+	unique := name
+	for count := 2; plan.usedNames[resourceType+"."+unique]; count++ {
+		unique = fmt.Sprintf("%s-%d", name, count)
+	}
+	plan.usedNames[resourceType+"."+unique] = true
+	return unique
+}
+
+// addResource adds a resource to the generated configuration, and returns a reference to its ID.
+// The Org and the NSX-T edge gateway are set when the resource supports them
+func (plan *nsxvMigrationPlan) addResource(resourceType, name string, values map[string]interface{}) hclExpression {
+	resourceSchema := globalResourceMap[resourceType].Schema
+	if _, ok := resourceSchema["org"]; ok {
+		values["org"] = plan.org
+	}
+	if _, ok := resourceSchema["edge_gateway_id"]; ok {
+		values["edge_gateway_id"] = plan.edgeGatewayId
+	}
+	address := plan.uniqueName("address:"+resourceType, resourceListAddress(name, ""))
+	plan.resources = append(plan.resources, nsxvMigrationResource{resourceType: resourceType, address: address, values: values})
+	plan.report.Resources[resourceType]++
+	return hclExpression(resourceType + "." + address + ".id")
+}
+
+func (plan *nsxvMigrationPlan) untranslated(sourceType, name, id, reason string, args ...interface{}) {
+	plan.report.Untranslated = append(plan.report.Untranslated, nsxvMigrationIssue{
+		SourceType: sourceType,
+		Name:       name,
+		Id:         id,
+		Reason:     fmt.Sprintf(reason, args...),
+	})
+}
+
+// translate converts the whole configuration of the NSX-V edge gateway
+func (plan *nsxvMigrationPlan) translate(edge *nsxvMigrationEdge) {
+	plan.translateFirewall(edge)
+	plan.translateNat(edge)
+	plan.translateLoadBalancer(edge)
+	plan.translateIpsecVpn(edge)
+}
+
+// translateFirewall converts the user defined firewall rules into a single vcloud_nsxt_firewall, keeping their order.
+// Parts of a rule that can't be translated must not make the NSX-T firewall allow more traffic than before: ALLOW
+// rules are created disabled, while DROP rules keep the translated parts and match any value in place of the others.
+// A DROP rule that can't be widened, because it matches translated addresses, prevents the translation of the whole
+// firewall
+func (plan *nsxvMigrationPlan) translateFirewall(edge *nsxvMigrationEdge) {
+	for _, rule := range edge.FirewallRules {
+		if rule.RuleType == "user" && rule.Action != "accept" && rule.MatchTranslated != nil && *rule.MatchTranslated {
+			plan.untranslated("vcloud_nsxv_firewall_rule", firstNonEmpty(rule.Name, "rule-"+rule.ID), rule.ID,
+				"it drops traffic matching translated addresses, while NSX-T uses the 'firewall_match' of each NAT "+
+					"rule: the firewall is not translated")
+			return
+		}
+	}
+
+	var rules []interface{}
+	for _, rule := range edge.FirewallRules {
+		if rule.RuleType != "user" {
+			continue
+		}
+		name := firstNonEmpty(rule.Name, "rule-"+rule.ID)
+		action := "ALLOW"
+		switch rule.Action {
+		case "deny":
+			action = "DROP"
+		case "reject":
+			action = "DROP"
+			plan.untranslated("vcloud_nsxv_firewall_rule", name, rule.ID, "action 'reject' is translated as 'DROP'")
+		}
+
+		sourceProblems := plan.firewallEndpointProblems(edge, "source", rule.Source)
+		destinationProblems := plan.firewallEndpointProblems(edge, "destination", rule.Destination)
+		applicationProblems := firewallApplicationProblems(rule.Application)
+		var sourceIds, destinationIds, appPortProfileIds []interface{}
+		enabled := rule.Enabled
+		if action == "ALLOW" {
+			problems := append(append(sourceProblems, destinationProblems...), applicationProblems...)
+			if rule.MatchTranslated != nil && *rule.MatchTranslated {
+				problems = append(problems, "it matches translated addresses, while NSX-T uses the 'firewall_match' of each NAT rule")
+			}
+			for _, problem := range problems {
+				plan.untranslated("vcloud_nsxv_firewall_rule", name, rule.ID, "%s: the NSX-T rule is disabled", problem)
+			}
+			enabled = enabled && len(problems) == 0
+			sourceIds = plan.firewallEndpointIds(edge, name, "source", rule.Source)
+			destinationIds = plan.firewallEndpointIds(edge, name, "destination", rule.Destination)
+			appPortProfileIds = plan.firewallApplicationIds(name, rule.Application)
+		} else {
+			for _, problem := range sourceProblems {
+				plan.untranslated("vcloud_nsxv_firewall_rule", name, rule.ID, "%s: the NSX-T rule drops traffic from any source", problem)
+			}
+			for _, problem := range destinationProblems {
+				plan.untranslated("vcloud_nsxv_firewall_rule", name, rule.ID, "%s: the NSX-T rule drops traffic to any destination", problem)
+			}
+			for _, problem := range applicationProblems {
+				plan.untranslated("vcloud_nsxv_firewall_rule", name, rule.ID, "%s: the NSX-T rule drops traffic of any service", problem)
+			}
+			if len(sourceProblems) == 0 {
+				sourceIds = plan.firewallEndpointIds(edge, name, "source", rule.Source)
+			}
+			if len(destinationProblems) == 0 {
+				destinationIds = plan.firewallEndpointIds(edge, name, "destination", rule.Destination)
+			}
+			if len(applicationProblems) == 0 {
+				appPortProfileIds = plan.firewallApplicationIds(name, rule.Application)
+			}
+		}
+
+		rules = append(rules, map[string]interface{}{
+			"name":                 name,
+			"direction":            nsxvMigrationDirection(rule.Direction),
+			"ip_protocol":          "IPV4_IPV6",
+			"action":               action,
+			"enabled":              enabled,
+			"logging":              rule.LoggingEnabled,
+			"source_ids":           sourceIds,
+			"destination_ids":      destinationIds,
+			"app_port_profile_ids": appPortProfileIds,
+		})
+	}
+
+	// NSX-T edge gateways drop the traffic not matched by any rule
+	if edge.FirewallDefaultAction == "accept" {
+		rules = append(rules, map[string]interface{}{
+			"name":        "default-policy",
+			"direction":   "IN_OUT",
+			"ip_protocol": "IPV4_IPV6",
+			"action":      "ALLOW",
+			"enabled":     true,
+		})
+	}
+	if len(rules) == 0 {
+		return
+	}
+	plan.addResource("vcloud_nsxt_firewall", edge.Name, map[string]interface{}{"rule": rules})
+}
+
+// firewallEndpointProblems returns the parts of the source or destination of a firewall rule that NSX-T edge
+// gateway firewalls can't match
+func (plan *nsxvMigrationPlan) firewallEndpointProblems(edge *nsxvMigrationEdge, side string, endpoint types.EdgeFirewallEndpoint) []string {
+	var problems []string
+	if endpoint.Exclude {
+		problems = append(problems, fmt.Sprintf("the %s is negated ('exclude'), which NSX-T edge gateway firewalls don't support", side))
+	}
+	if len(endpoint.VnicGroupIds) > 0 {
+		problems = append(problems, fmt.Sprintf("the %s includes gateway interfaces (%s), which have no NSX-T equivalent",
+			side, strings.Join(endpoint.VnicGroupIds, ", ")))
+	}
+	for _, objectId := range endpoint.GroupingObjectIds {
+		if nsxvMigrationIpSet(edge, objectId) == nil {
+			problems = append(problems, fmt.Sprintf("the %s includes '%s', which is not an IP set: it can be replaced "+
+				"by a vcloud_nsxt_security_group", side, objectId))
+		}
+	}
+	return problems
+}
+
+// firewallEndpointIds returns the IP sets matching the source or destination of a firewall rule. IP addresses are
+// grouped in a new IP set, while NSX-V IP sets are translated on first use. The parts reported by
+// firewallEndpointProblems are left out
+func (plan *nsxvMigrationPlan) firewallEndpointIds(edge *nsxvMigrationEdge, ruleName, side string, endpoint types.EdgeFirewallEndpoint) []interface{} {
+	var ids []interface{}
+	if len(endpoint.IpAddresses) > 0 {
+		name := plan.uniqueName("vcloud_nsxt_ip_set", ruleName+"-"+side)
+		ids = append(ids, plan.addResource("vcloud_nsxt_ip_set", name, map[string]interface{}{
+			"name":         name,
+			"description":  fmt.Sprintf("%s of NSX-V firewall rule '%s'", side, ruleName),
+			"ip_addresses": nsxvMigrationStrings(endpoint.IpAddresses),
+		}))
+	}
+	for _, objectId := range endpoint.GroupingObjectIds {
+		if ipSet := plan.ipSetReference(edge, objectId); ipSet != "" {
+			ids = append(ids, ipSet)
+		}
+	}
+	return ids
+}
+
+// nsxvMigrationIpSet returns the NSX-V IP set with the given ID, or nil when the ID is not one of the known IP sets
+func nsxvMigrationIpSet(edge *nsxvMigrationEdge, objectId string) *types.EdgeIpSet {
+	for _, ipSet := range edge.IpSets {
+		if ipSet.ID == objectId {
+			return ipSet
+		}
+	}
+	return nil
+}
+
+// ipSetReference returns the reference to the translation of an NSX-V IP set, adding it to the plan on first use.
+// It returns an empty expression when the ID is not one of the known IP sets
+func (plan *nsxvMigrationPlan) ipSetReference(edge *nsxvMigrationEdge, objectId string) hclExpression {
+	if reference, ok := plan.ipSets[objectId]; ok {
+		return reference
+	}
+	ipSet := nsxvMigrationIpSet(edge, objectId)
+	if ipSet == nil {
+		return ""
+	}
+	var addresses []string
+	for _, address := range strings.Split(ipSet.IPAddresses, ",") {
+		addresses = append(addresses, strings.TrimSpace(address))
+	}
+	name := plan.uniqueName("vcloud_nsxt_ip_set", ipSet.Name)
+	plan.ipSets[objectId] = plan.addResource("vcloud_nsxt_ip_set", name, map[string]interface{}{
+		"name":         name,
+		"description":  ipSet.Description,
+		"ip_addresses": nsxvMigrationStrings(addresses),
+	})
+	return plan.ipSets[objectId]
+}
+
+// firewallApplicationProblems returns the parts of the services of a firewall rule that NSX-T application port
+// profiles can't match
+func firewallApplicationProblems(application types.EdgeFirewallApplication) []string {
+	var problems []string
+	if application.ID != "" {
+		problems = append(problems, fmt.Sprintf("it uses the NSX-V application '%s': it can be replaced by the "+
+			"equivalent NSX-T application port profile", application.ID))
+	}
+	for _, service := range application.Services {
+		if strings.EqualFold(service.Protocol, "any") {
+			return problems
+		}
+		if nsxvMigrationProtocol(service.Protocol) == "" {
+			problems = append(problems, fmt.Sprintf("protocol '%s' has no NSX-T application port profile equivalent", service.Protocol))
+			continue
+		}
+		if len(nsxvMigrationPorts(service.SourcePort)) > 0 {
+			problems = append(problems, fmt.Sprintf("source port '%s' is not supported by NSX-T application port profiles", service.SourcePort))
+		}
+	}
+	return problems
+}
+
+// firewallApplicationIds returns the application port profile matching the services of a firewall rule. No
+// profile is needed when the rule applies to any service. The parts reported by firewallApplicationProblems are
+// left out
+func (plan *nsxvMigrationPlan) firewallApplicationIds(ruleName string, application types.EdgeFirewallApplication) []interface{} {
+	var appPorts []interface{}
+	for _, service := range application.Services {
+		if strings.EqualFold(service.Protocol, "any") {
+			return nil
+		}
+		protocol := nsxvMigrationProtocol(service.Protocol)
+		if protocol == "" {
+			continue
+		}
+		appPort := map[string]interface{}{"protocol": protocol}
+		if protocol == "TCP" || protocol == "UDP" {
+			appPort["port"] = nsxvMigrationPorts(service.Port)
+		}
+		appPorts = append(appPorts, appPort)
+	}
+	if len(appPorts) == 0 {
+		return nil
+	}
+	name := plan.uniqueName("vcloud_nsxt_app_port_profile", ruleName)
+	return []interface{}{plan.addResource("vcloud_nsxt_app_port_profile", name, map[string]interface{}{
+		"context_id":  plan.contextId,
+		"name":        name,
+		"description": fmt.Sprintf("Services of NSX-V firewall rule '%s'", ruleName),
+		"scope":       "TENANT",
+		"app_port":    appPorts,
+	})}
+}
+
+// translateNat converts the user defined SNAT and DNAT rules. Firewall matching uses the address seen by NSX-V
+// firewall rules, i.e. the original one
+func (plan *nsxvMigrationPlan) translateNat(edge *nsxvMigrationEdge) {
+	for _, rule := range edge.NatRules {
+		if rule.RuleType != "user" {
+			continue
+		}
+		action := strings.ToLower(rule.Action)
+		sourceType := "vcloud_nsxv_" + action
+		name := plan.uniqueName("vcloud_nsxt_nat_rule", firstNonEmpty(rule.Description, action+"-"+rule.ID))
+		values := map[string]interface{}{
+			"name":        name,
+			"description": rule.Description,
+			"enabled":     rule.Enabled,
+			"logging":     rule.LoggingEnabled,
+		}
+		if rule.Vnic != nil {
+			plan.untranslated(sourceType, name, rule.ID, "it applies to interface %d, while NSX-T NAT rules apply to the "+
+				"uplink of the edge gateway", *rule.Vnic)
+		}
+		protocol := nsxvMigrationProtocol(rule.Protocol)
+		if protocol == "" && rule.Protocol != "" && !strings.EqualFold(rule.Protocol, "any") {
+			plan.untranslated(sourceType, name, rule.ID, "protocol '%s' is not translated: the NSX-T rule applies to all protocols", rule.Protocol)
+		}
+
+		switch action {
+		case "dnat":
+			values["rule_type"] = "DNAT"
+			values["external_address"] = rule.OriginalAddress
+			values["internal_address"] = rule.TranslatedAddress
+			values["firewall_match"] = "MATCH_EXTERNAL_ADDRESS"
+			if rule.IcmpType != "" && !strings.EqualFold(rule.IcmpType, "any") {
+				plan.untranslated(sourceType, name, rule.ID, "ICMP type '%s' is not translated: the NSX-T rule applies to all ICMP types", rule.IcmpType)
+			}
+			translatedPorts := nsxvMigrationPorts(rule.TranslatedPort)
+			originalPorts := nsxvMigrationPorts(rule.OriginalPort)
+			if protocol == "" {
+				break
+			}
+			appPort := map[string]interface{}{"protocol": protocol}
+			if protocol == "TCP" || protocol == "UDP" {
+				if len(translatedPorts) == 0 {
+					plan.untranslated(sourceType, name, rule.ID, "protocol '%s' on any port is not translated: the NSX-T rule applies to all protocols", rule.Protocol)
+					break
+				}
+				appPort["port"] = translatedPorts
+				if len(originalPorts) > 0 && rule.OriginalPort != rule.TranslatedPort {
+					values["dnat_external_port"] = rule.OriginalPort
+				}
+			}
+			profileName := plan.uniqueName("vcloud_nsxt_app_port_profile", name)
+			values["app_port_profile_id"] = plan.addResource("vcloud_nsxt_app_port_profile", profileName, map[string]interface{}{
+				"context_id":  plan.contextId,
+				"name":        profileName,
+				"description": fmt.Sprintf("Services of NSX-V DNAT rule '%s'", name),
+				"scope":       "TENANT",
+				"app_port":    []interface{}{appPort},
+			})
+		case "snat":
+			values["rule_type"] = "SNAT"
+			values["external_address"] = rule.TranslatedAddress
+			values["internal_address"] = rule.OriginalAddress
+			values["firewall_match"] = "MATCH_INTERNAL_ADDRESS"
+			if len(nsxvMigrationPorts(rule.OriginalPort)) > 0 || len(nsxvMigrationPorts(rule.TranslatedPort)) > 0 {
+				plan.untranslated(sourceType, name, rule.ID, "ports are not translated: NSX-T SNAT rules apply to all ports")
+			}
+		default:
+			plan.untranslated("vcloud_nsxv_"+action, name, rule.ID, "NAT action '%s' is not supported", rule.Action)
+			continue
+		}
+		plan.addResource("vcloud_nsxt_nat_rule", name, values)
+	}
+}
+
+// translateLoadBalancer converts server pools and virtual servers into NSX-T ALB pools and virtual services.
+// The persistence of the NSX-V application profile goes to the pool, as NSX-T ALB defines it there.
+// ALB must be enabled on the NSX-T edge gateway, and its service engine group is a variable of the configuration
+func (plan *nsxvMigrationPlan) translateLoadBalancer(edge *nsxvMigrationEdge) {
+	monitors := make(map[string]*types.LbMonitor)
+	for _, monitor := range edge.LbMonitors {
+		monitors[monitor.ID] = monitor
+	}
+	appProfiles := make(map[string]*types.LbAppProfile)
+	for _, appProfile := range edge.LbAppProfiles {
+		appProfiles[appProfile.ID] = appProfile
+	}
+	appRuleNames := make(map[string]string)
+	for _, appRule := range edge.LbAppRules {
+		appRuleNames[appRule.ID] = appRule.Name
+	}
+
+	pools := make(map[string]map[string]interface{})
+	poolIds := make(map[string]hclExpression)
+	for _, pool := range edge.LbPools {
+		sourceType := "vcloud_lb_server_pool"
+		values := map[string]interface{}{
+			"name":        pool.Name,
+			"description": pool.Description,
+			"enabled":     true,
+		}
+		switch pool.Algorithm {
+		case "round-robin":
+			values["algorithm"] = "ROUND_ROBIN"
+		case "leastconn":
+			values["algorithm"] = "LEAST_CONNECTIONS"
+		default:
+			plan.untranslated(sourceType, pool.Name, pool.ID, "algorithm '%s' is not translated: the NSX-T pool uses LEAST_CONNECTIONS", pool.Algorithm)
+		}
+		if pool.Transparent {
+			plan.untranslated(sourceType, pool.Name, pool.ID, "transparent mode is not translated: it can be set with "+
+				"'is_transparent_mode_enabled' in the virtual services that use the pool")
+		}
+
+		var members []interface{}
+		for _, member := range pool.Members {
+			if (member.MonitorPort != 0 && member.MonitorPort != member.Port) || member.MinConn != 0 || member.MaxConn != 0 {
+				plan.untranslated(sourceType, pool.Name, pool.ID, "monitor port and connection limits of member '%s' are not translated", member.Name)
+			}
+			if member.Condition == "drain" {
+				plan.untranslated(sourceType, pool.Name, pool.ID, "member '%s' is draining: it is translated as enabled", member.Name)
+			}
+			members = append(members, map[string]interface{}{
+				"ip_address": member.IpAddress,
+				"port":       member.Port,
+				"ratio":      max(member.Weight, 1),
+				"enabled":    member.Condition != "disabled",
+			})
+		}
+		values["member"] = members
+
+		if monitor, ok := monitors[pool.MonitorId]; ok {
+			monitorType := nsxvMigrationMonitorType(monitor.Type)
+			if monitorType == "" {
+				plan.untranslated(sourceType, pool.Name, pool.ID, "service monitor '%s' of type '%s' is not translated", monitor.Name, monitor.Type)
+			} else {
+				values["health_monitor"] = []interface{}{map[string]interface{}{"type": monitorType}}
+				if monitor.URL != "" || monitor.Method != "" || monitor.Expected != "" || monitor.Send != "" || monitor.Receive != "" {
+					plan.untranslated(sourceType, pool.Name, pool.ID, "the settings of service monitor '%s' are not "+
+						"translated: the NSX-T pool uses the system defined %s monitor", monitor.Name, monitorType)
+				}
+			}
+		}
+		pools[pool.ID] = values
+		poolIds[pool.ID] = plan.addResource("vcloud_nsxt_alb_pool", pool.Name, values)
+	}
+
+	for _, virtualServer := range edge.LbVirtualServers {
+		sourceType := "vcloud_lb_virtual_server"
+		poolId, ok := poolIds[virtualServer.DefaultPoolId]
+		if !ok {
+			plan.untranslated(sourceType, virtualServer.Name, virtualServer.ID, "it has no default pool, which NSX-T ALB virtual services require")
+			continue
+		}
+		servicePort := map[string]interface{}{"start_port": virtualServer.Port, "type": "TCP_PROXY"}
+		values := map[string]interface{}{
+			"name":                    virtualServer.Name,
+			"description":             virtualServer.Description,
+			"enabled":                 virtualServer.Enabled,
+			"pool_id":                 poolId,
+			"service_engine_group_id": plan.addVariable("alb_service_engine_group_id", "ID of the ALB service engine group used by the virtual services", "", false),
+			"virtual_ip_address":      virtualServer.IpAddress,
+			"service_port":            []interface{}{servicePort},
+		}
+		switch strings.ToLower(virtualServer.Protocol) {
+		case "http":
+			values["application_profile_type"] = "HTTP"
+		case "https":
+			values["application_profile_type"] = "HTTPS"
+			values["ca_certificate_id"] = plan.addVariable("alb_certificate_id", "ID of the certificate used by the HTTPS virtual services", "", false)
+			servicePort["ssl_enabled"] = true
+		case "udp":
+			values["application_profile_type"] = "L4"
+			servicePort["type"] = "UDP_FAST_PATH"
+		default:
+			values["application_profile_type"] = "L4"
+		}
+		if virtualServer.ConnectionLimit != 0 || virtualServer.ConnectionRateLimit != 0 {
+			plan.untranslated(sourceType, virtualServer.Name, virtualServer.ID, "connection limits are not translated")
+		}
+		for _, appRuleId := range virtualServer.ApplicationRuleIds {
+			plan.untranslated(sourceType, virtualServer.Name, virtualServer.ID, "application rule '%s' is not translated: "+
+				"NSX-T ALB uses HTTP policies instead of scripts", firstNonEmpty(appRuleNames[appRuleId], appRuleId))
+		}
+		if appProfile, ok := appProfiles[virtualServer.ApplicationProfileId]; ok {
+			plan.translateLbAppProfile(virtualServer, appProfile, pools[virtualServer.DefaultPoolId])
+		}
+		plan.addResource("vcloud_nsxt_alb_virtual_service", virtualServer.Name, values)
+	}
+}
+
+// translateLbAppProfile sets the persistence of an application profile in the pool of the virtual server, and
+// reports the other profile features
+func (plan *nsxvMigrationPlan) translateLbAppProfile(virtualServer *types.LbVirtualServer, appProfile *types.LbAppProfile, pool map[string]interface{}) {
+	sourceType := "vcloud_lb_app_profile"
+	if appProfile.HttpRedirect != nil && appProfile.HttpRedirect.To != "" {
+		plan.untranslated(sourceType, appProfile.Name, appProfile.ID, "HTTP redirection is not translated: it can be replaced by an HTTP policy")
+	}
+	if appProfile.InsertXForwardedForHttpHeader || appProfile.ServerSslEnabled || appProfile.SslPassthrough {
+		plan.untranslated(sourceType, appProfile.Name, appProfile.ID, "X-Forwarded-For, pool side SSL, and SSL passthrough settings are not translated")
+	}
+	if appProfile.Persistence == nil || appProfile.Persistence.Method == "" {
+		return
+	}
+	persistence := map[string]interface{}{}
+	switch appProfile.Persistence.Method {
+	case "cookie":
+		persistence["type"] = "HTTP_COOKIE"
+		persistence["value"] = appProfile.Persistence.CookieName
+	case "sourceip":
+		persistence["type"] = "CLIENT_IP"
+	case "ssl_sessionid":
+		persistence["type"] = "TLS"
+	default:
+		plan.untranslated(sourceType, appProfile.Name, appProfile.ID, "persistence method '%s' is not translated", appProfile.Persistence.Method)
+		return
+	}
+	if existing, ok := pool["persistence_profile"]; ok {
+		if fmt.Sprintf("%v", existing) != fmt.Sprintf("%v", []interface{}{persistence}) {
+			plan.untranslated(sourceType, appProfile.Name, appProfile.ID, "persistence of virtual server '%s' is not "+
+				"translated: its pool already uses the persistence of another virtual server", virtualServer.Name)
+		}
+		return
+	}
+	pool["persistence_profile"] = []interface{}{persistence}
+}
+
+// translateIpsecVpn converts the IPsec VPN tunnels. Pre-shared keys are sensitive variables of the configuration
+func (plan *nsxvMigrationPlan) translateIpsecVpn(edge *nsxvMigrationEdge) {
+	sourceType := "vcloud_edgegateway_vpn"
+	for _, tunnel := range edge.IpsecTunnels {
+		localNetworks, err := nsxvMigrationCidrs(tunnel.LocalSubnet)
+		if err != nil {
+			plan.untranslated(sourceType, tunnel.Name, "", "%s", err)
+			continue
+		}
+		remoteNetworks, err := nsxvMigrationCidrs(tunnel.PeerSubnet)
+		if err != nil {
+			plan.untranslated(sourceType, tunnel.Name, "", "%s", err)
+			continue
+		}
+		plan.untranslated(sourceType, tunnel.Name, "", "the local IP address %s belongs to the NSX-V edge gateway: "+
+			"it must be replaced by an IP address of the NSX-T edge gateway", tunnel.LocalIPAddress)
+		if tunnel.EncryptionProtocol != "" {
+			plan.untranslated(sourceType, tunnel.Name, "", "encryption protocol '%s' is not translated: the NSX-T tunnel "+
+				"uses the default security profile, unless 'security_profile_customization' is set", tunnel.EncryptionProtocol)
+		}
+		if tunnel.Mtu != 0 && tunnel.Mtu != 1500 {
+			plan.untranslated(sourceType, tunnel.Name, "", "MTU %d is not translated", tunnel.Mtu)
+		}
+
+		variableName := "ipsec_psk_" + strings.TrimPrefix(resourceListAddress(tunnel.Name, ""), "_")
+		plan.addResource("vcloud_nsxt_ipsec_vpn_tunnel", tunnel.Name, map[string]interface{}{
+			"name":              plan.uniqueName("vcloud_nsxt_ipsec_vpn_tunnel", tunnel.Name),
+			"description":       tunnel.Description,
+			"enabled":           tunnel.IsEnabled,
+			"pre_shared_key":    plan.addVariable(variableName, fmt.Sprintf("Pre-shared key of IPsec VPN tunnel '%s'", tunnel.Name), "", true),
+			"local_ip_address":  tunnel.LocalIPAddress,
+			"local_networks":    localNetworks,
+			"remote_ip_address": tunnel.PeerIPAddress,
+			"remote_id":         tunnel.PeerID,
+			"remote_networks":   remoteNetworks,
+		})
+	}
+}
+
+// hcl returns the generated configuration: variables first, followed by resources in the order they were added
+func (plan *nsxvMigrationPlan) hcl() string {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Generated by vcloud_nsxv_migration_plan from NSX-V edge gateway '%s' (Org '%s', VDC '%s')\n",
+		plan.report.EdgeGateway, plan.report.Org, plan.report.Vdc))
+	builder.WriteString("# Review the configuration, and the untranslated features listed by the data source, before applying it\n\n")
+
+	for _, variable := range plan.variables {
+		builder.WriteString(fmt.Sprintf("variable %s {\n", hclQuote(variable.name)))
+		builder.WriteString(fmt.Sprintf("  description = %s\n", hclQuote(variable.description)))
+		builder.WriteString("  type        = string\n")
+		if variable.sensitive {
+			builder.WriteString("  sensitive   = true\n")
+		}
+		if variable.defaultValue != "" {
+			builder.WriteString(fmt.Sprintf("  default     = %s\n", hclQuote(variable.defaultValue)))
+		}
+		builder.WriteString("}\n\n")
+	}
+	for _, resource := range plan.resources {
+		builder.WriteString(fmt.Sprintf("resource %s %s {\n", hclQuote(resource.resourceType), hclQuote(resource.address)))
+		writeHclBody(&builder, globalResourceMap[resource.resourceType].Schema, resource.values, "  ")
+		builder.WriteString("}\n\n")
+	}
+	return strings.TrimSuffix(builder.String(), "\n")
+}
+
+func setNsxvMigrationPlanData(d *schema.ResourceData, hcl string, report nsxvMigrationReport) error {
+	dSet(d, "hcl", hcl)
+	var untranslated []interface{}
+	for _, issue := range report.Untranslated {
+		untranslated = append(untranslated, map[string]interface{}{
+			"source_type": issue.SourceType,
+			"name":        issue.Name,
+			"id":          issue.Id,
+			"reason":      issue.Reason,
+		})
+	}
+	err := d.Set("untranslated", untranslated)
+	if err != nil {
+		return fmt.Errorf("error setting 'untranslated': %s", err)
+	}
+	reportText, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding report: %s", err)
+	}
+	dSet(d, "report", string(reportText))
+	return nil
+}
+
+// nsxvMigrationDirection converts the direction of an NSX-V firewall rule. An empty direction matches both
+func nsxvMigrationDirection(direction string) string {
+	switch direction {
+	case "in":
+		return "IN"
+	case "out":
+		return "OUT"
+	}
+	return "IN_OUT"
+}
+
+// nsxvMigrationProtocol converts an NSX-V protocol into the protocol of an NSX-T application port profile.
+// It returns an empty string for 'any' and for protocols that can't be converted
+func nsxvMigrationProtocol(protocol string) string {
+	switch strings.ToLower(protocol) {
+	case "tcp":
+		return "TCP"
+	case "udp":
+		return "UDP"
+	case "icmp":
+		return "ICMPv4"
+	}
+	return ""
+}
+
+func nsxvMigrationMonitorType(monitorType string) string {
+	switch strings.ToLower(monitorType) {
+	case "http":
+		return "HTTP"
+	case "https":
+		return "HTTPS"
+	case "tcp":
+		return "TCP"
+	case "udp":
+		return "UDP"
+	case "icmp":
+		return "PING"
+	}
+	return ""
+}
+
+// nsxvMigrationPorts splits a list of NSX-V ports and port ranges (e.g. "80,443,8000-8080"). The port 'any'
+// results in an empty list
+func nsxvMigrationPorts(ports string) []interface{} {
+	var result []string
+	for _, port := range strings.Split(ports, ",") {
+		port = strings.TrimSpace(port)
+		if port != "" && !strings.EqualFold(port, "any") {
+			result = append(result, port)
+		}
+	}
+	return nsxvMigrationStrings(result)
+}
+
+func nsxvMigrationStrings(values []string) []interface{} {
+	sorted := append([]string{}, values...)
+	sort.Strings(sorted)
+	var result []interface{}
+	for _, value := range sorted {
+		result = append(result, value)
+	}
+	return result
+}
+
+// nsxvMigrationCidrs converts the subnets of an NSX-V IPsec VPN tunnel, defined by gateway and netmask, to CIDRs
+func nsxvMigrationCidrs(subnets []*types.IpsecVpnSubnet) ([]interface{}, error) {
+	var cidrs []string
+	for _, subnet := range subnets {
+		gateway := net.ParseIP(subnet.Gateway).To4()
+		netmask := net.ParseIP(subnet.Netmask).To4()
+		if gateway == nil || netmask == nil {
+			return nil, fmt.Errorf("subnet '%s' has an invalid gateway '%s' or netmask '%s'", subnet.Name, subnet.Gateway, subnet.Netmask)
+		}
+		mask := net.IPMask(netmask)
+		ones, bits := mask.Size()
+		if bits == 0 {
+			return nil, fmt.Errorf("subnet '%s' has a non-contiguous netmask '%s'", subnet.Name, subnet.Netmask)
+		}
+		cidrs = append(cidrs, fmt.Sprintf("%s/%d", gateway.Mask(mask), ones))
+	}
+	return nsxvMigrationStrings(cidrs), nil
+}
@@ -0,0 +1,265 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_nsxvMigrationPorts(t *testing.T) {
+	if ports := nsxvMigrationPorts("any"); len(ports) != 0 {
+		t.Errorf("expected no ports for 'any', got %v", ports)
+	}
+	want := []interface{}{"443", "80", "8000-8080"}
+	if ports := nsxvMigrationPorts("80, 443,8000-8080"); !reflect.DeepEqual(ports, want) {
+		t.Errorf("got ports %v, want %v", ports, want)
+	}
+}
+
+func Test_nsxvMigrationCidrs(t *testing.T) {
+	cidrs, err := nsxvMigrationCidrs([]*types.IpsecVpnSubnet{
+		{Name: "web", Gateway: "10.10.10.1", Netmask: "255.255.255.0"},
+		{Name: "db", Gateway: "10.20.0.1", Netmask: "255.255.0.0"},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	want := []interface{}{"10.10.10.0/24", "10.20.0.0/16"}
+	if !reflect.DeepEqual(cidrs, want) {
+		t.Errorf("got CIDRs %v, want %v", cidrs, want)
+	}
+	_, err = nsxvMigrationCidrs([]*types.IpsecVpnSubnet{{Name: "bad", Gateway: "10.10.10.1", Netmask: "255.0.255.0"}})
+	if err == nil {
+		t.Errorf("expected an error for a non-contiguous netmask")
+	}
+}
+
+// nsxvMigrationReasons returns the reasons reported for an NSX-V entity
+func nsxvMigrationReasons(report nsxvMigrationReport, name string) []string {
+	var reasons []string
+	for _, issue := range report.Untranslated {
+		if issue.Name == name {
+			reasons = append(reasons, issue.Reason)
+		}
+	}
+	return reasons
+}
+
+func Test_nsxvMigrationPlan(t *testing.T) {
+	vnic := 0
+	edge := &nsxvMigrationEdge{
+		Name: "edge-v",
+		FirewallRules: []*types.EdgeFirewallRule{
+			{ID: "131073", RuleType: "internal_high", Name: "firewall", Action: "accept", Enabled: true},
+			{
+				ID: "131074", RuleType: "user", Name: "web", Action: "accept", Direction: "in", Enabled: true,
+				Source:      types.EdgeFirewallEndpoint{IpAddresses: []string{"192.168.1.0/24"}},
+				Destination: types.EdgeFirewallEndpoint{GroupingObjectIds: []string{"vdc-uuid:ipset-1"}},
+				Application: types.EdgeFirewallApplication{Services: []types.EdgeFirewallApplicationService{
+					{Protocol: "tcp", Port: "80,443"},
+				}},
+			},
+			{
+				ID: "131075", RuleType: "user", Name: "admin", Action: "reject", Enabled: true,
+				Source: types.EdgeFirewallEndpoint{VnicGroupIds: []string{"vse"}},
+			},
+		},
+		FirewallDefaultAction: "accept",
+		IpSets: []*types.EdgeIpSet{
+			{ID: "vdc-uuid:ipset-1", Name: "web-servers", IPAddresses: "10.10.10.11,10.10.10.12"},
+			{ID: "vdc-uuid:ipset-2", Name: "unused", IPAddresses: "10.10.20.0/24"},
+		},
+		NatRules: []*types.EdgeNatRule{
+			{
+				ID: "196609", RuleType: "user", Action: "dnat", Description: "web-dnat", Enabled: true,
+				OriginalAddress: "203.0.113.10", OriginalPort: "8443", TranslatedAddress: "10.10.10.11",
+				TranslatedPort: "443", Protocol: "tcp",
+			},
+			{
+				ID: "196610", RuleType: "user", Action: "snat", Enabled: true, Vnic: &vnic,
+				OriginalAddress: "10.10.10.0/24", TranslatedAddress: "203.0.113.11",
+			},
+		},
+		LbMonitors: []*types.LbMonitor{{ID: "monitor-1", Name: "http-check", Type: "http", URL: "/health"}},
+		LbPools: []*types.LbPool{{
+			ID: "pool-1", Name: "web-pool", Algorithm: "round-robin", MonitorId: "monitor-1",
+			Members: types.LbPoolMembers{
+				{Name: "web1", IpAddress: "10.10.10.11", Port: 80, Weight: 1, Condition: "enabled"},
+				{Name: "web2", IpAddress: "10.10.10.12", Port: 80, Weight: 3, Condition: "disabled"},
+			},
+		}},
+		LbAppProfiles: []*types.LbAppProfile{{
+			ID: "applicationProfile-1", Name: "web-profile",
+			Persistence: &types.LbAppProfilePersistence{Method: "cookie", CookieName: "JSESSIONID"},
+		}},
+		LbAppRules: []*types.LbAppRule{{ID: "applicationRule-1", Name: "redirect-rule"}},
+		LbVirtualServers: []*types.LbVirtualServer{
+			{
+				ID: "virtualServer-1", Name: "web-vs", Enabled: true, IpAddress: "203.0.113.20", Protocol: "http",
+				Port: 80, ApplicationProfileId: "applicationProfile-1", DefaultPoolId: "pool-1",
+				ApplicationRuleIds: []string{"applicationRule-1"},
+			},
+			{ID: "virtualServer-2", Name: "no-pool-vs", Enabled: true, IpAddress: "203.0.113.21", Protocol: "tcp", Port: 22},
+		},
+		IpsecTunnels: []*types.GatewayIpsecVpnTunnel{{
+			Name: "branch", PeerIPAddress: "198.51.100.1", PeerID: "198.51.100.1", LocalIPAddress: "203.0.113.1",
+			LocalID: "203.0.113.1", SharedSecret: "do-not-leak", EncryptionProtocol: "AES256", Mtu: 1500, IsEnabled: true,
+			LocalSubnet: []*types.IpsecVpnSubnet{{Name: "local", Gateway: "10.10.10.1", Netmask: "255.255.255.0"}},
+			PeerSubnet:  []*types.IpsecVpnSubnet{{Name: "peer", Gateway: "172.16.0.1", Netmask: "255.255.0.0"}},
+		}},
+	}
+
+	plan := newNsxvMigrationPlan("my-org", "nsxv-vdc", edge.Name, "urn:vcloud:gateway:1", "urn:vcloud:vdc:2")
+	plan.translate(edge)
+	hcl := plan.hcl()
+
+	wantResources := map[string]int{
+		"vcloud_nsxt_ip_set":              2,
+		"vcloud_nsxt_app_port_profile":    2,
+		"vcloud_nsxt_firewall":            1,
+		"vcloud_nsxt_nat_rule":            2,
+		"vcloud_nsxt_alb_pool":            1,
+		"vcloud_nsxt_alb_virtual_service": 1,
+		"vcloud_nsxt_ipsec_vpn_tunnel":    1,
+	}
+	if !reflect.DeepEqual(plan.report.Resources, wantResources) {
+		t.Errorf("got resources %v, want %v", plan.report.Resources, wantResources)
+	}
+
+	for _, expected := range []string{
+		`default     = "urn:vcloud:gateway:1"`,
+		`resource "vcloud_nsxt_ip_set" "web-servers" {`,
+		`ip_addresses    = ["10.10.10.11", "10.10.10.12"]`,
+		`destination_ids      = [vcloud_nsxt_ip_set.web-servers.id]`,
+		`source_ids           = [vcloud_nsxt_ip_set.web-source.id]`,
+		`app_port_profile_ids = [vcloud_nsxt_app_port_profile.web.id]`,
+		`port     = ["443", "80"]`,
+		`name        = "default-policy"`,
+		`dnat_external_port  = "8443"`,
+		`app_port_profile_id = vcloud_nsxt_app_port_profile.web-dnat.id`,
+		`firewall_match   = "MATCH_INTERNAL_ADDRESS"`,
+		`algorithm       = "ROUND_ROBIN"`,
+		`service_engine_group_id  = var.alb_service_engine_group_id`,
+		`pool_id                  = vcloud_nsxt_alb_pool.web-pool.id`,
+		`value = "JSESSIONID"`,
+		`pre_shared_key    = var.ipsec_psk_branch`,
+		`local_networks    = ["10.10.10.0/24"]`,
+		`remote_networks   = ["172.16.0.0/16"]`,
+	} {
+		if !strings.Contains(hcl, expected) {
+			t.Errorf("expected generated configuration to contain '%s'", expected)
+		}
+	}
+	if strings.Contains(hcl, "do-not-leak") {
+		t.Errorf("the pre-shared key must not be written in the generated configuration")
+	}
+	if strings.Contains(hcl, `"unused"`) || strings.Contains(hcl, `"firewall"`) {
+		t.Errorf("unused IP sets and internal firewall rules must not be translated")
+	}
+
+	// The DROP rule with an untranslated source must drop traffic from any source
+	var rules []interface{}
+	for _, resource := range plan.resources {
+		if resource.resourceType == "vcloud_nsxt_firewall" {
+			rules = resource.values["rule"].([]interface{})
+		}
+	}
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 firewall rules, got %d", len(rules))
+	}
+	admin := rules[1].(map[string]interface{})
+	if admin["name"] != "admin" || admin["enabled"] != true || admin["action"] != "DROP" || len(admin["source_ids"].([]interface{})) != 0 {
+		t.Errorf("unexpected translation of rule 'admin': %v", admin)
+	}
+	if web := rules[0].(map[string]interface{}); web["enabled"] != true || web["direction"] != "IN" {
+		t.Errorf("unexpected translation of rule 'web': %v", web)
+	}
+
+	for name, count := range map[string]int{
+		"admin":       2, // vnic group, reject
+		"snat-196610": 1, // interface
+		"web-pool":    1, // monitor settings
+		"web-vs":      1, // application rule
+		"no-pool-vs":  1,
+		"branch":      2, // local IP address, encryption protocol
+	} {
+		if reasons := nsxvMigrationReasons(plan.report, name); len(reasons) != count {
+			t.Errorf("expected %d untranslated features for '%s', got %v", count, name, reasons)
+		}
+	}
+}
+
+// nsxvMigrationFirewallRules returns the rules of the generated vcloud_nsxt_firewall, or nil when it was not generated
+func nsxvMigrationFirewallRules(plan *nsxvMigrationPlan) []interface{} {
+	for _, resource := range plan.resources {
+		if resource.resourceType == "vcloud_nsxt_firewall" {
+			return resource.values["rule"].([]interface{})
+		}
+	}
+	return nil
+}
+
+func Test_nsxvMigrationPlanFirewallPartialRules(t *testing.T) {
+	edge := &nsxvMigrationEdge{
+		Name: "edge-v",
+		FirewallRules: []*types.EdgeFirewallRule{
+			{
+				ID: "131074", RuleType: "user", Name: "deny-outside", Action: "deny", Direction: "in", Enabled: true,
+				Source:      types.EdgeFirewallEndpoint{Exclude: true, IpAddresses: []string{"10.0.0.0/8"}},
+				Destination: types.EdgeFirewallEndpoint{IpAddresses: []string{"192.168.1.10"}},
+			},
+			{
+				ID: "131075", RuleType: "user", Name: "allow-outside", Action: "accept", Enabled: true,
+				Destination: types.EdgeFirewallEndpoint{Exclude: true, IpAddresses: []string{"192.168.1.10"}},
+			},
+		},
+		FirewallDefaultAction: "accept",
+	}
+	plan := newNsxvMigrationPlan("my-org", "nsxv-vdc", edge.Name, "", "")
+	plan.translate(edge)
+
+	rules := nsxvMigrationFirewallRules(plan)
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 firewall rules, got %d", len(rules))
+	}
+	// The negated source can't be translated: the DROP rule must stay enabled, and drop traffic from any source,
+	// otherwise 'default-policy' would allow the traffic that NSX-V dropped
+	deny := rules[0].(map[string]interface{})
+	if deny["action"] != "DROP" || deny["enabled"] != true || len(deny["source_ids"].([]interface{})) != 0 ||
+		!reflect.DeepEqual(deny["destination_ids"], []interface{}{hclExpression("vcloud_nsxt_ip_set.deny-outside-destination.id")}) {
+		t.Errorf("unexpected translation of rule 'deny-outside': %v", deny)
+	}
+	if allow := rules[1].(map[string]interface{}); allow["action"] != "ALLOW" || allow["enabled"] != false {
+		t.Errorf("unexpected translation of rule 'allow-outside': %v", allow)
+	}
+	if last := rules[2].(map[string]interface{}); last["name"] != "default-policy" || last["action"] != "ALLOW" {
+		t.Errorf("expected 'default-policy' as last rule, got %v", last)
+	}
+	if plan.report.Resources["vcloud_nsxt_ip_set"] != 2 {
+		t.Errorf("expected only the IP sets used by the rules, got %v", plan.report.Resources)
+	}
+	reasons := nsxvMigrationReasons(plan.report, "deny-outside")
+	if len(reasons) != 1 || !strings.HasSuffix(reasons[0], "the NSX-T rule drops traffic from any source") {
+		t.Errorf("unexpected untranslated features for 'deny-outside': %v", reasons)
+	}
+
+	// A DROP rule matching translated addresses can't be widened: the firewall is not translated
+	matchTranslated := true
+	edge.FirewallRules[0].MatchTranslated = &matchTranslated
+	plan = newNsxvMigrationPlan("my-org", "nsxv-vdc", edge.Name, "", "")
+	plan.translate(edge)
+	if rules := nsxvMigrationFirewallRules(plan); rules != nil {
+		t.Errorf("expected no firewall, got %v", rules)
+	}
+	if len(plan.report.Resources) != 0 {
+		t.Errorf("expected no resources, got %v", plan.report.Resources)
+	}
+	reasons = nsxvMigrationReasons(plan.report, "deny-outside")
+	if len(reasons) != 1 || !strings.HasSuffix(reasons[0], "the firewall is not translated") {
+		t.Errorf("unexpected untranslated features for 'deny-outside': %v", reasons)
+	}
+}
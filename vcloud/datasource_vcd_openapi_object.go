@@ -0,0 +1,99 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func datasourceVcdOpenApiObject() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdOpenApiObjectRead,
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description: "OpenAPI endpoint of the object, relative to '/cloudapi/' (e.g. '1.0.0/ipSpaces/urn:vcloud:ipSpace:...'). " +
+					"When 'filter' is set, it is the endpoint of the collection",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "FIQL filter to search the object in the collection (e.g. 'name==my-ip-space'). Exactly one object must match",
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API version used in the requests. Defaults to the version negotiated by the provider",
+			},
+			"id_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "id",
+				Description: "JSON path of the ID in the object (e.g. 'id' or 'entity.id'). The endpoint is used as ID when the object has no such field",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the object, as returned by VCD",
+			},
+		},
+	}
+}
+
+func datasourceVcdOpenApiObjectRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	apiVersion := firstNonEmpty(d.Get("api_version").(string), vcdClient.Client.APIVersion)
+	filter := d.Get("filter").(string)
+	endpoint := normalizeOpenApiObjectEndpoint(d.Get("endpoint").(string), filter != "")
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var object interface{}
+	if filter != "" {
+		var items []interface{}
+		err = vcdClient.Client.OpenApiGetAllItems(apiVersion, urlRef, url.Values{"filter": []string{filter}}, &items, nil)
+		if err != nil {
+			return diag.Errorf("error retrieving OpenAPI objects from %s: %s", endpoint, err)
+		}
+		if len(items) == 0 {
+			return diag.Errorf("%s: no OpenAPI object found in %s with filter '%s'", govcd.ErrorEntityNotFound, endpoint, filter)
+		}
+		if len(items) > 1 {
+			return diag.Errorf("found %d OpenAPI objects in %s with filter '%s', expected exactly one", len(items), endpoint, filter)
+		}
+		object = items[0]
+	} else {
+		err = vcdClient.Client.OpenApiGetItem(apiVersion, urlRef, nil, &object, nil)
+		if err != nil {
+			return diag.Errorf("error retrieving OpenAPI object %s: %s", endpoint, err)
+		}
+	}
+
+	response, err := json.Marshal(object)
+	if err != nil {
+		return diag.Errorf("error encoding OpenAPI object %s: %s", endpoint, err)
+	}
+	dSet(d, "response", string(response))
+
+	// Single documents, such as the DNS configuration of an Edge Gateway, have no ID of their own
+	id, err := getOpenApiObjectId(object, d.Get("id_path").(string))
+	if err != nil {
+		if filter != "" {
+			return diag.FromErr(fmt.Errorf("error getting the ID of the OpenAPI object found in %s: %s", endpoint, err))
+		}
+		id = endpoint
+	}
+	d.SetId(id)
+	return nil
+}
@@ -48,8 +48,24 @@ func datasourceVcdOrgVdc() *schema.Resource {
 				Description: "Organization to create the VDC in",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "filter"},
+				Description:  "Name of the VDC (Optional if 'filter' is used)",
+			},
+			"filter": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				MinItems:     1,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "filter"},
+				Description:  "Criteria for retrieving a VDC by various attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"metadata":   elementMetadata,
+					},
+				},
 			},
 			"description": {
 				Type:     schema.TypeString,
@@ -250,11 +266,26 @@ func datasourceVcdOrgVdcRead(_ context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf(errorRetrievingOrg, err)
 	}
 
+	if !nameOrFilterIsSet(d) {
+		return diag.Errorf(noNameOrFilterError, "vcd_org_vdc")
+	}
+
 	vdcName := d.Get("name").(string)
-	adminVdc, err := adminOrg.GetAdminVDCByName(vdcName, false)
-	if err != nil {
-		log.Printf("[DEBUG] Unable to find VDC")
-		return diag.Errorf("unable to find VDC %s", err)
+	var adminVdc *govcd.AdminVdc
+	filter, hasFilter := d.GetOk("filter")
+	if hasFilter {
+		adminVdc, err = getOrgVdcByFilter(adminOrg, filter, vcdClient.Client.IsSysAdmin)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		vdcName = adminVdc.AdminVdc.Name
+		dSet(d, "name", vdcName)
+	} else {
+		adminVdc, err = adminOrg.GetAdminVDCByName(vdcName, false)
+		if err != nil {
+			log.Printf("[DEBUG] Unable to find VDC")
+			return diag.Errorf("unable to find VDC %s", err)
+		}
 	}
 
 	d.SetId(adminVdc.AdminVdc.ID)
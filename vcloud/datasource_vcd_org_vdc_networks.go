@@ -0,0 +1,238 @@
+package vcloud
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// orgVdcNetworkTypes maps the network types of VCD to the ones shown in vcd_org_vdc_networks
+var orgVdcNetworkTypes = map[string]string{
+	types.OrgVdcNetworkTypeRouted:   "routed",
+	types.OrgVdcNetworkTypeIsolated: "isolated",
+	types.OrgVdcNetworkTypeOpaque:   "imported",
+	types.OrgVdcNetworkTypeDirect:   "direct",
+}
+
+func datasourceVcdOrgVdcNetworks() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdOrgVdcNetworksRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"owner_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "ID of VDC or VDC Group. When not set, the networks of all the VDCs and VDC Groups of the Org are returned",
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Criteria for retrieving the networks. When not set, all the networks are returned",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"ip":         elementIp,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
+			"networks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Org VDC networks matching the filter",
+				Elem:        orgVdcNetworksItem,
+			},
+		},
+	}
+}
+
+var orgVdcNetworksItem = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the network",
+		},
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the network",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the network",
+		},
+		"type": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the network: 'routed', 'isolated', 'imported' or 'direct'",
+		},
+		"status": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Status of the network",
+		},
+		"owner_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the VDC or VDC Group that owns the network",
+		},
+		"owner_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the VDC or VDC Group that owns the network",
+		},
+		"edge_gateway_id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "ID of the Edge Gateway of a routed network",
+		},
+		"is_shared": {
+			Type:        schema.TypeBool,
+			Computed:    true,
+			Description: "Whether the network is shared with the other VDCs of the Org",
+		},
+		"gateway": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Gateway IP address",
+		},
+		"prefix_length": {
+			Type:        schema.TypeInt,
+			Computed:    true,
+			Description: "Network prefix",
+		},
+		"dns1": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "DNS server 1",
+		},
+		"dns2": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "DNS server 2",
+		},
+		"dns_suffix": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "DNS suffix",
+		},
+		"static_ip_pool": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "IP ranges used for static pool allocation in the network",
+			Elem:        networkV2IpRangeComputed,
+		},
+		"metadata": {
+			Type:        schema.TypeMap,
+			Computed:    true,
+			Elem:        &schema.Schema{Type: schema.TypeString},
+			Description: "Values of the OpenAPI metadata of the network, by key",
+		},
+	},
+}
+
+func datasourceVcdOrgVdcNetworksRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	org, err := vcdClient.GetOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("error retrieving Org: %s", err)
+	}
+
+	ownerId := d.Get("owner_id").(string)
+	networks, err := getAllOpenApiOrgVdcNetworksByFilterInOwner(vcdClient, org, ownerId, d.Get("filter"))
+	if err != nil {
+		return diag.Errorf("error retrieving Org VDC networks: %s", err)
+	}
+
+	items := make([]interface{}, len(networks))
+	for i, network := range networks {
+		items[i], err = flattenOrgVdcNetworksItem(vcdClient, network)
+		if err != nil {
+			return diag.Errorf("error reading Org VDC network %s: %s", network.OpenApiOrgVdcNetwork.Name, err)
+		}
+	}
+
+	err = d.Set("networks", items)
+	if err != nil {
+		return diag.Errorf("error setting Org VDC networks: %s", err)
+	}
+	if ownerId != "" {
+		d.SetId(ownerId)
+	} else {
+		d.SetId(org.Org.ID)
+	}
+	return nil
+}
+
+// flattenOrgVdcNetworksItem converts a network into an element of the list of vcd_org_vdc_networks
+func flattenOrgVdcNetworksItem(vcdClient *VCDClient, network *govcd.OpenApiOrgVdcNetwork) (map[string]interface{}, error) {
+	orgVdcNetwork := network.OpenApiOrgVdcNetwork
+	networkType, ok := orgVdcNetworkTypes[orgVdcNetwork.NetworkType]
+	if !ok {
+		networkType = strings.ToLower(orgVdcNetwork.NetworkType)
+	}
+	item := map[string]interface{}{
+		"id":          orgVdcNetwork.ID,
+		"name":        orgVdcNetwork.Name,
+		"description": orgVdcNetwork.Description,
+		"type":        networkType,
+		"status":      orgVdcNetwork.Status,
+		"is_shared":   orgVdcNetwork.Shared != nil && *orgVdcNetwork.Shared,
+	}
+	if orgVdcNetwork.OwnerRef != nil {
+		item["owner_id"] = orgVdcNetwork.OwnerRef.ID
+		item["owner_name"] = orgVdcNetwork.OwnerRef.Name
+	}
+	if orgVdcNetwork.Connection != nil {
+		item["edge_gateway_id"] = orgVdcNetwork.Connection.RouterRef.ID
+	}
+
+	// Only the primary subnet is shown, as the networks of the other data sources do
+	if len(orgVdcNetwork.Subnets.Values) > 0 {
+		subnet := orgVdcNetwork.Subnets.Values[0]
+		item["gateway"] = subnet.Gateway
+		item["prefix_length"] = subnet.PrefixLength
+		item["dns1"] = subnet.DNSServer1
+		item["dns2"] = subnet.DNSServer2
+		item["dns_suffix"] = subnet.DNSSuffix
+		ipRanges := make([]interface{}, len(subnet.IPRanges.Values))
+		for j, ipRange := range subnet.IPRanges.Values {
+			ipRanges[j] = map[string]interface{}{
+				"start_address": ipRange.StartAddress,
+				"end_address":   ipRange.EndAddress,
+			}
+		}
+		item["static_ip_pool"] = ipRanges
+	}
+
+	metadata, err := openApiMetadataToFilterValues(openApiOrgVdcNetworkMetadata(vcdClient, openApiOrgVdcNetworkResourceType(network), orgVdcNetwork))
+	if err != nil {
+		return nil, err
+	}
+	item["metadata"] = metadata
+	return item, nil
+}
+
+// openApiOrgVdcNetworkResourceType returns the type of the resource that manages the given network
+func openApiOrgVdcNetworkResourceType(network *govcd.OpenApiOrgVdcNetwork) string {
+	switch {
+	case network.IsRouted():
+		return "vcd_network_routed_v2"
+	case network.IsImported():
+		return "vcd_nsxt_network_imported"
+	default:
+		return "vcd_network_isolated_v2"
+	}
+}
@@ -0,0 +1,269 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// queryMaxPageSize is the largest page that the query service returns
+const queryMaxPageSize = 128
+
+// datasourceVcdQuery runs any query of the VCD query service (/api/query) and returns the records as maps.
+// Unlike the data sources that search by filter, it does not convert the records into specific types, and
+// can then be used with the query types that have no dedicated data source.
+func datasourceVcdQuery() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdQueryRead,
+		Schema: map[string]*schema.Schema{
+			"query_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "Type of the query (e.g. 'adminVM', 'adminVApp', 'orgVdcNetwork', 'task', 'event')",
+			},
+			"filter": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Filter expression of the query service (e.g. 'status==POWERED_ON;name==web*')",
+			},
+			"fields": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "Fields to include in the records. Metadata is selected with 'metadata:key' or 'metadata@SYSTEM:key'",
+			},
+			"sort_asc": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"sort_desc"},
+				Description:   "Field used to sort the records in ascending order",
+			},
+			"sort_desc": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"sort_asc"},
+				Description:   "Field used to sort the records in descending order",
+			},
+			"page": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "Page to retrieve. When not set, all the pages are retrieved",
+			},
+			"page_size": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      queryMaxPageSize,
+				ValidateFunc: validation.IntBetween(1, queryMaxPageSize),
+				Description:  "Number of records in each page",
+			},
+			"format": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "records",
+				ValidateFunc: validation.StringInSlice([]string{"records", "idrecords"}, false),
+				Description:  "Format of the records. 'idrecords' returns URNs instead of HREFs in the 'id' field",
+			},
+			"total": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Total number of records matching the query, regardless of the paging",
+			},
+			"records": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeMap,
+					Elem: &schema.Schema{Type: schema.TypeString},
+				},
+				Description: "Records returned by the query. Metadata values are stored with key 'metadata:key'",
+			},
+		},
+	}
+}
+
+func datasourceVcdQueryRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	queryType := d.Get("query_type").(string)
+
+	params := map[string]string{
+		"type":   queryType,
+		"format": d.Get("format").(string),
+	}
+	if fields := convertTypeListToSliceOfStrings(d.Get("fields").([]interface{})); len(fields) > 0 {
+		params["fields"] = strings.Join(fields, ",")
+	}
+	if sortAsc := d.Get("sort_asc").(string); sortAsc != "" {
+		params["sortAsc"] = sortAsc
+	}
+	if sortDesc := d.Get("sort_desc").(string); sortDesc != "" {
+		params["sortDesc"] = sortDesc
+	}
+	// Like in the rest of the query service calls, the filter is passed without encoding, as VCD expects
+	// the operators to be sent as they are
+	notEncodedParams := map[string]string{}
+	if filter := d.Get("filter").(string); filter != "" {
+		notEncodedParams["filter"] = filter
+	}
+
+	records, total, err := queryRecords(&vcdClient.Client, params, notEncodedParams, d.Get("page").(int), d.Get("page_size").(int))
+	if err != nil {
+		return diag.Errorf("error running query of type '%s': %s", queryType, err)
+	}
+
+	var rawRecords = make([]interface{}, len(records))
+	for i, record := range records {
+		rawRecord := make(map[string]interface{}, len(record))
+		for key, value := range record {
+			rawRecord[key] = value
+		}
+		rawRecords[i] = rawRecord
+	}
+	dSet(d, "total", total)
+	err = d.Set("records", rawRecords)
+	if err != nil {
+		return diag.Errorf("error setting records: %s", err)
+	}
+	d.SetId(queryType)
+	return nil
+}
+
+// queryRecords runs a query and returns its records as maps, with the total number of records matching the query.
+// When page is 0, all the pages are retrieved
+func queryRecords(client *govcd.Client, params, notEncodedParams map[string]string, page, pageSize int) ([]map[string]string, int, error) {
+	var records []map[string]string
+	currentPage := page
+	if currentPage == 0 {
+		currentPage = 1
+	}
+	for {
+		params["page"] = strconv.Itoa(currentPage)
+		params["pageSize"] = strconv.Itoa(pageSize)
+		result, err := queryRecordsPage(client, params, notEncodedParams)
+		if err != nil {
+			return nil, 0, err
+		}
+		records = append(records, result.Records...)
+		if page != 0 || len(result.Records) == 0 || len(records) >= result.Total {
+			return records, result.Total, nil
+		}
+		currentPage++
+	}
+}
+
+// queryRecordsPage retrieves a single page of a query
+func queryRecordsPage(client *govcd.Client, params, notEncodedParams map[string]string) (*queryRecordsResult, error) {
+	queryUrl := client.VCDHREF
+	queryUrl.Path += "/query"
+	req := client.NewRequestWitNotEncodedParams(params, notEncodedParams, http.MethodGet, queryUrl, nil)
+	req.Header.Add("Accept", "vnd.vmware.vcloud.org+xml;version="+client.APIVersion)
+
+	resp, err := client.Http.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, govcd.ParseErr(types.BodyTypeXML, resp, &types.Error{})
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	result := &queryRecordsResult{}
+	err = xml.Unmarshal(body, result)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding query result: %s", err)
+	}
+	return result, nil
+}
+
+// queryRecordsResult is the generic form of a QueryResultRecords document, where every record becomes a map of its
+// attributes. Metadata entries are added to the map with key "metadata:key"
+type queryRecordsResult struct {
+	Total   int
+	Records []map[string]string
+}
+
+// UnmarshalXML decodes the records of any query type
+func (result *queryRecordsResult) UnmarshalXML(decoder *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "total" {
+			result.Total, _ = strconv.Atoi(attr.Value)
+		}
+	}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if !strings.HasSuffix(element.Name.Local, "Record") {
+				if err := decoder.Skip(); err != nil {
+					return err
+				}
+				continue
+			}
+			record, err := decodeQueryRecord(decoder, element)
+			if err != nil {
+				return err
+			}
+			result.Records = append(result.Records, record)
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// decodeQueryRecord converts a record element into a map with its attributes and metadata values
+func decodeQueryRecord(decoder *xml.Decoder, start xml.StartElement) (map[string]string, error) {
+	record := make(map[string]string)
+	for _, attr := range start.Attr {
+		// Namespace declarations and attributes such as xsi:type are not part of the record
+		if attr.Name.Space != "" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		record[attr.Name.Local] = attr.Value
+	}
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch element := token.(type) {
+		case xml.StartElement:
+			if element.Name.Local != "Metadata" {
+				if err := decoder.Skip(); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			var metadata types.Metadata
+			if err := decoder.DecodeElement(&metadata, &element); err != nil {
+				return nil, err
+			}
+			for _, entry := range metadata.MetadataEntry {
+				if entry.TypedValue != nil {
+					record["metadata:"+entry.Key] = entry.TypedValue.Value
+				}
+			}
+		case xml.EndElement:
+			return record, nil
+		}
+	}
+}
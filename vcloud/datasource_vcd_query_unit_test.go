@@ -0,0 +1,71 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"encoding/xml"
+	"reflect"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestMockVcdQuery(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	all := readMockDataSource(t, vcdClient, "vcloud_query", map[string]interface{}{
+		"query_type": types.QtAdminVm,
+		"sort_asc":   "name",
+	})
+	if all.Get("total").(int) != 2 || len(all.Get("records").([]interface{})) != 2 {
+		t.Fatalf("expected 2 VMs, got total %d and records %v", all.Get("total"), all.Get("records"))
+	}
+
+	filtered := readMockDataSource(t, vcdClient, "vcloud_query", map[string]interface{}{
+		"query_type": types.QtAdminVm,
+		"filter":     "name==tf_vm1",
+		"fields":     []interface{}{"name", "numberOfCpus"},
+	})
+	records := filtered.Get("records").([]interface{})
+	if len(records) != 1 {
+		t.Fatalf("expected 1 VM, got %v", records)
+	}
+	record := records[0].(map[string]interface{})
+	if record["name"] != "tf_vm1" || record["numberOfCpus"] != "2" || record["containerName"] != "tf_vapp" {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestQueryRecordsResultUnmarshal(t *testing.T) {
+	document := `<?xml version="1.0" encoding="UTF-8"?>
+<QueryResultRecords xmlns="http://www.vmware.com/vcloud/v1.5" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance" total="5" page="1" pageSize="2">
+  <Link rel="nextPage" href="https://vcd.example.com/api/query?type=event&amp;page=2"/>
+  <EventRecord entityName="web-01" eventStatus="0" eventType="vc/vm/poweredOn">
+    <Link rel="entity" href="https://vcd.example.com/api/vApp/vm-1"/>
+  </EventRecord>
+  <EventRecord entityName="web-02" eventStatus="1" eventType="vc/vm/poweredOff">
+    <Metadata>
+      <MetadataEntry>
+        <Key>owner</Key>
+        <TypedValue xsi:type="MetadataStringValue"><Value>team-a</Value></TypedValue>
+      </MetadataEntry>
+    </Metadata>
+  </EventRecord>
+</QueryResultRecords>`
+
+	var result queryRecordsResult
+	err := xml.Unmarshal([]byte(document), &result)
+	if err != nil {
+		t.Fatalf("error decoding query result: %s", err)
+	}
+	want := queryRecordsResult{
+		Total: 5,
+		Records: []map[string]string{
+			{"entityName": "web-01", "eventStatus": "0", "eventType": "vc/vm/poweredOn"},
+			{"entityName": "web-02", "eventStatus": "1", "eventType": "vc/vm/poweredOff", "metadata:owner": "team-a"},
+		},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Errorf("decoded query result = %v, want %v", result, want)
+	}
+}
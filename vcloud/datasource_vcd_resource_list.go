@@ -2,6 +2,7 @@ package vcloud
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -12,6 +13,7 @@ import (
 	"os"
 	"regexp"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -22,7 +24,23 @@ type resourceRef struct {
 	id           string
 	href         string
 	parent       string
+	parentId     string
 	importId     bool
+	importPath   string            // full import identifier, when it can't be built from the ancestors and the name
+	attributes   map[string]string // key attributes, only used in list_mode "json"
+}
+
+// resourceListJsonItem is the structure of each item in list_mode "json"
+type resourceListJsonItem struct {
+	Name         string            `json:"name"`
+	Id           string            `json:"id,omitempty"`
+	Href         string            `json:"href,omitempty"`
+	ResourceType string            `json:"resource_type"`
+	Parent       string            `json:"parent,omitempty"`
+	ParentId     string            `json:"parent_id,omitempty"`
+	Ancestors    []string          `json:"ancestors,omitempty"`
+	ImportId     string            `json:"import_id,omitempty"`
+	Attributes   map[string]string `json:"attributes,omitempty"`
 }
 
 func datasourceVcdResourceList() *schema.Resource {
@@ -82,12 +100,14 @@ func datasourceVcdResourceList() *schema.Resource {
 					"import",    // The list will contain the terraform import command
 					"name_id",   // The list will contain name + ID for each item
 					"hierarchy", // The list will contain parent names + resource name for each item
+					"generate",  // The list will contain resource address + import ID, and the configuration is written to 'import_file_name'
+					"json",      // The list will contain a JSON object for each item, with IDs of the item and its parent, and key attributes
 				}, true),
 			},
 			"import_file_name": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				Description: "File where to store the import info - Only used with 'import' list mode ",
+				Description: "File where to store the import info - Only used with 'import' and 'generate' list modes",
 			},
 			"name_regex": {
 				Type:         schema.TypeString,
@@ -588,6 +608,7 @@ func orgNetworkListV2(d *schema.ResourceData, meta interface{}) (list []string,
 			id:           net.OpenApiOrgVdcNetwork.ID,
 			href:         href.Path,
 			parent:       vdcName,
+			parentId:     vdc.Vdc.ID,
 			importId:     false,
 			resourceType: trueResourceType,
 		})
@@ -864,11 +885,16 @@ func getNsxtEdgeGatewayList(d *schema.ResourceData, meta interface{}) (list []st
 	}
 	for _, nsxtEdgeGateway := range nsxtEdgeGatewayList {
 
+		parentId := ""
+		if nsxtEdgeGateway.EdgeGateway.OwnerRef != nil {
+			parentId = nsxtEdgeGateway.EdgeGateway.OwnerRef.ID
+		}
 		items = append(items, resourceRef{
-			name:   nsxtEdgeGateway.EdgeGateway.Name,
-			id:     nsxtEdgeGateway.EdgeGateway.ID,
-			href:   "",
-			parent: parentName,
+			name:     nsxtEdgeGateway.EdgeGateway.Name,
+			id:       nsxtEdgeGateway.EdgeGateway.ID,
+			href:     "",
+			parent:   parentName,
+			parentId: parentId,
 		})
 	}
 	return genericResourceList(d, "vcd_nsxt_edgegateway", ancestors, items)
@@ -893,10 +919,11 @@ func diskList(d *schema.ResourceData, meta interface{}) (list []string, err erro
 	}
 	for _, diskRef := range *disks {
 		items = append(items, resourceRef{
-			name:   diskRef.Name,
-			id:     extractUuid(diskRef.HREF),
-			href:   diskRef.HREF,
-			parent: vdc.Vdc.Name,
+			name:     diskRef.Name,
+			id:       extractUuid(diskRef.HREF),
+			href:     diskRef.HREF,
+			parent:   vdc.Vdc.Name,
+			parentId: vdc.Vdc.ID,
 		})
 	}
 	return genericResourceList(d, "vcd_independent_disk", []string{org.Org.Name, vdc.Vdc.Name}, items)
@@ -919,10 +946,11 @@ func vappList(d *schema.ResourceData, meta interface{}, resType string) (list []
 		for _, resourceReference := range resourceEntities.ResourceEntity {
 			if resourceReference.Type == "application/vnd.vmware.vcloud.vApp+xml" {
 				items = append(items, resourceRef{
-					name:   resourceReference.Name,
-					id:     resourceReference.ID,
-					href:   resourceReference.HREF,
-					parent: vdc.Vdc.Name,
+					name:     resourceReference.Name,
+					id:       resourceReference.ID,
+					href:     resourceReference.HREF,
+					parent:   vdc.Vdc.Name,
+					parentId: vdc.Vdc.ID,
 				})
 			}
 		}
@@ -958,7 +986,8 @@ func vmList(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (list []s
 			name:     vm.Name,
 			id:       "urn:vcloud:vm:" + extractUuid(vm.HREF),
 			href:     vm.HREF,
-			parent:   vm.ContainerName,           // name of the hidden vApp
+			parent:   vm.ContainerName, // name of the hidden vApp
+			parentId: "urn:vcloud:vapp:" + extractUuid(vm.ContainerID),
 			importId: vmType == standaloneVmType, // import should use entity ID rather than name
 		})
 	}
@@ -1012,15 +1041,10 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 		case "href":
 			list = append(list, ref.href)
 		case "import":
-			identifier := ref.name
-			if ref.importId {
-				identifier = ref.id
-			}
-			list = append(list, fmt.Sprintf("terraform import %s.%s %s%s%s",
+			identifier := resourceListImportId(ref, ancestors)
+			list = append(list, fmt.Sprintf("terraform import %s.%s %s",
 				resourceType,
 				ref.name,
-				strings.Join(ancestors, ImportSeparator),
-				ImportSeparator,
 				identifier))
 
 			ancestorsText := ""
@@ -1030,13 +1054,29 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 			importData.WriteString(fmt.Sprintf("# Import directive for %s %s%s \n", resourceType, ancestorsText, ref.name))
 			importData.WriteString("import {\n")
 			importData.WriteString(fmt.Sprintf("  to = %s.%s-%s\n", resourceType, ref.name, idTail(ref.id)))
-			if len(ancestors) > 0 {
-				importData.WriteString(fmt.Sprintf("  id = \"%s%s%s\"\n",
-					strings.Join(ancestors, ImportSeparator), ImportSeparator, identifier))
-			} else {
-				importData.WriteString(fmt.Sprintf("  id = \"%s\"\n", identifier))
-			}
+			importData.WriteString(fmt.Sprintf("  id = \"%s\"\n", identifier))
 			importData.WriteString("}\n\n")
+		case "generate":
+			list = append(list, fmt.Sprintf("%s.%s %s",
+				providerResourceType(resourceType),
+				resourceListAddress(ref.name, firstNonEmpty(ref.id, ref.href)),
+				resourceListImportId(ref, ancestors)))
+		case "json":
+			item, err := json.Marshal(resourceListJsonItem{
+				Name:         ref.name,
+				Id:           ref.id,
+				Href:         ref.href,
+				ResourceType: providerResourceType(resourceType),
+				Parent:       ref.parent,
+				ParentId:     ref.parentId,
+				Ancestors:    ancestors,
+				ImportId:     resourceListImportId(ref, ancestors),
+				Attributes:   ref.attributes,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("error encoding %s '%s' as JSON: %s", resourceType, ref.name, err)
+			}
+			list = append(list, string(item))
 		}
 	}
 
@@ -1049,6 +1089,22 @@ func genericResourceList(d *schema.ResourceData, resType string, ancestors []str
 	return list, nil
 }
 
+// resourceListImportId returns the identifier used to import the resource: the ancestors followed by either
+// the name or the ID, unless the reference defines its own import path
+func resourceListImportId(ref resourceRef, ancestors []string) string {
+	if ref.importPath != "" {
+		return ref.importPath
+	}
+	identifier := ref.name
+	if ref.importId {
+		identifier = ref.id
+	}
+	if len(ancestors) > 0 {
+		identifier = strings.Join(ancestors, ImportSeparator) + ImportSeparator + identifier
+	}
+	return identifier
+}
+
 func idTail(id string) string {
 	if id == "" {
 		return ""
@@ -1262,6 +1318,488 @@ func nsxvNatRuleList(natType string, d *schema.ResourceData, meta interface{}) (
 	return genericResourceList(d, "vcd_lb_app_profile", []string{orgName, vdcName, edgeGateway.EdgeGateway.Name}, items)
 }
 
+// getNsxtEdgeGatewayDetails retrieves the NSX-T edge gateway given as "parent", which belongs to the VDC or VDC Group
+// given as "vdc" (or to the VDC defined in the provider)
+func getNsxtEdgeGatewayDetails(d *schema.ResourceData, meta interface{}) (orgName string, vdcOrVdcGroupName string, egw *govcd.NsxtEdgeGateway, err error) {
+	client := meta.(*VCDClient)
+
+	edgeGatewayName := d.Get("parent").(string)
+	if edgeGatewayName == "" {
+		return "", "", nil, fmt.Errorf(`NSX-T edge gateway name (as "parent") is required for this task`)
+	}
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return "", "", nil, err
+	}
+	vdcOrVdcGroupName = d.Get("vdc").(string)
+	if vdcOrVdcGroupName == "" {
+		vdcOrVdcGroupName = client.Vdc
+	}
+	if vdcOrVdcGroupName == "" {
+		return "", "", nil, fmt.Errorf("VDC or VDC group name not given either as 'vdc' or at provider level")
+	}
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(client, adminOrg.AdminOrg.Name, vdcOrVdcGroupName)
+	if err != nil {
+		return "", "", nil, err
+	}
+	egw, err = vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeGatewayName)
+	if err != nil {
+		return "", "", nil, fmt.Errorf("error retrieving NSX-T edge gateway '%s': %s", edgeGatewayName, err)
+	}
+	return adminOrg.AdminOrg.Name, vdcOrVdcGroupName, egw, nil
+}
+
+// getVdcOrVdcGroupReference finds the VDC or VDC Group given as "parent" or "vdc" (or the VDC defined in the provider),
+// and returns its name and ID
+func getVdcOrVdcGroupReference(d *schema.ResourceData, client *VCDClient, adminOrg *govcd.AdminOrg) (name string, id string, err error) {
+	name = d.Get("parent").(string)
+	if name == "" {
+		name = d.Get("vdc").(string)
+	}
+	if name == "" {
+		name = client.Vdc
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("VDC or VDC group name not given either as 'parent', 'vdc', or at provider level")
+	}
+	vdcGroup, err := adminOrg.GetVdcGroupByName(name)
+	if err == nil {
+		return name, vdcGroup.VdcGroup.Id, nil
+	}
+	if !govcd.ContainsNotFound(err) {
+		return "", "", fmt.Errorf("error retrieving VDC group '%s': %s", name, err)
+	}
+	vdc, err := adminOrg.GetVDCByName(name, false)
+	if err != nil {
+		return "", "", fmt.Errorf("neither a VDC or a VDC group found with name '%s'", name)
+	}
+	return name, vdc.Vdc.ID, nil
+}
+
+func nsxtNatRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	natRules, err := edgeGateway.GetAllNatRules(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NAT rules of NSX-T edge gateway '%s': %s", edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, rule := range natRules {
+		items = append(items, resourceRef{
+			name:     rule.NsxtNatRule.Name,
+			id:       rule.NsxtNatRule.ID,
+			parent:   edgeGateway.EdgeGateway.Name,
+			parentId: edgeGateway.EdgeGateway.ID,
+			importId: true, // NAT rule names are not unique
+			attributes: map[string]string{
+				"rule_type":          firstNonEmpty(rule.NsxtNatRule.RuleType, rule.NsxtNatRule.Type),
+				"external_address":   rule.NsxtNatRule.ExternalAddresses,
+				"internal_address":   rule.NsxtNatRule.InternalAddresses,
+				"enabled":            strconv.FormatBool(rule.NsxtNatRule.Enabled),
+				"dnat_external_port": rule.NsxtNatRule.DnatExternalPort,
+			},
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_nat_rule", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// nsxtFirewallList lists the firewall of an NSX-T edge gateway, which uses the edge gateway as identity:
+// one vcd_nsxt_firewall resource holds all the rules
+func nsxtFirewallList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	parentId := ""
+	if edgeGateway.EdgeGateway.OwnerRef != nil {
+		parentId = edgeGateway.EdgeGateway.OwnerRef.ID
+	}
+	return genericResourceList(d, "vcd_nsxt_firewall", []string{orgName, vdcOrVdcGroupName}, []resourceRef{{
+		name:     edgeGateway.EdgeGateway.Name,
+		id:       edgeGateway.EdgeGateway.ID,
+		parent:   vdcOrVdcGroupName,
+		parentId: parentId,
+	}})
+}
+
+// nsxtFirewallRuleList lists the single user defined rules of an NSX-T edge gateway firewall (vcd_nsxt_firewall_rule)
+func nsxtFirewallRuleList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	firewall, err := edgeGateway.GetNsxtFirewall()
+	if err != nil {
+		return list, fmt.Errorf("error retrieving firewall rules of NSX-T edge gateway '%s': %s", edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		items = append(items, resourceRef{
+			name:     rule.Name,
+			id:       rule.ID,
+			parent:   edgeGateway.EdgeGateway.Name,
+			parentId: edgeGateway.EdgeGateway.ID,
+			attributes: map[string]string{
+				"action":      rule.Action,
+				"direction":   rule.Direction,
+				"ip_protocol": rule.IpProtocol,
+				"enabled":     strconv.FormatBool(rule.Enabled),
+			},
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_firewall_rule", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// nsxtFirewallGroupList lists IP sets (vcd_nsxt_ip_set) or security groups (vcd_nsxt_security_group) of an NSX-T edge gateway
+func nsxtFirewallGroupList(d *schema.ResourceData, meta interface{}, resType, firewallGroupType string) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	firewallGroups, err := edgeGateway.GetAllNsxtFirewallGroups(nil, firewallGroupType)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s of NSX-T edge gateway '%s': %s", resType, edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, group := range firewallGroups {
+		attributes := map[string]string{
+			"description": group.NsxtFirewallGroup.Description,
+		}
+		if firewallGroupType == types.FirewallGroupTypeIpSet {
+			attributes["ip_addresses"] = strings.Join(group.NsxtFirewallGroup.IpAddresses, ",")
+		}
+		items = append(items, resourceRef{
+			name:       group.NsxtFirewallGroup.Name,
+			id:         group.NsxtFirewallGroup.ID,
+			parent:     edgeGateway.EdgeGateway.Name,
+			parentId:   edgeGateway.EdgeGateway.ID,
+			attributes: attributes,
+		})
+	}
+	return genericResourceList(d, resType, []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// nsxtAppPortProfileList lists the tenant Application Port Profiles of the VDC or VDC Group given as "parent" or "vdc"
+func nsxtAppPortProfileList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	org, err := client.GetOrg(adminOrg.AdminOrg.Name)
+	if err != nil {
+		return list, err
+	}
+	ownerName, ownerId, err := getVdcOrVdcGroupReference(d, client, adminOrg)
+	if err != nil {
+		return list, err
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", fmt.Sprintf("_context==%s", ownerId))
+	profiles, err := org.GetAllNsxtAppPortProfiles(queryParams, types.ApplicationPortProfileScopeTenant)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving NSX-T Application Port Profiles of '%s': %s", ownerName, err)
+	}
+	var items []resourceRef
+	for _, profile := range profiles {
+		items = append(items, resourceRef{
+			name:     profile.NsxtAppPortProfile.Name,
+			id:       profile.NsxtAppPortProfile.ID,
+			parent:   ownerName,
+			parentId: ownerId,
+			attributes: map[string]string{
+				"scope":       profile.NsxtAppPortProfile.Scope,
+				"description": profile.NsxtAppPortProfile.Description,
+			},
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_app_port_profile", []string{adminOrg.AdminOrg.Name, ownerName}, items)
+}
+
+func nsxtAlbPoolList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	pools, err := client.GetAllAlbPoolSummaries(edgeGateway.EdgeGateway.ID, nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving ALB pools of NSX-T edge gateway '%s': %s", edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, pool := range pools {
+		attributes := map[string]string{
+			"algorithm":    pool.NsxtAlbPool.Algorithm,
+			"member_count": strconv.Itoa(pool.NsxtAlbPool.MemberCount),
+		}
+		if pool.NsxtAlbPool.Enabled != nil {
+			attributes["enabled"] = strconv.FormatBool(*pool.NsxtAlbPool.Enabled)
+		}
+		items = append(items, resourceRef{
+			name:       pool.NsxtAlbPool.Name,
+			id:         pool.NsxtAlbPool.ID,
+			parent:     edgeGateway.EdgeGateway.Name,
+			parentId:   edgeGateway.EdgeGateway.ID,
+			attributes: attributes,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_alb_pool", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+func nsxtAlbVirtualServiceList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	virtualServices, err := client.GetAllAlbVirtualServiceSummaries(edgeGateway.EdgeGateway.ID, nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving ALB virtual services of NSX-T edge gateway '%s': %s", edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, virtualService := range virtualServices {
+		attributes := map[string]string{
+			"virtual_ip_address": virtualService.NsxtAlbVirtualService.VirtualIpAddress,
+			"pool_id":            virtualService.NsxtAlbVirtualService.LoadBalancerPoolRef.ID,
+			"health_status":      virtualService.NsxtAlbVirtualService.HealthStatus,
+		}
+		if virtualService.NsxtAlbVirtualService.Enabled != nil {
+			attributes["enabled"] = strconv.FormatBool(*virtualService.NsxtAlbVirtualService.Enabled)
+		}
+		items = append(items, resourceRef{
+			name:       virtualService.NsxtAlbVirtualService.Name,
+			id:         virtualService.NsxtAlbVirtualService.ID,
+			parent:     edgeGateway.EdgeGateway.Name,
+			parentId:   edgeGateway.EdgeGateway.ID,
+			attributes: attributes,
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_alb_virtual_service", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+func nsxtIpSecVpnTunnelList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	orgName, vdcOrVdcGroupName, edgeGateway, err := getNsxtEdgeGatewayDetails(d, meta)
+	if err != nil {
+		return list, err
+	}
+	tunnels, err := edgeGateway.GetAllIpSecVpnTunnels(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving IPsec VPN tunnels of NSX-T edge gateway '%s': %s", edgeGateway.EdgeGateway.Name, err)
+	}
+	var items []resourceRef
+	for _, tunnel := range tunnels {
+		items = append(items, resourceRef{
+			name:     tunnel.NsxtIpSecVpn.Name,
+			id:       tunnel.NsxtIpSecVpn.ID,
+			parent:   edgeGateway.EdgeGateway.Name,
+			parentId: edgeGateway.EdgeGateway.ID,
+			importId: true, // IPsec VPN tunnel names are not unique
+			attributes: map[string]string{
+				"local_ip_address":  tunnel.NsxtIpSecVpn.LocalEndpoint.LocalAddress,
+				"remote_ip_address": tunnel.NsxtIpSecVpn.RemoteEndpoint.RemoteAddress,
+				"enabled":           strconv.FormatBool(tunnel.NsxtIpSecVpn.Enabled),
+			},
+		})
+	}
+	return genericResourceList(d, "vcd_nsxt_ipsec_vpn_tunnel", []string{orgName, vdcOrVdcGroupName, edgeGateway.EdgeGateway.Name}, items)
+}
+
+// ipSpaceList lists the IP Spaces visible to the current user. Private IP Spaces are imported using their Org name
+func ipSpaceList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ipSpaces, err := client.GetAllIpSpaceSummaries(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving IP Spaces: %s", err)
+	}
+	var items []resourceRef
+	for _, ipSpace := range ipSpaces {
+		item := resourceRef{
+			name: ipSpace.IpSpace.Name,
+			id:   ipSpace.IpSpace.ID,
+			attributes: map[string]string{
+				"type":   ipSpace.IpSpace.Type,
+				"status": ipSpace.IpSpace.Status,
+			},
+		}
+		if ipSpace.IpSpace.OrgRef != nil && ipSpace.IpSpace.OrgRef.Name != "" {
+			item.parent = ipSpace.IpSpace.OrgRef.Name
+			item.parentId = ipSpace.IpSpace.OrgRef.ID
+			item.importPath = ipSpace.IpSpace.OrgRef.Name + ImportSeparator + ipSpace.IpSpace.Name
+		}
+		items = append(items, item)
+	}
+	return genericResourceList(d, "vcd_ip_space", nil, items)
+}
+
+// ipSpaceIpAllocationList lists the floating IPs and IP prefixes allocated to the Org from the IP Space given as "parent"
+func ipSpaceIpAllocationList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	ipSpaceName := d.Get("parent").(string)
+	if ipSpaceName == "" {
+		return list, fmt.Errorf(`IP Space name (as "parent") is required for this task`)
+	}
+	adminOrg, err := client.GetAdminOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	ipSpace, err := client.GetIpSpaceByName(ipSpaceName)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving IP Space '%s': %s", ipSpaceName, err)
+	}
+	queryParams := url.Values{}
+	queryParams.Add("filter", fmt.Sprintf("orgRef.id==%s", adminOrg.AdminOrg.ID))
+	var items []resourceRef
+	for _, allocationType := range []string{types.IpSpaceIpAllocationTypeFloatingIp, types.IpSpaceIpAllocationTypeIpPrefix} {
+		allocations, err := ipSpace.GetAllIpSpaceAllocations(allocationType, queryParams)
+		if err != nil {
+			return list, fmt.Errorf("error retrieving IP allocations of type %s from IP Space '%s': %s", allocationType, ipSpaceName, err)
+		}
+		for _, allocation := range allocations {
+			attributes := map[string]string{
+				"type":        allocation.IpSpaceIpAllocation.Type,
+				"usage_state": allocation.IpSpaceIpAllocation.UsageState,
+			}
+			if allocation.IpSpaceIpAllocation.UsedByRef != nil {
+				attributes["used_by_id"] = allocation.IpSpaceIpAllocation.UsedByRef.ID
+			}
+			items = append(items, resourceRef{
+				name:     allocation.IpSpaceIpAllocation.Value,
+				id:       allocation.IpSpaceIpAllocation.ID,
+				parent:   ipSpaceName,
+				parentId: ipSpace.IpSpace.ID,
+				importPath: strings.Join([]string{adminOrg.AdminOrg.Name, ipSpaceName,
+					allocation.IpSpaceIpAllocation.Type, allocation.IpSpaceIpAllocation.Value}, ImportSeparator),
+				attributes: attributes,
+			})
+		}
+	}
+	return genericResourceList(d, "vcd_ip_space_ip_allocation", []string{adminOrg.AdminOrg.Name, ipSpaceName}, items)
+}
+
+func rdeTypeList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	rdeTypes, err := client.GetAllRdeTypes(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving Runtime Defined Entity Types: %s", err)
+	}
+	var items []resourceRef
+	for _, rdeType := range rdeTypes {
+		items = append(items, resourceRef{
+			name: rdeType.DefinedEntityType.Name,
+			id:   rdeType.DefinedEntityType.ID,
+			importPath: strings.Join([]string{rdeType.DefinedEntityType.Vendor, rdeType.DefinedEntityType.Nss,
+				rdeType.DefinedEntityType.Version}, ImportSeparator),
+			attributes: map[string]string{
+				"vendor":  rdeType.DefinedEntityType.Vendor,
+				"nss":     rdeType.DefinedEntityType.Nss,
+				"version": rdeType.DefinedEntityType.Version,
+			},
+		})
+	}
+	return genericResourceList(d, "vcd_rde_type", nil, items)
+}
+
+// rdeList lists the Runtime Defined Entities of the type given as "parent", in the format vendor.nss.version
+func rdeList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	parent := d.Get("parent").(string)
+	rdeTypeIdentifier := strings.SplitN(parent, ImportSeparator, 3)
+	if len(rdeTypeIdentifier) != 3 {
+		return list, fmt.Errorf(`Runtime Defined Entity Type (as "parent") is required for this task, in the format vendor%snss%sversion`,
+			ImportSeparator, ImportSeparator)
+	}
+	rdeType, err := client.GetRdeType(rdeTypeIdentifier[0], rdeTypeIdentifier[1], rdeTypeIdentifier[2])
+	if err != nil {
+		return list, fmt.Errorf("error retrieving Runtime Defined Entity Type '%s': %s", parent, err)
+	}
+	rdes, err := rdeType.GetAllRdes(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving Runtime Defined Entities of type '%s': %s", parent, err)
+	}
+	var items []resourceRef
+	for _, rde := range rdes {
+		attributes := map[string]string{}
+		if rde.DefinedEntity.State != nil {
+			attributes["state"] = *rde.DefinedEntity.State
+		}
+		if rde.DefinedEntity.Org != nil {
+			attributes["org_id"] = rde.DefinedEntity.Org.ID
+		}
+		items = append(items, resourceRef{
+			name:       rde.DefinedEntity.Name,
+			id:         rde.DefinedEntity.ID,
+			parent:     parent,
+			parentId:   rdeType.DefinedEntityType.ID,
+			importId:   true, // RDE names are not unique
+			attributes: attributes,
+		})
+	}
+	return genericResourceList(d, "vcd_rde", nil, items)
+}
+
+// computePolicyList lists VM sizing policies (sizing) or VM placement policies (placement), which are imported by ID
+func computePolicyList(d *schema.ResourceData, meta interface{}, resType, policyType string) (list []string, err error) {
+	client := meta.(*VCDClient)
+	queryParams := url.Values{}
+	switch policyType {
+	case "sizing":
+		queryParams.Add("filter", "isSizingOnly==true")
+	case "placement":
+		queryParams.Add("filter", fmt.Sprintf("%spolicyType==VdcVmPolicy;isSizingOnly==false", getVgpuFilterToPrepend(client, false)))
+	default:
+		return list, fmt.Errorf("unrecognized type of compute policy: %s", policyType)
+	}
+	policies, err := client.GetAllVdcComputePoliciesV2(queryParams)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving %s policies: %s", policyType, err)
+	}
+	var items []resourceRef
+	for _, policy := range policies {
+		items = append(items, resourceRef{
+			name:     policy.VdcComputePolicyV2.Name,
+			id:       policy.VdcComputePolicyV2.ID,
+			href:     policy.Href,
+			importId: true,
+			attributes: map[string]string{
+				"description": stringOnNotNil(policy.VdcComputePolicyV2.Description),
+			},
+		})
+	}
+	return genericResourceList(d, resType, nil, items)
+}
+
+func serviceAccountList(d *schema.ResourceData, meta interface{}) (list []string, err error) {
+	client := meta.(*VCDClient)
+	org, err := client.GetOrgFromResource(d)
+	if err != nil {
+		return list, err
+	}
+	serviceAccounts, err := org.GetAllServiceAccounts(nil)
+	if err != nil {
+		return list, fmt.Errorf("error retrieving service accounts: %s", err)
+	}
+	var items []resourceRef
+	for _, serviceAccount := range serviceAccounts {
+		attributes := map[string]string{
+			"status":      serviceAccount.ServiceAccount.Status,
+			"software_id": serviceAccount.ServiceAccount.SoftwareID,
+		}
+		if serviceAccount.ServiceAccount.Role != nil {
+			attributes["role"] = serviceAccount.ServiceAccount.Role.Name
+		}
+		items = append(items, resourceRef{
+			name:       serviceAccount.ServiceAccount.Name,
+			id:         serviceAccount.ServiceAccount.ID,
+			parent:     org.Org.Name,
+			parentId:   org.Org.ID,
+			attributes: attributes,
+		})
+	}
+	return genericResourceList(d, "vcd_service_account", []string{org.Org.Name}, items)
+}
+
 func getResourcesList() ([]string, error) {
 	var list []string
 	resources := globalResourceMap
@@ -1273,11 +1811,36 @@ func getResourcesList() ([]string, error) {
 	return list, nil
 }
 
-func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func datasourceVcdResourceListRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	requested := d.Get("resource_type").(string)
-	var err error
-	var list []string
+	listMode := d.Get("list_mode").(string)
+	importFile := d.Get("import_file_name").(string)
+	if listMode == "generate" && importFile == "" {
+		return diag.Errorf("list_mode 'generate' requires 'import_file_name'")
+	}
+	list, err := resourceListByType(d, meta, requested)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if listMode == "generate" {
+		err = generateResourceListConfig(ctx, meta, list, importFile)
+		if err != nil {
+			return diag.Errorf("error generating configuration for resource list '%s': %s", d.Get("name").(string), err)
+		}
+	}
+	err = d.Set("list", list)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(d.Get("name").(string))
+
+	return diag.Diagnostics{}
+}
+
+// resourceListByType runs the listing function for the requested resource type, using the fields of the
+// vcd_resource_list data given in d
+func resourceListByType(d *schema.ResourceData, meta interface{}, requested string) (list []string, err error) {
 	switch requested {
 	// Note: do not try to get the data sources list, as it would result in a circular reference
 	case "resource", "resources":
@@ -1338,6 +1901,38 @@ func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, me
 		list, err = getEdgeGatewayList(d, meta, "vcd_edgegateway_settings")
 	case "vcd_nsxt_edgegateway", "nsxt_edge_gateway", "nsxt_edge", "nsxt_edgegateway":
 		list, err = getNsxtEdgeGatewayList(d, meta)
+	case "vcd_nsxt_nat_rule", "nsxt_nat_rule":
+		list, err = nsxtNatRuleList(d, meta)
+	case "vcd_nsxt_firewall", "nsxt_firewall":
+		list, err = nsxtFirewallList(d, meta)
+	case "vcd_nsxt_firewall_rule", "nsxt_firewall_rule":
+		list, err = nsxtFirewallRuleList(d, meta)
+	case "vcd_nsxt_ip_set", "nsxt_ip_set":
+		list, err = nsxtFirewallGroupList(d, meta, "vcd_nsxt_ip_set", types.FirewallGroupTypeIpSet)
+	case "vcd_nsxt_security_group", "nsxt_security_group":
+		list, err = nsxtFirewallGroupList(d, meta, "vcd_nsxt_security_group", types.FirewallGroupTypeSecurityGroup)
+	case "vcd_nsxt_app_port_profile", "nsxt_app_port_profile":
+		list, err = nsxtAppPortProfileList(d, meta)
+	case "vcd_nsxt_alb_pool", "nsxt_alb_pool":
+		list, err = nsxtAlbPoolList(d, meta)
+	case "vcd_nsxt_alb_virtual_service", "nsxt_alb_virtual_service":
+		list, err = nsxtAlbVirtualServiceList(d, meta)
+	case "vcd_nsxt_ipsec_vpn_tunnel", "nsxt_ipsec_vpn_tunnel":
+		list, err = nsxtIpSecVpnTunnelList(d, meta)
+	case "vcd_ip_space", "ip_space", "ip_spaces":
+		list, err = ipSpaceList(d, meta)
+	case "vcd_ip_space_ip_allocation", "ip_space_ip_allocation":
+		list, err = ipSpaceIpAllocationList(d, meta)
+	case "vcd_rde_type", "rde_type":
+		list, err = rdeTypeList(d, meta)
+	case "vcd_rde", "rde":
+		list, err = rdeList(d, meta)
+	case "vcd_vm_sizing_policy", "vm_sizing_policy":
+		list, err = computePolicyList(d, meta, "vcd_vm_sizing_policy", "sizing")
+	case "vcd_vm_placement_policy", "vm_placement_policy":
+		list, err = computePolicyList(d, meta, "vcd_vm_placement_policy", "placement")
+	case "vcd_service_account", "service_account":
+		list, err = serviceAccountList(d, meta)
 	case "vcd_lb_server_pool", "lb_server_pool":
 		list, err = lbServerPoolList(d, meta)
 	case "vcd_lb_service_monitor", "lb_service_monitor":
@@ -1371,6 +1966,8 @@ func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, me
 		list, err = globalRolesList(d, meta)
 	case "vcd_library_certificate":
 		list, err = libraryCertificateList(d, meta)
+	case "org_hierarchy", "vdc_hierarchy", "edge_gateway_hierarchy":
+		list, err = hierarchyResourceList(d, meta, requested)
 
 		//// place holder to remind of what needs to be implemented
 		//	case "edgegateway_vpn",
@@ -1379,17 +1976,7 @@ func datasourceVcdResourceListRead(_ context.Context, d *schema.ResourceData, me
 		//		"inserted_media":
 		//		list, err = []string{"not implemented yet"}, nil
 	default:
-		return diag.FromErr(fmt.Errorf("unhandled resource type '%s'", requested))
-	}
-
-	if err != nil {
-		return diag.FromErr(err)
-	}
-	err = d.Set("list", list)
-	if err != nil {
-		return diag.FromErr(err)
+		return nil, fmt.Errorf("unhandled resource type '%s'", requested)
 	}
-	d.SetId(d.Get("name").(string))
-
-	return diag.Diagnostics{}
+	return list, err
 }
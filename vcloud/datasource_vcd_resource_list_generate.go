@@ -0,0 +1,601 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// resourceListSubData returns a copy of the vcd_resource_list data, with the given fields replaced.
+// It is used to call the listing functions for the members of a hierarchy
+func resourceListSubData(d *schema.ResourceData, fields map[string]string) (*schema.ResourceData, error) {
+	subData := datasourceVcdResourceList().Data(nil)
+	for _, field := range []string{"org", "vdc", "parent", "name", "resource_type", "list_mode", "name_regex", "name_id_separator"} {
+		value := d.Get(field).(string)
+		if newValue, ok := fields[field]; ok {
+			value = newValue
+		}
+		err := subData.Set(field, value)
+		if err != nil {
+			return nil, fmt.Errorf("error setting field '%s' for resource list '%s': %s", field, d.Get("name").(string), err)
+		}
+	}
+	return subData, nil
+}
+
+// resourceListNames returns the plain names of a list of resources, ignoring the list mode and name filter of
+// the original data source. It is used to walk the hierarchy (e.g. to find the VMs of every vApp)
+func resourceListNames(d *schema.ResourceData, fields map[string]string,
+	listFunc func(*schema.ResourceData) ([]string, error)) ([]string, error) {
+	fields["list_mode"] = "name"
+	fields["name_regex"] = ""
+	subData, err := resourceListSubData(d, fields)
+	if err != nil {
+		return nil, err
+	}
+	return listFunc(subData)
+}
+
+// hierarchyResourceList lists all the supported resources below the Org, VDC, or edge gateway given in the
+// data source fields. The hierarchy types ("org_hierarchy", "vdc_hierarchy", "edge_gateway_hierarchy") are mostly
+// useful with list_mode "generate", to adopt a whole environment in one pass
+func hierarchyResourceList(d *schema.ResourceData, meta interface{}, hierarchy string) ([]string, error) {
+	client := meta.(*VCDClient)
+	switch hierarchy {
+	case "org_hierarchy":
+		return orgHierarchyList(d, meta, firstNonEmpty(d.Get("org").(string), d.Get("parent").(string), client.SysOrg))
+	case "vdc_hierarchy":
+		vdcName, err := getVdcName(client, d)
+		if err != nil {
+			return nil, err
+		}
+		return vdcHierarchyList(d, meta, d.Get("org").(string), vdcName)
+	case "edge_gateway_hierarchy":
+		edgeGatewayName := d.Get("parent").(string)
+		if edgeGatewayName == "" {
+			return nil, fmt.Errorf(`edge gateway name (as "parent") is required for resource type '%s'`, hierarchy)
+		}
+		org, vdc, err := client.GetOrgAndVdc(d.Get("org").(string), d.Get("vdc").(string))
+		if err != nil {
+			return nil, err
+		}
+		list, err := edgeGatewayHierarchyList(d, meta, org.Org.Name, vdc.Vdc.Name, vdc.IsNsxt(), edgeGatewayName)
+		if err != nil || !vdc.IsNsxt() {
+			return list, err
+		}
+		// The NAT rules of the edge gateway may use the Application Port Profiles of its VDC
+		subData, err := resourceListSubData(d, map[string]string{
+			"org":           org.Org.Name,
+			"vdc":           vdc.Vdc.Name,
+			"parent":        vdc.Vdc.Name,
+			"resource_type": "vcd_nsxt_app_port_profile",
+		})
+		if err != nil {
+			return nil, err
+		}
+		profiles, err := nsxtAppPortProfileList(subData, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error listing vcd_nsxt_app_port_profile in VDC '%s': %s", vdc.Vdc.Name, err)
+		}
+		return append(profiles, list...), nil
+	}
+	return nil, fmt.Errorf("unhandled resource hierarchy '%s'", hierarchy)
+}
+
+// orgHierarchyList lists the VDCs and catalogs of an Org, followed by the contents of each VDC
+func orgHierarchyList(d *schema.ResourceData, meta interface{}, orgName string) ([]string, error) {
+	var list []string
+	for _, resType := range []string{"vcd_org_vdc", "vcd_catalog"} {
+		subData, err := resourceListSubData(d, map[string]string{"org": orgName, "parent": "", "resource_type": resType})
+		if err != nil {
+			return nil, err
+		}
+		var items []string
+		if resType == "vcd_org_vdc" {
+			items, err = vdcList(subData, meta, resType)
+		} else {
+			items, err = catalogList(subData, meta, resType)
+		}
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, items...)
+	}
+
+	vdcNames, err := resourceListNames(d, map[string]string{"org": orgName, "parent": ""},
+		func(subData *schema.ResourceData) ([]string, error) {
+			return vdcList(subData, meta, "vcd_org_vdc")
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, vdcName := range vdcNames {
+		items, err := vdcHierarchyList(d, meta, orgName, vdcName)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, items...)
+	}
+	return list, nil
+}
+
+// vdcHierarchyList lists the vApps, VMs, independent disks, networks, and edge gateways of a VDC, followed by the
+// contents of each edge gateway
+func vdcHierarchyList(d *schema.ResourceData, meta interface{}, orgName, vdcName string) ([]string, error) {
+	client := meta.(*VCDClient)
+	org, vdc, err := client.GetOrgAndVdc(orgName, vdcName)
+	if err != nil {
+		return nil, err
+	}
+	orgName = org.Org.Name
+	isNsxt := vdc.IsNsxt()
+
+	var list []string
+	addList := func(resType, parent string, listFunc func(*schema.ResourceData) ([]string, error)) error {
+		subData, err := resourceListSubData(d, map[string]string{
+			"org":           orgName,
+			"vdc":           vdcName,
+			"parent":        parent,
+			"resource_type": resType,
+		})
+		if err != nil {
+			return err
+		}
+		items, err := listFunc(subData)
+		if err != nil {
+			return fmt.Errorf("error listing %s in VDC '%s': %s", resType, vdcName, err)
+		}
+		list = append(list, items...)
+		return nil
+	}
+
+	err = addList("vcd_vapp", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+		return vappList(subData, meta, "vcd_vapp")
+	})
+	if err != nil {
+		return nil, err
+	}
+	vappNames, err := resourceListNames(d, map[string]string{"org": orgName, "vdc": vdcName, "parent": vdcName},
+		func(subData *schema.ResourceData) ([]string, error) {
+			return vappList(subData, meta, "vcd_vapp")
+		})
+	if err != nil {
+		return nil, err
+	}
+	for _, vappName := range vappNames {
+		err = addList("vcd_vapp_vm", vappName, func(subData *schema.ResourceData) ([]string, error) {
+			return vmList(subData, meta, vappVmType)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = addList("vcd_vm", "", func(subData *schema.ResourceData) ([]string, error) {
+		return vmList(subData, meta, standaloneVmType)
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = addList("vcd_independent_disk", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+		return diskList(subData, meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var edgeGatewayNames []string
+	if isNsxt {
+		for _, resType := range []string{"vcd_network_routed_v2", "vcd_network_isolated_v2", "vcd_nsxt_network_imported"} {
+			err = addList(resType, vdcName, func(subData *schema.ResourceData) ([]string, error) {
+				return orgNetworkListV2(subData, meta)
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+		err = addList("vcd_nsxt_edgegateway", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+			return getNsxtEdgeGatewayList(subData, meta)
+		})
+		if err != nil {
+			return nil, err
+		}
+		// Application Port Profiles belong to the VDC, and are used by the NAT rules of its edge gateways
+		err = addList("vcd_nsxt_app_port_profile", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+			return nsxtAppPortProfileList(subData, meta)
+		})
+		if err != nil {
+			return nil, err
+		}
+		edgeGatewayNames, err = resourceListNames(d, map[string]string{"org": orgName, "vdc": vdcName, "parent": vdcName},
+			func(subData *schema.ResourceData) ([]string, error) {
+				return getNsxtEdgeGatewayList(subData, meta)
+			})
+	} else {
+		err = addList("network", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+			return networkList(subData, meta)
+		})
+		if err != nil {
+			return nil, err
+		}
+		err = addList("vcd_edgegateway", vdcName, func(subData *schema.ResourceData) ([]string, error) {
+			return getEdgeGatewayList(subData, meta, "vcd_edgegateway")
+		})
+		if err != nil {
+			return nil, err
+		}
+		edgeGatewayNames, err = resourceListNames(d, map[string]string{"org": orgName, "vdc": vdcName, "parent": vdcName},
+			func(subData *schema.ResourceData) ([]string, error) {
+				return getEdgeGatewayList(subData, meta, "vcd_edgegateway")
+			})
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, edgeGatewayName := range edgeGatewayNames {
+		items, err := edgeGatewayHierarchyList(d, meta, orgName, vdcName, isNsxt, edgeGatewayName)
+		if err != nil {
+			return nil, err
+		}
+		list = append(list, items...)
+	}
+	return list, nil
+}
+
+// hierarchyListFunc lists the resources of one type below a hierarchy level
+type hierarchyListFunc struct {
+	resType  string
+	listFunc func(*schema.ResourceData, interface{}) ([]string, error)
+}
+
+// edgeGatewayHierarchyList lists the firewall, NAT, and load balancer objects of an edge gateway.
+// For NSX-T edge gateways, the firewall rules are handled as a single resource (vcd_nsxt_firewall), next to the
+// NAT rules, IP sets, and security groups. Application Port Profiles belong to the VDC and are listed by the callers
+func edgeGatewayHierarchyList(d *schema.ResourceData, meta interface{}, orgName, vdcName string, isNsxt bool, edgeGatewayName string) ([]string, error) {
+	listFuncs := []hierarchyListFunc{
+		{"vcd_nsxv_firewall_rule", nsxvFirewallList},
+		{"vcd_nsxv_dnat", func(subData *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxvNatRuleList("dnat", subData, meta)
+		}},
+		{"vcd_nsxv_snat", func(subData *schema.ResourceData, meta interface{}) ([]string, error) {
+			return nsxvNatRuleList("snat", subData, meta)
+		}},
+		{"vcd_ipset", ipsetList},
+		{"vcd_lb_service_monitor", lbServiceMonitorList},
+		{"vcd_lb_server_pool", lbServerPoolList},
+		{"vcd_lb_app_profile", lbAppProfileList},
+		{"vcd_lb_app_rule", lbAppRuleList},
+		{"vcd_lb_virtual_server", lbVirtualServerList},
+	}
+	if isNsxt {
+		listFuncs = []hierarchyListFunc{
+			{"vcd_nsxt_firewall", nsxtFirewallList},
+			{"vcd_nsxt_nat_rule", nsxtNatRuleList},
+			{"vcd_nsxt_ip_set", func(subData *schema.ResourceData, meta interface{}) ([]string, error) {
+				return nsxtFirewallGroupList(subData, meta, "vcd_nsxt_ip_set", types.FirewallGroupTypeIpSet)
+			}},
+			{"vcd_nsxt_security_group", func(subData *schema.ResourceData, meta interface{}) ([]string, error) {
+				return nsxtFirewallGroupList(subData, meta, "vcd_nsxt_security_group", types.FirewallGroupTypeSecurityGroup)
+			}},
+		}
+	}
+
+	var list []string
+	for _, item := range listFuncs {
+		subData, err := resourceListSubData(d, map[string]string{
+			"org":           orgName,
+			"vdc":           vdcName,
+			"parent":        edgeGatewayName,
+			"resource_type": item.resType,
+		})
+		if err != nil {
+			return nil, err
+		}
+		items, err := item.listFunc(subData, meta)
+		if err != nil {
+			return nil, fmt.Errorf("error listing %s in edge gateway '%s': %s", item.resType, edgeGatewayName, err)
+		}
+		list = append(list, items...)
+	}
+	return list, nil
+}
+
+// providerResourceType converts the resource types used by vcd_resource_list, which keep the historical "vcd_"
+// prefix, into the resource names registered in the provider
+func providerResourceType(resType string) string {
+	return "vcloud_" + strings.TrimPrefix(strings.TrimPrefix(resType, "vcd_"), "vcloud_")
+}
+
+// resourceListAddress builds a valid Terraform resource name from the entity name, adding the tail of the ID to
+// make it unique
+func resourceListAddress(name, id string) string {
+	reInvalid := regexp.MustCompile(`[^a-zA-Z0-9_-]`)
+	address := reInvalid.ReplaceAllString(name, "_")
+	if tail := idTail(id); tail != "" {
+		address = address + "-" + tail
+	}
+	if address == "" || !regexp.MustCompile(`^[a-zA-Z_]`).MatchString(address) {
+		address = "_" + address
+	}
+	return address
+}
+
+// generateResourceListConfig writes, for each entry produced by list_mode "generate", an import block and the
+// configuration of the resource, populated by the resource's own importer and Read function.
+// Entries that can't be imported or read are reported as comments in the file
+func generateResourceListConfig(ctx context.Context, meta interface{}, entries []string, fileName string) error {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("# Generated by vcd_resource_list - %s\n", time.Now().Format(time.RFC3339)))
+	builder.WriteString("# Review the configuration before running 'terraform plan': computed and sensitive values\n")
+	builder.WriteString("# may need adjustments\n\n")
+
+	for _, entry := range entries {
+		address, importId, found := strings.Cut(entry, " ")
+		if !found {
+			return fmt.Errorf("invalid generate entry '%s'", entry)
+		}
+		resourceType, resourceName, _ := strings.Cut(address, ".")
+		builder.WriteString(fmt.Sprintf("# %s %s\n", resourceType, importId))
+
+		resource, ok := globalResourceMap[resourceType]
+		if !ok {
+			builder.WriteString(fmt.Sprintf("# skipped: resource type %s is not available in this provider\n\n", resourceType))
+			continue
+		}
+		d, err := importAndReadResource(ctx, resource, importId, meta)
+		if err != nil {
+			builder.WriteString(fmt.Sprintf("# skipped: %s\n\n", strings.ReplaceAll(err.Error(), "\n", " ")))
+			continue
+		}
+
+		builder.WriteString("import {\n")
+		builder.WriteString(fmt.Sprintf("  to = %s\n", address))
+		builder.WriteString(fmt.Sprintf("  id = %s\n", hclQuote(importId)))
+		builder.WriteString("}\n\n")
+		builder.WriteString(fmt.Sprintf("resource %s %s {\n", hclQuote(resourceType), hclQuote(resourceName)))
+		values := make(map[string]interface{})
+		for key := range resource.Schema {
+			values[key] = d.Get(key)
+		}
+		writeHclBody(&builder, resource.Schema, values, "  ")
+		builder.WriteString("}\n\n")
+	}
+	return os.WriteFile(fileName, []byte(builder.String()), 0600)
+}
+
+// importAndReadResource runs the importer of a resource with the given import ID, and then its Read function,
+// returning the resulting resource data
+func importAndReadResource(ctx context.Context, resource *schema.Resource, importId string, meta interface{}) (*schema.ResourceData, error) {
+	if resource.Importer == nil {
+		return nil, fmt.Errorf("resource does not support import")
+	}
+	d := resource.Data(nil)
+	d.SetId(importId)
+
+	var imported []*schema.ResourceData
+	var err error
+	switch {
+	case resource.Importer.StateContext != nil:
+		imported, err = resource.Importer.StateContext(ctx, d, meta)
+	case resource.Importer.State != nil:
+		imported, err = resource.Importer.State(d, meta) //nolint:staticcheck // some resources still use the legacy importer
+	default:
+		imported = []*schema.ResourceData{d}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error importing '%s': %s", importId, err)
+	}
+	if len(imported) == 0 {
+		return nil, fmt.Errorf("import of '%s' returned no resources", importId)
+	}
+	d = imported[0]
+
+	switch {
+	case resource.ReadContext != nil:
+		diags := resource.ReadContext(ctx, d, meta)
+		if diags.HasError() {
+			return nil, fmt.Errorf("error reading '%s': %v", importId, diags)
+		}
+	case resource.ReadWithoutTimeout != nil:
+		diags := resource.ReadWithoutTimeout(ctx, d, meta)
+		if diags.HasError() {
+			return nil, fmt.Errorf("error reading '%s': %v", importId, diags)
+		}
+	case resource.Read != nil: //nolint:staticcheck // some resources still use the legacy Read
+		err = resource.Read(d, meta) //nolint:staticcheck
+		if err != nil {
+			return nil, fmt.Errorf("error reading '%s': %s", importId, err)
+		}
+	}
+	if d.Id() == "" {
+		return nil, fmt.Errorf("'%s' was not found", importId)
+	}
+	return d, nil
+}
+
+// writeHclBody writes the configurable attributes and blocks of a schema, using the given values.
+// Computed-only and deprecated fields are omitted, as well as optional fields that have an empty or default value.
+// Attributes are written before blocks, each group in alphabetical order
+func writeHclBody(builder *strings.Builder, schemaMap map[string]*schema.Schema, values map[string]interface{}, indent string) {
+	var attributes, blocks []string
+	for key, fieldSchema := range schemaMap {
+		if key == "id" || fieldSchema.Deprecated != "" || (!fieldSchema.Required && !fieldSchema.Optional) {
+			continue
+		}
+		if _, isBlock := fieldSchema.Elem.(*schema.Resource); isBlock {
+			blocks = append(blocks, key)
+		} else {
+			attributes = append(attributes, key)
+		}
+	}
+	sort.Strings(attributes)
+	sort.Strings(blocks)
+
+	// Fields in conflict with one already written (e.g. alternative ways of defining subnets) are skipped
+	used := make(map[string]bool)
+	isConflicting := func(key string) bool {
+		for _, other := range append(append([]string{}, schemaMap[key].ConflictsWith...), schemaMap[key].ExactlyOneOf...) {
+			if other != key && used[other] {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Sensitive values can only be written when they are expressions (e.g. a variable)
+	isHidden := func(key string) bool {
+		_, isExpression := values[key].(hclExpression)
+		return schemaMap[key].Sensitive && !isExpression
+	}
+
+	var written []string
+	keyWidth := 0
+	for _, key := range attributes {
+		if (schemaMap[key].Required || isNonDefaultValue(schemaMap[key], values[key])) && !isConflicting(key) {
+			used[key] = true
+			written = append(written, key)
+			if !isHidden(key) && len(key) > keyWidth {
+				keyWidth = len(key)
+			}
+		}
+	}
+	for _, key := range written {
+		if isHidden(key) {
+			builder.WriteString(fmt.Sprintf("%s# %s is sensitive and must be set manually\n", indent, key))
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s%-*s = %s\n", indent, keyWidth, key, hclValue(values[key])))
+	}
+
+	for _, key := range blocks {
+		if isConflicting(key) {
+			continue
+		}
+		blockSchema := schemaMap[key].Elem.(*schema.Resource).Schema
+		for _, item := range hclListItems(values[key]) {
+			blockValues, ok := item.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var blockBody strings.Builder
+			writeHclBody(&blockBody, blockSchema, blockValues, indent+"  ")
+			// A block without configurable values would only add noise
+			if blockBody.Len() == 0 {
+				continue
+			}
+			used[key] = true
+			builder.WriteString(fmt.Sprintf("\n%s%s {\n%s%s}\n", indent, key, blockBody.String(), indent))
+		}
+	}
+}
+
+// isNonDefaultValue tells whether an optional field value needs to be written in the configuration
+func isNonDefaultValue(fieldSchema *schema.Schema, value interface{}) bool {
+	if value == nil {
+		return false
+	}
+	if fieldSchema.Default != nil {
+		return !reflect.DeepEqual(fieldSchema.Default, value)
+	}
+	switch typedValue := value.(type) {
+	case string:
+		return typedValue != ""
+	case hclExpression:
+		return typedValue != ""
+	case int:
+		return typedValue != 0
+	case float64:
+		return typedValue != 0
+	case bool:
+		return typedValue
+	case map[string]interface{}:
+		return len(typedValue) > 0
+	}
+	return len(hclListItems(value)) > 0
+}
+
+// hclListItems returns the items of a list or set value
+func hclListItems(value interface{}) []interface{} {
+	switch typedValue := value.(type) {
+	case []interface{}:
+		return typedValue
+	case *schema.Set:
+		return typedValue.List()
+	}
+	return nil
+}
+
+// hclExpression is a value written in the configuration as it is, such as a reference to another resource
+// (e.g. "vcloud_nsxt_ip_set.web.id") or a variable
+type hclExpression string
+
+// hclValue returns the HCL representation of a primitive, list, set, or map value
+func hclValue(value interface{}) string {
+	switch typedValue := value.(type) {
+	case string:
+		return hclQuote(typedValue)
+	case hclExpression:
+		return string(typedValue)
+	case map[string]interface{}:
+		var keys []string
+		for key := range typedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		var items []string
+		for _, key := range keys {
+			items = append(items, fmt.Sprintf("%s = %s", hclQuote(key), hclValue(typedValue[key])))
+		}
+		return "{ " + strings.Join(items, ", ") + " }"
+	case []interface{}, *schema.Set:
+		var items []string
+		for _, item := range hclListItems(typedValue) {
+			items = append(items, hclValue(item))
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case nil:
+		return "null"
+	}
+	return fmt.Sprintf("%v", value)
+}
+
+// hclQuote returns a quoted HCL string, escaping the characters that would be interpreted by Terraform
+// (including the template sequences "${" and "%{")
+func hclQuote(value string) string {
+	var builder strings.Builder
+	builder.WriteString(`"`)
+	for index, char := range value {
+		switch char {
+		case '"':
+			builder.WriteString(`\"`)
+		case '\\':
+			builder.WriteString(`\\`)
+		case '\n':
+			builder.WriteString(`\n`)
+		case '\r':
+			builder.WriteString(`\r`)
+		case '\t':
+			builder.WriteString(`\t`)
+		case '$', '%':
+			builder.WriteRune(char)
+			if index+1 < len(value) && value[index+1] == '{' {
+				builder.WriteRune(char)
+			}
+		default:
+			if char < 0x20 {
+				builder.WriteString(fmt.Sprintf(`\u%04x`, char))
+			} else {
+				builder.WriteRune(char)
+			}
+		}
+	}
+	builder.WriteString(`"`)
+	return builder.String()
+}
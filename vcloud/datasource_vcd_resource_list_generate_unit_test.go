@@ -0,0 +1,141 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func Test_hclQuote(t *testing.T) {
+	tests := map[string]string{
+		"simple":          `"simple"`,
+		`with "quotes"`:   `"with \"quotes\""`,
+		"multi\nline":     `"multi\nline"`,
+		"${var.name}":     `"$${var.name}"`,
+		"%{ if true }":    `"%%{ if true }"`,
+		"100% $ {":        `"100% $ {"`,
+		`back\slash`:      `"back\\slash"`,
+		"tab\tseparated":  `"tab\tseparated"`,
+		"unicode: àèìòù":  `"unicode: àèìòù"`,
+		"control\x01char": `"control\u0001char"`,
+	}
+	for input, want := range tests {
+		got := hclQuote(input)
+		if got != want {
+			t.Errorf("hclQuote(%q) = %s, want %s", input, got, want)
+		}
+	}
+}
+
+func Test_resourceListAddress(t *testing.T) {
+	tests := []struct {
+		name string
+		id   string
+		want string
+	}{
+		{"web", "urn:vcloud:vapp:0a1b2c3d-0000-0000-0000-abcdef012345", "web-abcdef012345"},
+		{"my vApp.1", "", "my_vApp_1"},
+		{"1st-vm", "urn:vcloud:vm:1111", "_1st-vm-1111"},
+		{"", "", "_"},
+	}
+	for _, tt := range tests {
+		got := resourceListAddress(tt.name, tt.id)
+		if got != tt.want {
+			t.Errorf("resourceListAddress(%q, %q) = %s, want %s", tt.name, tt.id, got, tt.want)
+		}
+	}
+}
+
+// TestMockVcdResourceListGenerate checks that list_mode "generate" writes import blocks and resource configuration
+// for the whole hierarchy of a VDC
+func TestMockVcdResourceListGenerate(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	fileName := filepath.Join(t.TempDir(), "generated.tf")
+
+	mock.Lock()
+	vdc := mock.fixtures.Orgs[0].Vdcs[0]
+	edgeId := "urn:vcloud:gateway:" + vdc.EdgeGateways[0].ID
+	securityGroupId := "urn:vcloud:firewallGroup:" + mockUuid()
+	mock.openApi["1.0.0/firewallGroups"] = []map[string]interface{}{
+		{"id": "urn:vcloud:firewallGroup:" + mockUuid(), "name": "web-servers", "type": types.FirewallGroupTypeIpSet, "typeValue": types.FirewallGroupTypeIpSet,
+			"ownerRef": map[string]interface{}{"id": edgeId}, "ipAddresses": []interface{}{"10.10.10.11"}},
+		{"id": securityGroupId, "name": "app-servers", "type": types.FirewallGroupTypeSecurityGroup, "typeValue": types.FirewallGroupTypeSecurityGroup,
+			"ownerRef": map[string]interface{}{"id": edgeId}},
+	}
+	mock.openApi["1.0.0/firewallGroups/"+securityGroupId+"/associatedVMs"] = []map[string]interface{}{}
+	mock.openApi["1.0.0/applicationPortProfiles"] = []map[string]interface{}{
+		{"id": "urn:vcloud:applicationPortProfile:" + mockUuid(), "name": "app-8443", "scope": types.ApplicationPortProfileScopeTenant,
+			"contextEntityId":  "urn:vcloud:vdc:" + vdc.ID,
+			"applicationPorts": []interface{}{map[string]interface{}{"protocol": "TCP", "destinationPorts": []interface{}{"8443"}}}},
+	}
+	mock.openApi["1.0.0/edgeGateways/"+edgeId+"/nat/rules"] = []map[string]interface{}{
+		{"id": mockUuid(), "name": "web-dnat", "type": "DNAT", "ruleType": "DNAT", "enabled": true,
+			"externalAddresses": "192.168.100.10", "internalAddresses": "10.10.10.11"},
+	}
+	mock.Unlock()
+
+	d := readMockDataSource(t, vcdClient, "vcloud_resource_list", map[string]interface{}{
+		"name":             "generated",
+		"resource_type":    "vdc_hierarchy",
+		"list_mode":        "generate",
+		"import_file_name": fileName,
+	})
+	list := convertTypeListToSliceOfStrings(d.Get("list").([]interface{}))
+	expectedPrefixes := []string{
+		"vcloud_vapp.tf_vapp-",
+		"vcloud_vapp_vm.tf_vm1-",
+		"vcloud_vapp_vm.tf_vm2-",
+		"vcloud_nsxt_edgegateway.tf_edge-",
+		"vcloud_nsxt_firewall.tf_edge-",
+		"vcloud_nsxt_nat_rule.web-dnat-",
+		"vcloud_nsxt_ip_set.web-servers-",
+		"vcloud_nsxt_security_group.app-servers-",
+		"vcloud_nsxt_app_port_profile.app-8443-",
+	}
+	for _, prefix := range expectedPrefixes {
+		found := false
+		for _, item := range list {
+			if strings.HasPrefix(item, prefix) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("no item starting with '%s' in generated list %v", prefix, list)
+		}
+	}
+
+	contents, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		t.Fatalf("error reading generated file: %s", err)
+	}
+	generated := string(contents)
+	expectedContents := []string{
+		`id = "tf_org.tf_vdc.tf_vapp"`,
+		`id = "tf_org.tf_vdc.tf_vapp.tf_vm1"`,
+		`id = "tf_org.tf_vdc.tf_edge"`,
+		`resource "vcloud_vapp" "tf_vapp-`,
+		`resource "vcloud_nsxt_firewall" "tf_edge-`,
+		`= "allow-outbound"`,
+		`resource "vcloud_nsxt_nat_rule" "web-dnat-`,
+		`resource "vcloud_nsxt_ip_set" "web-servers-`,
+		`resource "vcloud_nsxt_security_group" "app-servers-`,
+		`resource "vcloud_nsxt_app_port_profile" "app-8443-`,
+	}
+	for _, expected := range expectedContents {
+		if !strings.Contains(generated, expected) {
+			t.Errorf("generated file does not contain '%s':\n%s", expected, generated)
+		}
+	}
+	if strings.Contains(generated, "subnet_with_ip_count {") {
+		t.Errorf("generated file contains conflicting subnet definitions:\n%s", generated)
+	}
+	if strings.Contains(generated, "# skipped") {
+		t.Errorf("generated file contains skipped items:\n%s", generated)
+	}
+}
@@ -0,0 +1,91 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func Test_resourceListImportId(t *testing.T) {
+	tests := []struct {
+		name      string
+		ref       resourceRef
+		ancestors []string
+		want      string
+	}{
+		{"name with ancestors", resourceRef{name: "web", id: "urn:vcloud:vapp:1"}, []string{"org", "vdc"}, "org.vdc.web"},
+		{"ID with ancestors", resourceRef{name: "web", id: "urn:vcloud:vm:1", importId: true}, []string{"org", "vdc"}, "org.vdc.urn:vcloud:vm:1"},
+		{"ID without ancestors", resourceRef{name: "small", id: "urn:vcloud:vdcComputePolicy:1", importId: true}, nil, "urn:vcloud:vdcComputePolicy:1"},
+		{"import path", resourceRef{name: "10.0.0.1", importPath: "org.space.FLOATING_IP.10.0.0.1"}, []string{"org", "space"}, "org.space.FLOATING_IP.10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := resourceListImportId(tt.ref, tt.ancestors)
+			if got != tt.want {
+				t.Errorf("resourceListImportId() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestMockVcdResourceListJson checks that list_mode "json" returns parent IDs and key attributes, and that NSX-T
+// firewall rules can be listed
+func TestMockVcdResourceListJson(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	decodeList := func(list []interface{}) []resourceListJsonItem {
+		var items []resourceListJsonItem
+		for _, entry := range list {
+			var item resourceListJsonItem
+			err := json.Unmarshal([]byte(entry.(string)), &item)
+			if err != nil {
+				t.Fatalf("error decoding list item %s: %s", entry, err)
+			}
+			items = append(items, item)
+		}
+		return items
+	}
+
+	vms := readMockDataSource(t, vcdClient, "vcloud_resource_list", map[string]interface{}{
+		"name":          "vms",
+		"resource_type": "vcd_vapp_vm",
+		"parent":        "tf_vapp",
+		"list_mode":     "json",
+		"name_regex":    "vm1$",
+	})
+	vmItems := decodeList(vms.Get("list").([]interface{}))
+	if len(vmItems) != 1 {
+		t.Fatalf("expected 1 VM, got %v", vmItems)
+	}
+	vm := vmItems[0]
+	if vm.Name != "tf_vm1" || vm.ResourceType != "vcloud_vapp_vm" || vm.Parent != "tf_vapp" {
+		t.Errorf("unexpected VM item: %+v", vm)
+	}
+	if !strings.HasPrefix(vm.ParentId, "urn:vcloud:vapp:") || !strings.HasPrefix(vm.Id, "urn:vcloud:vm:") {
+		t.Errorf("unexpected VM IDs: %+v", vm)
+	}
+	if vm.ImportId != "tf_org.tf_vdc.tf_vapp.tf_vm1" {
+		t.Errorf("unexpected VM import ID: %s", vm.ImportId)
+	}
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	rules := readMockDataSource(t, vcdClient, "vcloud_resource_list", map[string]interface{}{
+		"name":          "rules",
+		"resource_type": "nsxt_firewall_rule",
+		"parent":        "tf_edge",
+		"list_mode":     "json",
+	})
+	ruleItems := decodeList(rules.Get("list").([]interface{}))
+	if len(ruleItems) != 1 {
+		t.Fatalf("expected 1 firewall rule, got %v", ruleItems)
+	}
+	rule := ruleItems[0]
+	if rule.Name != "allow-outbound" || rule.ResourceType != "vcloud_nsxt_firewall_rule" || rule.ParentId != edge.Id() {
+		t.Errorf("unexpected firewall rule item: %+v", rule)
+	}
+	if rule.ImportId != "tf_org.tf_vdc.tf_edge.allow-outbound" || rule.Attributes["action"] == "" {
+		t.Errorf("unexpected firewall rule import ID or attributes: %+v", rule)
+	}
+}
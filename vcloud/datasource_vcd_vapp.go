@@ -12,9 +12,27 @@ func datasourceVcdVApp() *schema.Resource {
 
 		Schema: map[string]*schema.Schema{
 			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "A name for the vApp, unique within the VDC",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "filter"},
+				Description:  "A name for the vApp, unique within the VDC (Optional if 'filter' is used)",
+			},
+			"filter": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				MinItems:     1,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "filter"},
+				Description:  "Criteria for retrieving a vApp by various attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"date":       elementDate,
+						"earliest":   elementEarliest,
+						"latest":     elementLatest,
+						"metadata":   elementMetadata,
+					},
+				},
 			},
 			"org": {
 				Type:     schema.TypeString,
@@ -88,5 +106,23 @@ func datasourceVcdVApp() *schema.Resource {
 }
 
 func datasourceVcdVAppRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if !nameOrFilterIsSet(d) {
+		return diag.Errorf(noNameOrFilterError, "vcd_vapp")
+	}
+
+	filter, hasFilter := d.GetOk("filter")
+	if hasFilter {
+		vcdClient := meta.(*VCDClient)
+		_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+		if err != nil {
+			return diag.Errorf(errorRetrievingOrgAndVdc, err)
+		}
+		vapp, err := getVappByFilter(vdc, filter, vcdClient.Client.IsSysAdmin)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		dSet(d, "name", vapp.VApp.Name)
+		d.SetId(vapp.VApp.ID)
+	}
 	return genericVcdVAppRead(d, meta, "datasource")
 }
@@ -31,9 +31,28 @@ func vcdVmDS(vmType typeOfVm) map[string]*schema.Schema {
 			Description: fmt.Sprintf("Type of VM: either '%s' or '%s'", vappVmType, standaloneVmType),
 		},
 		"name": {
-			Type:        schema.TypeString,
-			Required:    true,
-			Description: "A name for the VM, unique within the vApp",
+			Type:         schema.TypeString,
+			Optional:     true,
+			ExactlyOneOf: []string{"name", "filter"},
+			Description:  "A name for the VM, unique within the vApp (Optional if 'filter' is used)",
+		},
+		"filter": {
+			Type:         schema.TypeList,
+			MaxItems:     1,
+			MinItems:     1,
+			Optional:     true,
+			ExactlyOneOf: []string{"name", "filter"},
+			Description:  "Criteria for retrieving a VM by various attributes",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"name_regex": elementNameRegex,
+					"date":       elementDate,
+					"earliest":   elementEarliest,
+					"latest":     elementLatest,
+					"ip":         elementIp,
+					"metadata":   elementMetadata,
+				},
+			},
 		},
 		"org": {
 			Type:     schema.TypeString,
@@ -482,5 +501,33 @@ func datasourceVcdVAppVm() *schema.Resource {
 }
 
 func datasourceVcdVAppVmRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := setVmIdByFilter(d, meta, "vcd_vapp_vm")
+	if diags != nil {
+		return diags
+	}
 	return genericVcdVmRead(d, meta, "datasource")
 }
+
+// setVmIdByFilter sets the ID of the VM found with the filter of a VM data source, if any, so that it can be read by
+// genericVcdVmRead. When the data source has a vApp name, only the VMs of that vApp are searched
+func setVmIdByFilter(d *schema.ResourceData, meta interface{}, resourceType string) diag.Diagnostics {
+	if !nameOrFilterIsSet(d) {
+		return diag.Errorf(noNameOrFilterError, resourceType)
+	}
+	filter, hasFilter := d.GetOk("filter")
+	if !hasFilter {
+		return nil
+	}
+
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+	vm, err := getVmByFilter(vcdClient, vdc, filter, d.Get("vapp_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	d.SetId(vm.VM.ID)
+	return nil
+}
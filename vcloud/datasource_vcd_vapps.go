@@ -0,0 +1,96 @@
+package vcloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// vappsItemSchema returns the attributes of every vApp returned by vcd_vapps, which are the same as vcd_vapp
+func vappsItemSchema() map[string]*schema.Schema {
+	itemSchema := computedSchemaMap(datasourceVcdVApp().Schema, "filter", "org", "vdc")
+	itemSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the vApp",
+	}
+	return itemSchema
+}
+
+func datasourceVcdVApps() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVAppsRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Criteria for retrieving the vApps. When not set, all the vApps of the VDC are returned",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"date":       elementDate,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
+			"vapps": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "vApps matching the filter, with the same attributes as the vcd_vapp data source",
+				Elem: &schema.Resource{
+					Schema: vappsItemSchema(),
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVAppsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+
+	queryItems, err := getAllVappsByFilter(vdc, d.Get("filter"), vcdClient.Client.IsSysAdmin)
+	if err != nil {
+		return diag.Errorf("error retrieving vApps: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	itemSchema := vappsItemSchema()
+	vapps := make([]interface{}, len(queryItems))
+	for i, queryItem := range queryItems {
+		// Every vApp is read as the vcd_vapp data source would do, to get all its attributes
+		vappData := datasourceVcdVApp().Data(nil)
+		dSet(vappData, "org", d.Get("org"))
+		dSet(vappData, "vdc", d.Get("vdc"))
+		dSet(vappData, "name", queryItem.GetName())
+		vappData.SetId(normalizeId("urn:vcloud:vapp:", extractUuid(queryItem.GetHref())))
+		diags = append(diags, genericVcdVAppRead(vappData, meta, "datasource")...)
+		if diags.HasError() {
+			return diags
+		}
+		vapps[i] = resourceDataToMap(vappData, itemSchema)
+	}
+
+	err = d.Set("vapps", vapps)
+	if err != nil {
+		return diag.Errorf("error setting vApps: %s", err)
+	}
+	d.SetId(vdc.Vdc.ID)
+	return diags
+}
@@ -24,7 +24,7 @@ func datasourceVdcGroup() *schema.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "id"},
+				ExactlyOneOf: []string{"name", "id", "metadata_entry_filter", "filter"},
 				Description:  "Name of VDC group",
 			},
 			"id": {
@@ -32,9 +32,24 @@ func datasourceVdcGroup() *schema.Resource {
 				Optional:     true,
 				ForceNew:     true,
 				Computed:     true,
-				ExactlyOneOf: []string{"name", "id"},
+				ExactlyOneOf: []string{"name", "id", "metadata_entry_filter", "filter"},
 				Description:  "VDC group ID",
 			},
+			"metadata_entry_filter": openApiMetadataEntryFilterSchema("VDC Group", []string{"name", "id", "metadata_entry_filter", "filter"}),
+			"filter": {
+				Type:         schema.TypeList,
+				MaxItems:     1,
+				MinItems:     1,
+				Optional:     true,
+				ExactlyOneOf: []string{"name", "id", "metadata_entry_filter", "filter"},
+				Description:  "Criteria for retrieving a VDC group by various attributes",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
 			"description": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -163,6 +178,7 @@ func datasourceVdcGroup() *schema.Resource {
 					},
 				},
 			},
+			"metadata_entry": openApiMetadataEntryDatasourceSchema("VDC Group"),
 		},
 	}
 }
@@ -182,6 +198,17 @@ func datasourceVcdVdcGroupRead(_ context.Context, d *schema.ResourceData, meta i
 		vdcGroup, err = adminOrg.GetVdcGroupByName(name)
 	} else if d.Get("id").(string) != "" {
 		vdcGroup, err = adminOrg.GetVdcGroupById(d.Get("id").(string))
+	} else if filter, ok := d.GetOk("filter"); ok {
+		vdcGroup, err = getVdcGroupByFilter(vcdClient, adminOrg, filter)
+	} else if _, ok := d.GetOk("metadata_entry_filter"); ok {
+		var candidates []*govcd.VdcGroup
+		candidates, err = adminOrg.GetAllVdcGroups(nil)
+		if err == nil {
+			vdcGroup, err = getOpenApiEntityByMetadataFilter(d, "metadata_entry_filter", "VDC Group", candidates,
+				func(candidate *govcd.VdcGroup) openApiMetadataHandler {
+					return vdcGroupMetadata(vcdClient, candidate.VdcGroup)
+				})
+		}
 	} else {
 		return diag.Errorf("Id or Name value is missing %s", err)
 	}
@@ -195,5 +222,5 @@ func datasourceVcdVdcGroupRead(_ context.Context, d *schema.ResourceData, meta i
 		return diag.Errorf("[VDC group read] : %s", err)
 	}
 
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_vdc_group", vdcGroupMetadata(vcdClient, vdcGroup.VdcGroup))
 }
@@ -15,5 +15,9 @@ func datasourceVcdStandaloneVm() *schema.Resource {
 }
 
 func datasourceVcdStandaloneVmRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	diags := setVmIdByFilter(d, meta, "vcd_vm")
+	if diags != nil {
+		return diags
+	}
 	return genericVcdVmRead(d, meta, "datasource")
 }
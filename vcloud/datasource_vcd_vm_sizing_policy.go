@@ -19,9 +19,12 @@ func datasourceVcdVmSizingPolicy() *schema.Resource {
 				Description: "The name of organization to use - Deprecated and unneeded: will be ignored if used ",
 			},
 			"name": {
-				Type:     schema.TypeString,
-				Required: true,
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ExactlyOneOf: []string{"name", "metadata_entry_filter"},
 			},
+			"metadata_entry_filter": openApiMetadataEntryFilterSchema("VM sizing policy", []string{"name", "metadata_entry_filter"}),
 			"description": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -36,6 +39,7 @@ func datasourceVcdVmSizingPolicy() *schema.Resource {
 				Type:     schema.TypeList,
 				Elem:     sizingPolicyMemoryDS,
 			},
+			"metadata_entry": openApiMetadataEntryDatasourceSchema("VM sizing policy"),
 		},
 	}
 }
@@ -0,0 +1,78 @@
+package vcloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func datasourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmSnapshotRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The vApp that contains the VM. For standalone VMs, use the 'vapp_name' attribute of 'vcd_vm'",
+			},
+			"vm_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the VM",
+			},
+			"vm_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VM",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the snapshot was created",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the VM was powered on when the snapshot was taken",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the snapshot, in bytes",
+			},
+		},
+	}
+}
+
+func datasourceVcdVmSnapshotRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	_, _, _, _, _, vm, err := getVmFromResourceByIdentifier(d, meta, vappVmType, d.Get("vm_name").(string))
+	if err != nil {
+		return diag.Errorf("[VM snapshot data source read] %s", err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot data source read] %s", err)
+	}
+	if snapshot == nil {
+		return diag.Errorf("[VM snapshot data source read] VM '%s' has no snapshot", vm.VM.Name)
+	}
+
+	d.SetId(vm.VM.ID)
+	setVmSnapshotData(d, vm, snapshot)
+	return nil
+}
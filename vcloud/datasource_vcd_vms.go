@@ -0,0 +1,103 @@
+package vcloud
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// vmsItemSchema returns the attributes of every VM returned by vcd_vms, which are the same as vcd_vm
+func vmsItemSchema() map[string]*schema.Schema {
+	itemSchema := computedSchemaMap(vcdVmDS(standaloneVmType), "filter", "org", "vdc", "network_dhcp_wait_seconds")
+	itemSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "ID of the VM",
+	}
+	return itemSchema
+}
+
+func datasourceVcdVms() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: datasourceVcdVmsRead,
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "When set, only the VMs of this vApp are returned",
+			},
+			"filter": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "Criteria for retrieving the VMs. When not set, all the VMs are returned",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name_regex": elementNameRegex,
+						"date":       elementDate,
+						"ip":         elementIp,
+						"metadata":   elementMetadata,
+					},
+				},
+			},
+			"vms": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "VMs matching the filter, with the same attributes as the vcd_vm data source",
+				Elem: &schema.Resource{
+					Schema: vmsItemSchema(),
+				},
+			},
+		},
+	}
+}
+
+func datasourceVcdVmsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		return diag.Errorf(errorRetrievingOrgAndVdc, err)
+	}
+
+	queryItems, err := getAllVmsByFilter(vcdClient, vdc, d.Get("filter"), d.Get("vapp_name").(string))
+	if err != nil {
+		return diag.Errorf("error retrieving VMs: %s", err)
+	}
+
+	var diags diag.Diagnostics
+	itemSchema := vmsItemSchema()
+	vms := make([]interface{}, len(queryItems))
+	for i, queryItem := range queryItems {
+		// Every VM is read as the vcd_vm data source would do, to get all its attributes. The vApp name avoids
+		// querying all the VMs of the VDC again to find each VM by ID
+		vmData := datasourceVcdStandaloneVm().Data(nil)
+		dSet(vmData, "org", d.Get("org"))
+		dSet(vmData, "vdc", d.Get("vdc"))
+		dSet(vmData, "vapp_name", queryItem.GetParentName())
+		vmData.SetId(normalizeId("urn:vcloud:vm:", extractUuid(queryItem.GetHref())))
+		diags = append(diags, genericVcdVmRead(vmData, meta, "datasource")...)
+		if diags.HasError() {
+			return diags
+		}
+		vms[i] = resourceDataToMap(vmData, itemSchema)
+	}
+
+	err = d.Set("vms", vms)
+	if err != nil {
+		return diag.Errorf("error setting VMs: %s", err)
+	}
+	d.SetId(vdc.Vdc.ID)
+	return diags
+}
@@ -0,0 +1,49 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"testing"
+)
+
+func TestMockVcdPluralDataSources(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	allVms := readMockDataSource(t, vcdClient, "vcloud_vms", map[string]interface{}{})
+	vms := allVms.Get("vms").([]interface{})
+	if len(vms) != 2 {
+		t.Fatalf("expected 2 VMs, got %d", len(vms))
+	}
+
+	filteredVms := readMockDataSource(t, vcdClient, "vcloud_vms", map[string]interface{}{
+		"vapp_name": "tf_vapp",
+		"filter":    []interface{}{map[string]interface{}{"name_regex": "vm1$"}},
+	})
+	vms = filteredVms.Get("vms").([]interface{})
+	if len(vms) != 1 {
+		t.Fatalf("expected 1 VM, got %d", len(vms))
+	}
+	vm := vms[0].(map[string]interface{})
+	if vm["name"] != "tf_vm1" || vm["vapp_name"] != "tf_vapp" || vm["cpus"] != 2 || vm["id"] == "" {
+		t.Errorf("unexpected VM attributes: name %v, vApp %v, CPUs %v, ID %v", vm["name"], vm["vapp_name"], vm["cpus"], vm["id"])
+	}
+
+	vapps := readMockDataSource(t, vcdClient, "vcloud_vapps", map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"name_regex": "^tf_"}},
+	}).Get("vapps").([]interface{})
+	if len(vapps) != 1 || vapps[0].(map[string]interface{})["name"] != "tf_vapp" {
+		t.Errorf("expected vApp tf_vapp, got %v", vapps)
+	}
+
+	noVapps := readMockDataSource(t, vcdClient, "vcloud_vapps", map[string]interface{}{
+		"filter": []interface{}{map[string]interface{}{"name_regex": "^missing"}},
+	}).Get("vapps").([]interface{})
+	if len(noVapps) != 0 {
+		t.Errorf("expected no vApps, got %v", noVapps)
+	}
+
+	edgeGateways := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateways", map[string]interface{}{}).Get("edge_gateways").([]interface{})
+	if len(edgeGateways) != 1 || edgeGateways[0].(map[string]interface{})["name"] != "tf_edge" {
+		t.Errorf("expected Edge Gateway tf_edge, got %v", edgeGateways)
+	}
+}
@@ -2,6 +2,9 @@ package vcloud
 
 import (
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -37,6 +40,20 @@ func getEntityByFilter(search searchByFilterFunc, queryType, label string, filte
 	return queryItems[0], nil
 }
 
+// getAllEntitiesByFilter is the same as getEntityByFilter, but returns all the items that match the filter block.
+// An empty filter block returns all the items
+func getAllEntitiesByFilter(search searchByFilterFunc, queryType string, filter interface{}) ([]govcd.QueryItem, error) {
+	criteria, err := buildCriteria(filter)
+	if err != nil {
+		return nil, err
+	}
+	queryItems, _, err := search(queryType, criteria)
+	if err != nil {
+		return nil, err
+	}
+	return queryItems, nil
+}
+
 // getCatalogByFilter finds a catalog using a filter block
 func getCatalogByFilter(org *govcd.AdminOrg, filter interface{}, isSysAdmin bool) (*govcd.AdminCatalog, error) {
 	queryType := types.QtCatalog
@@ -227,3 +244,455 @@ func getEdgeGatewayByFilter(vdc *govcd.Vdc, filter interface{}) (*govcd.EdgeGate
 	}
 	return egw, nil
 }
+
+// getVappByFilter finds a vApp using a filter block
+func getVappByFilter(vdc *govcd.Vdc, filter interface{}, isSysAdmin bool) (*govcd.VApp, error) {
+	queryItem, err := getEntityByFilter(vappSearchFunc(vdc), vappQueryType(isSysAdmin), "vApp", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	vapp, err := vdc.GetVAppByHref(queryItem.GetHref())
+	if err != nil {
+		return nil, fmt.Errorf("[getVappByFilter] error retrieving vApp %s: %s", queryItem.GetName(), err)
+	}
+	return vapp, nil
+}
+
+// getAllVappsByFilter returns all the vApps of a VDC that match a filter block
+func getAllVappsByFilter(vdc *govcd.Vdc, filter interface{}, isSysAdmin bool) ([]govcd.QueryItem, error) {
+	return getAllEntitiesByFilter(vappSearchFunc(vdc), vappQueryType(isSysAdmin), filter)
+}
+
+// vappQueryType returns the query type used to search vApps
+func vappQueryType(isSysAdmin bool) string {
+	if isSysAdmin {
+		return types.QtAdminVapp
+	}
+	return types.QtVapp
+}
+
+// vappSearchFunc returns a function that searches the vApps of a VDC
+func vappSearchFunc(vdc *govcd.Vdc) searchByFilterFunc {
+	return func(queryType string, criteria *govcd.FilterDef) ([]govcd.QueryItem, string, error) {
+		return vdc.SearchByFilter(queryType, "vdc", criteria)
+	}
+}
+
+// getVmByFilter finds a VM using a filter block. When vappName is not empty, only the VMs of that vApp are
+// considered
+func getVmByFilter(vcdClient *VCDClient, vdc *govcd.Vdc, filter interface{}, vappName string) (*govcd.VM, error) {
+	queryItem, err := getEntityByFilter(vmSearchFunc(vdc, vappName), vmQueryType(vcdClient), "VM", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	vm, err := vcdClient.Client.GetVMByHref(queryItem.GetHref())
+	if err != nil {
+		return nil, fmt.Errorf("[getVmByFilter] error retrieving VM %s: %s", queryItem.GetName(), err)
+	}
+	return vm, nil
+}
+
+// getAllVmsByFilter returns all the VMs of a VDC that match a filter block. When vappName is not empty, only the VMs
+// of that vApp are considered
+func getAllVmsByFilter(vcdClient *VCDClient, vdc *govcd.Vdc, filter interface{}, vappName string) ([]govcd.QueryItem, error) {
+	return getAllEntitiesByFilter(vmSearchFunc(vdc, vappName), vmQueryType(vcdClient), filter)
+}
+
+// vmQueryType returns the query type used to search VMs
+func vmQueryType(vcdClient *VCDClient) string {
+	if vcdClient.Client.IsSysAdmin {
+		return types.QtAdminVm
+	}
+	return types.QtVm
+}
+
+// vmSearchFunc returns a function that searches the VMs of a VDC, excluding the ones of vApp templates.
+// When vappName is not empty, only the VMs of that vApp are returned
+func vmSearchFunc(vdc *govcd.Vdc, vappName string) searchByFilterFunc {
+	return func(queryType string, criteria *govcd.FilterDef) ([]govcd.QueryItem, string, error) {
+		items, explanation, err := vdc.SearchByFilter(queryType, "vdc", criteria)
+		var newItems []govcd.QueryItem
+		for _, item := range items {
+			// VMs of vApp templates are also returned by the query
+			if vm, ok := item.(govcd.QueryVm); ok && vm.VAppTemplate {
+				continue
+			}
+			if vappName == "" || item.GetParentName() == vappName {
+				newItems = append(newItems, item)
+			}
+		}
+		return newItems, explanation, err
+	}
+}
+
+// getOrgVdcByFilter finds a VDC using a filter block
+func getOrgVdcByFilter(adminOrg *govcd.AdminOrg, filter interface{}, isSysAdmin bool) (*govcd.AdminVdc, error) {
+	queryType := types.QtOrgVdc
+	if isSysAdmin {
+		queryType = types.QtAdminOrgVdc
+	}
+	var searchFunc = func(queryType string, criteria *govcd.FilterDef) ([]govcd.QueryItem, string, error) {
+		return adminOrg.SearchByFilter(queryType, criteria)
+	}
+
+	queryItem, err := getEntityByFilter(searchFunc, queryType, "VDC", filter)
+	if err != nil {
+		return nil, err
+	}
+
+	adminVdc, err := adminOrg.GetAdminVDCByName(queryItem.GetName(), false)
+	if err != nil {
+		return nil, fmt.Errorf("[getOrgVdcByFilter] error retrieving VDC %s: %s", queryItem.GetName(), err)
+	}
+	return adminVdc, nil
+}
+
+// getIndependentDiskByFilter finds an independent disk using a filter block. Disks are not handled by the search
+// engine of the SDK, and are filtered with searchItemsByFilter
+func getIndependentDiskByFilter(vdc *govcd.Vdc, filter interface{}) (*govcd.Disk, error) {
+	diskRecords, err := vdc.QueryDisks("*")
+	if err != nil {
+		return nil, err
+	}
+	var items []*providerQueryItem
+	for _, diskRecord := range *diskRecords {
+		items = append(items, &providerQueryItem{
+			name:       diskRecord.Name,
+			itemType:   "independent_disk",
+			href:       diskRecord.HREF,
+			parentName: diskRecord.VdcName,
+			parentId:   diskRecord.Vdc,
+		})
+	}
+
+	item, err := getProviderItemByFilter(items, "independent disk", filter, func(item *providerQueryItem) (map[string]string, error) {
+		disk, err := vdc.GetDiskByHref(item.href)
+		if err != nil {
+			return nil, err
+		}
+		metadata, err := disk.GetMetadata()
+		if err != nil {
+			return nil, err
+		}
+		return metadataToFilterValues(metadata), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	disk, err := vdc.GetDiskByHref(item.href)
+	if err != nil {
+		return nil, fmt.Errorf("[getIndependentDiskByFilter] error retrieving independent disk %s: %s", item.name, err)
+	}
+	return disk, nil
+}
+
+// getNsxtEdgeGatewayByFilter finds an NSX-T Edge Gateway among the given ones using a filter block. The IP of an Edge
+// Gateway is its primary IP, and the metadata is the OpenAPI one
+func getNsxtEdgeGatewayByFilter(vcdClient *VCDClient, edgeGateways []*govcd.NsxtEdgeGateway, filter interface{}) (*govcd.NsxtEdgeGateway, error) {
+	items, getMetadata := nsxtEdgeGatewayFilterItems(vcdClient, edgeGateways)
+	item, err := getProviderItemByFilter(items, "NSX-T Edge Gateway", filter, getMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return item.entity.(*govcd.NsxtEdgeGateway), nil
+}
+
+// getAllNsxtEdgeGatewaysByFilter returns all the given NSX-T Edge Gateways that match a filter block
+func getAllNsxtEdgeGatewaysByFilter(vcdClient *VCDClient, edgeGateways []*govcd.NsxtEdgeGateway, filter interface{}) ([]*govcd.NsxtEdgeGateway, error) {
+	items, getMetadata := nsxtEdgeGatewayFilterItems(vcdClient, edgeGateways)
+	found, err := getAllProviderItemsByFilter(items, filter, getMetadata)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*govcd.NsxtEdgeGateway, len(found))
+	for i, item := range found {
+		result[i] = item.entity.(*govcd.NsxtEdgeGateway)
+	}
+	return result, nil
+}
+
+// nsxtEdgeGatewayFilterItems converts NSX-T Edge Gateways into items for searchItemsByFilter, and returns them with
+// the function that retrieves their metadata
+func nsxtEdgeGatewayFilterItems(vcdClient *VCDClient, edgeGateways []*govcd.NsxtEdgeGateway) ([]*providerQueryItem, func(*providerQueryItem) (map[string]string, error)) {
+	var items []*providerQueryItem
+	for _, edgeGateway := range edgeGateways {
+		item := &providerQueryItem{
+			name:     edgeGateway.EdgeGateway.Name,
+			itemType: "nsxt_edgegateway",
+			href:     edgeGateway.EdgeGateway.ID,
+			entity:   edgeGateway,
+		}
+		if edgeGateway.EdgeGateway.OwnerRef != nil {
+			item.parentName = edgeGateway.EdgeGateway.OwnerRef.Name
+			item.parentId = edgeGateway.EdgeGateway.OwnerRef.ID
+		}
+		for _, uplink := range edgeGateway.EdgeGateway.EdgeGatewayUplinks {
+			for _, subnet := range uplink.Subnets.Values {
+				if subnet.PrimaryIP != "" && item.ip == "" {
+					item.ip = subnet.PrimaryIP
+				}
+			}
+		}
+		items = append(items, item)
+	}
+
+	getMetadata := func(item *providerQueryItem) (map[string]string, error) {
+		return openApiMetadataToFilterValues(nsxtEdgeGatewayMetadata(vcdClient, item.entity.(*govcd.NsxtEdgeGateway).EdgeGateway))
+	}
+	return items, getMetadata
+}
+
+// getOpenApiOrgVdcNetworkByFilterInOwner finds a network of the given owner (VDC or VDC Group) using a filter block.
+// Unlike getOpenApiOrgVdcNetworkByFilter, it does not use the query service, which does not return the networks of
+// VDC Groups. The IP of a network is its gateway, and the metadata is the OpenAPI one
+func getOpenApiOrgVdcNetworkByFilterInOwner(vcdClient *VCDClient, org *govcd.Org, ownerId, resourceType string, filter interface{}, wanted func(*govcd.OpenApiOrgVdcNetwork) bool) (*govcd.OpenApiOrgVdcNetwork, error) {
+	items, getMetadata, err := openApiOrgVdcNetworkFilterItems(vcdClient, org, ownerId, wanted, func(*govcd.OpenApiOrgVdcNetwork) string {
+		return resourceType
+	})
+	if err != nil {
+		return nil, err
+	}
+	item, err := getProviderItemByFilter(items, "Org VDC network", filter, getMetadata)
+	if err != nil {
+		return nil, err
+	}
+	return item.entity.(*govcd.OpenApiOrgVdcNetwork), nil
+}
+
+// getAllOpenApiOrgVdcNetworksByFilterInOwner returns all the networks of the given owner (VDC or VDC Group) that match a
+// filter block, regardless of their type. When ownerId is empty, the networks of all the owners in the Org are searched
+func getAllOpenApiOrgVdcNetworksByFilterInOwner(vcdClient *VCDClient, org *govcd.Org, ownerId string, filter interface{}) ([]*govcd.OpenApiOrgVdcNetwork, error) {
+	items, getMetadata, err := openApiOrgVdcNetworkFilterItems(vcdClient, org, ownerId, nil, openApiOrgVdcNetworkResourceType)
+	if err != nil {
+		return nil, err
+	}
+	found, err := getAllProviderItemsByFilter(items, filter, getMetadata)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*govcd.OpenApiOrgVdcNetwork, len(found))
+	for i, item := range found {
+		result[i] = item.entity.(*govcd.OpenApiOrgVdcNetwork)
+	}
+	return result, nil
+}
+
+// openApiOrgVdcNetworkFilterItems retrieves the networks of the given owner that satisfy the wanted function, if any,
+// and returns them as items for searchItemsByFilter, with the function that retrieves their metadata. When ownerId is
+// empty, all the networks of the Org are retrieved. resourceType returns the resource type used in the errors about
+// the metadata of a network
+func openApiOrgVdcNetworkFilterItems(vcdClient *VCDClient, org *govcd.Org, ownerId string, wanted func(*govcd.OpenApiOrgVdcNetwork) bool, resourceType func(*govcd.OpenApiOrgVdcNetwork) string) ([]*providerQueryItem, func(*providerQueryItem) (map[string]string, error), error) {
+	queryParameters := url.Values{}
+	if ownerId != "" {
+		queryParameters.Add("filter", "ownerRef.id=="+ownerId)
+	}
+	networks, err := org.GetAllOpenApiOrgVdcNetworks(queryParameters)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error retrieving Org VDC networks: %s", err)
+	}
+
+	var items []*providerQueryItem
+	for _, network := range networks {
+		if wanted != nil && !wanted(network) {
+			continue
+		}
+		item := &providerQueryItem{
+			name:     network.OpenApiOrgVdcNetwork.Name,
+			itemType: "network_" + strings.ToLower(network.GetType()),
+			href:     network.OpenApiOrgVdcNetwork.ID,
+			entity:   network,
+		}
+		if network.OpenApiOrgVdcNetwork.OwnerRef != nil {
+			item.parentName = network.OpenApiOrgVdcNetwork.OwnerRef.Name
+			item.parentId = network.OpenApiOrgVdcNetwork.OwnerRef.ID
+		}
+		if len(network.OpenApiOrgVdcNetwork.Subnets.Values) > 0 {
+			item.ip = network.OpenApiOrgVdcNetwork.Subnets.Values[0].Gateway
+		}
+		items = append(items, item)
+	}
+
+	getMetadata := func(item *providerQueryItem) (map[string]string, error) {
+		network := item.entity.(*govcd.OpenApiOrgVdcNetwork)
+		return openApiMetadataToFilterValues(openApiOrgVdcNetworkMetadata(vcdClient, resourceType(network), network.OpenApiOrgVdcNetwork))
+	}
+	return items, getMetadata, nil
+}
+
+// getVdcGroupByFilter finds a VDC Group using a filter block. The metadata of VDC Groups is the OpenAPI one
+func getVdcGroupByFilter(vcdClient *VCDClient, adminOrg *govcd.AdminOrg, filter interface{}) (*govcd.VdcGroup, error) {
+	vdcGroups, err := adminOrg.GetAllVdcGroups(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving VDC Groups: %s", err)
+	}
+
+	var items []*providerQueryItem
+	for _, vdcGroup := range vdcGroups {
+		items = append(items, &providerQueryItem{
+			name:       vdcGroup.VdcGroup.Name,
+			itemType:   "vdc_group",
+			href:       vdcGroup.VdcGroup.Id,
+			parentName: adminOrg.AdminOrg.Name,
+			parentId:   adminOrg.AdminOrg.ID,
+			entity:     vdcGroup,
+		})
+	}
+
+	item, err := getProviderItemByFilter(items, "VDC Group", filter, func(item *providerQueryItem) (map[string]string, error) {
+		return openApiMetadataToFilterValues(vdcGroupMetadata(vcdClient, item.entity.(*govcd.VdcGroup).VdcGroup))
+	})
+	if err != nil {
+		return nil, err
+	}
+	return item.entity.(*govcd.VdcGroup), nil
+}
+
+// providerQueryItem is a govcd.QueryItem for the entities that the search engine of the SDK does not handle, such as
+// independent disks and OpenAPI entities. These items are searched with searchItemsByFilter
+type providerQueryItem struct {
+	name       string
+	itemType   string
+	ip         string
+	href       string
+	parentName string
+	parentId   string
+	// metadata contains the metadata values by key. It is only retrieved when the filter uses metadata
+	metadata map[string]string
+	// entity is the object that the item was built from, if it was already retrieved
+	entity interface{}
+}
+
+func (item *providerQueryItem) GetHref() string       { return item.href }
+func (item *providerQueryItem) GetName() string       { return item.name }
+func (item *providerQueryItem) GetType() string       { return item.itemType }
+func (item *providerQueryItem) GetIp() string         { return item.ip }
+func (item *providerQueryItem) GetDate() string       { return "" }
+func (item *providerQueryItem) GetParentName() string { return item.parentName }
+func (item *providerQueryItem) GetParentId() string   { return item.parentId }
+func (item *providerQueryItem) GetMetadataValue(key string) string {
+	return item.metadata[key]
+}
+
+// getProviderItemByFilter builds criteria from a filter block and returns the only item that matches them.
+// getMetadata retrieves the metadata of an item, and is only called when the filter uses metadata
+func getProviderItemByFilter(items []*providerQueryItem, label string, filter interface{}, getMetadata func(*providerQueryItem) (map[string]string, error)) (*providerQueryItem, error) {
+	var searchFunc = func(_ string, criteria *govcd.FilterDef) ([]govcd.QueryItem, string, error) {
+		return searchItemsByFilter(items, criteria, getMetadata)
+	}
+	queryItem, err := getEntityByFilter(searchFunc, "", label, filter)
+	if err != nil {
+		return nil, err
+	}
+	return queryItem.(*providerQueryItem), nil
+}
+
+// getAllProviderItemsByFilter builds criteria from a filter block and returns all the items that match them
+func getAllProviderItemsByFilter(items []*providerQueryItem, filter interface{}, getMetadata func(*providerQueryItem) (map[string]string, error)) ([]*providerQueryItem, error) {
+	var searchFunc = func(_ string, criteria *govcd.FilterDef) ([]govcd.QueryItem, string, error) {
+		return searchItemsByFilter(items, criteria, getMetadata)
+	}
+	queryItems, err := getAllEntitiesByFilter(searchFunc, "", filter)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*providerQueryItem, len(queryItems))
+	for i, queryItem := range queryItems {
+		result[i] = queryItem.(*providerQueryItem)
+	}
+	return result, nil
+}
+
+// searchItemsByFilter applies the criteria of a filter block to the given items, following the same rules as the
+// search engine of the SDK: names, IPs and metadata values are matched with regular expressions.
+// Dates are not supported, as none of these entities has a creation date
+func searchItemsByFilter(items []*providerQueryItem, criteria *govcd.FilterDef, getMetadata func(*providerQueryItem) (map[string]string, error)) ([]govcd.QueryItem, string, error) {
+	explanation := fmt.Sprintf("criteria: %v", criteria.Filters)
+	conditions := map[string]*regexp.Regexp{}
+	for key, value := range criteria.Filters {
+		switch {
+		case value == "" || value == "false":
+			continue
+		case key == types.FilterNameRegex || key == types.FilterIp:
+			re, err := regexp.Compile(value)
+			if err != nil {
+				return nil, explanation, fmt.Errorf("error compiling regular expression '%s' : %s ", value, err)
+			}
+			conditions[key] = re
+		default:
+			return nil, explanation, fmt.Errorf("filter '%s' not supported for this entity", key)
+		}
+	}
+	metadataConditions := map[string]*regexp.Regexp{}
+	for _, definition := range criteria.Metadata {
+		explanation += fmt.Sprintf(" metadata(%s -> %v)", definition.Key, definition.Value)
+		re, err := regexp.Compile(fmt.Sprintf("%v", definition.Value))
+		if err != nil {
+			return nil, explanation, fmt.Errorf("error compiling regular expression '%v' : %s ", definition.Value, err)
+		}
+		metadataConditions[definition.Key] = re
+	}
+
+	var found []govcd.QueryItem
+	for _, item := range items {
+		if re, ok := conditions[types.FilterNameRegex]; ok && !re.MatchString(item.name) {
+			continue
+		}
+		if re, ok := conditions[types.FilterIp]; ok && !re.MatchString(item.ip) {
+			continue
+		}
+		if len(metadataConditions) > 0 && item.metadata == nil {
+			metadata, err := getMetadata(item)
+			if err != nil {
+				return nil, explanation, fmt.Errorf("error retrieving metadata of %s: %s", item.name, err)
+			}
+			item.metadata = metadata
+		}
+		matches := true
+		for key, re := range metadataConditions {
+			value, ok := item.metadata[key]
+			if !ok || !re.MatchString(value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			found = append(found, item)
+		}
+	}
+	return found, explanation, nil
+}
+
+// metadataToFilterValues returns the values of the given metadata by key, to be matched by searchItemsByFilter
+func metadataToFilterValues(metadata *types.Metadata) map[string]string {
+	values := map[string]string{}
+	if metadata == nil {
+		return values
+	}
+	for _, entry := range metadata.MetadataEntry {
+		if entry.TypedValue != nil {
+			values[entry.Key] = entry.TypedValue.Value
+		}
+	}
+	return values
+}
+
+// openApiMetadataToFilterValues returns the values of the OpenAPI metadata of an entity by key, to be matched by
+// searchItemsByFilter
+func openApiMetadataToFilterValues(handler openApiMetadataHandler) (map[string]string, error) {
+	allMetadata, err := handler.getAllMetadata()
+	if err != nil {
+		return nil, err
+	}
+	values := map[string]string{}
+	for _, entry := range allMetadata {
+		value, err := openApiMetadataValueToString(entry)
+		if err != nil {
+			return nil, err
+		}
+		values[entry.KeyValue.Key] = value
+	}
+	return values, nil
+}
@@ -0,0 +1,134 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestSearchItemsByFilter(t *testing.T) {
+	metadataByName := map[string]map[string]string{
+		"web-01": {"environment": "production", "owner": "team-a"},
+		"web-02": {"environment": "staging", "owner": "team-a"},
+		"db-01":  {"environment": "production"},
+	}
+	newItems := func() []*providerQueryItem {
+		return []*providerQueryItem{
+			{name: "web-01", ip: "10.10.10.1"},
+			{name: "web-02", ip: "10.10.20.1"},
+			{name: "db-01", ip: "10.10.30.1"},
+		}
+	}
+
+	tests := []struct {
+		name             string
+		filters          map[string]string
+		metadata         []govcd.MetadataDef
+		want             []string
+		wantMetadataRead int
+		wantErr          bool
+	}{
+		{
+			name:    "name regex",
+			filters: map[string]string{types.FilterNameRegex: "^web"},
+			want:    []string{"web-01", "web-02"},
+		},
+		{
+			name:    "name regex and IP",
+			filters: map[string]string{types.FilterNameRegex: "^web", types.FilterIp: `10\.10\.20\.`},
+			want:    []string{"web-02"},
+		},
+		{
+			name:             "metadata is only read for the items that match the other conditions",
+			filters:          map[string]string{types.FilterNameRegex: "-01$"},
+			metadata:         []govcd.MetadataDef{{Key: "environment", Value: "^prod"}},
+			want:             []string{"web-01", "db-01"},
+			wantMetadataRead: 2,
+		},
+		{
+			name:             "all metadata conditions must match",
+			metadata:         []govcd.MetadataDef{{Key: "environment", Value: "production"}, {Key: "owner", Value: "team"}},
+			want:             []string{"web-01"},
+			wantMetadataRead: 3,
+		},
+		{
+			name:    "unused boolean filters",
+			filters: map[string]string{types.FilterLatest: "false", types.FilterNameRegex: "db"},
+			want:    []string{"db-01"},
+		},
+		{
+			name:    "dates are not supported",
+			filters: map[string]string{types.FilterDate: "> 2024-01-01"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid regular expression",
+			filters: map[string]string{types.FilterNameRegex: "web("},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metadataRead := 0
+			criteria := &govcd.FilterDef{Filters: tt.filters, Metadata: tt.metadata}
+			found, _, err := searchItemsByFilter(newItems(), criteria, func(item *providerQueryItem) (map[string]string, error) {
+				metadataRead++
+				return metadataByName[item.name], nil
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("searchItemsByFilter() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var names []string
+			for _, item := range found {
+				names = append(names, item.GetName())
+			}
+			if fmt.Sprint(names) != fmt.Sprint(tt.want) {
+				t.Errorf("searchItemsByFilter() = %v, want %v", names, tt.want)
+			}
+			if metadataRead != tt.wantMetadataRead {
+				t.Errorf("metadata was read %d times, expected %d", metadataRead, tt.wantMetadataRead)
+			}
+		})
+	}
+}
+
+func TestMockVcdDataSourcesByFilter(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	filterBy := func(elements map[string]interface{}) []interface{} {
+		return []interface{}{elements}
+	}
+
+	vapp := readMockDataSource(t, vcdClient, "vcloud_vapp", map[string]interface{}{
+		"filter": filterBy(map[string]interface{}{"name_regex": "^tf_v"}),
+	})
+	if vapp.Get("name").(string) != "tf_vapp" {
+		t.Errorf("expected vApp tf_vapp, got %s", vapp.Get("name"))
+	}
+
+	vm := readMockDataSource(t, vcdClient, "vcloud_vapp_vm", map[string]interface{}{
+		"vapp_name": "tf_vapp",
+		"filter":    filterBy(map[string]interface{}{"name_regex": "vm2$"}),
+	})
+	if vm.Get("name").(string) != "tf_vm2" {
+		t.Errorf("expected VM tf_vm2, got %s", vm.Get("name"))
+	}
+
+	vdc := readMockDataSource(t, vcdClient, "vcloud_org_vdc", map[string]interface{}{
+		"filter": filterBy(map[string]interface{}{"name_regex": "^tf_"}),
+	})
+	if vdc.Get("name").(string) != "tf_vdc" {
+		t.Errorf("expected VDC tf_vdc, got %s", vdc.Get("name"))
+	}
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{
+		"filter": filterBy(map[string]interface{}{"name_regex": "edge$"}),
+	})
+	if edge.Get("name").(string) != "tf_edge" {
+		t.Errorf("expected Edge Gateway tf_edge, got %s", edge.Get("name"))
+	}
+}
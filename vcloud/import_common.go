@@ -0,0 +1,315 @@
+package vcloud
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// The functions in this file help the importers that receive the ID of an entity (URN, HREF or bare UUID) instead
+// of its name path, to find the names of the Org, VDC and other parents that the resources need in their state
+
+// queryImportRecord retrieves the query record of the entity with the given ID. The HREF used in the query is built
+// from the API path of the entity (such as "/vApp/vapp-") followed by the UUID of the ID
+func queryImportRecord(vcdClient *VCDClient, queryType, hrefPath, id string) (map[string]string, error) {
+	if vcdClient.Client.IsSysAdmin && types.AdminQueryTypes[queryType] != "" {
+		queryType = types.AdminQueryTypes[queryType]
+	}
+	href := vcdClient.Client.VCDHREF.String() + hrefPath + extractUuid(id)
+	records, _, err := queryRecords(&vcdClient.Client,
+		map[string]string{"type": queryType, "filterEncoded": "true"},
+		map[string]string{"filter": "href==" + url.QueryEscape(href)}, 1, 2)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %s '%s': %s", queryType, id, err)
+	}
+	if len(records) != 1 {
+		return nil, fmt.Errorf("error retrieving %s '%s': %s", queryType, id, govcd.ErrorEntityNotFound)
+	}
+	return records[0], nil
+}
+
+// importVdcNames returns the names of the Org and of the VDC with the given ID or HREF
+func importVdcNames(vcdClient *VCDClient, vdcId string) (string, string, error) {
+	record, err := queryImportRecord(vcdClient, types.QtOrgVdc, "/vdc/", vdcId)
+	if err != nil {
+		return "", "", err
+	}
+	return record["orgName"], record["name"], nil
+}
+
+// importOwnerNames returns the names of the Org and of the VDC or VDC Group that owns an OpenAPI entity
+func importOwnerNames(vcdClient *VCDClient, owner *types.OpenApiReference) (string, string, error) {
+	if owner == nil || owner.ID == "" {
+		return "", "", fmt.Errorf("the entity has no owner reference")
+	}
+	if !govcd.OwnerIsVdcGroup(owner.ID) {
+		return importVdcNames(vcdClient, owner.ID)
+	}
+
+	var vdcGroup types.VdcGroup
+	err := getOpenApiImportEntity(vcdClient, types.OpenApiEndpointVdcGroups, owner.ID, &vdcGroup)
+	if err != nil {
+		return "", "", err
+	}
+	org, err := vcdClient.GetOrgById(vdcGroup.OrgId)
+	if err != nil {
+		return "", "", fmt.Errorf("error retrieving Org of VDC Group '%s': %s", vdcGroup.Name, err)
+	}
+	return org.Org.Name, vdcGroup.Name, nil
+}
+
+// getOpenApiImportEntity retrieves an OpenAPI entity by ID without knowing its Org, as the importers need to do
+// before the Org is known
+func getOpenApiImportEntity(vcdClient *VCDClient, endpoint, id string, entity interface{}) error {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint + id)
+	if err != nil {
+		return err
+	}
+	err = vcdClient.Client.OpenApiGetItem(vcdClient.Client.APIVersion, urlRef, nil, entity, nil)
+	if err != nil {
+		return fmt.Errorf("error retrieving '%s': %s", id, err)
+	}
+	return nil
+}
+
+// importNsxtEdgeGatewayNames returns the names of the Org, of the VDC or VDC Group, and of the NSX-T Edge Gateway
+// with the given ID
+func importNsxtEdgeGatewayNames(vcdClient *VCDClient, edgeId string) (string, string, string, error) {
+	var edge types.OpenAPIEdgeGateway
+	err := getOpenApiImportEntity(vcdClient, types.OpenApiEndpointEdgeGateways, edgeId, &edge)
+	if err != nil {
+		return "", "", "", err
+	}
+	owner := edge.OwnerRef
+	if owner == nil {
+		owner = edge.OrgVdc
+	}
+	if edge.Org != nil && owner != nil && edge.Org.Name != "" {
+		return edge.Org.Name, owner.Name, edge.Name, nil
+	}
+	orgName, ownerName, err := importOwnerNames(vcdClient, owner)
+	if err != nil {
+		return "", "", "", err
+	}
+	return orgName, ownerName, edge.Name, nil
+}
+
+// importOrgVdcNetworkNames returns the names of the Org, of the VDC or VDC Group, and of the Org VDC network with
+// the given ID
+func importOrgVdcNetworkNames(vcdClient *VCDClient, networkId string) (string, string, string, error) {
+	var network types.OpenApiOrgVdcNetwork
+	err := getOpenApiImportEntity(vcdClient, types.OpenApiEndpointOrgVdcNetworks, networkId, &network)
+	if err != nil {
+		return "", "", "", err
+	}
+	orgName, ownerName, err := importOwnerNames(vcdClient, network.OwnerRef)
+	if err != nil {
+		return "", "", "", err
+	}
+	return orgName, ownerName, network.Name, nil
+}
+
+// importEdgeGatewayNames returns the names of the Org, of the VDC, and of the NSX-V Edge Gateway with the given ID
+func importEdgeGatewayNames(vcdClient *VCDClient, edgeId string) (string, string, string, error) {
+	record, err := queryImportRecord(vcdClient, types.QtEdgeGateway, "/admin/edgeGateway/", edgeId)
+	if err != nil {
+		return "", "", "", err
+	}
+	orgName, vdcName, err := importVdcNames(vcdClient, record["vdc"])
+	if err != nil {
+		return "", "", "", err
+	}
+	return orgName, vdcName, record["name"], nil
+}
+
+// importCatalogNames returns the names of the Org and of the Catalog with the given ID
+func importCatalogNames(vcdClient *VCDClient, catalogId string) (string, string, error) {
+	record, err := queryImportRecord(vcdClient, types.QtCatalog, "/catalog/", catalogId)
+	if err != nil {
+		return "", "", err
+	}
+	return record["orgName"], record["name"], nil
+}
+
+// importVAppNames returns the names of the Org, of the VDC and of the vApp with the given ID
+func importVAppNames(vcdClient *VCDClient, vappId string) (string, string, string, error) {
+	record, err := queryImportRecord(vcdClient, types.QtVapp, "/vApp/vapp-", vappId)
+	if err != nil {
+		return "", "", "", err
+	}
+	orgName, vdcName, err := importVdcNames(vcdClient, record["vdc"])
+	if err != nil {
+		return "", "", "", err
+	}
+	return orgName, vdcName, record["name"], nil
+}
+
+// importVmNames returns the names of the Org, of the VDC, of the vApp and of the VM with the given ID
+func importVmNames(vcdClient *VCDClient, vmId string) (string, string, string, string, error) {
+	record, err := queryImportRecord(vcdClient, types.QtVm, "/vApp/vm-", vmId)
+	if err != nil {
+		return "", "", "", "", err
+	}
+	orgName, vdcName, err := importVdcNames(vcdClient, record["vdc"])
+	if err != nil {
+		return "", "", "", "", err
+	}
+	return orgName, vdcName, record["containerName"], record["name"], nil
+}
+
+// importWholeEntityId returns the ID of the imported entity, as importEntityId does, when the import identifier is
+// made only of the ID, and an empty string when it is a name path or an ID followed by other names
+func importWholeEntityId(prefix, identifier string) string {
+	id, names := splitImportEntityId(prefix, identifier)
+	if len(names) > 0 {
+		return ""
+	}
+	return id
+}
+
+// importPath splits the import identifier of an entity into its names. The identifier is a name path starting with
+// the names of the parent of the entity, or starts with the ID of the parent (URN, HREF or bare UUID), which is then
+// replaced by the names that parentNames returns
+func importPath(identifier, prefix string, parentNames func(id string) ([]string, error)) ([]string, error) {
+	parentId, names := splitImportEntityId(prefix, identifier)
+	if parentId == "" {
+		return strings.Split(identifier, ImportSeparator), nil
+	}
+	parent, err := parentNames(parentId)
+	if err != nil {
+		return nil, err
+	}
+	return append(parent, names...), nil
+}
+
+// importOrgPath splits an import path starting with org-name, or with the ID of the Org
+func importOrgPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:org:", func(id string) ([]string, error) {
+		org, err := vcdClient.GetOrgById(id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Org '%s': %s", id, err)
+		}
+		return []string{org.Org.Name}, nil
+	})
+}
+
+// importVdcPath splits an import path starting with org-name.vdc-name, or with the ID of the VDC
+func importVdcPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:vdc:", func(id string) ([]string, error) {
+		orgName, vdcName, err := importVdcNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VDC '%s': %s", id, err)
+		}
+		return []string{orgName, vdcName}, nil
+	})
+}
+
+// importVdcOrVdcGroupPath splits an import path starting with org-name.vdc-or-vdc-group-name, or with the ID of the
+// VDC or of the VDC Group. A bare UUID is the one of a VDC, as VDC Groups are only recognized by their URN
+func importVdcOrVdcGroupPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:vdc:", func(id string) ([]string, error) {
+		orgName, ownerName, err := importOwnerNames(vcdClient, &types.OpenApiReference{ID: id})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VDC or VDC Group '%s': %s", id, err)
+		}
+		return []string{orgName, ownerName}, nil
+	})
+}
+
+// importVdcGroupPath splits an import path starting with org-name.vdc-group-name, or with the ID of the VDC Group
+func importVdcGroupPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:vdcGroup:", func(id string) ([]string, error) {
+		orgName, vdcGroupName, err := importOwnerNames(vcdClient, &types.OpenApiReference{ID: id})
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VDC Group '%s': %s", id, err)
+		}
+		return []string{orgName, vdcGroupName}, nil
+	})
+}
+
+// importCatalogPath splits an import path starting with org-name.catalog-name, or with the ID of the Catalog
+func importCatalogPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:catalog:", func(id string) ([]string, error) {
+		orgName, catalogName, err := importCatalogNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Catalog '%s': %s", id, err)
+		}
+		return []string{orgName, catalogName}, nil
+	})
+}
+
+// importVAppPath splits an import path starting with org-name.vdc-name.vapp-name, or with the ID of the vApp
+func importVAppPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:vapp:", func(id string) ([]string, error) {
+		orgName, vdcName, vappName, err := importVAppNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving vApp '%s': %s", id, err)
+		}
+		return []string{orgName, vdcName, vappName}, nil
+	})
+}
+
+// importVmPath splits an import path starting with org-name.vdc-name.vapp-name.vm-name, or with the ID of the VM
+func importVmPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:vm:", func(id string) ([]string, error) {
+		orgName, vdcName, vappName, vmName, err := importVmNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving VM '%s': %s", id, err)
+		}
+		return []string{orgName, vdcName, vappName, vmName}, nil
+	})
+}
+
+// importEdgeGatewayPath splits an import path starting with org-name.vdc-name.edge-gw-name, or with the ID of the
+// NSX-V Edge Gateway
+func importEdgeGatewayPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:gateway:", func(id string) ([]string, error) {
+		orgName, vdcName, edgeName, err := importEdgeGatewayNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Edge Gateway '%s': %s", id, err)
+		}
+		return []string{orgName, vdcName, edgeName}, nil
+	})
+}
+
+// importNsxtEdgeGatewayPath splits an import path starting with org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name, or
+// with the ID of the NSX-T Edge Gateway
+func importNsxtEdgeGatewayPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:gateway:", func(id string) ([]string, error) {
+		orgName, ownerName, edgeName, err := importNsxtEdgeGatewayNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving NSX-T Edge Gateway '%s': %s", id, err)
+		}
+		return []string{orgName, ownerName, edgeName}, nil
+	})
+}
+
+// importOrgVdcNetworkPath splits an import path starting with org-name.vdc-or-vdc-group-name.network-name, or with
+// the ID of the Org VDC network
+func importOrgVdcNetworkPath(vcdClient *VCDClient, identifier string) ([]string, error) {
+	return importPath(identifier, "urn:vcloud:network:", func(id string) ([]string, error) {
+		orgName, ownerName, networkName, err := importOrgVdcNetworkNames(vcdClient, id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Org VDC network '%s': %s", id, err)
+		}
+		return []string{orgName, ownerName, networkName}, nil
+	})
+}
+
+// nsxtFirewallGroupGetter is implemented by the parents of NSX-T Firewall Groups (NSX-T Edge Gateways and VDC Groups)
+type nsxtFirewallGroupGetter interface {
+	GetNsxtFirewallGroupByName(name string, firewallGroupType string) (*govcd.NsxtFirewallGroup, error)
+	GetNsxtFirewallGroupById(id string) (*govcd.NsxtFirewallGroup, error)
+}
+
+// importNsxtFirewallGroup retrieves the NSX-T Firewall Group of the given type by name, or by ID when the identifier
+// is a URN or a bare UUID
+func importNsxtFirewallGroup(parent nsxtFirewallGroupGetter, identifier, firewallGroupType string) (*govcd.NsxtFirewallGroup, error) {
+	if id := importEntityId("urn:vcloud:firewallGroup:", identifier); id != "" {
+		return parent.GetNsxtFirewallGroupById(id)
+	}
+	return parent.GetNsxtFirewallGroupByName(identifier, firewallGroupType)
+}
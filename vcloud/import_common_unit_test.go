@@ -0,0 +1,109 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// TestMockVcdImportById checks that the importers accept the ID of an entity, as URN or bare UUID, and find its
+// Org and VDC
+func TestMockVcdImportById(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	importById := func(resourceName, id string) *schema.ResourceData {
+		resource := Provider().ResourcesMap[resourceName]
+		d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+		d.SetId(id)
+		imported, err := resource.Importer.StateContext(context.Background(), d, vcdClient)
+		if err != nil {
+			t.Fatalf("error importing %s with ID %s: %s", resourceName, id, err)
+		}
+		return imported[0]
+	}
+
+	vapp := readMockDataSource(t, vcdClient, "vcloud_vapp", map[string]interface{}{"name": "tf_vapp"})
+	for _, resourceName := range []string{"vcloud_vapp", "vcloud_cloned_vapp"} {
+		imported := importById(resourceName, extractUuid(vapp.Id()))
+		if imported.Id() != vapp.Id() || imported.Get("name") != "tf_vapp" ||
+			imported.Get("org") != "tf_org" || imported.Get("vdc") != "tf_vdc" {
+			t.Errorf("unexpected %s import: ID %s, name %s, org %s, vdc %s", resourceName, imported.Id(),
+				imported.Get("name"), imported.Get("org"), imported.Get("vdc"))
+		}
+	}
+
+	vm := readMockDataSource(t, vcdClient, "vcloud_vapp_vm", map[string]interface{}{"vapp_name": "tf_vapp", "name": "tf_vm2"})
+	importedVm := importById("vcloud_vapp_vm", vm.Id())
+	if importedVm.Id() != vm.Id() || importedVm.Get("name") != "tf_vm2" || importedVm.Get("vapp_name") != "tf_vapp" ||
+		importedVm.Get("org") != "tf_org" || importedVm.Get("vdc") != "tf_vdc" {
+		t.Errorf("unexpected VM import: ID %s, name %s, vApp %s, org %s, vdc %s", importedVm.Id(),
+			importedVm.Get("name"), importedVm.Get("vapp_name"), importedVm.Get("org"), importedVm.Get("vdc"))
+	}
+
+	// The last element of a name path can still be an ID, and the name is set from the VM
+	importedVm = importById("vcloud_vapp_vm", "tf_org.tf_vdc.tf_vapp."+vm.Id())
+	if importedVm.Get("name") != "tf_vm2" {
+		t.Errorf("expected VM name tf_vm2 after import by path with ID, got %s", importedVm.Get("name"))
+	}
+
+	vdc := readMockDataSource(t, vcdClient, "vcloud_org_vdc", map[string]interface{}{"name": "tf_vdc"})
+	importedVdc := importById("vcloud_org_vdc", vdc.Id())
+	if importedVdc.Get("name") != "tf_vdc" || importedVdc.Get("org") != "tf_org" {
+		t.Errorf("unexpected VDC import: name %s, org %s", importedVdc.Get("name"), importedVdc.Get("org"))
+	}
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	importedEdge := importById("vcloud_nsxt_edgegateway", extractUuid(edge.Id()))
+	if importedEdge.Id() != edge.Id() || importedEdge.Get("org") != "tf_org" {
+		t.Errorf("unexpected Edge Gateway import: ID %s, org %s", importedEdge.Id(), importedEdge.Get("org"))
+	}
+
+	importedConfig := importById("vcloud_nsxt_edgegateway_config", edge.Id())
+	if importedConfig.Id() != edge.Id() || importedConfig.Get("edge_gateway_id") != edge.Id() {
+		t.Errorf("unexpected Edge Gateway config import: ID %s, edge_gateway_id %s", importedConfig.Id(),
+			importedConfig.Get("edge_gateway_id"))
+	}
+
+	importedRule := importById("vcloud_nsxt_firewall_rule", edge.Id()+ImportSeparator+"allow-outbound")
+	if importedRule.Id() == "" || importedRule.Get("edge_gateway_id") != edge.Id() {
+		t.Errorf("unexpected firewall rule import: ID %s, edge_gateway_id %s", importedRule.Id(),
+			importedRule.Get("edge_gateway_id"))
+	}
+	importedRule = importById("vcloud_nsxt_firewall_rule", strings.Join([]string{"tf_org", "tf_vdc", "tf_edge", importedRule.Id()}, ImportSeparator))
+	if importedRule.Get("edge_gateway_id") != edge.Id() {
+		t.Errorf("unexpected firewall rule import by rule ID: edge_gateway_id %s", importedRule.Get("edge_gateway_id"))
+	}
+
+	// The resources that belong to a parent accept the ID of the parent in place of its names
+	importedFirewall := importById("vcloud_nsxt_firewall", extractUuid(edge.Id()))
+	if importedFirewall.Id() != edge.Id() || importedFirewall.Get("org") != "tf_org" ||
+		importedFirewall.Get("edge_gateway_id") != edge.Id() {
+		t.Errorf("unexpected NSX-T firewall import: ID %s, org %s, edge_gateway_id %s", importedFirewall.Id(),
+			importedFirewall.Get("org"), importedFirewall.Get("edge_gateway_id"))
+	}
+
+	importedVappAcl := importById("vcloud_vapp_access_control", vapp.Id())
+	if importedVappAcl.Id() != vapp.Id() || importedVappAcl.Get("vapp_id") != vapp.Id() ||
+		importedVappAcl.Get("org") != "tf_org" || importedVappAcl.Get("vdc") != "tf_vdc" {
+		t.Errorf("unexpected vApp access control import: ID %s, vapp_id %s, org %s, vdc %s", importedVappAcl.Id(),
+			importedVappAcl.Get("vapp_id"), importedVappAcl.Get("org"), importedVappAcl.Get("vdc"))
+	}
+
+	importedVdcAcl := importById("vcloud_org_vdc_access_control", extractUuid(vdc.Id()))
+	if importedVdcAcl.Get("org") != "tf_org" || importedVdcAcl.Get("vdc") != "tf_vdc" {
+		t.Errorf("unexpected VDC access control import: org %s, vdc %s", importedVdcAcl.Get("org"),
+			importedVdcAcl.Get("vdc"))
+	}
+
+	catalog := readMockDataSource(t, vcdClient, "vcloud_catalog", map[string]interface{}{"name": "tf_catalog"})
+	importedCatalogAcl := importById("vcloud_catalog_access_control", catalog.Id())
+	if importedCatalogAcl.Id() != catalog.Id() || importedCatalogAcl.Get("catalog_id") != catalog.Id() ||
+		importedCatalogAcl.Get("org") != "tf_org" {
+		t.Errorf("unexpected Catalog access control import: ID %s, catalog_id %s, org %s", importedCatalogAcl.Id(),
+			importedCatalogAcl.Get("catalog_id"), importedCatalogAcl.Get("org"))
+	}
+}
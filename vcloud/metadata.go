@@ -1,8 +1,10 @@
 package vcloud
 
 import (
+	"context"
 	"fmt"
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/hashicorp/go-cty/cty"
@@ -113,6 +115,119 @@ func getIgnoredMetadata(d *schema.ResourceData, ignoredMetadataAttribute string)
 	return result, nil
 }
 
+// defaultMetadataSchema returns the schema associated to default_metadata for the provider configuration.
+func defaultMetadataSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Description: "Defines a set of `metadata_entry` that are added to every resource that supports it. " +
+			"A `metadata_entry` with the same key in a resource overrides the default one",
+		Elem: metadataEntryResourceSchema("").Elem,
+	}
+}
+
+// getDefaultMetadata transforms the default metadata from the provider configuration to the structure that
+// is merged into the metadata of the resources.
+func getDefaultMetadata(d *schema.ResourceData, defaultMetadataAttribute string) (map[string]types.MetadataValue, error) {
+	defaultMetadata, err := convertFromStateToMetadataValues(d.Get(defaultMetadataAttribute).(*schema.Set).List())
+	if err != nil {
+		return nil, err
+	}
+	for key := range defaultMetadata {
+		if autogeneratedMetadataKeys[key] {
+			return nil, fmt.Errorf("the key '%s' is reserved for the metadata inherited by VCD and can't be used in '%s'", key, defaultMetadataAttribute)
+		}
+	}
+	return defaultMetadata, nil
+}
+
+// defaultMetadataString returns a representation of the default metadata that is stable across runs, to
+// identify the provider configuration in the connection cache
+func defaultMetadataString(defaultMetadata map[string]types.MetadataValue) string {
+	entries := make([]string, 0, len(defaultMetadata))
+	for key, value := range defaultMetadata {
+		entries = append(entries, fmt.Sprintf("%s=%s:%s:%s:%s", key, value.TypedValue.XsiType, value.TypedValue.Value, value.Domain.Domain, value.Domain.Visibility))
+	}
+	sort.Strings(entries)
+	return strings.Join(entries, ",")
+}
+
+// setDefaultMetadataInDiff plans the `metadata_entry` of a resource as the `default_metadata` of the provider
+// merged with the entries in the resource configuration, which take precedence when they have the same key.
+// Default entries that are filtered out by `ignore_metadata_changes` for this resource are not added, as they could
+// never be read back.
+// Resources with a deprecated metadata attribute are left untouched when there is no default metadata or when
+// that attribute is used, to keep their computed behaviour.
+func setDefaultMetadataInDiff(d *schema.ResourceDiff, meta interface{}, resourceType, deprecatedMetadataAttribute string) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+	vcdClient := meta.(*VCDClient)
+	if deprecatedMetadataAttribute != "" {
+		if len(vcdClient.DefaultMetadata) == 0 || !rawConfig.GetAttr(deprecatedMetadataAttribute).IsNull() {
+			return nil
+		}
+	}
+	// The name is needed to know which default entries are ignored
+	if !rawConfig.GetAttr("metadata_entry").IsWhollyKnown() || (len(vcdClient.DefaultMetadata) > 0 && !d.NewValueKnown("name")) {
+		return d.SetNewComputed("metadata_entry")
+	}
+
+	merged := map[string]map[string]interface{}{}
+	for key, value := range vcdClient.DefaultMetadata {
+		entry := map[string]interface{}{
+			"key":         key,
+			"value":       value.TypedValue.Value,
+			"type":        value.TypedValue.XsiType,
+			"user_access": value.Domain.Visibility,
+			"is_system":   value.Domain.Domain == "SYSTEM",
+		}
+		if isIgnoredMetadataEntry(vcdClient, resourceType, d.Get("name").(string), entry) {
+			util.Logger.Printf("[DEBUG] default metadata entry with key '%s' is not added to %s as it is ignored by 'ignore_metadata_changes'", key, resourceType)
+			continue
+		}
+		merged[key] = entry
+	}
+	for _, block := range getRawConfigBlocks(rawConfig, "metadata_entry") {
+		key := ctyStringOrDefault(block.GetAttr("key"), "")
+		if key == "" {
+			// An empty `metadata_entry {}` is the way to remove all the metadata
+			continue
+		}
+		isSystem := block.GetAttr("is_system")
+		merged[key] = map[string]interface{}{
+			"key":         key,
+			"value":       ctyStringOrDefault(block.GetAttr("value"), ""),
+			"type":        ctyStringOrDefault(block.GetAttr("type"), types.MetadataStringValue),
+			"user_access": ctyStringOrDefault(block.GetAttr("user_access"), types.MetadataReadWriteVisibility),
+			"is_system":   !isSystem.IsNull() && isSystem.True(),
+		}
+	}
+
+	metadataEntries := make([]interface{}, 0, len(merged))
+	for _, entry := range merged {
+		metadataEntries = append(metadataEntries, entry)
+	}
+	return d.SetNew("metadata_entry", metadataEntries)
+}
+
+// defaultMetadataCustomizeDiff returns a CustomizeDiff function that merges the provider `default_metadata` in the
+// `metadata_entry` of the given resource type. See setDefaultMetadataInDiff
+func defaultMetadataCustomizeDiff(resourceType, deprecatedMetadataAttribute string) schema.CustomizeDiffFunc {
+	return func(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+		return setDefaultMetadataInDiff(d, meta, resourceType, deprecatedMetadataAttribute)
+	}
+}
+
+// ctyStringOrDefault returns the given string value, or the default when it is null
+func ctyStringOrDefault(value cty.Value, defaultValue string) string {
+	if value.IsNull() {
+		return defaultValue
+	}
+	return value.AsString()
+}
+
 // This map is used by getIgnoredMetadata and the Schema validation. It links a Terraform
 // resource type (how the resource was named) with a Metadata API endpoint object present in
 // https://developer.vmware.com/apis/1601/vmware-cloud-director
@@ -135,6 +250,12 @@ var resourceMetadataApiRelation = map[string]string{
 	"vcd_vapp_vm":               "vApp",
 	"vcd_vm":                    "vApp",
 	"vcd_rde":                   "entity",
+	// Entities with OpenAPI metadata handled by openApiEntityMetadata
+	"vcd_nsxt_edgegateway":         "edgeGateway",
+	"vcd_vdc_group":                "vdcGroup",
+	"vcd_ip_space":                 "ipSpace",
+	"vcd_vm_sizing_policy":         "vdcComputePolicy",
+	"vcd_nsxt_alb_virtual_service": "virtualService",
 }
 
 // metadataEntryDatasourceSchema returns the schema associated to metadata_entry for a given data source.
@@ -183,6 +304,7 @@ func metadataEntryResourceSchema(resourceType string) *schema.Schema {
 	return &schema.Schema{
 		Type:        schema.TypeSet,
 		Optional:    true,
+		Computed:    true, // The planned value is calculated by setDefaultMetadataInDiff, to merge the provider default_metadata
 		Description: fmt.Sprintf("Metadata entries for the given %s", resourceType),
 		Elem: &schema.Resource{
 			Schema: map[string]*schema.Schema{
@@ -324,7 +446,13 @@ func createOrUpdateMetadataEntryInVcd(d *schema.ResourceData, resource metadataC
 // checkIgnoredMetadataConflicts checks that no `metadata_entry` managed by Terraform is ignored due to being filtered out
 // in any `ignore_metadata_changes` block and errors/warns if so, depending on the value of `conflict_action`.
 func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient, resourceType string) diag.Diagnostics {
-	metadataEntryList := d.Get("metadata_entry").(*schema.Set).List()
+	return checkIgnoredMetadataConflictsInAttribute(d, vcdClient, resourceType, "metadata_entry")
+}
+
+// checkIgnoredMetadataConflictsInAttribute works like checkIgnoredMetadataConflicts, for the metadata entries stored
+// in the given attribute.
+func checkIgnoredMetadataConflictsInAttribute(d *schema.ResourceData, vcdClient *VCDClient, resourceType, attribute string) diag.Diagnostics {
+	metadataEntryList := d.Get(attribute).(*schema.Set).List()
 	if len(metadataEntryList) == 0 {
 		return nil
 	}
@@ -332,12 +460,7 @@ func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient,
 	for _, newEntryRaw := range metadataEntryList {
 		newEntry := newEntryRaw.(map[string]interface{})
 		for _, ignoredMetadata := range vcdClient.Client.IgnoredMetadata {
-			conflictDetected := (ignoredMetadata.ObjectType == nil || strings.TrimSpace(*ignoredMetadata.ObjectType) == "" || *ignoredMetadata.ObjectType == resourceMetadataApiRelation[resourceType]) &&
-				(ignoredMetadata.ObjectName == nil || strings.TrimSpace(*ignoredMetadata.ObjectName) == "" || strings.TrimSpace(d.Get("name").(string)) == "" || *ignoredMetadata.ObjectName == d.Get("name").(string)) &&
-				(ignoredMetadata.KeyRegex == nil || ignoredMetadata.KeyRegex.MatchString(newEntry["key"].(string))) &&
-				(ignoredMetadata.ValueRegex == nil || ignoredMetadata.ValueRegex.MatchString(newEntry["value"].(string)))
-
-			if !conflictDetected {
+			if !ignoredMetadataMatches(ignoredMetadata, resourceType, d.Get("name").(string), newEntry) {
 				continue
 			}
 
@@ -371,6 +494,26 @@ func checkIgnoredMetadataConflicts(d *schema.ResourceData, vcdClient *VCDClient,
 	return nil
 }
 
+// ignoredMetadataMatches returns true if the given metadata entry of the resource with the given type and name
+// is filtered out by the ignored metadata block
+func ignoredMetadataMatches(ignoredMetadata govcd.IgnoredMetadata, resourceType, resourceName string, entry map[string]interface{}) bool {
+	return (ignoredMetadata.ObjectType == nil || strings.TrimSpace(*ignoredMetadata.ObjectType) == "" || *ignoredMetadata.ObjectType == resourceMetadataApiRelation[resourceType]) &&
+		(ignoredMetadata.ObjectName == nil || strings.TrimSpace(*ignoredMetadata.ObjectName) == "" || strings.TrimSpace(resourceName) == "" || *ignoredMetadata.ObjectName == resourceName) &&
+		(ignoredMetadata.KeyRegex == nil || ignoredMetadata.KeyRegex.MatchString(entry["key"].(string))) &&
+		(ignoredMetadata.ValueRegex == nil || ignoredMetadata.ValueRegex.MatchString(entry["value"].(string)))
+}
+
+// isIgnoredMetadataEntry returns true if the given metadata entry is filtered out by any of the
+// `ignore_metadata_changes` blocks of the provider
+func isIgnoredMetadataEntry(vcdClient *VCDClient, resourceType, resourceName string, entry map[string]interface{}) bool {
+	for _, ignoredMetadata := range vcdClient.Client.IgnoredMetadata {
+		if ignoredMetadataMatches(ignoredMetadata, resourceType, resourceName, entry) {
+			return true
+		}
+	}
+	return false
+}
+
 // updateMetadataInStateDeprecated updates deprecated metadata and the new metadata_entry in the Terraform state for the given receiver object.
 // This can be done as both are Computed, for compatibility reasons.
 // TODO: Remove this function once "metadata" attribute is deleted in a future major release.
@@ -453,6 +596,13 @@ func updateMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourc
 	return nil
 }
 
+// autogeneratedMetadataKeys are the keys of the metadata entries that VCD creates automatically in vApps, VMs
+// and vApp Templates (inherited metadata)
+var autogeneratedMetadataKeys = map[string]bool{
+	"vm.origin.id": true, "vm.origin.name": true, "vm.origin.type": true,
+	"vapp.origin.id": true, "vapp.origin.name": true, "vapp.origin.type": true,
+}
+
 // filterAndGetVcdInheritedMetadata filters out the metadata entries that were created automatically by VCD (inherited metadata)
 // from the input metadata parameter, then returns these entries as a structure that is ready to be set in the
 // Terraform schema.
@@ -462,11 +612,6 @@ func filterAndGetVcdInheritedMetadata(metadata *types.Metadata) StringMap {
 		return nil
 	}
 
-	autogeneratedMetadataKeys := map[string]bool{
-		"vm.origin.id": true, "vm.origin.name": true, "vm.origin.type": true,
-		"vapp.origin.id": true, "vapp.origin.name": true, "vapp.origin.type": true,
-	}
-
 	var filteredMetadata []*types.MetadataEntry
 	var inheritedMetadataBlock = StringMap{}
 	for _, metadataEntry := range metadata.MetadataEntry {
@@ -8,8 +8,10 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
+	"net/url"
 	"reflect"
 	"strconv"
+	"strings"
 )
 
 // openApiMetadataEntryDatasourceSchema returns the schema associated to the OpenAPI metadata_entry for a given data source.
@@ -134,43 +136,273 @@ type openApiMetadataCompatible interface {
 	AddMetadata(metadataEntry types.OpenApiMetadataEntry) (*govcd.OpenApiMetadataEntry, error)
 }
 
+// openApiMetadataHandler contains the operations needed to manage the OpenAPI metadata of an object, either with the
+// methods provided by the SDK (see govcdOpenApiMetadata) or with direct calls to the OpenAPI metadata endpoints of the
+// object (see openApiEntityMetadata)
+type openApiMetadataHandler interface {
+	getAllMetadata() ([]*types.OpenApiMetadataEntry, error)
+	addMetadata(metadataEntry types.OpenApiMetadataEntry) error
+	updateMetadata(metadataEntry types.OpenApiMetadataEntry) error
+	deleteMetadata(metadataEntry types.OpenApiMetadataEntry) error
+}
+
+// govcdOpenApiMetadata is an openApiMetadataHandler for the objects that implement OpenAPI metadata in the SDK,
+// like Runtime Defined Entities
+type govcdOpenApiMetadata struct {
+	object openApiMetadataCompatible
+}
+
+func (m govcdOpenApiMetadata) getAllMetadata() ([]*types.OpenApiMetadataEntry, error) {
+	allMetadata, err := m.object.GetMetadata()
+	if err != nil {
+		return nil, err
+	}
+	result := make([]*types.OpenApiMetadataEntry, len(allMetadata))
+	for i, entry := range allMetadata {
+		result[i] = entry.MetadataEntry
+	}
+	return result, nil
+}
+
+func (m govcdOpenApiMetadata) addMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	_, err := m.object.AddMetadata(metadataEntry)
+	return err
+}
+
+func (m govcdOpenApiMetadata) updateMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	toUpdate, err := m.object.GetMetadataByKey(metadataEntry.KeyValue.Domain, metadataEntry.KeyValue.Namespace, metadataEntry.KeyValue.Key) // Refreshes ETags
+	if err != nil {
+		return err
+	}
+	return toUpdate.Update(metadataEntry.KeyValue.Value.Value, metadataEntry.IsPersistent)
+}
+
+func (m govcdOpenApiMetadata) deleteMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	toDelete, err := m.object.GetMetadataByKey(metadataEntry.KeyValue.Domain, metadataEntry.KeyValue.Namespace, metadataEntry.KeyValue.Key) // Refreshes ETags
+	if err != nil {
+		return err
+	}
+	return toDelete.Delete()
+}
+
+// openApiEntityMetadata is an openApiMetadataHandler for the OpenAPI entities which metadata is not handled by the
+// SDK. It uses the metadata endpoints of the entity, which are '<entity endpoint>/<entity ID>/metadata', as the ones
+// of Runtime Defined Entities
+type openApiEntityMetadata struct {
+	client *VCDClient
+	// endpoint is the versioned OpenAPI endpoint of the entity, such as types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGateways
+	endpoint string
+	// id, name and objectType identify the entity, and are used to evaluate the 'ignore_metadata_changes' provider blocks
+	id         string
+	name       string
+	objectType string
+}
+
+// newOpenApiEntityMetadata returns an openApiEntityMetadata for the entity with the given ID and name. The resource type
+// is one of the keys of resourceMetadataApiRelation
+func newOpenApiEntityMetadata(vcdClient *VCDClient, endpoint, resourceType, id, name string) *openApiEntityMetadata {
+	return &openApiEntityMetadata{
+		client:     vcdClient,
+		endpoint:   endpoint,
+		id:         id,
+		name:       name,
+		objectType: resourceMetadataApiRelation[resourceType],
+	}
+}
+
+// metadataUrl returns the URL of the metadata collection of the entity or, if given, of a single entry
+func (m *openApiEntityMetadata) metadataUrl(entryId string) (*url.URL, error) {
+	path := fmt.Sprintf("%s/metadata", m.id)
+	if entryId != "" {
+		path = fmt.Sprintf("%s/%s", path, entryId)
+	}
+	return m.client.Client.OpenApiBuildEndpoint(m.endpoint, path)
+}
+
+// isSupported returns an error if the VCD does not support OpenAPI metadata in entities other than Runtime Defined Entities
+func (m *openApiEntityMetadata) isSupported() error {
+	if m.client.Client.APIVCDMaxVersionIs("< 38.0") {
+		return fmt.Errorf("OpenAPI metadata of %s requires VCD 10.5.0+ (API 38.0+)", m.objectType)
+	}
+	return nil
+}
+
+// apiVersion returns the API version of the metadata requests, as the OpenAPI metadata of these entities was added
+// in 38.0
+func (m *openApiEntityMetadata) apiVersion() string {
+	return m.client.Client.GetSpecificApiVersionOnCondition(">=38.0", "38.0")
+}
+
+func (m *openApiEntityMetadata) getAllMetadata() ([]*types.OpenApiMetadataEntry, error) {
+	// Reading must work in older versions, where these entities simply don't have OpenAPI metadata
+	if m.isSupported() != nil {
+		return nil, nil
+	}
+	return m.getMetadata(nil)
+}
+
+// getMetadata retrieves the metadata entries of the entity that are not ignored by the provider configuration
+func (m *openApiEntityMetadata) getMetadata(queryParameters url.Values) ([]*types.OpenApiMetadataEntry, error) {
+	urlRef, err := m.metadataUrl("")
+	if err != nil {
+		return nil, err
+	}
+
+	var allMetadata []*types.OpenApiMetadataEntry
+	err = m.client.Client.OpenApiGetAllItems(m.apiVersion(), urlRef, queryParameters, &allMetadata, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving metadata of %s '%s': %s", m.objectType, m.id, err)
+	}
+
+	var result []*types.OpenApiMetadataEntry
+	for _, entry := range allMetadata {
+		if isIgnoredOpenApiMetadata(m.client.Client.IgnoredMetadata, m.objectType, m.name, entry) {
+			util.Logger.Printf("[DEBUG] the metadata entry with key '%s' of %s '%s' is being ignored", entry.KeyValue.Key, m.objectType, m.id)
+			continue
+		}
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// getMetadataEntryWithEtag retrieves the metadata entry with the domain, namespace and key of the given one, with the
+// ETag needed to update it
+func (m *openApiEntityMetadata) getMetadataEntryWithEtag(metadataEntry types.OpenApiMetadataEntry) (*types.OpenApiMetadataEntry, string, error) {
+	queryParameters := url.Values{}
+	// The OpenAPI metadata endpoints only support filtering by key
+	queryParameters.Add("filter", fmt.Sprintf("keyValue.key==%s", metadataEntry.KeyValue.Key))
+	candidates, err := m.getMetadata(queryParameters)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var found *types.OpenApiMetadataEntry
+	for _, candidate := range candidates {
+		if candidate.KeyValue.Key == metadataEntry.KeyValue.Key && candidate.KeyValue.Namespace == metadataEntry.KeyValue.Namespace &&
+			candidate.KeyValue.Domain == metadataEntry.KeyValue.Domain {
+			found = candidate
+			break
+		}
+	}
+	if found == nil {
+		return nil, "", fmt.Errorf("%s: metadata entry with namespace '%s' and key '%s' of %s '%s'", govcd.ErrorEntityNotFound,
+			metadataEntry.KeyValue.Namespace, metadataEntry.KeyValue.Key, m.objectType, m.id)
+	}
+
+	urlRef, err := m.metadataUrl(found.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	result := &types.OpenApiMetadataEntry{}
+	headers, err := m.client.Client.OpenApiGetItemAndHeaders(m.apiVersion(), urlRef, nil, result, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, headers.Get("Etag"), nil
+}
+
+func (m *openApiEntityMetadata) addMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	if err := m.isSupported(); err != nil {
+		return err
+	}
+	urlRef, err := m.metadataUrl("")
+	if err != nil {
+		return err
+	}
+	_, err = m.client.Client.OpenApiPostItemAndGetHeaders(m.apiVersion(), urlRef, nil, metadataEntry, &types.OpenApiMetadataEntry{}, nil)
+	return err
+}
+
+func (m *openApiEntityMetadata) updateMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	if err := m.isSupported(); err != nil {
+		return err
+	}
+	existing, etag, err := m.getMetadataEntryWithEtag(metadataEntry)
+	if err != nil {
+		return err
+	}
+	urlRef, err := m.metadataUrl(existing.ID)
+	if err != nil {
+		return err
+	}
+
+	// Only the value and the persistence of an entry can be changed
+	existing.IsPersistent = metadataEntry.IsPersistent
+	existing.KeyValue.Value.Value = metadataEntry.KeyValue.Value.Value
+	_, err = m.client.Client.OpenApiPutItemAndGetHeaders(m.apiVersion(), urlRef, nil, existing, &types.OpenApiMetadataEntry{}, map[string]string{"If-Match": etag})
+	return err
+}
+
+func (m *openApiEntityMetadata) deleteMetadata(metadataEntry types.OpenApiMetadataEntry) error {
+	if err := m.isSupported(); err != nil {
+		return err
+	}
+	existing, _, err := m.getMetadataEntryWithEtag(metadataEntry)
+	if err != nil {
+		return err
+	}
+	urlRef, err := m.metadataUrl(existing.ID)
+	if err != nil {
+		return err
+	}
+	return m.client.Client.OpenApiDeleteItem(m.apiVersion(), urlRef, nil, nil)
+}
+
+// isIgnoredOpenApiMetadata returns true if the given metadata entry of an object matches any of the
+// 'ignore_metadata_changes' blocks of the provider. It follows the same rules that the SDK applies to the
+// metadata of the objects it supports.
+func isIgnoredOpenApiMetadata(ignoredMetadata []govcd.IgnoredMetadata, objectType, objectName string, entry *types.OpenApiMetadataEntry) bool {
+	value := fmt.Sprintf("%v", entry.KeyValue.Value.Value)
+	for _, entryToIgnore := range ignoredMetadata {
+		if entryToIgnore.ObjectType == nil && entryToIgnore.ObjectName == nil && entryToIgnore.KeyRegex == nil && entryToIgnore.ValueRegex == nil {
+			continue
+		}
+		if (entryToIgnore.ObjectType == nil || strings.TrimSpace(*entryToIgnore.ObjectType) == "" || *entryToIgnore.ObjectType == objectType) &&
+			(entryToIgnore.ObjectName == nil || strings.TrimSpace(*entryToIgnore.ObjectName) == "" || strings.TrimSpace(objectName) == "" || *entryToIgnore.ObjectName == objectName) &&
+			(entryToIgnore.KeyRegex == nil || entryToIgnore.KeyRegex.MatchString(entry.KeyValue.Key)) &&
+			(entryToIgnore.ValueRegex == nil || entryToIgnore.ValueRegex.MatchString(value)) {
+			return true
+		}
+	}
+	return false
+}
+
 // createOrUpdateOpenApiMetadataEntryInVcd creates or updates OpenAPI metadata entries in VCD for the given resource, only if the attribute
 // metadata_entry has been set or updated in the state.
-func createOrUpdateOpenApiMetadataEntryInVcd(d *schema.ResourceData, resource openApiMetadataCompatible) error {
-	if !d.HasChange("metadata_entry") {
+func createOrUpdateOpenApiMetadataEntryInVcd(d *schema.ResourceData, resource openApiMetadataHandler) error {
+	return createOrUpdateOpenApiMetadataAttributeInVcd(d, "metadata_entry", resource)
+}
+
+// createOrUpdateOpenApiMetadataAttributeInVcd works like createOrUpdateOpenApiMetadataEntryInVcd, for resources that
+// keep the OpenAPI metadata in an attribute with a different name, because 'metadata_entry' is already used by the
+// legacy metadata.
+func createOrUpdateOpenApiMetadataAttributeInVcd(d *schema.ResourceData, attribute string, resource openApiMetadataHandler) error {
+	if !d.HasChange(attribute) {
 		return nil
 	}
 
-	oldRaw, newRaw := d.GetChange("metadata_entry")
+	oldRaw, newRaw := d.GetChange(attribute)
 	metadataToAdd, metadataToUpdate, metadataToDelete, err := getOpenApiMetadataOperations(oldRaw.(*schema.Set).List(), newRaw.(*schema.Set).List())
 	if err != nil {
 		return fmt.Errorf("could not calculate the needed metadata operations: %s", err)
 	}
 
 	for _, entry := range metadataToDelete {
-		toDelete, err := resource.GetMetadataByKey(entry.KeyValue.Domain, entry.KeyValue.Namespace, entry.KeyValue.Key) // Refreshes ETags
-		if err != nil {
-			return fmt.Errorf("error reading metadata with namespace '%s' and key '%s': %s", entry.KeyValue.Namespace, entry.KeyValue.Key, err)
-		}
-		err = toDelete.Delete()
+		err = resource.deleteMetadata(entry)
 		if err != nil {
 			return fmt.Errorf("error deleting metadata with namespace '%s' and key '%s': %s", entry.KeyValue.Namespace, entry.KeyValue.Key, err)
 		}
 	}
 
 	for _, entry := range metadataToUpdate {
-		toUpdate, err := resource.GetMetadataByKey(entry.KeyValue.Domain, entry.KeyValue.Namespace, entry.KeyValue.Key) // Refreshes ETags
-		if err != nil {
-			return fmt.Errorf("error reading metadata with namespace '%s' and key '%s': %s", entry.KeyValue.Namespace, entry.KeyValue.Key, err)
-		}
-		err = toUpdate.Update(entry.KeyValue.Value.Value, entry.IsPersistent)
+		err = resource.updateMetadata(entry)
 		if err != nil {
 			return fmt.Errorf("error updating metadata with namespace '%s' and key '%s': %s", entry.KeyValue.Namespace, entry.KeyValue.Key, err)
 		}
 	}
 
 	for _, metadataEntry := range metadataToAdd {
-		_, err := resource.AddMetadata(metadataEntry)
+		err = resource.addMetadata(metadataEntry)
 		if err != nil {
 			return fmt.Errorf("error adding metadata entry: %s", err)
 		}
@@ -178,6 +410,97 @@ func createOrUpdateOpenApiMetadataEntryInVcd(d *schema.ResourceData, resource op
 	return nil
 }
 
+// openApiMetadataEntryFilterSchema returns the schema of the block that data sources use to find an object by its
+// OpenAPI metadata. All the blocks must match a metadata entry of the object.
+func openApiMetadataEntryFilterSchema(resourceType string, exactlyOneOf []string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeSet,
+		Optional:     true,
+		ExactlyOneOf: exactlyOneOf,
+		Description:  fmt.Sprintf("Metadata entries that the %s must have. It must match only one %s", resourceType, resourceType),
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"key": {
+					Type:        schema.TypeString,
+					Required:    true,
+					Description: "Key of the metadata entry",
+				},
+				"value": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Value of the metadata entry. Any value matches when it is not set",
+				},
+				"domain": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      "TENANT",
+					Description:  "Domain of the metadata entry. One of: `TENANT`, `PROVIDER`",
+					ValidateFunc: validation.StringInSlice([]string{"TENANT", "PROVIDER"}, false),
+				},
+				"namespace": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Namespace of the metadata entry",
+				},
+			},
+		},
+	}
+}
+
+// getOpenApiEntityByMetadataFilter returns the only candidate which OpenAPI metadata matches all the blocks of the
+// given filter attribute, which is defined with openApiMetadataEntryFilterSchema. The label is used in error messages.
+func getOpenApiEntityByMetadataFilter[T any](d *schema.ResourceData, filterAttribute, label string, candidates []T, metadataOf func(T) openApiMetadataHandler) (T, error) {
+	var empty T
+	filters := d.Get(filterAttribute).(*schema.Set).List()
+
+	var found []T
+	for _, candidate := range candidates {
+		allMetadata, err := metadataOf(candidate).getAllMetadata()
+		if err != nil {
+			return empty, err
+		}
+		if openApiMetadataMatchesFilters(allMetadata, filters) {
+			found = append(found, candidate)
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		return empty, fmt.Errorf("%s: no %s matches the metadata filter", govcd.ErrorEntityNotFound, label)
+	case 1:
+		return found[0], nil
+	default:
+		return empty, fmt.Errorf("%d objects of type %s match the metadata filter, but only one is expected", len(found), label)
+	}
+}
+
+// openApiMetadataMatchesFilters returns true if every filter from openApiMetadataEntryFilterSchema matches one of the
+// given metadata entries
+func openApiMetadataMatchesFilters(allMetadata []*types.OpenApiMetadataEntry, filters []interface{}) bool {
+	for _, rawFilter := range filters {
+		filter := rawFilter.(map[string]interface{})
+		matched := false
+		for _, entry := range allMetadata {
+			if entry.KeyValue.Key != filter["key"].(string) || entry.KeyValue.Domain != filter["domain"].(string) ||
+				entry.KeyValue.Namespace != filter["namespace"].(string) {
+				continue
+			}
+			value, err := openApiMetadataValueToString(entry)
+			if err != nil {
+				continue
+			}
+			if filter["value"].(string) == "" || filter["value"].(string) == value {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	return true
+}
+
 // getOpenApiMetadataOperations retrieves the metadata that needs to be added, to be updated and to be deleted depending
 // on the old and new attribute values from Terraform state.
 func getOpenApiMetadataOperations(oldMetadata []interface{}, newMetadata []interface{}) ([]types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, []types.OpenApiMetadataEntry, error) {
@@ -276,49 +599,62 @@ func getOpenApiMetadataEntryMap(metadataAttribute []interface{}) (map[string]typ
 
 // updateOpenApiMetadataInState updates metadata_entry in the Terraform state for the given receiver object.
 // This can be done as both are Computed, for compatibility reasons.
-func updateOpenApiMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject openApiMetadataCompatible) diag.Diagnostics {
-	diags := checkIgnoredMetadataConflicts(d, vcdClient, resourceType)
+func updateOpenApiMetadataInState(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, receiverObject openApiMetadataHandler) diag.Diagnostics {
+	return updateOpenApiMetadataAttributeInState(d, vcdClient, resourceType, "metadata_entry", receiverObject)
+}
+
+// updateOpenApiMetadataAttributeInState works like updateOpenApiMetadataInState, for resources that keep the OpenAPI
+// metadata in an attribute with a different name, because 'metadata_entry' is already used by the legacy metadata.
+func updateOpenApiMetadataAttributeInState(d *schema.ResourceData, vcdClient *VCDClient, resourceType, attribute string, receiverObject openApiMetadataHandler) diag.Diagnostics {
+	diags := checkIgnoredMetadataConflictsInAttribute(d, vcdClient, resourceType, attribute)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
 
-	allMetadata, err := receiverObject.GetMetadata()
+	allMetadata, err := receiverObject.getAllMetadata()
 	if err != nil {
 		return append(diags, diag.FromErr(err)...)
 	}
 
 	metadata := make([]interface{}, len(allMetadata))
 	for i, metadataEntryFromVcd := range allMetadata {
-		// We need to set the correct type, otherwise saving the state will fail
-		value := ""
-		switch metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Type {
-		case types.OpenApiMetadataBooleanEntry:
-			value = fmt.Sprintf("%t", metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Value.(bool))
-		case types.OpenApiMetadataNumberEntry:
-			value = fmt.Sprintf("%.0f", metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Value.(float64))
-		case types.OpenApiMetadataStringEntry:
-			value = metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Value.(string)
-		default:
-			return append(diags, diag.Errorf("not supported metadata type %s", metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Type)...)
+		value, err := openApiMetadataValueToString(metadataEntryFromVcd)
+		if err != nil {
+			return append(diags, diag.FromErr(err)...)
 		}
 
 		metadataEntry := map[string]interface{}{
-			"id":         metadataEntryFromVcd.MetadataEntry.ID,
-			"key":        metadataEntryFromVcd.MetadataEntry.KeyValue.Key,
-			"readonly":   metadataEntryFromVcd.MetadataEntry.IsReadOnly,
-			"domain":     metadataEntryFromVcd.MetadataEntry.KeyValue.Domain,
-			"namespace":  metadataEntryFromVcd.MetadataEntry.KeyValue.Namespace,
-			"type":       metadataEntryFromVcd.MetadataEntry.KeyValue.Value.Type,
+			"id":         metadataEntryFromVcd.ID,
+			"key":        metadataEntryFromVcd.KeyValue.Key,
+			"readonly":   metadataEntryFromVcd.IsReadOnly,
+			"domain":     metadataEntryFromVcd.KeyValue.Domain,
+			"namespace":  metadataEntryFromVcd.KeyValue.Namespace,
+			"type":       metadataEntryFromVcd.KeyValue.Value.Type,
 			"value":      value,
-			"persistent": metadataEntryFromVcd.MetadataEntry.IsPersistent,
+			"persistent": metadataEntryFromVcd.IsPersistent,
 		}
 		metadata[i] = metadataEntry
 	}
 
-	err = d.Set("metadata_entry", metadata)
+	err = d.Set(attribute, metadata)
 	return append(diags, diag.FromErr(err)...)
 }
 
+// openApiMetadataValueToString returns the value of a metadata entry as it is stored in Terraform state
+func openApiMetadataValueToString(metadataEntry *types.OpenApiMetadataEntry) (string, error) {
+	// We need to set the correct type, otherwise saving the state will fail
+	switch metadataEntry.KeyValue.Value.Type {
+	case types.OpenApiMetadataBooleanEntry:
+		return fmt.Sprintf("%t", metadataEntry.KeyValue.Value.Value.(bool)), nil
+	case types.OpenApiMetadataNumberEntry:
+		return fmt.Sprintf("%.0f", metadataEntry.KeyValue.Value.Value.(float64)), nil
+	case types.OpenApiMetadataStringEntry:
+		return metadataEntry.KeyValue.Value.Value.(string), nil
+	default:
+		return "", fmt.Errorf("not supported metadata type %s", metadataEntry.KeyValue.Value.Type)
+	}
+}
+
 // convertOpenApiMetadataValue converts a metadata value from plain string to a correct typed value that can be sent
 // in OpenAPI payloads.
 func convertOpenApiMetadataValue(valueType, value string) (interface{}, error) {
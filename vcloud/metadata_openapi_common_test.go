@@ -1,4 +1,4 @@
-//go:build rde || functional || ALL
+//go:build rde || vdc || functional || ALL
 
 package vcloud
 
@@ -0,0 +1,126 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func TestMockVcdOpenApiEntityMetadata(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	handler := nsxtEdgeGatewayMetadata(vcdClient, &types.OpenAPIEdgeGateway{ID: edge.Id(), Name: "tf_edge"})
+
+	// The metadata endpoints of these entities require API version 38.0, while the client defaults to an older one
+	var metadataAcceptHeaders []string
+	transport := vcdClient.Client.Http.Transport
+	vcdClient.Client.Http.Transport = roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if strings.Contains(request.URL.Path, "/metadata") {
+			metadataAcceptHeaders = append(metadataAcceptHeaders, request.Header.Get("Accept"))
+		}
+		return transport.RoundTrip(request)
+	})
+	defer func() { vcdClient.Client.Http.Transport = transport }()
+
+	resourceSchema := Provider().ResourcesMap["vcloud_nsxt_edgegateway"].Schema
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"name": "tf_edge",
+		"metadata_entry": []interface{}{
+			map[string]interface{}{"key": "cost-center", "value": "42", "type": types.OpenApiMetadataNumberEntry, "namespace": "billing"},
+			map[string]interface{}{"key": "owner", "value": "team-a"},
+		},
+	})
+	err := createOrUpdateOpenApiMetadataEntryInVcd(d, handler)
+	if err != nil {
+		t.Fatalf("error adding metadata: %s", err)
+	}
+
+	mock.Lock()
+	stored := mock.openApiMetadata["1.0.0/edgeGateways/"+edge.Id()]
+	mock.Unlock()
+	if len(stored) != 2 {
+		t.Fatalf("expected 2 metadata entries in VCD, got %d", len(stored))
+	}
+	for _, entry := range stored {
+		if entry.KeyValue.Key == "cost-center" && entry.KeyValue.Value.Value != float64(42) {
+			t.Errorf("expected a numeric value for 'cost-center', got %#v", entry.KeyValue.Value.Value)
+		}
+	}
+
+	for _, accept := range metadataAcceptHeaders {
+		if !strings.Contains(accept, "version=38.0") {
+			t.Errorf("expected API version 38.0 in metadata requests, got '%s'", accept)
+		}
+	}
+	if len(metadataAcceptHeaders) == 0 {
+		t.Errorf("no metadata requests were sent")
+	}
+
+	// Updates need the ETag of the entry
+	err = handler.updateMetadata(types.OpenApiMetadataEntry{KeyValue: types.OpenApiMetadataKeyValue{
+		Domain: "TENANT", Namespace: "billing", Key: "cost-center",
+		Value: types.OpenApiMetadataTypedValue{Type: types.OpenApiMetadataNumberEntry, Value: float64(43)},
+	}})
+	if err != nil {
+		t.Fatalf("error updating metadata: %s", err)
+	}
+	err = handler.deleteMetadata(types.OpenApiMetadataEntry{KeyValue: types.OpenApiMetadataKeyValue{Domain: "TENANT", Key: "owner"}})
+	if err != nil {
+		t.Fatalf("error deleting metadata: %s", err)
+	}
+	err = handler.deleteMetadata(types.OpenApiMetadataEntry{KeyValue: types.OpenApiMetadataKeyValue{Domain: "TENANT", Key: "owner"}})
+	if err == nil || !govcd.ContainsNotFound(err) {
+		t.Errorf("expected a not found error when deleting a missing entry, got %v", err)
+	}
+
+	diags := updateOpenApiMetadataInState(d, vcdClient, "vcd_nsxt_edgegateway", handler)
+	if diags.HasError() {
+		t.Fatalf("error reading metadata: %v", diags)
+	}
+	entries := d.Get("metadata_entry").(*schema.Set).List()
+	if len(entries) != 1 || entries[0].(map[string]interface{})["value"] != "43" {
+		t.Errorf("unexpected metadata in state: %v", entries)
+	}
+
+	// Data sources find the only Edge Gateway that matches the filter
+	found := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{
+		"metadata_entry_filter": []interface{}{
+			map[string]interface{}{"key": "cost-center", "value": "43", "namespace": "billing"},
+		},
+	})
+	if found.Id() != edge.Id() || found.Get("name").(string) != "tf_edge" {
+		t.Errorf("expected Edge Gateway %s, got %s", edge.Id(), found.Id())
+	}
+	dataSource := Provider().DataSourcesMap["vcloud_nsxt_edgegateway"]
+	notFound := schema.TestResourceDataRaw(t, dataSource.Schema, map[string]interface{}{
+		"metadata_entry_filter": []interface{}{
+			map[string]interface{}{"key": "cost-center", "value": "43"}, // Different namespace
+		},
+	})
+	diags = dataSource.ReadContext(context.Background(), notFound, vcdClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, govcd.ErrorEntityNotFound.Error()) {
+		t.Errorf("expected a not found error for a filter without matches, got %v", diags)
+	}
+
+	// Entries ignored in the provider configuration are not read
+	vcdClient.Client.IgnoredMetadata = []govcd.IgnoredMetadata{{
+		ObjectType: addrOf("edgeGateway"),
+		KeyRegex:   regexp.MustCompile(`^cost`),
+	}}
+	allMetadata, err := handler.getAllMetadata()
+	if err != nil {
+		t.Fatalf("error reading metadata: %s", err)
+	}
+	if len(allMetadata) != 0 {
+		t.Errorf("expected the ignored metadata to be filtered out, got %d entries", len(allMetadata))
+	}
+}
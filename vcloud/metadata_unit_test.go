@@ -0,0 +1,195 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// testPlanMetadataEntries runs the diff of the given resource with the given configuration, and returns the planned
+// metadata entries as "key=value" strings
+func testPlanMetadataEntries(t *testing.T, resource *schema.Resource, config map[string]interface{}, vcdClient *VCDClient) []string {
+	configType := resource.CoreConfigSchema().ImpliedType()
+	entryType := configType.AttributeType("metadata_entry").ElementType()
+	rawValues := map[string]cty.Value{}
+	for attribute, attributeType := range configType.AttributeTypes() {
+		rawValues[attribute] = cty.NullVal(attributeType)
+	}
+	if name, ok := config["name"]; ok {
+		rawValues["name"] = cty.StringVal(name.(string))
+	}
+	if entries, ok := config["metadata_entry"]; ok {
+		var rawEntries []cty.Value
+		for _, entry := range entries.([]interface{}) {
+			fields := map[string]cty.Value{}
+			for field, fieldType := range entryType.AttributeTypes() {
+				fields[field] = cty.NullVal(fieldType)
+				if value, ok := entry.(map[string]interface{})[field]; ok {
+					fields[field] = cty.StringVal(value.(string))
+				}
+			}
+			rawEntries = append(rawEntries, cty.ObjectVal(fields))
+		}
+		rawValues["metadata_entry"] = cty.SetVal(rawEntries)
+	}
+
+	state := &terraform.InstanceState{RawConfig: cty.ObjectVal(rawValues)}
+	diff, err := resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), vcdClient)
+	if err != nil {
+		t.Fatalf("error computing the diff: %s", err)
+	}
+	var result []string
+	for attribute, attributeDiff := range diff.Attributes {
+		if strings.HasPrefix(attribute, "metadata_entry.") && strings.HasSuffix(attribute, ".key") {
+			prefix := strings.TrimSuffix(attribute, "key")
+			result = append(result, attributeDiff.New+"="+diff.Attributes[prefix+"value"].New)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func TestDefaultMetadataInDiff(t *testing.T) {
+	defaultMetadata := map[string]types.MetadataValue{
+		"owner": {
+			Domain:     &types.MetadataDomainTag{Domain: "GENERAL", Visibility: types.MetadataReadWriteVisibility},
+			TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataStringValue, Value: "team-a"},
+		},
+		"cost-center": {
+			Domain:     &types.MetadataDomainTag{Domain: "GENERAL", Visibility: types.MetadataReadWriteVisibility},
+			TypedValue: &types.MetadataTypedValue{XsiType: types.MetadataNumberValue, Value: "42"},
+		},
+	}
+	resourceMetadata := []interface{}{
+		map[string]interface{}{"key": "owner", "value": "team-b", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility},
+		map[string]interface{}{"key": "environment", "value": "prod", "type": types.MetadataStringValue, "user_access": types.MetadataReadWriteVisibility},
+	}
+
+	tests := []struct {
+		name            string
+		resourceType    string
+		config          map[string]interface{}
+		defaultMetadata map[string]types.MetadataValue
+		ignoredMetadata []govcd.IgnoredMetadata
+		want            []string
+	}{
+		{
+			name:            "defaults only",
+			resourceType:    "vcloud_vapp",
+			config:          map[string]interface{}{"name": "my-vapp"},
+			defaultMetadata: defaultMetadata,
+			want:            []string{"cost-center=42", "owner=team-a"},
+		},
+		{
+			name:            "resource entries override defaults",
+			resourceType:    "vcloud_vapp",
+			config:          map[string]interface{}{"name": "my-vapp", "metadata_entry": resourceMetadata},
+			defaultMetadata: defaultMetadata,
+			want:            []string{"cost-center=42", "environment=prod", "owner=team-b"},
+		},
+		{
+			name:            "ignored defaults are not added",
+			resourceType:    "vcloud_vapp",
+			config:          map[string]interface{}{"name": "my-vapp"},
+			defaultMetadata: defaultMetadata,
+			ignoredMetadata: []govcd.IgnoredMetadata{{ObjectType: addrOf("vApp"), KeyRegex: regexp.MustCompile(`^cost`)}},
+			want:            []string{"owner=team-a"},
+		},
+		{
+			name:            "defaults ignored for other resources are added",
+			resourceType:    "vcloud_vapp",
+			config:          map[string]interface{}{"name": "my-vapp"},
+			defaultMetadata: defaultMetadata,
+			ignoredMetadata: []govcd.IgnoredMetadata{{ObjectType: addrOf("catalog"), KeyRegex: regexp.MustCompile(`^cost`)}},
+			want:            []string{"cost-center=42", "owner=team-a"},
+		},
+		{
+			name:         "deprecated metadata schema without defaults is computed",
+			resourceType: "vcloud_vapp",
+			config:       map[string]interface{}{"name": "my-vapp"},
+			want:         nil,
+		},
+		{
+			name:         "resource entries without defaults",
+			resourceType: "vcloud_provider_vdc",
+			config:       map[string]interface{}{"name": "my-pvdc", "metadata_entry": resourceMetadata},
+			want:         []string{"environment=prod", "owner=team-b"},
+		},
+		{
+			name:            "defaults in resources without deprecated metadata",
+			resourceType:    "vcloud_provider_vdc",
+			config:          map[string]interface{}{"name": "my-pvdc"},
+			defaultMetadata: defaultMetadata,
+			want:            []string{"cost-center=42", "owner=team-a"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			vcdClient := &VCDClient{VCDClient: &govcd.VCDClient{}, DefaultMetadata: tt.defaultMetadata}
+			vcdClient.Client.IgnoredMetadata = tt.ignoredMetadata
+			got := testPlanMetadataEntries(t, Provider().ResourcesMap[tt.resourceType], tt.config, vcdClient)
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("planned metadata = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetDefaultMetadata(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []interface{}
+		wantKeys []string
+		wantErr  bool
+	}{
+		{
+			name:     "no default metadata",
+			wantKeys: []string{},
+		},
+		{
+			name: "valid entries",
+			entries: []interface{}{
+				map[string]interface{}{"key": "owner", "value": "team-a"},
+				map[string]interface{}{"key": "cost-center", "value": "42", "type": types.MetadataNumberValue},
+			},
+			wantKeys: []string{"cost-center", "owner"},
+		},
+		{
+			name: "inherited metadata key",
+			entries: []interface{}{
+				map[string]interface{}{"key": "vm.origin.name", "value": "my-template"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := schema.TestResourceDataRaw(t, Provider().Schema, map[string]interface{}{"default_metadata": tt.entries})
+			got, err := getDefaultMetadata(d, "default_metadata")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getDefaultMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			keys := []string{}
+			for key := range got {
+				keys = append(keys, key)
+			}
+			sort.Strings(keys)
+			if strings.Join(keys, ",") != strings.Join(tt.wantKeys, ",") {
+				t.Errorf("getDefaultMetadata() keys = %v, want %v", keys, tt.wantKeys)
+			}
+		})
+	}
+}
@@ -0,0 +1,1698 @@
+//go:build unit || api || functional || catalog || vapp || network || extnetwork || org || query || vm || vdc || gateway || disk || binary || lb || lbServiceMonitor || lbServerPool || lbAppProfile || lbAppRule || lbVirtualServer || access_control || user || standaloneVm || search || auth || nsxt || role || alb || certificate || vdcGroup || ldap || rde || uiPlugin || providerVdc || cse || ALL
+
+package vcloud
+
+// This module provides an in-process fake VMware Cloud Director that speaks enough of the XML API (/api) and of
+// the OpenAPI (/cloudapi) for go-vcloud-director to log in and retrieve the seeded entities. Org, VDC and VM are
+// read-only, vApps can also be powered on and off and deleted, while Catalogs, metadata and the OpenAPI entities
+// (such as NSX-T Edge Gateways and NSX-T Firewall rules) support the full CRUD.
+//
+// The server is started by TestMain when the test configuration contains "useMockServer": true (or when the
+// environment variable VCD_MOCK_SERVER is set), and can also be started directly by unit tests with
+// newMockVcd(defaultMockVcdFixtures()).
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// mockVcdApiVersions are the API versions advertised by the mock server in /api/versions
+var mockVcdApiVersions = []string{"37.0", "37.1", "37.2", "38.0", "38.1"}
+
+// mockVcdFixtures describes the initial content of the mock VCD. It can be loaded from a JSON file
+// (see test-resources/mock_vcd_fixtures.json) or built in code with defaultMockVcdFixtures.
+// Entities without an ID receive a random one when the server is seeded.
+type mockVcdFixtures struct {
+	User       string           `json:"user"`
+	Password   string           `json:"password"`
+	SysOrg     string           `json:"sysOrg"`
+	VcdVersion string           `json:"vcdVersion"`
+	Orgs       []*mockVcdOrgFix `json:"orgs"`
+}
+
+type mockVcdOrgFix struct {
+	ID          string               `json:"id,omitempty"`
+	Name        string               `json:"name"`
+	FullName    string               `json:"fullName,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Vdcs        []*mockVcdVdcFix     `json:"vdcs,omitempty"`
+	Catalogs    []*mockVcdCatalogFix `json:"catalogs,omitempty"`
+}
+
+type mockVcdVdcFix struct {
+	ID           string                   `json:"id,omitempty"`
+	Name         string                   `json:"name"`
+	Description  string                   `json:"description,omitempty"`
+	IsNsxt       bool                     `json:"isNsxt"`
+	VApps        []*mockVcdVAppFix        `json:"vapps,omitempty"`
+	EdgeGateways []*mockVcdEdgeGatewayFix `json:"edgeGateways,omitempty"`
+	org          *mockVcdOrgFix
+	// defaultPolicyId is the ID of the sizing policy assigned to the VDC as default compute policy
+	defaultPolicyId string
+}
+
+type mockVcdCatalogFix struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	created     time.Time
+	org         *mockVcdOrgFix
+}
+
+type mockVcdVAppFix struct {
+	ID          string          `json:"id,omitempty"`
+	Name        string          `json:"name"`
+	Description string          `json:"description,omitempty"`
+	PoweredOn   bool            `json:"poweredOn"`
+	Vms         []*mockVcdVmFix `json:"vms,omitempty"`
+	vdc         *mockVcdVdcFix
+}
+
+type mockVcdVmFix struct {
+	ID          string `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Cpus        int    `json:"cpus"`
+	MemoryMB    int64  `json:"memoryMB"`
+	OsType      string `json:"osType,omitempty"`
+	vapp        *mockVcdVAppFix
+	// snapshot is the only snapshot that VCD allows for a VM
+	snapshot *types.SnapshotItem
+	// gcStatus, toolsStatus, nicIp and guestProperties simulate the state reported by the guest OS. An empty
+	// gcStatus means GC_COMPLETE
+	gcStatus        string
+	toolsStatus     string
+	nicIp           string
+	guestProperties map[string]string
+}
+
+type mockVcdEdgeGatewayFix struct {
+	ID            string                    `json:"id,omitempty"`
+	Name          string                    `json:"name"`
+	Description   string                    `json:"description,omitempty"`
+	FirewallRules []*types.NsxtFirewallRule `json:"firewallRules,omitempty"`
+}
+
+// mockVcd is the in-process fake VCD
+type mockVcd struct {
+	server   *httptest.Server
+	fixtures *mockVcdFixtures
+
+	sync.Mutex
+	// sessions maps an access token to the information of the session opened with it
+	sessions map[string]*types.CurrentSessionInfo
+	// tasks holds every task created by the server. They are completed successfully, unless holdTasks is set
+	tasks map[string]*types.Task
+	// holdTasks keeps the new tasks running until they are cancelled, to test timeouts
+	holdTasks bool
+	// openApi holds OpenAPI entities. Keys are endpoint paths relative to /cloudapi/ (e.g. "1.0.0/edgeGateways").
+	// A collection is a list of JSON objects, each one with an "id" field
+	openApi map[string][]map[string]interface{}
+	// openApiDocs holds single OpenAPI documents that are read and replaced as a whole (e.g. firewall rules)
+	openApiDocs map[string]interface{}
+	// metadata holds metadata entries, keyed by the HREF of the owner entity
+	metadata map[string][]*types.MetadataEntry
+	// openApiMetadata holds OpenAPI metadata entries, keyed by the path of the owner entity relative to /cloudapi/
+	// (e.g. "1.0.0/edgeGateways/urn:vcloud:gateway:...")
+	openApiMetadata map[string][]*types.OpenApiMetadataEntry
+	// unhandled records the requests that the mock could not serve, to help extending it
+	unhandled []string
+	// faults holds the errors injected with failNext
+	faults []*mockVcdFault
+}
+
+// mockVcdFault makes the mock fail the next requests matching a method and a path suffix
+type mockVcdFault struct {
+	method         string
+	pathSuffix     string
+	status         int
+	minorErrorCode string
+	message        string
+	remaining      int
+}
+
+// defaultMockVcdFixtures returns a small, NSX-T backed, tenant hierarchy that mirrors the names used in
+// sample_vcd_test_config.json
+func defaultMockVcdFixtures() *mockVcdFixtures {
+	return &mockVcdFixtures{
+		User:       "administrator",
+		Password:   "mock-password",
+		SysOrg:     "System",
+		VcdVersion: "10.5.1.22844984",
+		Orgs: []*mockVcdOrgFix{
+			{
+				Name:        "tf_org",
+				FullName:    "Terraform test Org",
+				Description: "Org seeded by the mock VCD",
+				Vdcs: []*mockVcdVdcFix{
+					{
+						Name:   "tf_vdc",
+						IsNsxt: true,
+						VApps: []*mockVcdVAppFix{
+							{
+								Name:        "tf_vapp",
+								Description: "vApp seeded by the mock VCD",
+								PoweredOn:   true,
+								Vms: []*mockVcdVmFix{
+									{Name: "tf_vm1", Cpus: 2, MemoryMB: 2048, OsType: "ubuntu64Guest"},
+									{Name: "tf_vm2", Cpus: 1, MemoryMB: 1024, OsType: "ubuntu64Guest"},
+								},
+							},
+						},
+						EdgeGateways: []*mockVcdEdgeGatewayFix{
+							{
+								Name:        "tf_edge",
+								Description: "NSX-T Edge Gateway seeded by the mock VCD",
+								FirewallRules: []*types.NsxtFirewallRule{
+									{
+										Name:       "allow-outbound",
+										Action:     "ALLOW",
+										Enabled:    true,
+										IpProtocol: "IPV4_IPV6",
+										Direction:  "OUT",
+									},
+								},
+							},
+						},
+					},
+				},
+				Catalogs: []*mockVcdCatalogFix{
+					{Name: "tf_catalog", Description: "Catalog seeded by the mock VCD"},
+				},
+			},
+		},
+	}
+}
+
+// loadMockVcdFixtures reads mock fixtures from a JSON file
+func loadMockVcdFixtures(fileName string) (*mockVcdFixtures, error) {
+	contents, err := os.ReadFile(filepath.Clean(fileName))
+	if err != nil {
+		return nil, fmt.Errorf("error reading mock fixtures file %s: %s", fileName, err)
+	}
+	var fixtures mockVcdFixtures
+	err = json.Unmarshal(contents, &fixtures)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding mock fixtures file %s: %s", fileName, err)
+	}
+	return &fixtures, nil
+}
+
+// newMockVcd seeds and starts a mock VCD. The caller must invoke Close() when done.
+func newMockVcd(fixtures *mockVcdFixtures) *mockVcd {
+	mock := &mockVcd{
+		fixtures:    fixtures,
+		sessions:    make(map[string]*types.CurrentSessionInfo),
+		tasks:       make(map[string]*types.Task),
+		openApi:     make(map[string][]map[string]interface{}),
+		openApiDocs: make(map[string]interface{}),
+		metadata:    make(map[string][]*types.MetadataEntry),
+
+		openApiMetadata: make(map[string][]*types.OpenApiMetadataEntry),
+	}
+	mock.server = httptest.NewTLSServer(http.HandlerFunc(mock.serveHTTP))
+	mock.seed()
+	return mock
+}
+
+// Close stops the mock server
+func (m *mockVcd) Close() {
+	m.server.Close()
+}
+
+// URL returns the API endpoint of the mock, in the same format used by the "url" provider property
+func (m *mockVcd) URL() string {
+	return m.server.URL + "/api"
+}
+
+// Unhandled returns the list of requests that were not served by the mock
+func (m *mockVcd) Unhandled() []string {
+	m.Lock()
+	defer m.Unlock()
+	return append([]string{}, m.unhandled...)
+}
+
+// expireSessions invalidates all the open sessions, as VCD does when they reach their idle timeout
+func (m *mockVcd) expireSessions() {
+	m.Lock()
+	defer m.Unlock()
+	m.sessions = make(map[string]*types.CurrentSessionInfo)
+}
+
+// failNext makes the next 'count' requests with the given method and path suffix fail with an API error
+func (m *mockVcd) failNext(method, pathSuffix string, status int, minorErrorCode, message string, count int) {
+	m.Lock()
+	defer m.Unlock()
+	m.faults = append(m.faults, &mockVcdFault{
+		method:         method,
+		pathSuffix:     pathSuffix,
+		status:         status,
+		minorErrorCode: minorErrorCode,
+		message:        message,
+		remaining:      count,
+	})
+}
+
+// injectFault writes the error of the first pending fault that matches the request, if any
+func (m *mockVcd) injectFault(w http.ResponseWriter, r *http.Request) bool {
+	for _, fault := range m.faults {
+		if fault.remaining == 0 || fault.method != r.Method || !strings.HasSuffix(r.URL.Path, fault.pathSuffix) {
+			continue
+		}
+		fault.remaining--
+		if strings.HasPrefix(r.URL.Path, "/cloudapi/") {
+			m.writeJson(w, fault.status, types.OpenApiError{MinorErrorCode: fault.minorErrorCode, Message: fault.message})
+		} else {
+			m.writeXml(w, fault.status, types.Error{Message: fault.message, MajorErrorCode: fault.status, MinorErrorCode: fault.minorErrorCode})
+		}
+		return true
+	}
+	return false
+}
+
+// seed assigns IDs and parent pointers to the fixtures and loads the OpenAPI entities
+func (m *mockVcd) seed() {
+	// Collections that start empty, but must answer listing requests
+	for _, collection := range []string{"1.0.0/orgVdcNetworks", "1.0.0/vdcGroups", "1.0.0/firewallGroups", "1.0.0/applicationPortProfiles"} {
+		m.openApi[collection] = []map[string]interface{}{}
+	}
+	for _, org := range m.fixtures.Orgs {
+		setMockUuid(&org.ID)
+		for _, catalog := range org.Catalogs {
+			setMockUuid(&catalog.ID)
+			catalog.org = org
+			catalog.created = time.Now()
+		}
+		for _, vdc := range org.Vdcs {
+			setMockUuid(&vdc.ID)
+			vdc.org = org
+			m.addVdcOpenApiEntities(vdc)
+			for _, vapp := range vdc.VApps {
+				setMockUuid(&vapp.ID)
+				vapp.vdc = vdc
+				for _, vm := range vapp.Vms {
+					setMockUuid(&vm.ID)
+					vm.vapp = vapp
+					m.openApiDocs["1.0.0/securityTags/vm/urn:vcloud:vm:"+vm.ID] = &types.EntitySecurityTags{Tags: []string{}}
+				}
+			}
+			for _, egw := range vdc.EdgeGateways {
+				setMockUuid(&egw.ID)
+				m.addEdgeGateway(org, vdc, egw)
+			}
+		}
+	}
+}
+
+// addVdcOpenApiEntities creates the OpenAPI documents related to a VDC: its capabilities, network profile, and
+// a sizing policy assigned as default compute policy
+func (m *mockVcd) addVdcOpenApiEntities(vdc *mockVcdVdcFix) {
+	vdcUrn := "urn:vcloud:vdc:" + vdc.ID
+	networkProvider := types.VdcCapabilityNetworkProviderNsxv
+	if vdc.IsNsxt {
+		networkProvider = types.VdcCapabilityNetworkProviderNsxt
+	}
+	m.openApi["1.0.0/vdcs/"+vdcUrn+"/capabilities"] = []map[string]interface{}{
+		toMockJsonMap(types.VdcCapability{Name: "networkProvider", Value: networkProvider, Type: "String", Category: "General"}),
+	}
+	m.openApiDocs["1.0.0/vdcs/"+vdcUrn+"/networkProfile"] = &types.VdcNetworkProfile{}
+
+	vdc.defaultPolicyId = "urn:vcloud:vdcComputePolicy:" + mockUuid()
+	policy := map[string]interface{}{
+		"id":           vdc.defaultPolicyId,
+		"name":         "System Default",
+		"description":  "Default compute policy of " + vdc.Name,
+		"isSizingOnly": true,
+		"policyType":   "VdcVmPolicy",
+	}
+	m.openApi["2.0.0/vdcComputePolicies"] = append(m.openApi["2.0.0/vdcComputePolicies"], policy)
+	m.openApi["2.0.0/vdcs/urn:vcloud:vdc:"+vdc.ID+"/computePolicies"] = []map[string]interface{}{policy}
+}
+
+// addEdgeGateway stores an NSX-T Edge Gateway, its firewall rule container, and its NAT rule collection
+func (m *mockVcd) addEdgeGateway(org *mockVcdOrgFix, vdc *mockVcdVdcFix, egw *mockVcdEdgeGatewayFix) {
+	egwId := "urn:vcloud:gateway:" + egw.ID
+	edge := types.OpenAPIEdgeGateway{
+		ID:          egwId,
+		Name:        egw.Name,
+		Description: egw.Description,
+		Status:      "REALIZED",
+		OwnerRef:    &types.OpenApiReference{ID: "urn:vcloud:vdc:" + vdc.ID, Name: vdc.Name},
+		OrgVdc:      &types.OpenApiReference{ID: "urn:vcloud:vdc:" + vdc.ID, Name: vdc.Name},
+		Org:         &types.OpenApiReference{ID: "urn:vcloud:org:" + org.ID, Name: org.Name},
+		GatewayBacking: &types.OpenAPIEdgeGatewayBacking{
+			BackingID:   egw.ID,
+			GatewayType: "NSXT_BACKED",
+			NetworkProvider: types.NetworkProvider{
+				Name: "nsxManager1",
+				ID:   "urn:vcloud:nsxtmanager:" + mockUuid(),
+			},
+		},
+		EdgeClusterConfig: &types.OpenAPIEdgeGatewayEdgeClusterConfig{
+			PrimaryEdgeCluster: types.OpenAPIEdgeGatewayEdgeCluster{
+				EdgeClusterRef: types.OpenApiReference{Name: "edgeCluster1"},
+				BackingID:      mockUuid(),
+			},
+		},
+		EdgeGatewayUplinks: []types.EdgeGatewayUplinks{{
+			UplinkID:    "urn:vcloud:network:" + mockUuid(),
+			UplinkName:  "tf_external_network",
+			Connected:   true,
+			BackingType: addrOf("NSXT_TIER0"),
+			Subnets: types.OpenAPIEdgeGatewaySubnets{Values: []types.OpenAPIEdgeGatewaySubnetValue{{
+				Gateway:      "192.168.100.1",
+				PrefixLength: 24,
+				PrimaryIP:    "192.168.100.10",
+				Enabled:      true,
+				IPRanges: &types.OpenApiIPRanges{Values: []types.OpenApiIPRangeValues{{
+					StartAddress: "192.168.100.10",
+					EndAddress:   "192.168.100.10",
+				}}},
+			}}},
+		}},
+	}
+	m.openApi["1.0.0/edgeGateways"] = append(m.openApi["1.0.0/edgeGateways"], toMockJsonMap(edge))
+	m.openApi["1.0.0/edgeGateways/"+egwId+"/usedIpAddresses"] = []map[string]interface{}{}
+	m.openApi["1.0.0/edgeGateways/"+egwId+"/nat/rules"] = []map[string]interface{}{}
+
+	for _, rule := range egw.FirewallRules {
+		if rule.ID == "" {
+			rule.ID = mockUuid()
+		}
+	}
+	m.openApiDocs["1.0.0/edgeGateways/"+egwId+"/firewall/rules"] = &types.NsxtFirewallRuleContainer{
+		SystemRules:      []*types.NsxtFirewallRule{},
+		DefaultRules:     []*types.NsxtFirewallRule{{ID: mockUuid(), Name: "Default Rule", Action: "DROP", Enabled: true, IpProtocol: "IPV4_IPV6", Direction: "IN_OUT"}},
+		UserDefinedRules: egw.FirewallRules,
+	}
+}
+
+// serveHTTP dispatches the requests to the XML or OpenAPI handlers
+func (m *mockVcd) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.Lock()
+	defer m.Unlock()
+
+	p := r.URL.Path
+	switch {
+	case p == "/api/versions":
+		m.serveVersions(w)
+		return
+	case strings.HasPrefix(p, "/cloudapi/1.0.0/sessions") && r.Method == http.MethodPost:
+		m.serveLogin(w, r)
+		return
+	}
+
+	session := m.getSession(r)
+	if session == nil {
+		m.writeError(w, r, http.StatusUnauthorized, "authentication required")
+		return
+	}
+	if m.injectFault(w, r) {
+		return
+	}
+
+	var handled bool
+	switch {
+	case p == "/cloudapi/1.0.0/sessions/current" && r.Method == http.MethodGet:
+		m.writeJson(w, http.StatusOK, session)
+		handled = true
+	case strings.HasPrefix(p, "/cloudapi/"):
+		handled = m.serveOpenApi(w, r, strings.TrimPrefix(p, "/cloudapi/"))
+	case strings.HasPrefix(p, "/api/"):
+		handled = m.serveXmlApi(w, r, strings.TrimPrefix(p, "/api"))
+	}
+	if !handled {
+		m.unhandled = append(m.unhandled, r.Method+" "+r.URL.RequestURI())
+		m.writeError(w, r, http.StatusNotFound, fmt.Sprintf("[ mock ] %s: %s %s", govcd.ErrorEntityNotFound, r.Method, p))
+	}
+}
+
+func (m *mockVcd) serveVersions(w http.ResponseWriter) {
+	versions := govcd.SupportedVersions{}
+	for _, version := range mockVcdApiVersions {
+		versions.VersionInfos = append(versions.VersionInfos, govcd.VersionInfo{
+			Version:  version,
+			LoginUrl: m.server.URL + "/api/sessions",
+		})
+	}
+	m.writeXml(w, http.StatusOK, versions)
+}
+
+// serveLogin checks the basic authentication credentials against the fixtures and returns a bearer token
+func (m *mockVcd) serveLogin(w http.ResponseWriter, r *http.Request) {
+	user, password, ok := r.BasicAuth()
+	if !ok {
+		m.writeError(w, r, http.StatusUnauthorized, "missing credentials")
+		return
+	}
+	userName, orgName, _ := strings.Cut(user, "@")
+	if userName != m.fixtures.User || password != m.fixtures.Password {
+		m.writeError(w, r, http.StatusUnauthorized, "invalid credentials")
+		return
+	}
+	isProvider := strings.HasSuffix(r.URL.Path, "/provider")
+	if isProvider != strings.EqualFold(orgName, "system") {
+		m.writeError(w, r, http.StatusUnauthorized, "invalid login endpoint for org "+orgName)
+		return
+	}
+
+	role := "Organization Administrator"
+	if isProvider {
+		role = "System Administrator"
+	}
+	session := &types.CurrentSessionInfo{
+		ID:                        "urn:vcloud:session:" + mockUuid(),
+		User:                      types.OpenApiReference{ID: "urn:vcloud:user:" + mockUuid(), Name: userName},
+		Org:                       types.OpenApiReference{ID: "urn:vcloud:org:" + mockUuid(), Name: orgName},
+		Roles:                     []string{role},
+		SessionIdleTimeoutMinutes: 30,
+	}
+	token := mockToken()
+	m.sessions[token] = session
+	w.Header().Set(govcd.BearerTokenHeader, token)
+	m.writeJson(w, http.StatusOK, session)
+}
+
+// getSession returns the session of the bearer token used in the request, or nil when the token is unknown
+func (m *mockVcd) getSession(r *http.Request) *types.CurrentSessionInfo {
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if token == "" {
+		token = r.Header.Get(govcd.BearerTokenHeader)
+	}
+	return m.sessions[token]
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+// XML API
+// -------------------------------------------------------------------------------------------------------------------
+
+var (
+	reMockOrg           = regexp.MustCompile(`^/org/([0-9a-f-]+)$`)
+	reMockAdminOrg      = regexp.MustCompile(`^/admin/org/([0-9a-f-]+)$`)
+	reMockAdminCatalogs = regexp.MustCompile(`^/admin/org/([0-9a-f-]+)/catalogs$`)
+	reMockVdc           = regexp.MustCompile(`^/(admin/)?vdc/([0-9a-f-]+)$`)
+	reMockCatalog       = regexp.MustCompile(`^/(admin/)?catalog/([0-9a-f-]+)$`)
+	reMockVApp          = regexp.MustCompile(`^/vApp/vapp-([0-9a-f-]+)$`)
+	reMockVm            = regexp.MustCompile(`^/vApp/vm-([0-9a-f-]+)$`)
+	reMockVmAction      = regexp.MustCompile(`^/vApp/vm-([0-9a-f-]+)/action/(\w+)$`)
+	reMockVmSection     = regexp.MustCompile(`^/vApp/vm-([0-9a-f-]+)/(.+)$`)
+	reMockVAppSection   = regexp.MustCompile(`^/vApp/vapp-([0-9a-f-]+)/(\w+)$`)
+	reMockVAppPower     = regexp.MustCompile(`^/vApp/vapp-([0-9a-f-]+)/(power/action/\w+|action/\w+)$`)
+	reMockTask          = regexp.MustCompile(`^/task/([0-9a-f-]+)$`)
+	reMockTaskCancel    = regexp.MustCompile(`^/task/([0-9a-f-]+)/action/cancel$`)
+)
+
+func (m *mockVcd) serveXmlApi(w http.ResponseWriter, r *http.Request, p string) bool {
+	p = strings.TrimSuffix(p, "/")
+	if strings.HasSuffix(p, "/metadata") || strings.Contains(p, "/metadata/") {
+		return m.serveXmlMetadata(w, r, p)
+	}
+
+	switch {
+	case p == "/admin" && r.Method == http.MethodGet:
+		m.writeXml(w, http.StatusOK, types.VCloud{
+			Name:        "VMware Cloud Director",
+			HREF:        m.server.URL + "/api/admin",
+			Description: m.fixtures.VcdVersion + " Fri Jan 26 2024 12:00:00 GMT",
+		})
+		return true
+	case p == "/org" && r.Method == http.MethodGet:
+		orgList := types.OrgList{}
+		for _, org := range m.fixtures.Orgs {
+			orgList.Org = append(orgList.Org, &types.Org{HREF: m.href("/org/" + org.ID), Name: org.Name, Type: types.MimeOrg})
+		}
+		m.writeXml(w, http.StatusOK, orgList)
+		return true
+	case p == "/query":
+		m.serveQuery(w, r)
+		return true
+	}
+
+	if match := reMockTask.FindStringSubmatch(p); match != nil {
+		task, found := m.tasks[match[1]]
+		if !found {
+			return false
+		}
+		m.writeXml(w, http.StatusOK, task)
+		return true
+	}
+	if match := reMockTaskCancel.FindStringSubmatch(p); match != nil && r.Method == http.MethodPost {
+		task, found := m.tasks[match[1]]
+		if !found {
+			return false
+		}
+		if task.Status == "running" {
+			task.Status = "aborted"
+			task.EndTime = time.Now().Format(time.RFC3339)
+		}
+		w.WriteHeader(http.StatusNoContent)
+		return true
+	}
+	if match := reMockAdminCatalogs.FindStringSubmatch(p); match != nil && r.Method == http.MethodPost {
+		return m.createCatalog(w, r, match[1])
+	}
+	if match := reMockOrg.FindStringSubmatch(p); match != nil && r.Method == http.MethodGet {
+		org := m.findOrg(match[1])
+		if org == nil {
+			return false
+		}
+		m.writeXml(w, http.StatusOK, m.renderOrg(org))
+		return true
+	}
+	if match := reMockAdminOrg.FindStringSubmatch(p); match != nil && r.Method == http.MethodGet {
+		org := m.findOrg(match[1])
+		if org == nil {
+			return false
+		}
+		m.writeXml(w, http.StatusOK, m.renderAdminOrg(org))
+		return true
+	}
+	if match := reMockVdc.FindStringSubmatch(p); match != nil && r.Method == http.MethodGet {
+		vdc := m.findVdc(match[2])
+		if vdc == nil {
+			return false
+		}
+		if match[1] != "" {
+			m.writeXml(w, http.StatusOK, m.renderAdminVdc(vdc))
+		} else {
+			m.writeXml(w, http.StatusOK, m.renderVdc(vdc))
+		}
+		return true
+	}
+	if match := reMockCatalog.FindStringSubmatch(p); match != nil {
+		catalog := m.findCatalog(match[2])
+		if catalog == nil {
+			return false
+		}
+		switch r.Method {
+		case http.MethodGet:
+			if match[1] != "" {
+				m.writeXml(w, http.StatusOK, m.renderAdminCatalog(catalog))
+			} else {
+				m.writeXml(w, http.StatusOK, m.renderCatalog(catalog))
+			}
+		case http.MethodPut:
+			var update types.AdminCatalog
+			if !m.readXml(w, r, &update) {
+				return true
+			}
+			catalog.Name = update.Name
+			catalog.Description = update.Description
+			m.writeXml(w, http.StatusOK, m.renderAdminCatalog(catalog))
+		case http.MethodDelete:
+			org := catalog.org
+			for i, c := range org.Catalogs {
+				if c == catalog {
+					org.Catalogs = append(org.Catalogs[:i], org.Catalogs[i+1:]...)
+					break
+				}
+			}
+			m.writeTask(w, "catalogDelete", nil)
+		default:
+			return false
+		}
+		return true
+	}
+	if match := reMockVAppSection.FindStringSubmatch(p); match != nil && r.Method == http.MethodGet {
+		return m.serveVAppSection(w, match[1], match[2])
+	}
+	if match := reMockVAppPower.FindStringSubmatch(p); match != nil && r.Method == http.MethodPost {
+		vapp := m.findVApp(match[1])
+		if vapp == nil {
+			return false
+		}
+		switch {
+		case strings.HasSuffix(match[2], "powerOn"), strings.HasSuffix(match[2], "deploy"):
+			vapp.PoweredOn = true
+		case strings.HasSuffix(match[2], "powerOff"), strings.HasSuffix(match[2], "undeploy"):
+			vapp.PoweredOn = false
+		}
+		m.writeTask(w, "vappPower", &types.Reference{HREF: m.href("/vApp/vapp-" + vapp.ID), Name: vapp.Name})
+		return true
+	}
+	if match := reMockVApp.FindStringSubmatch(p); match != nil {
+		vapp := m.findVApp(match[1])
+		if vapp == nil {
+			return false
+		}
+		switch r.Method {
+		case http.MethodGet:
+			m.writeXml(w, http.StatusOK, m.renderVApp(vapp))
+		case http.MethodDelete:
+			vdc := vapp.vdc
+			for i, v := range vdc.VApps {
+				if v == vapp {
+					vdc.VApps = append(vdc.VApps[:i], vdc.VApps[i+1:]...)
+					break
+				}
+			}
+			m.writeTask(w, "vdcDeleteVapp", nil)
+		default:
+			return false
+		}
+		return true
+	}
+	if match := reMockVm.FindStringSubmatch(p); match != nil && r.Method == http.MethodGet {
+		vm := m.findVm(match[1])
+		if vm == nil {
+			return false
+		}
+		m.writeXml(w, http.StatusOK, m.renderVm(vm))
+		return true
+	}
+	if match := reMockVmAction.FindStringSubmatch(p); match != nil && r.Method == http.MethodPost {
+		return m.serveVmAction(w, r, match[1], match[2])
+	}
+	if match := reMockVmSection.FindStringSubmatch(p); match != nil {
+		return m.serveVmSection(w, r, match[1], match[2])
+	}
+	return false
+}
+
+// serveVAppSection serves the sub-sections of a vApp that are retrieved separately from the vApp itself
+func (m *mockVcd) serveVAppSection(w http.ResponseWriter, vappId, section string) bool {
+	vapp := m.findVApp(vappId)
+	if vapp == nil {
+		return false
+	}
+	switch section {
+	case "productSections":
+		m.writeXml(w, http.StatusOK, types.ProductSectionList{Ovf: types.XMLNamespaceOVF, Xmlns: types.XMLNamespaceVCloud})
+	case "leaseSettingsSection":
+		m.writeXml(w, http.StatusOK, types.LeaseSettingsSection{Type: types.MimeLeaseSettingSection})
+	case "networkConfigSection":
+		m.writeXml(w, http.StatusOK, m.renderVApp(vapp).NetworkConfigSection)
+	default:
+		return false
+	}
+	return true
+}
+
+// serveVmAction handles the VM actions that change the mock state
+func (m *mockVcd) serveVmAction(w http.ResponseWriter, r *http.Request, vmId, action string) bool {
+	vm := m.findVm(vmId)
+	if vm == nil {
+		return false
+	}
+	switch action {
+	case "createSnapshot":
+		vm.snapshot = &types.SnapshotItem{
+			Created:   time.Now().UTC().Format(time.RFC3339),
+			PoweredOn: vm.vapp.PoweredOn,
+			Size:      int(vm.MemoryMB) * 1024,
+		}
+	case "revertToCurrentSnapshot":
+		if vm.snapshot == nil {
+			m.writeError(w, r, http.StatusBadRequest, "[ mock ] the VM has no snapshot")
+			return true
+		}
+	case "removeAllSnapshots":
+		vm.snapshot = nil
+	default:
+		return false
+	}
+	m.writeTask(w, "vm"+strings.ToUpper(action[:1])+action[1:], &types.Reference{HREF: m.href("/vApp/vm-" + vm.ID), Name: vm.Name})
+	return true
+}
+
+// serveVmSection serves the sub-sections of a VM that are retrieved separately from the VM itself
+func (m *mockVcd) serveVmSection(w http.ResponseWriter, r *http.Request, vmId, section string) bool {
+	vm := m.findVm(vmId)
+	if vm == nil || r.Method != http.MethodGet {
+		return false
+	}
+	switch section {
+	case "productSections":
+		section := types.ProductSectionList{Ovf: types.XMLNamespaceOVF, Xmlns: types.XMLNamespaceVCloud, ProductSection: &types.ProductSection{}}
+		for key, value := range vm.guestProperties {
+			section.ProductSection.Property = append(section.ProductSection.Property,
+				&types.Property{Key: key, Type: "string", Value: &types.Value{Value: value}})
+		}
+		m.writeXml(w, http.StatusOK, section)
+	case "guestCustomizationSection":
+		m.writeXml(w, http.StatusOK, types.GuestCustomizationSection{
+			Xmlns:        types.XMLNamespaceVCloud,
+			Ovf:          types.XMLNamespaceOVF,
+			Info:         "Specifies Guest OS Customization Settings",
+			Enabled:      addrOf(false),
+			ComputerName: vm.Name,
+		})
+	case "guestcustomizationstatus":
+		status := vm.gcStatus
+		if status == "" {
+			status = types.GuestCustStatusComplete
+		}
+		m.writeXml(w, http.StatusOK, types.GuestCustomizationStatusSection{GuestCustStatus: status})
+	case "virtualHardwareSection":
+		m.writeXml(w, http.StatusOK, m.renderVm(vm).VirtualHardwareSection)
+	case "virtualHardwareSection/cpu", "virtualHardwareSection/memory":
+		m.writeXml(w, http.StatusOK, types.OVFItem{})
+	case "snapshotSection":
+		section := types.SnapshotSection{Info: "Snapshot information section", HREF: m.href("/vApp/vm-" + vm.ID + "/snapshotSection")}
+		if vm.snapshot != nil {
+			section.Snapshot = []*types.SnapshotItem{vm.snapshot}
+		}
+		m.writeXml(w, http.StatusOK, section)
+	default:
+		return false
+	}
+	return true
+}
+
+// serveXmlMetadata returns and stores metadata for any entity
+func (m *mockVcd) serveXmlMetadata(w http.ResponseWriter, r *http.Request, p string) bool {
+	ownerHref, key, _ := strings.Cut(p, "/metadata")
+	ownerHref = m.href(ownerHref)
+	key = strings.TrimPrefix(key, "/")
+	switch r.Method {
+	case http.MethodGet:
+		metadata := types.Metadata{
+			Xmlns:         types.XMLNamespaceVCloud,
+			HREF:          ownerHref + "/metadata",
+			Xsi:           types.XMLNamespaceXSI,
+			MetadataEntry: m.metadata[ownerHref],
+		}
+		m.writeXml(w, http.StatusOK, metadata)
+	case http.MethodPost:
+		var metadata types.Metadata
+		if !m.readXml(w, r, &metadata) {
+			return true
+		}
+		for _, entry := range metadata.MetadataEntry {
+			m.setMetadataEntry(ownerHref, entry)
+		}
+		m.writeTask(w, "metadataUpdate", nil)
+	case http.MethodPut:
+		var value types.MetadataValue
+		if !m.readXml(w, r, &value) {
+			return true
+		}
+		m.setMetadataEntry(ownerHref, &types.MetadataEntry{Key: key, Domain: value.Domain, TypedValue: value.TypedValue})
+		m.writeTask(w, "metadataUpdate", nil)
+	case http.MethodDelete:
+		entries := m.metadata[ownerHref]
+		for i, entry := range entries {
+			if entry.Key == key {
+				m.metadata[ownerHref] = append(entries[:i], entries[i+1:]...)
+				break
+			}
+		}
+		m.writeTask(w, "metadataDelete", nil)
+	default:
+		return false
+	}
+	return true
+}
+
+func (m *mockVcd) setMetadataEntry(ownerHref string, newEntry *types.MetadataEntry) {
+	for i, entry := range m.metadata[ownerHref] {
+		if entry.Key == newEntry.Key {
+			m.metadata[ownerHref][i] = newEntry
+			return
+		}
+	}
+	m.metadata[ownerHref] = append(m.metadata[ownerHref], newEntry)
+}
+
+// createCatalog handles POST /api/admin/org/{id}/catalogs
+func (m *mockVcd) createCatalog(w http.ResponseWriter, r *http.Request, orgId string) bool {
+	org := m.findOrg(orgId)
+	if org == nil {
+		return false
+	}
+	var newCatalog types.AdminCatalog
+	if !m.readXml(w, r, &newCatalog) {
+		return true
+	}
+	for _, catalog := range org.Catalogs {
+		if catalog.Name == newCatalog.Name {
+			m.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("DUPLICATE_NAME: catalog '%s' already exists", newCatalog.Name))
+			return true
+		}
+	}
+	catalog := &mockVcdCatalogFix{
+		ID:          mockUuid(),
+		Name:        newCatalog.Name,
+		Description: newCatalog.Description,
+		created:     time.Now(),
+		org:         org,
+	}
+	org.Catalogs = append(org.Catalogs, catalog)
+	m.writeXml(w, http.StatusCreated, m.renderAdminCatalog(catalog))
+	return true
+}
+
+// serveQuery implements the query service (/api/query) for the types known to the mock.
+// Only the "==" operator of the filter expression is supported.
+func (m *mockVcd) serveQuery(w http.ResponseWriter, r *http.Request) {
+	queryType := r.URL.Query().Get("type")
+	var records []map[string]string
+	switch queryType {
+	case types.QtOrgVdc, types.QtAdminOrgVdc:
+		for _, org := range m.fixtures.Orgs {
+			for _, vdc := range org.Vdcs {
+				records = append(records, map[string]string{
+					"href": m.href("/vdc/" + vdc.ID), "name": vdc.Name, "orgName": org.Name,
+					"org": m.href("/org/" + org.ID), "isEnabled": "true",
+				})
+			}
+		}
+	case types.QtCatalog, types.QtAdminCatalog:
+		for _, org := range m.fixtures.Orgs {
+			for _, catalog := range org.Catalogs {
+				records = append(records, map[string]string{
+					"href": m.href("/catalog/" + catalog.ID), "name": catalog.Name, "orgName": org.Name,
+					"description": catalog.Description, "creationDate": catalog.created.Format(time.RFC3339),
+				})
+			}
+		}
+	case types.QtVapp, types.QtAdminVapp:
+		for _, vapp := range m.allVApps() {
+			records = append(records, map[string]string{
+				"href": m.href("/vApp/vapp-" + vapp.ID), "name": vapp.Name, "vdc": m.href("/vdc/" + vapp.vdc.ID),
+				"vdcName": vapp.vdc.Name, "status": mockPowerStatus(vapp.PoweredOn),
+				"numberOfVMs": strconv.Itoa(len(vapp.Vms)),
+			})
+		}
+	case types.QtVm, types.QtAdminVm:
+		for _, vapp := range m.allVApps() {
+			for _, vm := range vapp.Vms {
+				records = append(records, map[string]string{
+					"href": m.href("/vApp/vm-" + vm.ID), "name": vm.Name, "containerName": vapp.Name,
+					"container": m.href("/vApp/vapp-" + vapp.ID), "vdc": m.href("/vdc/" + vapp.vdc.ID),
+					"isVAppTemplate": "false", "status": mockPowerStatus(vapp.PoweredOn),
+					"numberOfCpus": strconv.Itoa(vm.Cpus), "memoryMB": strconv.FormatInt(vm.MemoryMB, 10),
+					"vmToolsStatus": vm.toolsStatus,
+				})
+			}
+		}
+	}
+	records = filterMockRecords(records, r.URL.Query().Get("filter"))
+	m.writeRaw(w, http.StatusOK, types.MimeQueryRecords, renderMockQueryRecords(m.server.URL+r.URL.RequestURI(), queryType, records))
+}
+
+// filterMockRecords applies a query service filter containing only "==" conditions joined by ";"
+func filterMockRecords(records []map[string]string, filter string) []map[string]string {
+	if filter == "" {
+		return records
+	}
+	filter = strings.Trim(filter, "()")
+	var result []map[string]string
+	for _, record := range records {
+		matches := true
+		for _, condition := range strings.Split(filter, ";") {
+			key, value, found := strings.Cut(strings.Trim(condition, "()"), "==")
+			if !found {
+				continue
+			}
+			unescaped, err := url.QueryUnescape(value)
+			if err == nil {
+				value = unescaped
+			}
+			recordValue, known := record[key]
+			if known && recordValue != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, record)
+		}
+	}
+	return result
+}
+
+// renderMockQueryRecords builds the XML of a query result. The element names follow the rule used by VCD,
+// where the record name is the capitalized query type, with a few exceptions
+func renderMockQueryRecords(href, queryType string, records []map[string]string) string {
+	recordName := map[string]string{
+		types.QtAdminOrgVdc: "AdminVdcRecord",
+		types.QtVm:          "VMRecord",
+		types.QtAdminVm:     "AdminVMRecord",
+	}[queryType]
+	if recordName == "" && queryType != "" {
+		recordName = strings.ToUpper(queryType[:1]) + queryType[1:] + "Record"
+	}
+	var builder strings.Builder
+	builder.WriteString(xml.Header)
+	builder.WriteString(fmt.Sprintf(`<QueryResultRecords xmlns="%s" href="%s" name="%s" page="1" pageSize="%d" total="%d">`,
+		types.XMLNamespaceVCloud, xmlEscape(href), queryType, len(records)+1, len(records)))
+	for _, record := range records {
+		keys := make([]string, 0, len(record))
+		for key := range record {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		builder.WriteString("<" + recordName)
+		for _, key := range keys {
+			builder.WriteString(fmt.Sprintf(` %s="%s"`, key, xmlEscape(record[key])))
+		}
+		builder.WriteString("/>")
+	}
+	builder.WriteString("</QueryResultRecords>")
+	return builder.String()
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+// OpenAPI
+// -------------------------------------------------------------------------------------------------------------------
+
+// serveOpenApi handles documents, collections and items of the OpenAPI store.
+// p is the path relative to /cloudapi/ (e.g. "1.0.0/edgeGateways/urn:vcloud:gateway:...")
+func (m *mockVcd) serveOpenApi(w http.ResponseWriter, r *http.Request, p string) bool {
+	p = strings.TrimSuffix(p, "/")
+	if match := reMockOpenApiMetadata.FindStringSubmatch(p); match != nil {
+		return m.serveOpenApiMetadata(w, r, match[1], match[2])
+	}
+	if doc, found := m.openApiDocs[p]; found {
+		switch r.Method {
+		case http.MethodGet:
+			m.writeJson(w, http.StatusOK, doc)
+		case http.MethodPut:
+			newDoc := make(map[string]interface{})
+			if !m.readJson(w, r, &newDoc) {
+				return true
+			}
+			assignMockRuleIds(newDoc)
+			m.openApiDocs[p] = newDoc
+			m.writeTask(w, "openApiUpdate", nil)
+		case http.MethodDelete:
+			// Deleting a rule container removes the rules that users can manage
+			newDoc := toMockJsonMap(doc)
+			if _, isRuleContainer := newDoc["userDefinedRules"]; isRuleContainer {
+				newDoc["userDefinedRules"] = []interface{}{}
+			}
+			m.openApiDocs[p] = newDoc
+			m.writeTask(w, "openApiDelete", nil)
+		default:
+			return false
+		}
+		return true
+	}
+
+	if items, found := m.openApi[p]; found {
+		switch r.Method {
+		case http.MethodGet:
+			m.writeOpenApiPage(w, filterMockOpenApiItems(items, r.URL.Query().Get("filter")))
+		case http.MethodPost:
+			item := make(map[string]interface{})
+			if !m.readJson(w, r, &item) {
+				return true
+			}
+			item["id"] = mockOpenApiUrn(p, mockUuid())
+			m.openApi[p] = append(m.openApi[p], item)
+			m.writeTask(w, "openApiCreate", &types.Reference{ID: item["id"].(string), Name: fmt.Sprint(item["name"])})
+		default:
+			return false
+		}
+		return true
+	}
+
+	collection, id := p[:strings.LastIndex(p, "/")+1], p[strings.LastIndex(p, "/")+1:]
+	collection = strings.TrimSuffix(collection, "/")
+	// Summaries (e.g. of firewall groups) are served as the full items of the collection
+	if items, found := m.openApi[collection]; found && id == "summaries" && r.Method == http.MethodGet {
+		m.writeOpenApiPage(w, filterMockOpenApiItems(items, r.URL.Query().Get("filter")))
+		return true
+	}
+	if doc, found := m.openApiDocs[collection]; found && r.Method == http.MethodDelete {
+		return m.deleteMockRule(w, r, collection, toMockJsonMap(doc), id)
+	}
+	items, found := m.openApi[collection]
+	if !found {
+		return false
+	}
+	for i, item := range items {
+		if item["id"] != id {
+			continue
+		}
+		switch r.Method {
+		case http.MethodGet:
+			m.writeJson(w, http.StatusOK, item)
+		case http.MethodPut:
+			newItem := make(map[string]interface{})
+			if !m.readJson(w, r, &newItem) {
+				return true
+			}
+			newItem["id"] = id
+			items[i] = newItem
+			m.writeTask(w, "openApiUpdate", &types.Reference{ID: id})
+		case http.MethodDelete:
+			m.openApi[collection] = append(items[:i], items[i+1:]...)
+			for docPath := range m.openApiDocs {
+				if strings.HasPrefix(docPath, p+"/") {
+					delete(m.openApiDocs, docPath)
+				}
+			}
+			m.writeTask(w, "openApiDelete", nil)
+		default:
+			return false
+		}
+		return true
+	}
+	m.writeError(w, r, http.StatusNotFound, fmt.Sprintf("[ mock ] %s: %s", govcd.ErrorEntityNotFound, id))
+	return true
+}
+
+var reMockOpenApiMetadata = regexp.MustCompile(`^(.+/urn:vcloud:[^/]+)/metadata(?:/([^/]+))?$`)
+
+// serveOpenApiMetadata handles the OpenAPI metadata of any entity. Entries are identified by their ID, and updates
+// require the ETag of the entry as the real VCD does
+func (m *mockVcd) serveOpenApiMetadata(w http.ResponseWriter, r *http.Request, entityPath, entryId string) bool {
+	entries := m.openApiMetadata[entityPath]
+	if entryId == "" {
+		switch r.Method {
+		case http.MethodGet:
+			var items []map[string]interface{}
+			key, hasKeyFilter := strings.CutPrefix(r.URL.Query().Get("filter"), "keyValue.key==")
+			for _, entry := range entries {
+				if !hasKeyFilter || entry.KeyValue.Key == key {
+					items = append(items, toMockJsonMap(entry))
+				}
+			}
+			m.writeOpenApiPage(w, items)
+		case http.MethodPost:
+			entry := &types.OpenApiMetadataEntry{}
+			if !m.readJson(w, r, entry) {
+				return true
+			}
+			for _, existing := range entries {
+				if existing.KeyValue.Key == entry.KeyValue.Key && existing.KeyValue.Namespace == entry.KeyValue.Namespace &&
+					existing.KeyValue.Domain == entry.KeyValue.Domain {
+					m.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("duplicate metadata key %s", entry.KeyValue.Key))
+					return true
+				}
+			}
+			entry.ID = "urn:vcloud:metadata:" + mockUuid()
+			m.openApiMetadata[entityPath] = append(entries, entry)
+			w.Header().Set("Etag", mockOpenApiMetadataEtag(entry))
+			m.writeJson(w, http.StatusCreated, entry)
+		default:
+			return false
+		}
+		return true
+	}
+
+	for i, entry := range entries {
+		if entry.ID != entryId {
+			continue
+		}
+		switch r.Method {
+		case http.MethodGet:
+			w.Header().Set("Etag", mockOpenApiMetadataEtag(entry))
+			m.writeJson(w, http.StatusOK, entry)
+		case http.MethodPut:
+			if r.Header.Get("If-Match") != mockOpenApiMetadataEtag(entry) {
+				m.writeError(w, r, http.StatusPreconditionFailed, "the ETag of the metadata entry does not match")
+				return true
+			}
+			newEntry := &types.OpenApiMetadataEntry{}
+			if !m.readJson(w, r, newEntry) {
+				return true
+			}
+			entry.KeyValue.Value = newEntry.KeyValue.Value
+			entry.IsPersistent = newEntry.IsPersistent
+			w.Header().Set("Etag", mockOpenApiMetadataEtag(entry))
+			m.writeJson(w, http.StatusOK, entry)
+		case http.MethodDelete:
+			m.openApiMetadata[entityPath] = append(entries[:i], entries[i+1:]...)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			return false
+		}
+		return true
+	}
+	m.writeError(w, r, http.StatusNotFound, fmt.Sprintf("[ mock ] %s: %s", govcd.ErrorEntityNotFound, entryId))
+	return true
+}
+
+// mockOpenApiMetadataEtag returns an ETag that changes with the contents of the metadata entry
+func mockOpenApiMetadataEtag(entry *types.OpenApiMetadataEntry) string {
+	contents, _ := json.Marshal(entry)
+	return fmt.Sprintf("%x", sha256.Sum256(contents))
+}
+
+// writeOpenApiPage returns all items in a single page
+func (m *mockVcd) writeOpenApiPage(w http.ResponseWriter, items []map[string]interface{}) {
+	if items == nil {
+		items = []map[string]interface{}{}
+	}
+	m.writeJson(w, http.StatusOK, map[string]interface{}{
+		"resultTotal": len(items),
+		"pageCount":   1,
+		"page":        1,
+		"pageSize":    len(items) + 1,
+		"values":      items,
+	})
+}
+
+// filterMockOpenApiItems applies an OpenAPI (FIQL) filter containing only "==" conditions joined by ";".
+// Field names can be dotted paths, such as "ownerRef.id"
+func filterMockOpenApiItems(items []map[string]interface{}, filter string) []map[string]interface{} {
+	if filter == "" {
+		return items
+	}
+	var result []map[string]interface{}
+	for _, item := range items {
+		matches := true
+		for _, condition := range strings.Split(strings.Trim(filter, "()"), ";") {
+			key, value, found := strings.Cut(strings.Trim(condition, "()"), "==")
+			if !found {
+				continue
+			}
+			actual := fmt.Sprint(mockJsonPath(item, key))
+			// '_context' matches the owner of an entity, such as the Edge Gateway of an IP Set or the VDC of a
+			// tenant Application Port Profile
+			if key == "_context" {
+				actual = fmt.Sprint(mockJsonPath(item, "ownerRef.id"))
+				if actual != value {
+					actual = fmt.Sprint(mockJsonPath(item, "contextEntityId"))
+				}
+			}
+			if actual != value {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// mockJsonPath returns the value of a dotted path inside a JSON object, or nil if it does not exist
+func mockJsonPath(item map[string]interface{}, path string) interface{} {
+	var current interface{} = item
+	for _, field := range strings.Split(path, ".") {
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = currentMap[field]
+	}
+	return current
+}
+
+// assignMockRuleIds gives an ID to every rule without one in a rule container document
+func assignMockRuleIds(doc map[string]interface{}) {
+	for _, value := range doc {
+		rules, ok := value.([]interface{})
+		if !ok {
+			continue
+		}
+		for _, rule := range rules {
+			ruleMap, ok := rule.(map[string]interface{})
+			if ok && (ruleMap["id"] == nil || ruleMap["id"] == "") {
+				ruleMap["id"] = mockUuid()
+			}
+		}
+	}
+}
+
+// deleteMockRule removes a single user defined rule from a rule container document
+func (m *mockVcd) deleteMockRule(w http.ResponseWriter, r *http.Request, docPath string, doc map[string]interface{}, id string) bool {
+	rules, _ := doc["userDefinedRules"].([]interface{})
+	for i, rule := range rules {
+		ruleMap, ok := rule.(map[string]interface{})
+		if ok && ruleMap["id"] == id {
+			doc["userDefinedRules"] = append(rules[:i], rules[i+1:]...)
+			m.openApiDocs[docPath] = doc
+			m.writeTask(w, "openApiDelete", nil)
+			return true
+		}
+	}
+	m.writeError(w, r, http.StatusNotFound, fmt.Sprintf("[ mock ] %s: %s", govcd.ErrorEntityNotFound, id))
+	return true
+}
+
+// mockOpenApiUrn builds a URN for a new OpenAPI entity, using the last element of the collection path as entity type
+func mockOpenApiUrn(collection, uuid string) string {
+	entityTypes := map[string]string{
+		"edgeGateways":   "gateway",
+		"orgVdcNetworks": "network",
+	}
+	name := collection[strings.LastIndex(collection, "/")+1:]
+	entityType, found := entityTypes[name]
+	if !found {
+		entityType = strings.ToLower(strings.TrimSuffix(name, "s"))
+	}
+	return fmt.Sprintf("urn:vcloud:%s:%s", entityType, uuid)
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+// Renderers
+// -------------------------------------------------------------------------------------------------------------------
+
+func (m *mockVcd) renderOrg(org *mockVcdOrgFix) *types.Org {
+	result := &types.Org{
+		HREF:        m.href("/org/" + org.ID),
+		Type:        types.MimeOrg,
+		ID:          "urn:vcloud:org:" + org.ID,
+		Name:        org.Name,
+		FullName:    org.FullName,
+		Description: org.Description,
+		IsEnabled:   true,
+	}
+	for _, vdc := range org.Vdcs {
+		result.Link = append(result.Link, &types.Link{Rel: "down", Type: types.MimeVDC, Name: vdc.Name, HREF: m.href("/vdc/" + vdc.ID)})
+	}
+	for _, catalog := range org.Catalogs {
+		result.Link = append(result.Link, &types.Link{Rel: "down", Type: types.MimeCatalog, Name: catalog.Name, HREF: m.href("/catalog/" + catalog.ID)})
+	}
+	return result
+}
+
+func (m *mockVcd) renderAdminOrg(org *mockVcdOrgFix) *types.AdminOrg {
+	result := &types.AdminOrg{
+		Xmlns:       types.XMLNamespaceVCloud,
+		HREF:        m.href("/admin/org/" + org.ID),
+		Type:        types.MimeAdminOrg,
+		ID:          "urn:vcloud:org:" + org.ID,
+		Name:        org.Name,
+		FullName:    org.FullName,
+		Description: org.Description,
+		IsEnabled:   true,
+		Link: types.LinkList{
+			{Rel: "add", Type: types.MimeAdminCatalog, HREF: m.href("/admin/org/" + org.ID + "/catalogs")},
+		},
+		Catalogs: &types.CatalogsList{},
+		Vdcs:     &types.VDCList{},
+		Users:    &types.OrgUserList{},
+		Groups:   &types.OrgGroupList{},
+		Networks: &types.NetworksList{},
+		OrgSettings: &types.OrgSettings{
+			OrgGeneralSettings: &types.OrgGeneralSettings{
+				CanPublishCatalogs:       false,
+				DeployedVMQuota:          0,
+				StoredVMQuota:            0,
+				DelayAfterPowerOnSeconds: 0,
+			},
+			OrgVAppLeaseSettings:    &types.VAppLeaseSettings{DeploymentLeaseSeconds: addrOf(0), StorageLeaseSeconds: addrOf(0), DeleteOnStorageLeaseExpiration: addrOf(false), PowerOffOnRuntimeLeaseExpiration: addrOf(false)},
+			OrgVAppTemplateSettings: &types.VAppTemplateLeaseSettings{StorageLeaseSeconds: addrOf(0), DeleteOnStorageLeaseExpiration: addrOf(false)},
+			OrgLdapSettings:         &types.OrgLdapSettingsType{OrgLdapMode: "NONE"},
+		},
+	}
+	for _, vdc := range org.Vdcs {
+		result.Vdcs.Vdcs = append(result.Vdcs.Vdcs, &types.Reference{Type: types.MimeVDC, Name: vdc.Name, HREF: m.href("/vdc/" + vdc.ID)})
+	}
+	for _, catalog := range org.Catalogs {
+		result.Catalogs.Catalog = append(result.Catalogs.Catalog, &types.Reference{Type: types.MimeAdminCatalog, Name: catalog.Name, HREF: m.href("/admin/catalog/" + catalog.ID)})
+	}
+	return result
+}
+
+func (m *mockVcd) renderVdc(vdc *mockVcdVdcFix) *types.Vdc {
+	result := &types.Vdc{
+		HREF:            m.href("/vdc/" + vdc.ID),
+		Type:            types.MimeVDC,
+		ID:              "urn:vcloud:vdc:" + vdc.ID,
+		Name:            vdc.Name,
+		Description:     vdc.Description,
+		Status:          1,
+		AllocationModel: "Flex",
+		IsEnabled:       true,
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeOrg, HREF: m.href("/org/" + vdc.org.ID)},
+		},
+		ComputeCapacity: []*types.ComputeCapacity{{
+			CPU:    &types.CapacityWithUsage{Units: "MHz"},
+			Memory: &types.CapacityWithUsage{Units: "MB"},
+		}},
+		ResourceEntities:     []*types.ResourceEntities{{}},
+		VdcStorageProfiles:   &types.VdcStorageProfiles{},
+		DefaultComputePolicy: &types.Reference{ID: vdc.defaultPolicyId, Name: "System Default"},
+	}
+	for _, vapp := range vdc.VApps {
+		result.ResourceEntities[0].ResourceEntity = append(result.ResourceEntities[0].ResourceEntity, &types.ResourceReference{
+			HREF: m.href("/vApp/vapp-" + vapp.ID), Type: types.MimeVApp, Name: vapp.Name,
+		})
+	}
+	if vdc.IsNsxt {
+		result.Link = append(result.Link, &types.Link{Rel: "down", Type: "application/json", Name: "nsxt", HREF: m.server.URL + "/cloudapi/1.0.0/vdcs/urn:vcloud:vdc:" + vdc.ID})
+	}
+	return result
+}
+
+func (m *mockVcd) renderAdminVdc(vdc *mockVcdVdcFix) *types.AdminVdc {
+	result := &types.AdminVdc{
+		Xmlns: types.XMLNamespaceVCloud,
+		Vdc:   *m.renderVdc(vdc),
+	}
+	result.HREF = m.href("/admin/vdc/" + vdc.ID)
+	result.Type = types.MimeAdminVDC
+	result.Link = append(result.Link, &types.Link{Rel: "up", Type: types.MimeAdminOrg, HREF: m.href("/admin/org/" + vdc.org.ID)})
+	result.IsThinProvision = addrOf(true)
+	result.UsesFastProvisioning = addrOf(false)
+	result.IsElastic = addrOf(false)
+	result.IncludeMemoryOverhead = addrOf(true)
+	result.ProviderVdcReference = &types.Reference{Name: "nsxtPvdc", HREF: m.href("/admin/providervdc/" + vdc.ID)}
+	return result
+}
+
+func (m *mockVcd) renderCatalog(catalog *mockVcdCatalogFix) *types.Catalog {
+	return &types.Catalog{
+		HREF:        m.href("/catalog/" + catalog.ID),
+		Type:        types.MimeCatalog,
+		ID:          "urn:vcloud:catalog:" + catalog.ID,
+		Name:        catalog.Name,
+		Description: catalog.Description,
+		DateCreated: catalog.created.Format(time.RFC3339),
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeOrg, HREF: m.href("/org/" + catalog.org.ID)},
+		},
+		CatalogItems: []*types.CatalogItems{{}},
+	}
+}
+
+func (m *mockVcd) renderAdminCatalog(catalog *mockVcdCatalogFix) *types.AdminCatalog {
+	result := &types.AdminCatalog{
+		Xmlns:   types.XMLNamespaceVCloud,
+		Catalog: *m.renderCatalog(catalog),
+	}
+	result.HREF = m.href("/admin/catalog/" + catalog.ID)
+	result.Type = types.MimeAdminCatalog
+	result.Link = types.LinkList{
+		{Rel: "up", Type: types.MimeAdminOrg, HREF: m.href("/admin/org/" + catalog.org.ID)},
+		{Rel: "edit", Type: types.MimeAdminCatalog, HREF: result.HREF},
+		{Rel: "remove", HREF: result.HREF},
+	}
+	result.PublishExternalCatalogParams = &types.PublishExternalCatalogParams{IsPublishedExternally: addrOf(false)}
+	result.CatalogStorageProfiles = &types.CatalogStorageProfiles{}
+	return result
+}
+
+func (m *mockVcd) renderVApp(vapp *mockVcdVAppFix) *types.VApp {
+	href := m.href("/vApp/vapp-" + vapp.ID)
+	result := &types.VApp{
+		HREF:        href,
+		Type:        types.MimeVApp,
+		ID:          "urn:vcloud:vapp:" + vapp.ID,
+		Name:        vapp.Name,
+		Description: vapp.Description,
+		Status:      mockPowerStatusCode(vapp.PoweredOn),
+		Deployed:    vapp.PoweredOn,
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeVDC, HREF: m.href("/vdc/" + vapp.vdc.ID)},
+			{Rel: "remove", HREF: href},
+			{Rel: "power:powerOn", HREF: href + "/power/action/powerOn"},
+			{Rel: "power:powerOff", HREF: href + "/power/action/powerOff"},
+			{Rel: "deploy", Type: types.MimeDeployVappParams, HREF: href + "/action/deploy"},
+			{Rel: "undeploy", Type: types.MimeUndeployVappParams, HREF: href + "/action/undeploy"},
+		},
+		LeaseSettingsSection: &types.LeaseSettingsSection{HREF: href + "/leaseSettingsSection/", Type: types.MimeLeaseSettingSection},
+		NetworkConfigSection: &types.NetworkConfigSection{Info: "The configuration parameters for logical networks"},
+		Owner:                &types.Owner{User: &types.Reference{Name: m.fixtures.User}},
+		Children:             &types.VAppChildren{},
+	}
+	for _, vm := range vapp.Vms {
+		result.Children.VM = append(result.Children.VM, m.renderVm(vm))
+	}
+	return result
+}
+
+func (m *mockVcd) renderVm(vm *mockVcdVmFix) *types.Vm {
+	href := m.href("/vApp/vm-" + vm.ID)
+	networkConnectionSection := &types.NetworkConnectionSection{
+		HREF: href + "/networkConnectionSection/",
+		Info: "Specifies the available VM network connections",
+	}
+	if vm.nicIp != "" {
+		networkConnectionSection.NetworkConnection = []*types.NetworkConnection{
+			{Network: "none", NetworkConnectionIndex: 0, IPAddress: vm.nicIp, IsConnected: true, IPAddressAllocationMode: "DHCP"},
+		}
+	}
+	return &types.Vm{
+		HREF:        href,
+		Type:        types.MimeVM,
+		ID:          "urn:vcloud:vm:" + vm.ID,
+		Name:        vm.Name,
+		Description: vm.Description,
+		Status:      mockPowerStatusCode(vm.vapp.PoweredOn),
+		Deployed:    vm.vapp.PoweredOn,
+		VAppParent:  &types.Reference{HREF: m.href("/vApp/vapp-" + vm.vapp.ID), Name: vm.vapp.Name},
+		Link: types.LinkList{
+			{Rel: "up", Type: types.MimeVApp, HREF: m.href("/vApp/vapp-" + vm.vapp.ID)},
+		},
+		NetworkConnectionSection: networkConnectionSection,
+		GuestCustomizationSection: &types.GuestCustomizationSection{
+			HREF:         href + "/guestCustomizationSection/",
+			Enabled:      addrOf(false),
+			ComputerName: vm.Name,
+		},
+		VmSpecSection: &types.VmSpecSection{
+			Modified:          addrOf(false),
+			Info:              "Virtual Machine specification",
+			OsType:            vm.OsType,
+			NumCpus:           addrOf(vm.Cpus),
+			NumCoresPerSocket: addrOf(1),
+			MemoryResourceMb:  &types.MemoryResourceMb{Configured: vm.MemoryMB},
+			DiskSection:       &types.DiskSection{},
+			HardwareVersion:   &types.HardwareVersion{Value: "vmx-19"},
+			VirtualCpuType:    "VM32",
+		},
+		VMCapabilities: &types.VmCapabilities{HREF: href + "/vmCapabilities/"},
+		VirtualHardwareSection: &types.VirtualHardwareSection{
+			Info: "Virtual hardware requirements",
+			HREF: href + "/virtualHardwareSection/",
+		},
+		StorageProfile: &types.Reference{Name: "*"},
+	}
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+// Lookup helpers
+// -------------------------------------------------------------------------------------------------------------------
+
+func (m *mockVcd) findOrg(id string) *mockVcdOrgFix {
+	for _, org := range m.fixtures.Orgs {
+		if org.ID == id {
+			return org
+		}
+	}
+	return nil
+}
+
+func (m *mockVcd) findVdc(id string) *mockVcdVdcFix {
+	for _, org := range m.fixtures.Orgs {
+		for _, vdc := range org.Vdcs {
+			if vdc.ID == id {
+				return vdc
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockVcd) findCatalog(id string) *mockVcdCatalogFix {
+	for _, org := range m.fixtures.Orgs {
+		for _, catalog := range org.Catalogs {
+			if catalog.ID == id {
+				return catalog
+			}
+		}
+	}
+	return nil
+}
+
+func (m *mockVcd) allVApps() []*mockVcdVAppFix {
+	var result []*mockVcdVAppFix
+	for _, org := range m.fixtures.Orgs {
+		for _, vdc := range org.Vdcs {
+			result = append(result, vdc.VApps...)
+		}
+	}
+	return result
+}
+
+func (m *mockVcd) findVApp(id string) *mockVcdVAppFix {
+	for _, vapp := range m.allVApps() {
+		if vapp.ID == id {
+			return vapp
+		}
+	}
+	return nil
+}
+
+func (m *mockVcd) findVm(id string) *mockVcdVmFix {
+	for _, vapp := range m.allVApps() {
+		for _, vm := range vapp.Vms {
+			if vm.ID == id {
+				return vm
+			}
+		}
+	}
+	return nil
+}
+
+// -------------------------------------------------------------------------------------------------------------------
+// Writers and readers
+// -------------------------------------------------------------------------------------------------------------------
+
+func (m *mockVcd) href(path string) string {
+	return m.server.URL + "/api" + path
+}
+
+// writeTask creates a completed task and returns it, with its HREF in the Location header.
+// The owner, when given, is used by go-vcloud-director to retrieve newly created OpenAPI entities
+func (m *mockVcd) writeTask(w http.ResponseWriter, operation string, owner *types.Reference) {
+	id := mockUuid()
+	now := time.Now().Format(time.RFC3339)
+	task := &types.Task{
+		HREF:          m.href("/task/" + id),
+		Type:          types.MimeTask,
+		ID:            "urn:vcloud:task:" + id,
+		Name:          "task",
+		Status:        "success",
+		OperationName: operation,
+		StartTime:     now,
+		EndTime:       now,
+		Progress:      100,
+		Owner:         owner,
+	}
+	if m.holdTasks {
+		task.Status = "running"
+		task.EndTime = ""
+		task.Progress = 0
+	}
+	m.tasks[id] = task
+	w.Header().Set("Location", task.HREF)
+	m.writeXml(w, http.StatusAccepted, task)
+}
+
+func (m *mockVcd) writeXml(w http.ResponseWriter, status int, value interface{}) {
+	contents, err := xml.Marshal(value)
+	if err != nil {
+		m.writeRaw(w, http.StatusInternalServerError, "text/plain", err.Error())
+		return
+	}
+	m.writeRaw(w, status, "application/*+xml", xml.Header+string(contents))
+}
+
+func (m *mockVcd) writeJson(w http.ResponseWriter, status int, value interface{}) {
+	contents, err := json.Marshal(value)
+	if err != nil {
+		m.writeRaw(w, http.StatusInternalServerError, "text/plain", err.Error())
+		return
+	}
+	m.writeRaw(w, status, types.JSONMime, string(contents))
+}
+
+func (m *mockVcd) writeRaw(w http.ResponseWriter, status int, contentType, body string) {
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	_, _ = io.WriteString(w, body)
+}
+
+// writeError returns an error in the format expected by the API family of the request
+func (m *mockVcd) writeError(w http.ResponseWriter, r *http.Request, status int, message string) {
+	if strings.HasPrefix(r.URL.Path, "/cloudapi/") {
+		m.writeJson(w, status, types.OpenApiError{MinorErrorCode: http.StatusText(status), Message: message})
+		return
+	}
+	m.writeXml(w, status, types.Error{Message: message, MajorErrorCode: status, MinorErrorCode: http.StatusText(status)})
+}
+
+func (m *mockVcd) readXml(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	contents, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = xml.Unmarshal(contents, value)
+	}
+	if err != nil {
+		m.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid XML body: %s", err))
+		return false
+	}
+	return true
+}
+
+func (m *mockVcd) readJson(w http.ResponseWriter, r *http.Request, value interface{}) bool {
+	contents, err := io.ReadAll(r.Body)
+	if err == nil {
+		err = json.Unmarshal(contents, value)
+	}
+	if err != nil {
+		m.writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid JSON body: %s", err))
+		return false
+	}
+	return true
+}
+
+// toMockJsonMap converts a typed OpenAPI structure into the generic form used by the OpenAPI store
+func toMockJsonMap(value interface{}) map[string]interface{} {
+	contents, err := json.Marshal(value)
+	if err != nil {
+		panic(fmt.Sprintf("error encoding mock entity: %s", err))
+	}
+	result := make(map[string]interface{})
+	err = json.Unmarshal(contents, &result)
+	if err != nil {
+		panic(fmt.Sprintf("error decoding mock entity: %s", err))
+	}
+	return result
+}
+
+func xmlEscape(text string) string {
+	var builder strings.Builder
+	_ = xml.EscapeText(&builder, []byte(text))
+	return builder.String()
+}
+
+func mockPowerStatusCode(poweredOn bool) int {
+	if poweredOn {
+		return 4 // POWERED_ON in types.VAppStatuses
+	}
+	return 8 // POWERED_OFF in types.VAppStatuses
+}
+
+func mockPowerStatus(poweredOn bool) string {
+	if poweredOn {
+		return "POWERED_ON"
+	}
+	return "POWERED_OFF"
+}
+
+// mockUuid returns a random UUID (version 4)
+func mockUuid() string {
+	b := make([]byte, 16)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(fmt.Sprintf("error generating random UUID: %s", err))
+	}
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func setMockUuid(id *string) {
+	if *id == "" {
+		*id = mockUuid()
+	}
+}
+
+func mockToken() string {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(fmt.Sprintf("error generating token: %s", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
@@ -0,0 +1,225 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mockVcdTestClient starts a mock VCD seeded with the default fixtures and returns a provider client
+// connected to it as System administrator, with "tf_org" and "tf_vdc" as default Org and VDC
+func mockVcdTestClient(t *testing.T) (*mockVcd, *VCDClient) {
+	mock := newMockVcd(defaultMockVcdFixtures())
+	t.Cleanup(func() {
+		for _, request := range mock.Unhandled() {
+			t.Logf("request not handled by mock VCD: %s", request)
+		}
+		mock.Close()
+	})
+
+	config := Config{
+		User:            mock.fixtures.User,
+		Password:        mock.fixtures.Password,
+		SysOrg:          mock.fixtures.SysOrg,
+		Org:             "tf_org",
+		Vdc:             "tf_vdc",
+		Href:            mock.URL(),
+		MaxRetryTimeout: 5,
+		InsecureFlag:    true,
+	}
+	vcdClient, err := config.Client()
+	if err != nil {
+		t.Fatalf("error connecting to mock VCD: %s", err)
+	}
+	return mock, vcdClient
+}
+
+// readMockDataSource runs the read function of a data source against the mock VCD
+func readMockDataSource(t *testing.T, vcdClient *VCDClient, name string, raw map[string]interface{}) *schema.ResourceData {
+	dataSource, ok := Provider().DataSourcesMap[name]
+	if !ok {
+		t.Fatalf("data source %s not found", name)
+	}
+	d := schema.TestResourceDataRaw(t, dataSource.Schema, raw)
+	diags := dataSource.ReadContext(context.Background(), d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading data source %s: %v", name, diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("data source %s did not set an ID", name)
+	}
+	return d
+}
+
+func TestMockVcdConnection(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+
+	version, err := vcdClient.Client.GetVcdShortVersion()
+	if err != nil {
+		t.Fatalf("error retrieving VCD version: %s", err)
+	}
+	if version != "10.5.1" {
+		t.Fatalf("expected VCD version 10.5.1, got %s", version)
+	}
+
+	// Wrong credentials must be rejected
+	config := Config{
+		User:         mock.fixtures.User,
+		Password:     "wrong-password",
+		SysOrg:       mock.fixtures.SysOrg,
+		Href:         mock.URL(),
+		InsecureFlag: true,
+	}
+	_, err = config.Client()
+	if err == nil {
+		t.Fatalf("expected error when connecting with wrong password")
+	}
+}
+
+func TestMockVcdDataSources(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+
+	org := readMockDataSource(t, vcdClient, "vcloud_org", map[string]interface{}{"name": "tf_org"})
+	if org.Get("full_name").(string) != "Terraform test Org" {
+		t.Errorf("unexpected Org full name: %s", org.Get("full_name"))
+	}
+
+	vdc := readMockDataSource(t, vcdClient, "vcloud_org_vdc", map[string]interface{}{"name": "tf_vdc"})
+	if vdc.Get("allocation_model").(string) != "Flex" {
+		t.Errorf("unexpected VDC allocation model: %s", vdc.Get("allocation_model"))
+	}
+
+	catalog := readMockDataSource(t, vcdClient, "vcloud_catalog", map[string]interface{}{"name": "tf_catalog"})
+	if catalog.Get("description").(string) != "Catalog seeded by the mock VCD" {
+		t.Errorf("unexpected Catalog description: %s", catalog.Get("description"))
+	}
+
+	vapp := readMockDataSource(t, vcdClient, "vcloud_vapp", map[string]interface{}{"name": "tf_vapp"})
+	if vapp.Get("status_text").(string) != "POWERED_ON" {
+		t.Errorf("unexpected vApp status: %s", vapp.Get("status_text"))
+	}
+
+	vm := readMockDataSource(t, vcdClient, "vcloud_vapp_vm", map[string]interface{}{"vapp_name": "tf_vapp", "name": "tf_vm1"})
+	if vm.Get("cpus").(int) != 2 || vm.Get("memory").(int) != 2048 {
+		t.Errorf("unexpected VM sizing: %d CPUs, %d MB", vm.Get("cpus"), vm.Get("memory"))
+	}
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	if edge.Get("owner_id").(string) != vdc.Id() {
+		t.Errorf("expected Edge Gateway owner %s, got %s", vdc.Id(), edge.Get("owner_id"))
+	}
+
+	firewall := readMockDataSource(t, vcdClient, "vcloud_nsxt_firewall", map[string]interface{}{"edge_gateway_id": edge.Id()})
+	if firewall.Get("rule.#").(int) != 1 || firewall.Get("rule.0.name").(string) != "allow-outbound" {
+		t.Errorf("unexpected firewall rules: %v", firewall.Get("rule"))
+	}
+}
+
+func TestMockVcdCatalogLifecycle(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+	resource := Provider().ResourcesMap["vcloud_catalog"]
+
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"name":        "mock_catalog",
+		"description": "created in the mock VCD",
+	})
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating catalog: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatalf("catalog ID not set after creation")
+	}
+
+	diags = resource.ReadContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading catalog: %v", diags)
+	}
+	if d.Get("description").(string) != "created in the mock VCD" {
+		t.Errorf("unexpected catalog description: %s", d.Get("description"))
+	}
+
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting catalog: %v", diags)
+	}
+
+	// After deletion, a read must remove the resource from state
+	diags = resource.ReadContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading deleted catalog: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected empty ID for deleted catalog, got %s", d.Id())
+	}
+}
+
+func TestMockVcdNsxtFirewallLifecycle(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+
+	resource := Provider().ResourcesMap["vcloud_nsxt_firewall"]
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"edge_gateway_id": edge.Id(),
+		"rule": []interface{}{
+			map[string]interface{}{
+				"name":        "allow-ssh",
+				"direction":   "IN",
+				"ip_protocol": "IPV4",
+				"action":      "ALLOW",
+				"enabled":     true,
+			},
+			map[string]interface{}{
+				"name":        "drop-all",
+				"direction":   "IN_OUT",
+				"ip_protocol": "IPV4_IPV6",
+				"action":      "DROP",
+				"enabled":     true,
+			},
+		},
+	})
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating firewall rules: %v", diags)
+	}
+	if d.Id() != edge.Id() {
+		t.Fatalf("expected firewall ID %s, got %s", edge.Id(), d.Id())
+	}
+	if d.Get("rule.#").(int) != 2 || d.Get("rule.1.name").(string) != "drop-all" {
+		t.Errorf("unexpected firewall rules after creation: %v", d.Get("rule"))
+	}
+	if d.Get("rule.0.id").(string) == "" {
+		t.Errorf("expected computed ID for firewall rule")
+	}
+
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting firewall rules: %v", diags)
+	}
+}
+
+// TestMockVcdFixturesFile checks that the sample fixtures file describes the same hierarchy as the default fixtures
+func TestMockVcdFixturesFile(t *testing.T) {
+	fixtures, err := loadMockVcdFixtures(getCurrentDir() + "/../test-resources/mock_vcd_fixtures.json")
+	if err != nil {
+		t.Fatalf("%s", err)
+	}
+	fromFile, err := json.Marshal(fixtures)
+	if err != nil {
+		t.Fatalf("error encoding fixtures from file: %s", err)
+	}
+	fromCode, err := json.Marshal(defaultMockVcdFixtures())
+	if err != nil {
+		t.Fatalf("error encoding default fixtures: %s", err)
+	}
+	if string(fromFile) != string(fromCode) {
+		t.Errorf("fixtures file differs from defaultMockVcdFixtures:\n%s\n%s", fromFile, fromCode)
+	}
+}
@@ -144,13 +144,17 @@ func natRuleDelete(natType string) schema.DeleteFunc {
 // natRuleImporter returns a schema.StateFunc for both SNAT and DNAT rules
 func natRuleImport(natType string) schema.StateFunc {
 	return func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		vcdClient := meta.(*VCDClient)
+		resourceURI, err := importEdgeGatewayPath(vcdClient, d.Id())
+		if err != nil {
+			return nil, err
+		}
 		if len(resourceURI) != 4 {
-			return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.rule-id")
+			return nil, fmt.Errorf("resource name must be specified in such way org.vdc.edge-gw.rule-id " +
+				"or as edge-gw-ID.rule-id")
 		}
 		orgName, vdcName, edgeName, natRuleId := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-		vcdClient := meta.(*VCDClient)
 		edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 		if err != nil {
 			return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
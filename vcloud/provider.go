@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 
@@ -157,6 +158,16 @@ var globalDataSourceMap = map[string]*schema.Resource{
 	"vcloud_version":                                      datasourceVcdVersion(),                                 // 3.12
 	"vcloud_solution_landing_zone":                        datasourceVcdSolutionLandingZone(),                     // 3.13
 	"vcloud_org_oidc":                                     datasourceVcdOrgOidc(),                                 // 3.13
+	"vcloud_vm_snapshot":                                  datasourceVcdVmSnapshot(),                              // 3.14
+	"vcloud_drift_report":                                 datasourceVcdDriftReport(),                             // 3.14
+	"vcloud_nsxv_migration_plan":                          datasourceVcdNsxvMigrationPlan(),                       // 3.14
+	"vcloud_nsxt_edgegateway_config":                      datasourceVcdNsxtEdgeGatewayConfig(),                   // 3.14
+	"vcloud_openapi_object":                               datasourceVcdOpenApiObject(),                           // 3.14
+	"vcloud_query":                                        datasourceVcdQuery(),                                   // 3.14
+	"vcloud_vms":                                          datasourceVcdVms(),                                     // 3.14
+	"vcloud_vapps":                                        datasourceVcdVApps(),                                   // 3.14
+	"vcloud_org_vdc_networks":                             datasourceVcdOrgVdcNetworks(),                          // 3.14
+	"vcloud_nsxt_edgegateways":                            datasourceVcdNsxtEdgeGateways(),                        // 3.14
 }
 
 var globalResourceMap = map[string]*schema.Resource{
@@ -269,6 +280,13 @@ var globalResourceMap = map[string]*schema.Resource{
 	"vcloud_cse_kubernetes_cluster":                       resourceVcdCseKubernetesCluster(),                    // 3.12
 	"vcloud_solution_landing_zone":                        resourceVcdSolutionLandingZone(),                     // 3.13
 	"vcloud_org_oidc":                                     resourceVcdOrgOidc(),                                 // 3.13
+	"vcloud_vm_snapshot":                                  resourceVcdVmSnapshot(),                              // 3.14
+	"vcloud_nsxt_firewall_rule":                           resourceVcdNsxtFirewallRule(),                        // 3.14
+	"vcloud_nsxt_edgegateway_config":                      resourceVcdNsxtEdgeGatewayConfig(),                   // 3.14
+	"vcloud_nsxt_alb_virtual_service_http_req_rules":      resourceVcdAlbVirtualServiceHttpReqRules(),           // 3.14
+	"vcloud_nsxt_alb_virtual_service_http_resp_rules":     resourceVcdAlbVirtualServiceHttpRespRules(),          // 3.14
+	"vcloud_nsxt_alb_virtual_service_http_sec_rules":      resourceVcdAlbVirtualServiceHttpSecRules(),           // 3.14
+	"vcloud_openapi_object":                               resourceVcdOpenApiObject(),                           // 3.14
 }
 
 // Provider returns a terraform.ResourceProvider.
@@ -401,6 +419,70 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("VCLOUD_API_LOGGING_FILE", "go-vcloud-director.log"),
 				Description: "Defines the full name of the logging file for API calls (requires 'logging')",
 			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCLOUD_PROXY_URL", ""),
+				Description: "URL of the HTTP(S) proxy used to reach VCD. If empty, the proxy is taken from the HTTPS_PROXY and HTTP_PROXY environment variables",
+			},
+			"no_proxy": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("VCLOUD_NO_PROXY", ""),
+				Description: "Comma separated list of hosts, domains, and networks that are reached without proxy. It replaces the NO_PROXY environment variable",
+			},
+			"ca_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCLOUD_CA_FILE", ""),
+				ConflictsWith: []string{"ca_pem"},
+				Description:   "File containing PEM encoded certificate authorities, added to the system ones to verify the VCD certificate",
+			},
+			"ca_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCLOUD_CA_PEM", ""),
+				ConflictsWith: []string{"ca_file"},
+				Description:   "PEM encoded certificate authorities, added to the system ones to verify the VCD certificate",
+			},
+			"client_cert_file": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCLOUD_CLIENT_CERT_FILE", ""),
+				ConflictsWith: []string{"client_cert_pem"},
+				RequiredWith:  []string{"client_key_file"},
+				Description:   "File containing the PEM encoded certificate for TLS client authentication",
+			},
+			"client_key_file": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCLOUD_CLIENT_KEY_FILE", ""),
+				RequiredWith: []string{"client_cert_file"},
+				Description:  "File containing the PEM encoded private key of 'client_cert_file'",
+			},
+			"client_cert_pem": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				DefaultFunc:   schema.EnvDefaultFunc("VCLOUD_CLIENT_CERT_PEM", ""),
+				ConflictsWith: []string{"client_cert_file"},
+				RequiredWith:  []string{"client_key_pem"},
+				Description:   "PEM encoded certificate for TLS client authentication",
+			},
+			"client_key_pem": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCLOUD_CLIENT_KEY_PEM", ""),
+				RequiredWith: []string{"client_cert_pem"},
+				Description:  "PEM encoded private key of 'client_cert_pem'",
+			},
+			"connection_cache_ttl": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("VCLOUD_CONNECTION_CACHE_TTL", 20),
+				ValidateFunc: validation.IntAtLeast(1),
+				Description:  "How long, in minutes, a cached connection is reused, when the connection cache is enabled (defaults to 20)",
+			},
 			"import_separator": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -408,9 +490,12 @@ func Provider() *schema.Provider {
 				Description: "Defines the import separation string to be used with 'terraform import'",
 			},
 			"ignore_metadata_changes": ignoreMetadataSchema(),
+			"default_metadata":        defaultMetadataSchema(),
+			"retry":                   retrySchema(),
+			"api_logging":             apiLogSchema(),
 		},
-		ResourcesMap:         globalResourceMap,
-		DataSourcesMap:       globalDataSourceMap,
+		ResourcesMap:         withResourceOperations(withRetryPolicy(globalResourceMap)),
+		DataSourcesMap:       withDataSourceOperations(globalDataSourceMap),
 		ConfigureContextFunc: providerConfigure,
 	}
 }
@@ -444,6 +529,15 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		Href:                    d.Get("url").(string),
 		MaxRetryTimeout:         maxRetryTimeout,
 		InsecureFlag:            d.Get("allow_unverified_ssl").(bool),
+		ProxyUrl:                d.Get("proxy_url").(string),
+		NoProxy:                 d.Get("no_proxy").(string),
+		CaFile:                  d.Get("ca_file").(string),
+		CaPem:                   d.Get("ca_pem").(string),
+		ClientCertFile:          d.Get("client_cert_file").(string),
+		ClientKeyFile:           d.Get("client_key_file").(string),
+		ClientCertPem:           d.Get("client_cert_pem").(string),
+		ClientKeyPem:            d.Get("client_key_pem").(string),
+		ConnectionCacheTtl:      time.Duration(d.Get("connection_cache_ttl").(int)) * time.Minute,
 	}
 
 	// auth_type dependent configuration
@@ -532,6 +626,18 @@ func providerConfigure(_ context.Context, d *schema.ResourceData) (interface{},
 		IgnoreMetadataChangesConflictActions[im.IgnoredMetadata.String()] = ignoredMetadata[i].ConflictAction
 	}
 
+	config.DefaultMetadata, err = getDefaultMetadata(d, "default_metadata")
+	if err != nil {
+		return nil, diag.Errorf("[provider validation] invalid 'default_metadata' block: %s", err)
+	}
+
+	config.RetryPolicy, err = getRetryPolicy(d)
+	if err != nil {
+		return nil, diag.Errorf("[provider validation] invalid 'retry' block: %s", err)
+	}
+
+	config.ApiLog = getApiLogConfig(d)
+
 	vcdClient, err := config.Client()
 	if err != nil {
 		return nil, diag.FromErr(err)
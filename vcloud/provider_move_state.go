@@ -0,0 +1,203 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceStateMover translates the state of a resource into the state of another resource type, to support
+// Terraform `moved` blocks between resource types (Terraform 1.8+). The state is the JSON map of the source attributes,
+// and is changed in place. Attributes that don't exist in the target resource are removed afterwards, and the values
+// that can't be translated are filled by the refresh that follows the move
+type resourceStateMover func(meta interface{}, state map[string]interface{}) error
+
+// resourceStateMovers lists, for each target resource type, the source resource types that can be moved into it
+var resourceStateMovers = map[string]map[string]resourceStateMover{
+	"vcloud_network_isolated_v2": {"vcloud_network_isolated": moveNetworkState},
+	"vcloud_network_routed_v2":   {"vcloud_network_routed": moveNetworkState},
+	"vcloud_nsxt_edgegateway":    {"vcloud_edgegateway": moveEdgeGatewayState},
+	"vcloud_vm":                  {"vcloud_vapp_vm": moveVappVmState},
+}
+
+// ProviderServer returns the gRPC server of the provider. It is the server of the plugin SDK, with the addition of
+// the MoveResourceState RPC for the resource types listed in resourceStateMovers.
+// The plugin framework implements moves with ResourceWithMoveState, but terraform-plugin-mux sends
+// MoveResourceState to the server of the target resource type: the targets, which are plugin SDK resources, would
+// have to be rewritten with the framework first
+func ProviderServer() tfprotov5.ProviderServer {
+	return newMoveStateProviderServer(Provider())
+}
+
+func newMoveStateProviderServer(provider *schema.Provider) *moveStateProviderServer {
+	return &moveStateProviderServer{
+		GRPCProviderServer: schema.NewGRPCProviderServer(provider),
+		provider:           provider,
+	}
+}
+
+type moveStateProviderServer struct {
+	*schema.GRPCProviderServer
+	provider *schema.Provider
+}
+
+// GetMetadata advertises the MoveResourceState capability
+func (s *moveStateProviderServer) GetMetadata(ctx context.Context, req *tfprotov5.GetMetadataRequest) (*tfprotov5.GetMetadataResponse, error) {
+	resp, err := s.GRPCProviderServer.GetMetadata(ctx, req)
+	if resp != nil {
+		resp.ServerCapabilities = withMoveResourceState(resp.ServerCapabilities)
+	}
+	return resp, err
+}
+
+// GetProviderSchema advertises the MoveResourceState capability
+func (s *moveStateProviderServer) GetProviderSchema(ctx context.Context, req *tfprotov5.GetProviderSchemaRequest) (*tfprotov5.GetProviderSchemaResponse, error) {
+	resp, err := s.GRPCProviderServer.GetProviderSchema(ctx, req)
+	if resp != nil {
+		resp.ServerCapabilities = withMoveResourceState(resp.ServerCapabilities)
+	}
+	return resp, err
+}
+
+func withMoveResourceState(capabilities *tfprotov5.ServerCapabilities) *tfprotov5.ServerCapabilities {
+	if capabilities == nil {
+		capabilities = &tfprotov5.ServerCapabilities{}
+	}
+	capabilities.MoveResourceState = true
+	return capabilities
+}
+
+// MoveResourceState translates the state of a source resource with resourceStateMovers, and converts it to the
+// schema of the target resource in the same way as an upgrade of the target state. Moves that are not listed are
+// handled (and refused) by the plugin SDK
+func (s *moveStateProviderServer) MoveResourceState(ctx context.Context, req *tfprotov5.MoveResourceStateRequest) (*tfprotov5.MoveResourceStateResponse, error) {
+	if req == nil || req.SourceState == nil {
+		return s.GRPCProviderServer.MoveResourceState(ctx, req)
+	}
+	mover, found := resourceStateMovers[req.TargetTypeName][req.SourceTypeName]
+	if !found {
+		return s.GRPCProviderServer.MoveResourceState(ctx, req)
+	}
+
+	movedState, err := moveResourceStateJson(s.provider.Meta(), mover, req.SourceState.JSON)
+	if err != nil {
+		return &tfprotov5.MoveResourceStateResponse{
+			Diagnostics: []*tfprotov5.Diagnostic{{
+				Severity: tfprotov5.DiagnosticSeverityError,
+				Summary:  "Unable to Move Resource State",
+				Detail:   fmt.Sprintf("error moving %s to %s: %s", req.SourceTypeName, req.TargetTypeName, err),
+			}},
+		}, nil
+	}
+
+	upgraded, err := s.GRPCProviderServer.UpgradeResourceState(ctx, &tfprotov5.UpgradeResourceStateRequest{
+		TypeName: req.TargetTypeName,
+		Version:  int64(s.provider.ResourcesMap[req.TargetTypeName].SchemaVersion),
+		RawState: &tfprotov5.RawState{JSON: movedState},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &tfprotov5.MoveResourceStateResponse{
+		TargetState: upgraded.UpgradedState,
+		Diagnostics: upgraded.Diagnostics,
+	}, nil
+}
+
+// moveResourceStateJson applies a resourceStateMover to the JSON state of a resource
+func moveResourceStateJson(meta interface{}, mover resourceStateMover, sourceState []byte) ([]byte, error) {
+	state := make(map[string]interface{})
+	err := json.Unmarshal(sourceState, &state)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding source state: %s", err)
+	}
+	err = mover(meta, state)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(state)
+}
+
+// moveNetworkState translates the state of vcloud_network_isolated and vcloud_network_routed into the state of
+// their _v2 replacements. Both use the network URN as ID. The edge gateway of a routed network and the owner of the
+// network are set by the refresh, while DHCP pools are not managed by the _v2 resources and stay as they are in VCD
+func moveNetworkState(_ interface{}, state map[string]interface{}) error {
+	if netmask, ok := state["netmask"].(string); ok && netmask != "" {
+		prefixLength, err := netmaskToPrefixLength(netmask)
+		if err != nil {
+			return err
+		}
+		state["prefix_length"] = prefixLength
+	}
+	if shared, ok := state["shared"]; ok {
+		state["is_shared"] = shared
+	}
+	return nil
+}
+
+// moveEdgeGatewayState translates the state of vcloud_edgegateway into the state of vcloud_nsxt_edgegateway.
+// vcloud_edgegateway only manages NSX-V Edge Gateways, which get a new ID when they are migrated to NSX-T: the NSX-T
+// Edge Gateway is looked up by name in the Org, as it is usually in a new VDC or in a VDC Group after the migration.
+// The moved state is the one that the read of vcloud_nsxt_edgegateway sets, as the external networks and subnets of
+// the migrated Edge Gateway don't need to match the ones of the NSX-V Edge Gateway
+func moveEdgeGatewayState(meta interface{}, state map[string]interface{}) error {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return fmt.Errorf("the provider is not configured: it is needed to find the NSX-T Edge Gateway")
+	}
+	orgName, _ := state["org"].(string)
+	name, _ := state["name"].(string)
+	org, err := vcdClient.GetOrg(orgName)
+	if err != nil {
+		return err
+	}
+	edge, err := org.GetNsxtEdgeGatewayByName(name)
+	if err != nil {
+		return fmt.Errorf("no single NSX-T Edge Gateway '%s' found in Org %s, it can be imported into "+
+			"vcloud_nsxt_edgegateway instead: %s", name, org.Org.Name, err)
+	}
+
+	resource := resourceVcdNsxtEdgeGateway()
+	d := resource.Data(nil)
+	d.SetId(edge.EdgeGateway.ID)
+	dSet(d, "org", orgName)
+	err = setNsxtEdgeGatewayData(vcdClient, edge, d)
+	if err != nil {
+		return fmt.Errorf("error reading NSX-T Edge Gateway '%s': %s", name, err)
+	}
+
+	stateType := resource.CoreConfigSchema().ImpliedType()
+	stateValue, err := d.State().AttrsAsObjectValue(stateType)
+	if err != nil {
+		return err
+	}
+	movedState, err := ctyjson.Marshal(stateValue, stateType)
+	if err != nil {
+		return err
+	}
+	clear(state)
+	return json.Unmarshal(movedState, &state)
+}
+
+// moveVappVmState translates the state of vcloud_vapp_vm into the state of vcloud_vm, which share the same schema
+func moveVappVmState(_ interface{}, _ map[string]interface{}) error {
+	return nil
+}
+
+// netmaskToPrefixLength converts an IPv4 netmask, such as 255.255.255.0, into its prefix length
+func netmaskToPrefixLength(netmask string) (int, error) {
+	ip := net.ParseIP(netmask).To4()
+	if ip == nil {
+		return 0, fmt.Errorf("invalid netmask '%s'", netmask)
+	}
+	prefixLength, bits := net.IPMask(ip).Size()
+	if bits == 0 {
+		return 0, fmt.Errorf("netmask '%s' is not canonical", netmask)
+	}
+	return prefixLength, nil
+}
@@ -0,0 +1,310 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/go-cty/cty/msgpack"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+)
+
+func Test_netmaskToPrefixLength(t *testing.T) {
+	tests := []struct {
+		netmask string
+		want    int
+		wantErr bool
+	}{
+		{"255.255.255.0", 24, false},
+		{"255.255.255.240", 28, false},
+		{"255.255.0.0", 16, false},
+		{"255.0.255.0", 0, true},
+		{"not-a-netmask", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.netmask, func(t *testing.T) {
+			got, err := netmaskToPrefixLength(tt.netmask)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("netmaskToPrefixLength() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("netmaskToPrefixLength() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestProviderServerMoveResourceState checks that the state of a deprecated resource is translated into the state of
+// its replacement, and that moves between other resource types are refused
+func TestProviderServerMoveResourceState(t *testing.T) {
+	ctx := context.Background()
+	server := ProviderServer()
+
+	metadata, err := server.GetMetadata(ctx, &tfprotov5.GetMetadataRequest{})
+	if err != nil || metadata.ServerCapabilities == nil || !metadata.ServerCapabilities.MoveResourceState {
+		t.Fatalf("expected MoveResourceState capability, got %+v (%v)", metadata, err)
+	}
+
+	sourceState, err := json.Marshal(map[string]interface{}{
+		"id":          "urn:vcloud:network:7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d",
+		"name":        "net-isolated",
+		"org":         "my-org",
+		"vdc":         "my-vdc",
+		"gateway":     "192.168.2.1",
+		"netmask":     "255.255.255.0",
+		"dns1":        "8.8.8.8",
+		"shared":      true,
+		"href":        "https://vcd.example.com/api/network/7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d",
+		"dhcp_pool":   []interface{}{map[string]interface{}{"start_address": "192.168.2.2", "end_address": "192.168.2.50"}},
+		"description": "",
+		"static_ip_pool": []interface{}{
+			map[string]interface{}{"start_address": "192.168.2.100", "end_address": "192.168.2.120"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_network_isolated",
+		TargetTypeName: "vcloud_network_isolated_v2",
+		SourceState:    &tfprotov5.RawState{JSON: sourceState},
+	})
+	if err != nil {
+		t.Fatalf("error moving state: %s", err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+	}
+
+	targetType := Provider().ResourcesMap["vcloud_network_isolated_v2"].CoreConfigSchema().ImpliedType()
+	target, err := msgpack.Unmarshal(resp.TargetState.MsgPack, targetType)
+	if err != nil {
+		t.Fatalf("error decoding target state: %s", err)
+	}
+	if id := target.GetAttr("id").AsString(); id != "urn:vcloud:network:7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d" {
+		t.Errorf("unexpected ID %s", id)
+	}
+	if !target.GetAttr("prefix_length").Equals(cty.NumberIntVal(24)).True() {
+		t.Errorf("expected prefix_length 24, got %#v", target.GetAttr("prefix_length"))
+	}
+	if !target.GetAttr("is_shared").True() {
+		t.Errorf("expected is_shared true")
+	}
+	if target.GetAttr("static_ip_pool").LengthInt() != 1 || target.GetAttr("dns1").AsString() != "8.8.8.8" {
+		t.Errorf("unexpected static IP pool or DNS: %#v", target)
+	}
+
+	resp, err = server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_vapp_vm",
+		TargetTypeName: "vcloud_vm",
+		SourceState: &tfprotov5.RawState{JSON: []byte(`{"id":"urn:vcloud:vm:26c04f4d-2185-4a33-8ef9-019768d29003",` +
+			`"name":"web","vapp_name":"web-vapp","memory":1024,"cpus":2}`)},
+	})
+	if err != nil || len(resp.Diagnostics) > 0 {
+		t.Fatalf("error moving VM state: %v %v", err, resp.Diagnostics)
+	}
+	vmType := Provider().ResourcesMap["vcloud_vm"].CoreConfigSchema().ImpliedType()
+	vm, err := msgpack.Unmarshal(resp.TargetState.MsgPack, vmType)
+	if err != nil {
+		t.Fatalf("error decoding VM state: %s", err)
+	}
+	if vm.GetAttr("vapp_name").AsString() != "web-vapp" || !vm.GetAttr("memory").Equals(cty.NumberIntVal(1024)).True() {
+		t.Errorf("unexpected VM state: %#v", vm)
+	}
+
+	resp, err = server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_catalog",
+		TargetTypeName: "vcloud_vm",
+		SourceState:    &tfprotov5.RawState{JSON: []byte(`{"id":"urn:vcloud:catalog:7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d"}`)},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error for an unsupported move: %s", err)
+	}
+	if len(resp.Diagnostics) == 0 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError {
+		t.Errorf("expected an error diagnostic for an unsupported move")
+	}
+}
+
+// TestProviderServerMoveRoutedNetworkState checks the translation of vcloud_network_routed into
+// vcloud_network_routed_v2
+func TestProviderServerMoveRoutedNetworkState(t *testing.T) {
+	sourceState, err := json.Marshal(map[string]interface{}{
+		"id":             "urn:vcloud:network:0d4f3b8e-6a2c-4e51-9b7d-3c2a1f0e9d8c",
+		"name":           "net-routed",
+		"org":            "my-org",
+		"vdc":            "my-vdc",
+		"edge_gateway":   "my-edge",
+		"interface_type": "distributed",
+		"gateway":        "10.10.102.1",
+		"netmask":        "255.255.255.240",
+		"dns1":           "8.8.8.8",
+		"dns_suffix":     "example.com",
+		"shared":         false,
+		"href":           "https://vcd.example.com/api/network/0d4f3b8e-6a2c-4e51-9b7d-3c2a1f0e9d8c",
+		"dhcp_pool":      []interface{}{map[string]interface{}{"start_address": "10.10.102.2", "end_address": "10.10.102.5"}},
+		"static_ip_pool": []interface{}{
+			map[string]interface{}{"start_address": "10.10.102.6", "end_address": "10.10.102.10"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := ProviderServer().MoveResourceState(context.Background(), &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_network_routed",
+		TargetTypeName: "vcloud_network_routed_v2",
+		SourceState:    &tfprotov5.RawState{JSON: sourceState},
+	})
+	if err != nil {
+		t.Fatalf("error moving state: %s", err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+	}
+
+	targetType := Provider().ResourcesMap["vcloud_network_routed_v2"].CoreConfigSchema().ImpliedType()
+	target, err := msgpack.Unmarshal(resp.TargetState.MsgPack, targetType)
+	if err != nil {
+		t.Fatalf("error decoding target state: %s", err)
+	}
+	if id := target.GetAttr("id").AsString(); id != "urn:vcloud:network:0d4f3b8e-6a2c-4e51-9b7d-3c2a1f0e9d8c" {
+		t.Errorf("unexpected ID %s", id)
+	}
+	if !target.GetAttr("prefix_length").Equals(cty.NumberIntVal(28)).True() {
+		t.Errorf("expected prefix_length 28, got %#v", target.GetAttr("prefix_length"))
+	}
+	for attribute, expected := range map[string]string{
+		"name":           "net-routed",
+		"gateway":        "10.10.102.1",
+		"interface_type": "distributed",
+		"dns1":           "8.8.8.8",
+		"dns_suffix":     "example.com",
+	} {
+		if got := target.GetAttr(attribute); got.IsNull() || got.AsString() != expected {
+			t.Errorf("expected %s '%s', got %#v", attribute, expected, got)
+		}
+	}
+	pools := target.GetAttr("static_ip_pool").AsValueSlice()
+	if len(pools) != 1 || pools[0].GetAttr("start_address").AsString() != "10.10.102.6" ||
+		pools[0].GetAttr("end_address").AsString() != "10.10.102.10" {
+		t.Errorf("unexpected static IP pool: %#v", pools)
+	}
+	// The edge gateway ID and the owner are set by the refresh
+	if !target.GetAttr("edge_gateway_id").IsNull() {
+		t.Errorf("expected no edge_gateway_id before the refresh, got %#v", target.GetAttr("edge_gateway_id"))
+	}
+}
+
+// TestMockVcdMoveEdgeGatewayState checks the translation of vcloud_edgegateway into vcloud_nsxt_edgegateway: the
+// NSX-V Edge Gateway is replaced by the NSX-T Edge Gateway with the same name, which has another ID
+func TestMockVcdMoveEdgeGatewayState(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+
+	sourceState := func(name string) []byte {
+		state, err := json.Marshal(map[string]interface{}{
+			"id":                              "urn:vcloud:gateway:11111111-1111-1111-1111-111111111111",
+			"name":                            name,
+			"org":                             "tf_org",
+			"vdc":                             "tf_vdc_nsxv",
+			"description":                     "main edge",
+			"configuration":                   "compact",
+			"distributed_routing":             false,
+			"use_default_route_for_dns_relay": false,
+			"external_network": []interface{}{map[string]interface{}{
+				"name":                "nsxv_external_network",
+				"enable_rate_limit":   false,
+				"incoming_rate_limit": 0,
+				"outgoing_rate_limit": 0,
+				"subnet": []interface{}{map[string]interface{}{
+					"gateway":               "10.10.0.1",
+					"netmask":               "255.255.0.0",
+					"ip_address":            "10.10.0.10",
+					"use_for_default_route": true,
+				}},
+			}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return state
+	}
+
+	// The NSX-T Edge Gateway can only be found with a configured provider
+	resp, err := ProviderServer().MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_edgegateway",
+		TargetTypeName: "vcloud_nsxt_edgegateway",
+		SourceState:    &tfprotov5.RawState{JSON: sourceState("tf_edge")},
+	})
+	if err != nil || len(resp.Diagnostics) == 0 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError {
+		t.Fatalf("expected an error diagnostic without a configured provider, got %v (%v)", resp, err)
+	}
+
+	provider := Provider()
+	provider.SetMeta(vcdClient)
+	server := newMoveStateProviderServer(provider)
+	resp, err = server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_edgegateway",
+		TargetTypeName: "vcloud_nsxt_edgegateway",
+		SourceState:    &tfprotov5.RawState{JSON: sourceState("tf_edge")},
+	})
+	if err != nil {
+		t.Fatalf("error moving state: %s", err)
+	}
+	if len(resp.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %s: %s", resp.Diagnostics[0].Summary, resp.Diagnostics[0].Detail)
+	}
+	targetType := provider.ResourcesMap["vcloud_nsxt_edgegateway"].CoreConfigSchema().ImpliedType()
+	target, err := msgpack.Unmarshal(resp.TargetState.MsgPack, targetType)
+	if err != nil {
+		t.Fatalf("error decoding target state: %s", err)
+	}
+
+	// The values are the ones that the read of vcloud_nsxt_edgegateway sets, so that a configuration matching the
+	// NSX-T Edge Gateway gives an empty plan
+	if id := target.GetAttr("id").AsString(); id != edge.Id() {
+		t.Errorf("expected the ID of the NSX-T Edge Gateway %s, got %s", edge.Id(), id)
+	}
+	if org := target.GetAttr("org"); org.IsNull() || org.AsString() != "tf_org" {
+		t.Errorf("expected the Org of the source state, got %#v", org)
+	}
+	for _, name := range []string{"owner_id", "vdc", "external_network_id", "primary_ip"} {
+		expected := edge.Get(name).(string)
+		if got := target.GetAttr(name); got.IsNull() || got.AsString() != expected {
+			t.Errorf("expected %s '%s', got %#v", name, expected, got)
+		}
+	}
+	subnets := target.GetAttr("subnet").AsValueSlice()
+	if len(subnets) != 1 {
+		t.Fatalf("expected 1 subnet, got %#v", subnets)
+	}
+	subnet := subnets[0]
+	if subnet.GetAttr("gateway").AsString() != "192.168.100.1" || !subnet.GetAttr("prefix_length").Equals(cty.NumberIntVal(24)).True() ||
+		subnet.GetAttr("primary_ip").AsString() != "192.168.100.10" {
+		t.Errorf("unexpected subnet: %#v", subnet)
+	}
+	allocatedIps := subnet.GetAttr("allocated_ips").AsValueSlice()
+	if len(allocatedIps) != 1 || allocatedIps[0].GetAttr("start_address").AsString() != "192.168.100.10" ||
+		allocatedIps[0].GetAttr("end_address").AsString() != "192.168.100.10" {
+		t.Errorf("unexpected allocated IPs: %#v", allocatedIps)
+	}
+	if externalNetworks := target.GetAttr("external_network"); !externalNetworks.IsNull() && externalNetworks.LengthInt() != 0 {
+		t.Errorf("expected no NSX-T Segment backed external networks, got %#v", externalNetworks)
+	}
+	if target.GetAttr("name").AsString() != "tf_edge" {
+		t.Errorf("unexpected name: %#v", target.GetAttr("name"))
+	}
+
+	// NSX-V Edge Gateways that were not migrated can't be moved
+	resp, err = server.MoveResourceState(ctx, &tfprotov5.MoveResourceStateRequest{
+		SourceTypeName: "vcloud_edgegateway",
+		TargetTypeName: "vcloud_nsxt_edgegateway",
+		SourceState:    &tfprotov5.RawState{JSON: sourceState("other_edge")},
+	})
+	if err != nil || len(resp.Diagnostics) == 0 || resp.Diagnostics[0].Severity != tfprotov5.DiagnosticSeverityError {
+		t.Errorf("expected an error diagnostic for an Edge Gateway that was not migrated, got %v (%v)", resp, err)
+	}
+}
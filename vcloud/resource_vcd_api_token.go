@@ -114,14 +114,23 @@ func resourceVcdApiTokenDelete(ctx context.Context, d *schema.ResourceData, meta
 func resourceVcdApiTokenImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] API token import initiated")
 
+	vcdClient := meta.(*VCDClient)
+	if tokenId := importWholeEntityId("urn:vcloud:token:", d.Id()); tokenId != "" {
+		token, err := vcdClient.GetTokenById(tokenId)
+		if err != nil {
+			return []*schema.ResourceData{}, fmt.Errorf("error getting token by ID: %s", err)
+		}
+		d.SetId(token.Token.ID)
+		dSet(d, "name", token.Token.Name)
+		return []*schema.ResourceData{d}, nil
+	}
+
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 1 {
-		return nil, fmt.Errorf("resource name must be specified as token-name")
+		return nil, fmt.Errorf("resource name must be specified as token-name or as token ID")
 	}
 	tokenName := resourceURI[0]
 
-	vcdClient := meta.(*VCDClient)
-
 	sessionInfo, err := vcdClient.Client.GetSessionInfo()
 	if err != nil {
 		return []*schema.ResourceData{}, fmt.Errorf("error getting username: %s", err)
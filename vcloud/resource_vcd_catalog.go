@@ -24,6 +24,7 @@ func resourceVcdCatalog() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcdCatalogCreate,
 		DeleteContext: resourceVcdCatalogDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_catalog", "metadata"),
 		ReadContext:   resourceVcdCatalogRead,
 		UpdateContext: resourceVcdCatalogUpdate,
 		Importer: &schema.ResourceImporter{
@@ -445,18 +446,28 @@ func resourceVcdCatalogDelete(_ context.Context, d *schema.ResourceData, meta in
 
 // resourceVcdCatalogImport imports a Catalog into Terraform state
 // This function task is to get the data from vCD and fill the resource data container
-// Expects the d.ID() to be a path to the resource made of org_name.catalog_name
+// Expects the d.ID() to be a path to the resource made of org_name.catalog_name, or the catalog ID
 //
 // Example import path (id): org_name.catalog_name
+// Example import path (id): urn:vcloud:catalog:2ba39e8a-0c2e-4a8e-9a4f-5e2b1d7f3c6a
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog")
-	}
-	orgName, catalogName := resourceURI[0], resourceURI[1]
-
 	vcdClient := meta.(*VCDClient)
+
+	var orgName, catalogName string
+	if catalogId := importEntityId("urn:vcloud:catalog:", d.Id()); catalogId != "" {
+		record, err := queryImportRecord(vcdClient, types.QtCatalog, "/catalog/", catalogId)
+		if err != nil {
+			return nil, fmt.Errorf("[catalog import] %s", err)
+		}
+		orgName, catalogName = record["orgName"], record["name"]
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 2 {
+			return nil, fmt.Errorf("resource name must be specified as org.catalog or as catalog ID")
+		}
+		orgName, catalogName = resourceURI[0], resourceURI[1]
+	}
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[catalog import] "+errorRetrievingOrg, orgName)
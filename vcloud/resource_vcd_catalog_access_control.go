@@ -9,7 +9,6 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
-	"strings"
 	"time"
 )
 
@@ -296,13 +295,17 @@ func resourceVcdCatalogAccessControlDelete(_ context.Context, d *schema.Resource
 }
 
 func resourceVcdCatalogAccessControlImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importCatalogPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalogID or org.catalogName")
+		return nil, fmt.Errorf("resource name must be specified as org.catalogID or org.catalogName " +
+			"or as catalogID")
 	}
 
 	orgName, catalogIdentifier := resourceURI[0], resourceURI[1]
-	vcdClient := meta.(*VCDClient)
 
 	org, err := vcdClient.GetOrg(orgName)
 	if err != nil {
@@ -6,7 +6,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"log"
-	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
@@ -16,11 +16,15 @@ func resourceVcdCatalogItem() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcdCatalogItemCreate,
 		DeleteContext: resourceVcdCatalogItemDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_catalog_item", "catalog_item_metadata"),
 		ReadContext:   resourceVcdCatalogItemRead,
 		UpdateContext: resourceVcdCatalogItemUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogItemImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -110,9 +114,9 @@ func resourceVcdCatalogItemCreate(ctx context.Context, d *schema.ResourceData, m
 	var diagError diag.Diagnostics
 	itemName := d.Get("name").(string)
 	if d.Get("ova_path").(string) != "" {
-		diagError = uploadOvaFromFilePath(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadOvaFromFilePath(ctx, d, catalog, itemName, "vcd_catalog_item")
 	} else if d.Get("ovf_url").(string) != "" {
-		diagError = uploadFromUrl(d, catalog, itemName, "vcd_catalog_item")
+		diagError = uploadFromUrl(ctx, d, catalog, itemName, "vcd_catalog_item")
 	} else {
 		return diag.Errorf("`ova_path` or `ovf_url` value is missing %s", err)
 	}
@@ -312,11 +316,29 @@ func createOrUpdateCatalogItemMetadata(d *schema.ResourceData, meta interface{})
 // Example import path (id): org_name.catalog_name.catalog_item_name
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogItemImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog.catalog_item")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, catalogName, catalogItemName string
+	if id := importWholeEntityId("urn:vcloud:catalogitem:", d.Id()); id != "" {
+		record, err := queryImportRecord(vcdClient, types.QtCatalogItem, "/catalogItem/", id)
+		if err != nil {
+			return nil, fmt.Errorf("import: %s", err)
+		}
+		orgName, catalogName, err = importCatalogNames(vcdClient, record["catalog"])
+		if err != nil {
+			return nil, fmt.Errorf("import: error retrieving catalog of catalog item '%s': %s", id, err)
+		}
+		catalogItemName = record["name"]
+	} else {
+		resourceURI, err := importCatalogPath(vcdClient, d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("import: %s", err)
+		}
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("resource name must be specified as org.catalog.catalog_item, as catalog-ID.catalog_item or as catalog item ID")
+		}
+		orgName, catalogName, catalogItemName = resourceURI[0], resourceURI[1], resourceURI[2]
 	}
-	orgName, catalogName, catalogItemName := resourceURI[0], resourceURI[1], resourceURI[2]
 
 	if orgName == "" {
 		return nil, fmt.Errorf("import: empty org name provided")
@@ -328,8 +350,6 @@ func resourceVcdCatalogItemImport(_ context.Context, d *schema.ResourceData, met
 		return nil, fmt.Errorf("import: empty catalog item name provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
-
 	catalog, err := vcdClient.Client.GetCatalogByName(orgName, catalogName)
 	if err != nil {
 		return nil, govcd.ErrorEntityNotFound
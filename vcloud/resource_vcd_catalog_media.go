@@ -6,24 +6,28 @@ import (
 	"log"
 	"os"
 	"path"
-	"strings"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 func resourceVcdCatalogMedia() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcdMediaCreate,
 		DeleteContext: resourceVcdMediaDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_catalog_media", "metadata"),
 		ReadContext:   resourceVcdMediaRead,
 		UpdateContext: resourceVcdMediaUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogMediaImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"org": {
@@ -211,7 +215,7 @@ func resourceVcdMediaCreate(ctx context.Context, d *schema.ResourceData, meta in
 		}
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, *task.Task)
 	if err != nil {
 		return diag.Errorf("error waiting for task to complete: %+v", err)
 	}
@@ -397,11 +401,29 @@ func createOrUpdateMediaItemMetadata(d *schema.ResourceData, meta interface{}) e
 // Example resource name (_resource_name_): vcd_catalog_media.my-media
 // Example import path (_the_id_string_): org.catalog.my-media-name
 func resourceVcdCatalogMediaImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog.my-media-name")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, catalogName, mediaName string
+	if id := importWholeEntityId("urn:vcloud:media:", d.Id()); id != "" {
+		record, err := queryImportRecord(vcdClient, types.QtMedia, "/media/", id)
+		if err != nil {
+			return nil, fmt.Errorf("import: %s", err)
+		}
+		orgName, catalogName, err = importCatalogNames(vcdClient, record["catalog"])
+		if err != nil {
+			return nil, fmt.Errorf("import: error retrieving catalog of media '%s': %s", id, err)
+		}
+		mediaName = record["name"]
+	} else {
+		resourceURI, err := importCatalogPath(vcdClient, d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("import: %s", err)
+		}
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("resource name must be specified as org.catalog.my-media-name, as catalog-ID.my-media-name or as media ID")
+		}
+		orgName, catalogName, mediaName = resourceURI[0], resourceURI[1], resourceURI[2]
 	}
-	orgName, catalogName, mediaName := resourceURI[0], resourceURI[1], resourceURI[2]
 
 	if orgName == "" {
 		return nil, fmt.Errorf("import: empty org name provided")
@@ -413,8 +435,6 @@ func resourceVcdCatalogMediaImport(_ context.Context, d *schema.ResourceData, me
 		return nil, fmt.Errorf("import: empty media item name provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
-
 	catalog, err := vcdClient.Client.GetCatalogByName(orgName, catalogName)
 	if err != nil {
 		return nil, govcd.ErrorEntityNotFound
@@ -22,9 +22,13 @@ func resourceVcdCatalogVappTemplate() *schema.Resource {
 		ReadContext:   resourceVcdCatalogVappTemplateRead,
 		UpdateContext: resourceVcdCatalogVappTemplateUpdate,
 		DeleteContext: resourceVcdCatalogVappTemplateDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_catalog_vapp_template", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCatalogVappTemplateImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -182,9 +186,9 @@ func resourceVcdCatalogVappTemplateCreate(ctx context.Context, d *schema.Resourc
 
 	switch {
 	case ovaPath != "":
-		diagError = uploadOvaFromFilePath(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadOvaFromFilePath(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case ovfUrl != "":
-		diagError = uploadFromUrl(d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
+		diagError = uploadFromUrl(ctx, d, catalog, vappTemplateName, "vcd_catalog_vapp_template")
 	case len(capturevAppTemplate) == 1:
 		templateCaptureSettings := capturevAppTemplate[0].(map[string]interface{})
 		sourceId := templateCaptureSettings["source_id"].(string)
@@ -471,9 +475,27 @@ func vappTemplateLeaseUpdate(vcdClient *VCDClient, vAppTemplate *govcd.VAppTempl
 // Example import path (id): myOrg1.myVdc2.myvAppTemplate3
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdCatalogVappTemplateImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	// The path can start with the ID of the Catalog or of the VDC that holds the vApp Template
+	resourceURI, err := importPath(d.Id(), "urn:vcloud:catalog:", func(id string) ([]string, error) {
+		var orgName, catalogOrVdcName string
+		var err error
+		if strings.Contains(id, "urn:vcloud:vdc:") {
+			orgName, catalogOrVdcName, err = importVdcNames(vcdClient, id)
+		} else {
+			orgName, catalogOrVdcName, err = importCatalogNames(vcdClient, id)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Catalog or VDC '%s': %s", id, err)
+		}
+		return []string{orgName, catalogOrVdcName}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog_name.vapp_template_name")
+		return nil, fmt.Errorf("resource name must be specified as org.catalog_name.vapp_template_name " +
+			"or as catalog-or-vdc-ID.vapp_template_name")
 	}
 	orgName, catalogOrVdcName, vAppTemplateName := resourceURI[0], resourceURI[1], resourceURI[2]
 
@@ -487,8 +509,6 @@ func resourceVcdCatalogVappTemplateImport(_ context.Context, d *schema.ResourceD
 		return nil, fmt.Errorf("import: empty vApp Template name provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
-
 	catalog, err := vcdClient.Client.GetCatalogByName(orgName, catalogOrVdcName)
 	var vdc *govcd.Vdc
 	if err != nil {
@@ -639,7 +659,7 @@ func checkSynchronisedVappTemplate(vcdClient *VCDClient, vAppTemplate *govcd.VAp
 }
 
 // uploadOvaFromFilePath uploads an OVA file specified in the resource to the given catalog
-func uploadOvaFromFilePath(d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
+func uploadOvaFromFilePath(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, vappTemplate, resourceName string) diag.Diagnostics {
 	uploadPieceSize := d.Get("upload_piece_size").(int)
 	task, err := catalog.UploadOvf(d.Get("ova_path").(string), vappTemplate, d.Get("description").(string), int64(uploadPieceSize)*1024*1024) // Convert from megabytes to bytes
 	if err != nil {
@@ -647,20 +667,20 @@ func uploadOvaFromFilePath(d *schema.ResourceData, catalog *govcd.Catalog, vappT
 		return diag.Errorf("error uploading file: %s", err)
 	}
 
-	return finishHandlingTask(d, *task.Task, vappTemplate, resourceName)
+	return finishHandlingTask(ctx, d, *task.Task, vappTemplate, resourceName)
 }
 
-func uploadFromUrl(d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
+func uploadFromUrl(ctx context.Context, d *schema.ResourceData, catalog *govcd.Catalog, itemName, resourceName string) diag.Diagnostics {
 	task, err := catalog.UploadOvfByLink(d.Get("ovf_url").(string), itemName, d.Get("description").(string))
 	if err != nil {
 		log.Printf("[DEBUG] Error uploading OVF from URL: %s", err)
 		return diag.Errorf("error uploading OVF from URL: %s", err)
 	}
 
-	return finishHandlingTask(d, task, itemName, resourceName)
+	return finishHandlingTask(ctx, d, task, itemName, resourceName)
 }
 
-func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
+func finishHandlingTask(ctx context.Context, d *schema.ResourceData, task govcd.Task, itemName string, resourceName string) diag.Diagnostics {
 	// This is a deprecated feature from vcd_catalog_item, to be removed with vcd_catalog_item
 	if resourceName == "vcd_catalog_item" && d.Get("show_upload_progress").(bool) {
 		for {
@@ -677,7 +697,7 @@ func finishHandlingTask(d *schema.ResourceData, task govcd.Task, itemName string
 		}
 	}
 
-	err := task.WaitTaskCompletion()
+	err := waitForTask(ctx, task)
 	if err != nil {
 		return diag.Errorf("error waiting for task to complete: %+v", err)
 	}
@@ -191,22 +191,32 @@ func resourceVcdAlbLibraryCertificateDelete(_ context.Context, d *schema.Resourc
 }
 
 func resourceLibraryCertificateImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.certificate-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.certificate-name or as org-ID.certificate-name, " +
+			"where certificate-name can also be the certificate ID")
 	}
 	orgName, certificateName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[certificate import] error retrieving org %s: %s", orgName, err)
 	}
 
 	var certificate *govcd.Certificate
-	if isSysOrg(adminOrg) {
+	certificateId := importEntityId("urn:vcloud:certificateLibraryItem:", certificateName)
+	switch {
+	case isSysOrg(adminOrg) && certificateId != "":
+		certificate, err = vcdClient.Client.GetCertificateFromLibraryById(certificateId)
+	case isSysOrg(adminOrg):
 		certificate, err = vcdClient.Client.GetCertificateFromLibraryByName(certificateName)
-	} else {
+	case certificateId != "":
+		certificate, err = adminOrg.GetCertificateFromLibraryById(certificateId)
+	default:
 		certificate, err = adminOrg.GetCertificateFromLibraryByName(certificateName)
 	}
 	if err != nil {
@@ -19,6 +19,9 @@ func resourceVcdClonedVApp() *schema.Resource {
 		CreateContext: resourceVcdClonedVAppCreate,
 		ReadContext:   resourceVcdClonedVAppRead,
 		DeleteContext: resourceVcdClonedVAppDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVappImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
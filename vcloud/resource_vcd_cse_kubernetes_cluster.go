@@ -29,6 +29,12 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdCseKubernetesImport,
 		},
+		// These limit 'operations_timeout_minutes', which is kept as the preferred way of configuring the
+		// wait for the cluster operations
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(180 * time.Minute),
+			Delete: schema.DefaultTimeout(180 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"cse_version": {
 				Type:         schema.TypeString,
@@ -297,7 +303,8 @@ func resourceVcdCseKubernetesCluster() *schema.Resource {
 				Default:  60,
 				Description: "The time, in minutes, to wait for the cluster operations to be successfully completed. For example, during cluster creation, it should be in `provisioned`" +
 					"state before the timeout is reached, otherwise the operation will return an error. For cluster deletion, this timeout" +
-					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait indefinitely",
+					"specifies the time to wait until the cluster is completely deleted. Setting this argument to `0` means to wait until " +
+					"the create or delete timeout of the resource expires",
 				ValidateDiagFunc: validation.ToDiagFunc(validation.IntAtLeast(0)),
 			},
 			"kubernetes_version": {
@@ -473,7 +480,7 @@ func resourceVcdCseKubernetesClusterCreate(ctx context.Context, d *schema.Resour
 		}
 	}
 
-	cluster, err := org.CseCreateKubernetesCluster(creationData, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute)
+	cluster, err := org.CseCreateKubernetesCluster(creationData, timeoutFromContext(ctx, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute))
 	if err != nil && cluster == nil {
 		return diag.Errorf("Kubernetes cluster creation failed: %s", err)
 	}
@@ -625,7 +632,7 @@ func resourceVcdCseKubernetesUpdate(ctx context.Context, d *schema.ResourceData,
 // the flags "markForDelete" and "forceDelete" back to true, so the CSE Server is able to delete all cluster elements
 // and perform a cleanup. Hence, this function sends an update of just these two properties and waits for the cluster RDE
 // to be gone.
-func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdCseKubernetesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 	cluster, err := vcdClient.CseGetKubernetesClusterById(d.Id())
 	if err != nil {
@@ -634,7 +641,7 @@ func resourceVcdCseKubernetesDelete(_ context.Context, d *schema.ResourceData, m
 		}
 		return diag.FromErr(err)
 	}
-	err = cluster.Delete(time.Duration(d.Get("operations_timeout_minutes").(int)) * time.Minute)
+	err = cluster.Delete(timeoutFromContext(ctx, time.Duration(d.Get("operations_timeout_minutes").(int))*time.Minute))
 	if err != nil {
 		return diag.FromErr(err)
 	}
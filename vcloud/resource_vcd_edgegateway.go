@@ -494,13 +494,24 @@ func resourceVcdEdgeGatewayDelete(d *schema.ResourceData, meta interface{}) erro
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 // Note: the edge gateway can be identified by either the name or the ID
 func resourceVcdEdgeGatewayImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge-gw-name (or edge-gw-ID)")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcName, edgeName string
+	if edgeId := importEntityId("urn:vcloud:gateway:", d.Id()); edgeId != "" {
+		var err error
+		orgName, vdcName, _, err = importEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		}
+		edgeName = edgeId
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge-gw-name (or edge-gw-ID), or as edge gateway ID")
+		}
+		orgName, vdcName, edgeName = resourceURI[0], resourceURI[1], resourceURI[2]
 	}
-	orgName, vdcName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	org, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("unable to find org %s: %s", orgName, err)
@@ -6,6 +6,7 @@ package vcloud
 import (
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -54,6 +55,9 @@ func resourceVcdEdgeGatewayVpn() *schema.Resource {
 		Create: resourceVcdEdgeGatewayVpnCreate,
 		Read:   resourceVcdEdgeGatewayVpnRead,
 		Delete: resourceVcdEdgeGatewayVpnDelete,
+		Importer: &schema.ResourceImporter{
+			State: resourceVcdEdgeGatewayVpnImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 
@@ -345,6 +349,51 @@ func resourceVcdEdgeGatewayVpnRead(d *schema.ResourceData, meta interface{}) err
 	return nil
 }
 
+// resourceVcdEdgeGatewayVpnImport imports the VPN tunnel of an edge gateway. The import path is the one of the
+// edge gateway, as the resource has no identity of its own:
+// org-name.vdc-name.edge-gw-name, or the edge gateway ID (URN, HREF or UUID)
+func resourceVcdEdgeGatewayVpnImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcName, edgeName string
+	if edgeId := importEntityId("urn:vcloud:gateway:", d.Id()); edgeId != "" {
+		var err error
+		orgName, vdcName, edgeName, err = importEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge-gw-name or as edge gateway ID")
+		}
+		orgName, vdcName, edgeName = resourceURI[0], resourceURI[1], resourceURI[2]
+	}
+
+	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
+	if err != nil {
+		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
+	}
+	var tunnels []*types.GatewayIpsecVpnTunnel
+	serviceConfiguration := edgeGateway.EdgeGateway.Configuration.EdgeGatewayServiceConfiguration
+	if serviceConfiguration != nil && serviceConfiguration.GatewayIpsecVpnService != nil {
+		tunnels = serviceConfiguration.GatewayIpsecVpnService.Tunnel
+	}
+	switch len(tunnels) {
+	case 0:
+		return nil, fmt.Errorf("edge gateway %s has no VPN tunnel", edgeName)
+	case 1:
+	default:
+		return nil, fmt.Errorf("edge gateway %s has %d VPN tunnels: multiple tunnels not currently supported", edgeName, len(tunnels))
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "edge_gateway", edgeGateway.EdgeGateway.Name)
+	d.SetId(edgeGateway.EdgeGateway.Name)
+	return []*schema.ResourceData{d}, nil
+}
+
 func convertAndSet(key, prefix string, hashObejct *schema.Resource, subNets []*types.IpsecVpnSubnet, d *schema.ResourceData) error {
 	var items []interface{}
 
@@ -280,7 +280,13 @@ func resourceVcdExternalNetworkV2Delete(_ context.Context, d *schema.ResourceDat
 func resourceVcdExternalNetworkV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 
-	extNetRes, err := govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, d.Id())
+	var extNetRes *govcd.ExternalNetworkV2
+	var err error
+	if id := importWholeEntityId("urn:vcloud:network:", d.Id()); id != "" {
+		extNetRes, err = govcd.GetExternalNetworkV2ById(vcdClient.VCDClient, id)
+	} else {
+		extNetRes, err = govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, d.Id())
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error fetching external network V2 details %s", err)
 	}
@@ -310,17 +310,23 @@ func getTenants(client *VCDClient, label string, d *schema.ResourceData) ([]type
 func resourceVcdGlobalRoleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 1 {
-		return nil, fmt.Errorf("resource name must be specified as globalrole-name")
+		return nil, fmt.Errorf("resource name must be specified as globalrole-name or as globalrole-ID")
 	}
 	globalRoleName := resourceURI[0]
 
 	vcdClient := meta.(*VCDClient)
 
-	globalRole, err := vcdClient.Client.GetGlobalRoleByName(globalRoleName)
+	var globalRole *govcd.GlobalRole
+	var err error
+	if id := importWholeEntityId("urn:vcloud:globalRole:", d.Id()); id != "" {
+		globalRole, err = vcdClient.Client.GetGlobalRoleById(id)
+	} else {
+		globalRole, err = vcdClient.Client.GetGlobalRoleByName(globalRoleName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("[global role import] error retrieving global role %s: %s", globalRoleName, err)
 	}
-	dSet(d, "name", globalRoleName)
+	dSet(d, "name", globalRole.GlobalRole.Name)
 	dSet(d, "description", globalRole.GlobalRole.Description)
 	dSet(d, "bundle_key", globalRole.GlobalRole.BundleKey)
 	publishAll := false
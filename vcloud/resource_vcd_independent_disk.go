@@ -7,6 +7,7 @@ import (
 	"log"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -25,9 +26,14 @@ func resourceVcdIndependentDisk() *schema.Resource {
 		ReadContext:   resourceVcdIndependentDiskRead,
 		UpdateContext: resourceVcdIndependentDiskUpdate,
 		DeleteContext: resourceVcdIndependentDiskDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_independent_disk", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdIndependentDiskImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -224,7 +230,7 @@ func resourceVcdIndependentDiskCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error creating independent disk: %s", err)
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, task)
 	if err != nil {
 		return diag.Errorf("error waiting to finish creation of independent disk: %s", err)
 	}
@@ -543,7 +549,7 @@ func setMainData(d *schema.ResourceData, vcdClient *VCDClient, disk *govcd.Disk,
 	return nil
 }
 
-func resourceVcdIndependentDiskDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdIndependentDiskDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -573,9 +579,8 @@ func resourceVcdIndependentDiskDelete(_ context.Context, d *schema.ResourceData,
 		return diag.Errorf("error deleting disk : %#v", err)
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, task)
 	if err != nil {
-		d.SetId("")
 		return diag.Errorf("error waiting for deleting disk : %#v", err)
 	}
 
@@ -584,6 +589,7 @@ func resourceVcdIndependentDiskDelete(_ context.Context, d *schema.ResourceData,
 
 var errHelpDiskImport = fmt.Errorf(`resource id must be specified in one of these formats:
 'org-name.vdc-name.my-independent-disk-id' to import by rule id
+'my-independent-disk-id' (URN, HREF or UUID) to import by id only
 'list@org-name.vdc-name.my-independent-disk-name' or 'list@org-name.vdc-name' to get a list of disks with their IDs`)
 
 // resourceVcdIndependentDiskImport is responsible for importing the resource.
@@ -601,14 +607,32 @@ var errHelpDiskImport = fmt.Errorf(`resource id must be specified in one of thes
 //
 // Example resource name (_resource_name_): vcd_independent_disk.my-disk
 // Example import path (_the_id_string_): org-name.vdc-name.my-independent-disk-id
+// Example import path (_the_id_string_): my-independent-disk-id
 // Example list path (_the_id_string_): list@org-name.vdc-name.my-independent-disk-name
 func resourceVcdIndependentDiskImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, diskName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-
 	log.Printf("[DEBUG] importing vcd_independent_disk resource with provided id %s", d.Id())
 
+	if diskId = importEntityId("urn:vcloud:disk:", d.Id()); diskId != "" {
+		vcdClient := meta.(*VCDClient)
+		queryType := "disk"
+		if vcdClient.Client.IsSysAdmin {
+			queryType = "adminDisk"
+		}
+		record, err := queryImportRecord(vcdClient, queryType, "/disk/", diskId)
+		if err != nil {
+			return nil, fmt.Errorf("[independent disk import] %s", err)
+		}
+		orgName, vdcName, err = importVdcNames(vcdClient, record["vdc"])
+		if err != nil {
+			return nil, fmt.Errorf("[independent disk import] error retrieving VDC of disk %s: %s", diskId, err)
+		}
+		return getDiskForImport(d, meta, orgName, vdcName, diskId)
+	}
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+
 	if len(resourceURI) != 3 && len(resourceURI) != 2 {
 		return nil, errHelpDiskImport
 	}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
@@ -18,6 +19,9 @@ func resourceVcdInsertedMedia() *schema.Resource {
 		DeleteContext: resourceVcdMediaEject,
 		ReadContext:   resourceVcdVmInsertedMediaRead,
 		UpdateContext: resourceVcdMediaEjectUpdate,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdInsertedMediaImport,
+		},
 
 		Schema: map[string]*schema.Schema{
 			"vdc": {
@@ -76,17 +80,7 @@ func resourceVcdMediaInsert(ctx context.Context, d *schema.ResourceData, meta in
 	vcdClient.lockParentVapp(d)
 	defer vcdClient.unLockParentVapp(d)
 
-	vm, org, err := getVM(d, meta)
-	if err != nil || org == nil {
-		return diag.Errorf("error: %s", err)
-	}
-
-	task, err := vm.HandleInsertMedia(org, d.Get("catalog").(string), d.Get("name").(string))
-	if err != nil {
-		return diag.Errorf("error: %s", err)
-	}
-
-	err = task.WaitTaskCompletion()
+	err := insertVmMedia(d, meta)
 	if err != nil {
 		return diag.Errorf("error: %s", err)
 	}
@@ -128,22 +122,44 @@ func resourceVcdMediaEject(_ context.Context, d *schema.ResourceData, meta inter
 	vcdClient.lockParentVapp(d)
 	defer vcdClient.unLockParentVapp(d)
 
-	vm, org, err := getVM(d, meta)
+	err := ejectVmMedia(d, meta)
 	if err != nil {
 		return diag.Errorf("error: %s", err)
 	}
 
-	task, err := vm.HandleEjectMedia(org, d.Get("catalog").(string), d.Get("name").(string))
+	return nil
+}
+
+// insertVmMedia inserts the media described by the fields of vcloud_inserted_media in the VM. The caller must hold
+// the lock of the parent vApp
+func insertVmMedia(d *schema.ResourceData, meta interface{}) error {
+	vm, org, err := getVM(d, meta)
 	if err != nil {
-		return diag.Errorf("error: %s", err)
+		return err
 	}
 
-	err = task.WaitTaskCompletion(d.Get("eject_force").(bool))
+	task, err := vm.HandleInsertMedia(org, d.Get("catalog").(string), d.Get("name").(string))
 	if err != nil {
-		return diag.Errorf("error: %s", err)
+		return err
 	}
 
-	return nil
+	return task.WaitTaskCompletion()
+}
+
+// ejectVmMedia ejects the media described by the fields of vcloud_inserted_media from the VM, answering the
+// question of the guest OS according to 'eject_force'. The caller must hold the lock of the parent vApp
+func ejectVmMedia(d *schema.ResourceData, meta interface{}) error {
+	vm, org, err := getVM(d, meta)
+	if err != nil {
+		return err
+	}
+
+	task, err := vm.HandleEjectMedia(org, d.Get("catalog").(string), d.Get("name").(string))
+	if err != nil {
+		return err
+	}
+
+	return task.WaitTaskCompletion(d.Get("eject_force").(bool))
 }
 
 func getVM(d *schema.ResourceData, meta interface{}) (*govcd.VM, *govcd.Org, error) {
@@ -174,3 +190,70 @@ func resourceVcdMediaEjectUpdate(_ context.Context, d *schema.ResourceData, m in
 	dSet(d, "eject_force", d.Get("eject_force"))
 	return nil
 }
+
+// resourceVcdInsertedMediaImport imports a media inserted in a VM. The media can't be read from the VM, and is part of
+// the import path:
+// org-name.vdc-name.vapp-name.vm-name.catalog-name.media-name
+// or
+// VM-ID.catalog-name.media-name, where the VM ID is a URN or a bare UUID
+func resourceVcdInsertedMediaImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcName, vappName, vmName, catalogName, mediaName string
+	switch {
+	case len(resourceURI) == 3 && importEntityId("urn:vcloud:vm:", resourceURI[0]) != "":
+		vmId := importEntityId("urn:vcloud:vm:", resourceURI[0])
+		record, err := queryImportRecord(vcdClient, types.QtVm, "/vApp/vm-", vmId)
+		if err != nil {
+			return nil, fmt.Errorf("[inserted media import] %s", err)
+		}
+		orgName, vdcName, err = importVdcNames(vcdClient, record["vdc"])
+		if err != nil {
+			return nil, fmt.Errorf("[inserted media import] error retrieving VDC of VM %s: %s", vmId, err)
+		}
+		vappName, vmName = record["containerName"], record["name"]
+		catalogName, mediaName = resourceURI[1], resourceURI[2]
+	case len(resourceURI) == 6:
+		orgName, vdcName, vappName, vmName = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+		catalogName, mediaName = resourceURI[4], resourceURI[5]
+	default:
+		return nil, fmt.Errorf("[inserted media import] resource name must be specified as " +
+			"org-name.vdc-name.vapp-name.vm-name.catalog-name.media-name or VM-ID.catalog-name.media-name")
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "vapp_name", vappName)
+	dSet(d, "vm_name", vmName)
+	dSet(d, "catalog", catalogName)
+	dSet(d, "name", mediaName)
+
+	vm, org, err := getVM(d, meta)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] %s", err)
+	}
+	isIsoMounted := false
+	for _, hardwareItem := range vm.VM.VirtualHardwareSection.Item {
+		if hardwareItem.ResourceSubType == types.VMsCDResourceSubType {
+			isIsoMounted = true
+			break
+		}
+	}
+	if !isIsoMounted {
+		return nil, fmt.Errorf("[inserted media import] VM %s has no inserted media", vmName)
+	}
+
+	catalog, err := org.GetCatalogByName(catalogName, false)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] error retrieving catalog %s: %s", catalogName, err)
+	}
+	_, err = catalog.GetMediaByName(mediaName, false)
+	if err != nil {
+		return nil, fmt.Errorf("[inserted media import] error retrieving media %s: %s", mediaName, err)
+	}
+
+	dSet(d, "eject_force", true)
+	d.SetId(vappName + "_" + vmName + "_" + mediaName)
+	return []*schema.ResourceData{d}, nil
+}
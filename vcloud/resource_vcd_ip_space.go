@@ -170,6 +170,7 @@ func resourceVcdIpSpace() *schema.Resource {
 				Default:     false,
 				Description: "Flag whether SNAT rule creation should be enabled (VCD 10.5.0+)",
 			},
+			"metadata_entry": openApiMetadataEntryResourceSchema("IP Space"),
 		},
 	}
 }
@@ -190,6 +191,11 @@ func resourceVcdIpSpaceCreate(ctx context.Context, d *schema.ResourceData, meta
 
 	d.SetId(createdIpSpace.IpSpace.ID)
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, ipSpaceMetadata(vcdClient, createdIpSpace.IpSpace))
+	if err != nil {
+		return diag.Errorf("error adding metadata to IP Space: %s", err)
+	}
+
 	return resourceVcdIpSpaceRead(ctx, d, meta)
 }
 
@@ -207,6 +213,11 @@ func resourceVcdIpSpaceUpdate(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("error finding IP Space by ID '%s': %s", d.Id(), err)
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, ipSpaceMetadata(vcdClient, ipSpace.IpSpace))
+	if err != nil {
+		return diag.Errorf("error updating IP Space metadata: %s", err)
+	}
+
 	ipSpaceConfig.ID = d.Id()
 	_, err = ipSpace.Update(ipSpaceConfig)
 	if err != nil {
@@ -262,7 +273,13 @@ func resourceVcdIpSpaceRead(ctx context.Context, d *schema.ResourceData, meta in
 		return diag.Errorf("error storing IP Space state: %s", err)
 	}
 
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_ip_space", ipSpaceMetadata(vcdClient, ipSpace.IpSpace))
+}
+
+// ipSpaceMetadata returns the handler of the OpenAPI metadata of an IP Space
+func ipSpaceMetadata(vcdClient *VCDClient, ipSpace *types.IpSpace) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointIpSpaces,
+		"vcd_ip_space", ipSpace.ID, ipSpace.Name)
 }
 
 func resourceVcdIpSpaceDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -298,15 +315,25 @@ func resourceVcdIpSpaceDelete(ctx context.Context, d *schema.ResourceData, meta
 func resourceVcdIpSpaceImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] IP Space import initiated")
 
+	vcdClient := meta.(*VCDClient)
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 1 && len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as ip-space-name or org-name.ip-space-name")
+	ipSpaceId := importWholeEntityId("urn:vcloud:ipSpace:", d.Id())
+	if ipSpaceId == "" && len(resourceURI) != 1 && len(resourceURI) != 2 {
+		return nil, fmt.Errorf("resource name must be specified as ip-space-name, org-name.ip-space-name or IP Space ID")
 	}
-	vcdClient := meta.(*VCDClient)
 
 	var ipSpace *govcd.IpSpace
 
 	switch {
+	case ipSpaceId != "": // Resource path is supplied as the ID of the IP Space
+		var err error
+		ipSpace, err = vcdClient.GetIpSpaceById(ipSpaceId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving IP Space '%s': %s", ipSpaceId, err)
+		}
+		if ipSpace.IpSpace.OrgRef != nil {
+			dSet(d, "org_id", ipSpace.IpSpace.OrgRef.ID)
+		}
 	case len(resourceURI) == 2: // Resource path is supplied as `org-name.ip-space-name`
 		ipSpaceName := resourceURI[1]
 		orgName := resourceURI[0]
@@ -172,7 +172,8 @@ func resourceVcdIpSpaceCustomQuotaImport(ctx context.Context, d *schema.Resource
 
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as ip-space-name.org-name")
+		return nil, fmt.Errorf("resource name must be specified as ip-space-name.org-name " +
+			"or as ip-space-ID.org-name")
 	}
 	vcdClient := meta.(*VCDClient)
 
@@ -186,7 +187,11 @@ func resourceVcdIpSpaceCustomQuotaImport(ctx context.Context, d *schema.Resource
 		return nil, fmt.Errorf("error retrieving Org '%s': %s", orgName, err)
 	}
 
-	ipSpace, err = vcdClient.GetIpSpaceByName(ipSpaceName)
+	if ipSpaceId := importEntityId("urn:vcloud:ipSpace:", ipSpaceName); ipSpaceId != "" {
+		ipSpace, err = vcdClient.GetIpSpaceById(ipSpaceId)
+	} else {
+		ipSpace, err = vcdClient.GetIpSpaceByName(ipSpaceName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving IP Space '%s: %s", orgName, err)
 	}
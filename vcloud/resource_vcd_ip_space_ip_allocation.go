@@ -11,6 +11,7 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"github.com/vmware/go-vcloud-director/v2/util"
 )
@@ -306,7 +307,8 @@ func resourceVcdIpAllocationImport(ctx context.Context, d *schema.ResourceData,
 
 	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.ip-space-name.ip-allocation-type.ip-allocation-ip")
+		return nil, fmt.Errorf("resource name must be specified as org-name.ip-space-name.ip-allocation-type.ip-allocation-ip " +
+			"or as org-name.ip-space-ID.ip-allocation-type.ip-allocation-ip")
 	}
 
 	orgName, ipSpaceName, ipAllocationType, ipAllocationIp := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
@@ -317,7 +319,12 @@ func resourceVcdIpAllocationImport(ctx context.Context, d *schema.ResourceData,
 		return nil, fmt.Errorf("error retrieving Org '%s': %s", orgName, err)
 	}
 
-	ipSpace, err := vcdClient.GetIpSpaceByName(ipSpaceName)
+	var ipSpace *govcd.IpSpace
+	if ipSpaceId := importEntityId("urn:vcloud:ipSpace:", ipSpaceName); ipSpaceId != "" {
+		ipSpace, err = vcdClient.GetIpSpaceById(ipSpaceId)
+	} else {
+		ipSpace, err = vcdClient.GetIpSpaceByName(ipSpaceName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving IP Space %s: %s ", ipSpaceName, err)
 	}
@@ -181,16 +181,25 @@ func resourceVcdIpSpaceUplinkDelete(ctx context.Context, d *schema.ResourceData,
 func resourceVcdIpSpaceUplinkImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] IP Space Uplink import initiated")
 
+	vcdClient := meta.(*VCDClient)
+	if id := importWholeEntityId("urn:vcloud:ipSpaceUplink:", d.Id()); id != "" {
+		ipSpaceUplink, err := vcdClient.GetIpSpaceUplinkById(id)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving IP Space Uplink by ID '%s': %s", id, err)
+		}
+		d.SetId(ipSpaceUplink.IpSpaceUplink.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as external-network-name.uplink-name")
+		return nil, fmt.Errorf("resource name must be specified as external-network-name.uplink-name " +
+			"or as uplink-ID")
 	}
 
 	externalNetworkName := resourceURI[0]
 	ipSpaceUplinkName := resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
-
 	extNetRes, err := govcd.GetExternalNetworkV2ByName(vcdClient.VCDClient, externalNetworkName)
 	if err != nil {
 		return nil, fmt.Errorf("error fetching external network V2 details %s", err)
@@ -2,7 +2,6 @@ package vcloud
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -235,13 +234,17 @@ func resourceVcdLBServerPoolDelete(d *schema.ResourceData, meta interface{}) err
 // Example import path (id): org.vdc.edge-gw.lb-server-pool
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLBServerPoolImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-server-pool")
+		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-server-pool " +
+			"or as edge-gw-ID.lb-server-pool")
 	}
 	orgName, vdcName, edgeName, poolName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
@@ -196,13 +196,17 @@ func resourceVcdLbServiceMonitorDelete(d *schema.ResourceData, meta interface{})
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLbServiceMonitorImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-service-monitor")
+		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-service-monitor " +
+			"or as edge-gw-ID.lb-service-monitor")
 	}
 	orgName, vdcName, edgeName, monitorName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
@@ -2,7 +2,6 @@ package vcloud
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -208,13 +207,17 @@ func resourceVcdLBVirtualServerDelete(d *schema.ResourceData, meta interface{})
 // Example import path (_the_id_string_): org.vdc.edge-gw.existing-virtual-server
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdLBVirtualServerImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-virtual-server")
+		return nil, fmt.Errorf("resource name must be specified as org.vdc.edge-gw.lb-virtual-server " +
+			"or as edge-gw-ID.lb-virtual-server")
 	}
 	orgName, vdcName, edgeName, virtualServerName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
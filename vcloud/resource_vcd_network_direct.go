@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
@@ -18,6 +17,7 @@ func resourceVcdNetworkDirect() *schema.Resource {
 		ReadContext:   resourceVcdNetworkDirectRead,
 		UpdateContext: resourceVcdNetworkDirectUpdate,
 		DeleteContext: resourceVcdNetworkDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_network_direct", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkDirectImport,
 		},
@@ -300,13 +300,17 @@ func getNetwork(d *schema.ResourceData, vcdClient *VCDClient, isDataSource bool,
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdNetworkDirectImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgVdcNetworkPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[direct network import] resource name must be specified as org-name.vdc-name.network-name")
+		return nil, fmt.Errorf("[direct network import] resource name must be specified as org-name.vdc-name.network-name " +
+			"or as network ID")
 	}
 	orgName, vdcName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[direct network import] unable to find VDC %s: %s ", vdcName, err)
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -19,6 +18,7 @@ func resourceVcdNetworkIsolated() *schema.Resource {
 		ReadContext:   resourceVcdNetworkIsolatedRead,
 		UpdateContext: resourceVcdNetworkIsolatedUpdate,
 		DeleteContext: resourceVcdNetworkDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_network_isolated", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkIsolatedImport,
 		},
@@ -398,13 +398,17 @@ func resourceVcdNetworkIsolatedDhcpPoolHash(v interface{}) int {
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdNetworkIsolatedImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgVdcNetworkPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[isolated network import] resource name must be specified as org-name.vdc-name.network-name")
+		return nil, fmt.Errorf("[isolated network import] resource name must be specified as org-name.vdc-name.network-name " +
+			"or as network ID")
 	}
 	orgName, vdcName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[isolated network import] unable to find VDC %s: %s ", vdcName, err)
@@ -19,6 +19,7 @@ func resourceVcdNetworkIsolatedV2() *schema.Resource {
 		ReadContext:   resourceVcdNetworkIsolatedV2Read,
 		UpdateContext: resourceVcdNetworkIsolatedV2Update,
 		DeleteContext: resourceVcdNetworkIsolatedV2Delete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_network_isolated_v2", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkIsolatedV2Import,
 		},
@@ -130,7 +131,8 @@ func resourceVcdNetworkIsolatedV2() *schema.Resource {
 				Deprecated:    "Use metadata_entry instead",
 				ConflictsWith: []string{"metadata_entry"},
 			},
-			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
+			"metadata_entry":         metadataEntryResourceSchemaDeprecated("Network"),
+			"openapi_metadata_entry": openApiMetadataEntryResourceSchema("Network"),
 		},
 	}
 }
@@ -160,7 +162,7 @@ func resourceVcdNetworkIsolatedV2Create(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(orgNetwork.OpenApiOrgVdcNetwork.ID)
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(d, vcdClient, "vcd_network_isolated_v2", orgNetwork)
 	if err != nil {
 		return diag.Errorf("[isolated network v2 create] error adding metadata to Isolated network: %s", err)
 	}
@@ -211,7 +213,7 @@ func resourceVcdNetworkIsolatedV2Update(ctx context.Context, d *schema.ResourceD
 		return diag.Errorf("[isolated network v2 update] error updating Isolated network: %s", err)
 	}
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(d, vcdClient, "vcd_network_isolated_v2", orgNetwork)
 	if err != nil {
 		return diag.Errorf("[isolated network v2 update] error updating Isolated network metadata: %s", err)
 	}
@@ -257,6 +259,8 @@ func resourceVcdNetworkIsolatedV2Read(_ context.Context, d *schema.ResourceData,
 			diags = append(diags, diag.FromErr(err)...)
 		}
 	}
+	diags = append(diags, updateOpenApiMetadataAttributeInState(d, vcdClient, "vcd_network_isolated_v2", "openapi_metadata_entry",
+		openApiOrgVdcNetworkMetadata(vcdClient, "vcd_network_isolated_v2", orgNetwork.OpenApiOrgVdcNetwork))...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -296,12 +300,23 @@ func resourceVcdNetworkIsolatedV2Delete(_ context.Context, d *schema.ResourceDat
 }
 
 func resourceVcdNetworkIsolatedV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[isolated network v2 import] resource name must be specified as org-name.vdc-name.network-name")
-	}
-	orgName, vdcOrVdcGroupName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcOrVdcGroupName, networkName string
+	if networkId := importEntityId("urn:vcloud:network:", d.Id()); networkId != "" {
+		var err error
+		orgName, vdcOrVdcGroupName, networkName, err = importOrgVdcNetworkNames(vcdClient, networkId)
+		if err != nil {
+			return nil, fmt.Errorf("[isolated network v2 import] error retrieving network '%s': %s", networkId, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("[isolated network v2 import] resource name must be specified as org-name.vdc-name.network-name or as network ID")
+		}
+		orgName, vdcOrVdcGroupName, networkName = resourceURI[0], resourceURI[1], resourceURI[2]
+	}
+
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -405,9 +420,14 @@ func getOpenApiOrgVdcIsolatedNetworkType(d *schema.ResourceData, vcdClient *VCDC
 	return orgVdcNetworkConfig, nil
 }
 
-func createOrUpdateOpenApiNetworkMetadata(d *schema.ResourceData, network *govcd.OpenApiOrgVdcNetwork) error {
+func createOrUpdateOpenApiNetworkMetadata(d *schema.ResourceData, vcdClient *VCDClient, resourceType string, network *govcd.OpenApiOrgVdcNetwork) error {
 	log.Printf("[TRACE] adding/updating metadata to Network V2")
 
+	err := createOrUpdateOpenApiMetadataAttributeInVcd(d, "openapi_metadata_entry", openApiOrgVdcNetworkMetadata(vcdClient, resourceType, network.OpenApiOrgVdcNetwork))
+	if err != nil {
+		return err
+	}
+
 	// Metadata is not supported when the network is in a VDC Group
 	if govcd.OwnerIsVdcGroup(network.OpenApiOrgVdcNetwork.OwnerRef.ID) {
 		return nil
@@ -415,3 +435,10 @@ func createOrUpdateOpenApiNetworkMetadata(d *schema.ResourceData, network *govcd
 
 	return createOrUpdateMetadata(d, network, "metadata")
 }
+
+// openApiOrgVdcNetworkMetadata returns the handler of the OpenAPI metadata of an Org VDC network. Unlike the legacy
+// metadata, OpenAPI metadata is also supported when the network is in a VDC Group
+func openApiOrgVdcNetworkMetadata(vcdClient *VCDClient, resourceType string, network *types.OpenApiOrgVdcNetwork) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointOrgVdcNetworks,
+		resourceType, network.ID, network.Name)
+}
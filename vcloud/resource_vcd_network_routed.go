@@ -21,6 +21,7 @@ func resourceVcdNetworkRouted() *schema.Resource {
 		CreateContext: resourceVcdNetworkRoutedCreate,
 		ReadContext:   resourceVcdNetworkRoutedRead,
 		DeleteContext: resourceVcdNetworkDeleteLocked,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_network_routed", "metadata"),
 		UpdateContext: resourceVcdNetworkRoutedUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkRoutedImport,
@@ -571,13 +572,17 @@ func resourceVcdNetworkRoutedDhcpPoolHash(v interface{}) int {
 // Example import path (_the_id_string_): org.vdc.my-network
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdNetworkRoutedImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgVdcNetworkPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[routed network import] resource name must be specified as org-name.vdc-name.network-name")
+		return nil, fmt.Errorf("[routed network import] resource name must be specified as org-name.vdc-name.network-name " +
+			"or as network ID")
 	}
 	orgName, vdcName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[routed network import] unable to find VDC %s: %s ", vdcName, err)
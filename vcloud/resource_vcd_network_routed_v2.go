@@ -20,6 +20,7 @@ func resourceVcdNetworkRoutedV2() *schema.Resource {
 		ReadContext:   resourceVcdNetworkRoutedV2Read,
 		UpdateContext: resourceVcdNetworkRoutedV2Update,
 		DeleteContext: resourceVcdNetworkRoutedV2Delete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_network_routed_v2", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNetworkRoutedV2Import,
 		},
@@ -136,7 +137,8 @@ func resourceVcdNetworkRoutedV2() *schema.Resource {
 				Deprecated:    "Use metadata_entry instead",
 				ConflictsWith: []string{"metadata_entry"},
 			},
-			"metadata_entry": metadataEntryResourceSchemaDeprecated("Network"),
+			"metadata_entry":         metadataEntryResourceSchemaDeprecated("Network"),
+			"openapi_metadata_entry": openApiMetadataEntryResourceSchema("Network"),
 			"route_advertisement_enabled": {
 				Type:        schema.TypeBool,
 				Optional:    true,
@@ -180,7 +182,7 @@ func resourceVcdNetworkRoutedV2Create(ctx context.Context, d *schema.ResourceDat
 
 	d.SetId(orgNetwork.OpenApiOrgVdcNetwork.ID)
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(d, vcdClient, "vcd_network_routed_v2", orgNetwork)
 	if err != nil {
 		return diag.Errorf("[routed network create v2] error adding metadata to Routed network: %s", err)
 	}
@@ -232,7 +234,7 @@ func resourceVcdNetworkRoutedV2Update(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("[routed network update v2] error updating Routed network: %s", err)
 	}
 
-	err = createOrUpdateOpenApiNetworkMetadata(d, orgNetwork)
+	err = createOrUpdateOpenApiNetworkMetadata(d, vcdClient, "vcd_network_routed_v2", orgNetwork)
 	if err != nil {
 		return diag.Errorf("[routed network v2 update] error updating Routed network metadata: %s", err)
 	}
@@ -278,6 +280,8 @@ func resourceVcdNetworkRoutedV2Read(_ context.Context, d *schema.ResourceData, m
 			diags = append(diags, diag.FromErr(err)...)
 		}
 	}
+	diags = append(diags, updateOpenApiMetadataAttributeInState(d, vcdClient, "vcd_network_routed_v2", "openapi_metadata_entry",
+		openApiOrgVdcNetworkMetadata(vcdClient, "vcd_network_routed_v2", orgNetwork.OpenApiOrgVdcNetwork))...)
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -324,12 +328,23 @@ func resourceVcdNetworkRoutedV2Delete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdNetworkRoutedV2Import(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[routed network import v2] resource name must be specified as org-name.vdc-name.network-name or org-name.vdc-group-name.network-name")
-	}
-	orgName, vdcOrVdcGroupName, networkName := resourceURI[0], resourceURI[1], resourceURI[2]
 	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcOrVdcGroupName, networkName string
+	if networkId := importEntityId("urn:vcloud:network:", d.Id()); networkId != "" {
+		var err error
+		orgName, vdcOrVdcGroupName, networkName, err = importOrgVdcNetworkNames(vcdClient, networkId)
+		if err != nil {
+			return nil, fmt.Errorf("[routed network import v2] error retrieving network '%s': %s", networkId, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("[routed network import v2] resource name must be specified as org-name.vdc-name.network-name or org-name.vdc-group-name.network-name or as network ID")
+		}
+		orgName, vdcOrVdcGroupName, networkName = resourceURI[0], resourceURI[1], resourceURI[2]
+	}
+
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
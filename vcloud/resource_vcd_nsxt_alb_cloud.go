@@ -139,9 +139,15 @@ func resourceVcdAlbCloudImport(_ context.Context, d *schema.ResourceData, meta i
 	}
 
 	resourceURI := d.Id()
-	albCloud, err := vcdClient.GetAlbCloudByName(resourceURI)
+	var albCloud *govcd.NsxtAlbCloud
+	var err error
+	if id := importWholeEntityId("urn:vcloud:loadBalancerCloud:", resourceURI); id != "" {
+		albCloud, err = vcdClient.GetAlbCloudById(id)
+	} else {
+		albCloud, err = vcdClient.GetAlbCloudByName(resourceURI)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Cloud with Name '%s': %s", d.Id(), err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Cloud '%s': %s", d.Id(), err)
 	}
 
 	d.SetId(albCloud.NsxtAlbCloud.ID)
@@ -151,9 +151,15 @@ func resourceVcdAlbControllerImport(_ context.Context, d *schema.ResourceData, m
 	}
 
 	resourceURI := d.Id()
-	albController, err := vcdClient.GetAlbControllerByName(resourceURI)
+	var albController *govcd.NsxtAlbController
+	var err error
+	if id := importWholeEntityId("urn:vcloud:loadBalancerController:", resourceURI); id != "" {
+		albController, err = vcdClient.GetAlbControllerById(id)
+	} else {
+		albController, err = vcdClient.GetAlbControllerByName(resourceURI)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Controller with Name '%s': %s", d.Id(), err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Controller '%s': %s", d.Id(), err)
 	}
 
 	d.SetId(albController.NsxtAlbController.ID)
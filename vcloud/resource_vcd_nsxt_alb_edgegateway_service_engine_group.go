@@ -6,7 +6,6 @@ import (
 	"log"
 	"net/url"
 	"strconv"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -167,13 +166,17 @@ func resourceVcdAlbEdgeGatewayServiceEngineGroupDelete(_ context.Context, d *sch
 func resourceVcdAlbEdgeGatewayServiceEngineGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T ALB Service Engine Group assignment import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.vdc-name.nsxt-edge-gw-name.se-group-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.se-group-name " +
+			"or as nsxt-edge-gw-ID.se-group-name")
 	}
 	orgName, vdcOrVdcGroupName, edgeName, seGroupName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/util"
 
@@ -358,13 +357,32 @@ func resourceVcdAlbPoolDelete(_ context.Context, d *schema.ResourceData, meta in
 func resourceVcdAlbPoolImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T ALB Pool import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	if poolId := importWholeEntityId("urn:vcloud:loadBalancerPool:", d.Id()); poolId != "" {
+		albPool, err := vcdClient.GetAlbPoolById(poolId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve ALB Pool '%s': %s", poolId, err)
+		}
+		orgName, _, _, err := importNsxtEdgeGatewayNames(vcdClient, albPool.NsxtAlbPool.GatewayRef.ID)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway of ALB Pool '%s': %s", poolId, err)
+		}
+		dSet(d, "org", orgName)
+		dSet(d, "edge_gateway_id", albPool.NsxtAlbPool.GatewayRef.ID)
+		d.SetId(albPool.NsxtAlbPool.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.pool_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.pool_name, " +
+			"as nsxt-edge-gw-ID.pool_name or as pool ID")
 	}
 	orgName, vdcOrVdcGroupName, edgeName, poolName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -214,9 +214,15 @@ func resourceVcdAlbServiceEngineGroupImport(_ context.Context, d *schema.Resourc
 	}
 
 	resourceURI := d.Id()
-	albSeGroup, err := vcdClient.GetAlbServiceEngineGroupByName("", resourceURI)
+	var albSeGroup *govcd.NsxtAlbServiceEngineGroup
+	var err error
+	if id := importWholeEntityId("urn:vcloud:serviceEngineGroup:", resourceURI); id != "" {
+		albSeGroup, err = vcdClient.GetAlbServiceEngineGroupById(id)
+	} else {
+		albSeGroup, err = vcdClient.GetAlbServiceEngineGroupByName("", resourceURI)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T ALB Service Engine Group with Name '%s': %s", d.Id(), err)
+		return nil, fmt.Errorf("error finding NSX-T ALB Service Engine Group '%s': %s", d.Id(), err)
 	}
 
 	// This value is an internal flag and it cannot be read from resource itself. However, it makes sense to set it to
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
@@ -103,6 +102,7 @@ func resourceVcdAlbVirtualService() *schema.Resource {
 				Computed:    true,
 				Description: "Preserves Client IP on a Virtual Service (VCD 10.4.1+)",
 			},
+			"metadata_entry": openApiMetadataEntryResourceSchema("NSX-T ALB Virtual Service"),
 		},
 	}
 }
@@ -149,6 +149,11 @@ func resourceVcdAlbVirtualServiceCreate(ctx context.Context, d *schema.ResourceD
 
 	d.SetId(createdAlbVirtualService.NsxtAlbVirtualService.ID)
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, nsxtAlbVirtualServiceMetadata(vcdClient, createdAlbVirtualService.NsxtAlbVirtualService))
+	if err != nil {
+		return diag.Errorf("error adding metadata to NSX-T ALB Virtual Service: %s", err)
+	}
+
 	return resourceVcdAlbVirtualServiceRead(ctx, d, meta)
 }
 
@@ -173,6 +178,11 @@ func resourceVcdAlbVirtualServiceUpdate(ctx context.Context, d *schema.ResourceD
 		return diag.FromErr(fmt.Errorf("error updating NSX-T ALB Virtual Service: %s", err))
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, nsxtAlbVirtualServiceMetadata(vcdClient, albVirtualService.NsxtAlbVirtualService))
+	if err != nil {
+		return diag.Errorf("error updating NSX-T ALB Virtual Service metadata: %s", err)
+	}
+
 	return resourceVcdAlbVirtualServiceRead(ctx, d, meta)
 }
 
@@ -193,7 +203,13 @@ func resourceVcdAlbVirtualServiceRead(_ context.Context, d *schema.ResourceData,
 		return diag.Errorf("error setting NSX-T ALB Virtual Service data: %s", err)
 	}
 	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_nsxt_alb_virtual_service", nsxtAlbVirtualServiceMetadata(vcdClient, albVirtualService.NsxtAlbVirtualService))
+}
+
+// nsxtAlbVirtualServiceMetadata returns the handler of the OpenAPI metadata of an NSX-T ALB Virtual Service
+func nsxtAlbVirtualServiceMetadata(vcdClient *VCDClient, virtualService *types.NsxtAlbVirtualService) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointAlbVirtualServices,
+		"vcd_nsxt_alb_virtual_service", virtualService.ID, virtualService.Name)
 }
 
 func resourceVcdAlbVirtualServiceDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -217,13 +233,33 @@ func resourceVcdAlbVirtualServiceDelete(_ context.Context, d *schema.ResourceDat
 func resourceVcdAlbVirtualServiceImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T ALB Virtual Service import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	if virtualServiceId := importWholeEntityId("urn:vcloud:loadBalancerVirtualService:", d.Id()); virtualServiceId != "" {
+		albVirtualService, err := vcdClient.GetAlbVirtualServiceById(virtualServiceId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", virtualServiceId, err)
+		}
+		edgeId := albVirtualService.NsxtAlbVirtualService.GatewayRef.ID
+		orgName, _, _, err := importNsxtEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway of ALB Virtual Service '%s': %s", virtualServiceId, err)
+		}
+		dSet(d, "org", orgName)
+		dSet(d, "edge_gateway_id", edgeId)
+		d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name, " +
+			"as nsxt-edge-gw-ID.virtual_service_name or as virtual service ID")
 	}
 	orgName, vdcOrVdcGroupName, edgeName, virtualServiceName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
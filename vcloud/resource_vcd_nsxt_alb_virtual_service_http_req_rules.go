@@ -0,0 +1,196 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpReqRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpReqRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpReqRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpReqRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ALB Virtual Service",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "HTTP request rules, evaluated in their order",
+				Elem: &schema.Resource{
+					Schema: nsxtAlbVsHttpRuleCommonSchema(nsxtAlbVsHttpMatchCriteriaSchema(false), nsxtAlbVsHttpReqRuleActionsSchema),
+				},
+			},
+		},
+	}
+}
+
+var nsxtAlbVsHttpReqRuleActionsSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"redirect": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Redirect the request",
+			Elem:        nsxtAlbVsHttpRedirectSchema,
+		},
+		"modify_header": nsxtAlbVsHttpModifyHeaderSchema,
+		"rewrite_url": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Rewrite the URL of the request before sending it to the pool",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"host_header": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "New host header",
+					},
+					"existing_path": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "New path",
+					},
+					"keep_query": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Keep the query of the request",
+					},
+				},
+			},
+		},
+	},
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := checkNsxtAlbVsHttpRulesSupport(vcdClient, virtualServiceId)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP request rules] %s", err)
+	}
+	rules, err := getNsxtAlbVsHttpReqRulesType(d)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP request rules] %s", err)
+	}
+	err = updateNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpRequestRulesEndpoint, virtualServiceId, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP request rules] error setting rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+	return resourceVcdAlbVirtualServiceHttpReqRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpRequestRule]{}
+	err := getNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpRequestRulesEndpoint, d.Id(), rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] ALB Virtual Service '%s' not found. Removing HTTP request rules from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ALB Virtual Service HTTP request rules] error retrieving rules: %s", err)
+	}
+
+	err = setNsxtAlbVsHttpReqRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP request rules] %s", err)
+	}
+	dSet(d, "virtual_service_id", d.Id())
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdAlbVsHttpRulesRemove(d, meta, nsxtAlbVsHttpRequestRulesEndpoint, "HTTP request rules")
+}
+
+func resourceVcdAlbVirtualServiceHttpReqRulesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP request rules import initiated")
+	return resourceVcdAlbVsHttpRulesImport(d, meta)
+}
+
+func getNsxtAlbVsHttpReqRulesType(d *schema.ResourceData) (*nsxtAlbVsHttpRules[nsxtAlbVsHttpRequestRule], error) {
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpRequestRule]{Values: []nsxtAlbVsHttpRequestRule{}}
+	for _, rawRule := range d.Get("rule").([]interface{}) {
+		ruleMap := rawRule.(map[string]interface{})
+		rule := nsxtAlbVsHttpRequestRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getNsxtAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{})),
+		}
+		actions := nsxtAlbVsHttpSingleBlock(ruleMap["actions"])
+		if actions == nil {
+			return nil, fmt.Errorf("rule '%s' must have an 'actions' block", rule.Name)
+		}
+		if redirect := nsxtAlbVsHttpSingleBlock(actions["redirect"]); redirect != nil {
+			rule.RedirectAction = getNsxtAlbVsHttpRedirectType(redirect)
+		}
+		if rewrite := nsxtAlbVsHttpSingleBlock(actions["rewrite_url"]); rewrite != nil {
+			rule.RewriteURLAction = &nsxtAlbVsHttpRewriteURLAction{
+				HostHeader:   rewrite["host_header"].(string),
+				ExistingPath: rewrite["existing_path"].(string),
+				KeepQuery:    rewrite["keep_query"].(bool),
+			}
+		}
+		rule.HeaderActions = getNsxtAlbVsHttpModifyHeaderType(actions["modify_header"])
+		if rule.RedirectAction == nil && rule.RewriteURLAction == nil && len(rule.HeaderActions) == 0 {
+			return nil, fmt.Errorf("rule '%s' must have at least one action", rule.Name)
+		}
+		rules.Values = append(rules.Values, rule)
+	}
+	return rules, nil
+}
+
+func setNsxtAlbVsHttpReqRulesData(d *schema.ResourceData, rules *nsxtAlbVsHttpRules[nsxtAlbVsHttpRequestRule]) error {
+	var ruleBlocks []interface{}
+	for _, rule := range rules.Values {
+		actions := map[string]interface{}{
+			"modify_header": getNsxtAlbVsHttpModifyHeaderData(rule.HeaderActions),
+		}
+		if rule.RedirectAction != nil {
+			actions["redirect"] = getNsxtAlbVsHttpRedirectData(rule.RedirectAction)
+		}
+		if rule.RewriteURLAction != nil {
+			actions["rewrite_url"] = []interface{}{map[string]interface{}{
+				"host_header":   rule.RewriteURLAction.HostHeader,
+				"existing_path": rule.RewriteURLAction.ExistingPath,
+				"keep_query":    rule.RewriteURLAction.KeepQuery,
+			}}
+		}
+		ruleBlocks = append(ruleBlocks, map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getNsxtAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"actions":        []interface{}{actions},
+		})
+	}
+	err := d.Set("rule", ruleBlocks)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' blocks: %s", err)
+	}
+	return nil
+}
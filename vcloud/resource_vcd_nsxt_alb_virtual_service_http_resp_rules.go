@@ -0,0 +1,205 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpRespRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpRespRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpRespRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpRespRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ALB Virtual Service",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "HTTP response rules, evaluated in their order",
+				Elem: &schema.Resource{
+					Schema: nsxtAlbVsHttpRuleCommonSchema(nsxtAlbVsHttpMatchCriteriaSchema(true), nsxtAlbVsHttpRespRuleActionsSchema),
+				},
+			},
+		},
+	}
+}
+
+var nsxtAlbVsHttpRespRuleActionsSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"rewrite_location_header": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Rewrite the location header of the response",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"protocol": {
+						Type:         schema.TypeString,
+						Required:     true,
+						Description:  "Protocol of the new location - 'HTTP' or 'HTTPS'",
+						ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+					},
+					"host": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Host of the new location",
+					},
+					"port": {
+						Type:         schema.TypeInt,
+						Optional:     true,
+						Description:  "Port of the new location",
+						ValidateFunc: validation.IsPortNumber,
+					},
+					"path": {
+						Type:        schema.TypeString,
+						Optional:    true,
+						Description: "Path of the new location",
+					},
+					"keep_query": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Default:     true,
+						Description: "Keep the query of the original location",
+					},
+				},
+			},
+		},
+		"modify_header": nsxtAlbVsHttpModifyHeaderSchema,
+	},
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := checkNsxtAlbVsHttpRulesSupport(vcdClient, virtualServiceId)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP response rules] %s", err)
+	}
+	rules, err := getNsxtAlbVsHttpRespRulesType(d)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP response rules] %s", err)
+	}
+	err = updateNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpResponseRulesEndpoint, virtualServiceId, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP response rules] error setting rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+	return resourceVcdAlbVirtualServiceHttpRespRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpResponseRule]{}
+	err := getNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpResponseRulesEndpoint, d.Id(), rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] ALB Virtual Service '%s' not found. Removing HTTP response rules from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ALB Virtual Service HTTP response rules] error retrieving rules: %s", err)
+	}
+
+	err = setNsxtAlbVsHttpRespRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP response rules] %s", err)
+	}
+	dSet(d, "virtual_service_id", d.Id())
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdAlbVsHttpRulesRemove(d, meta, nsxtAlbVsHttpResponseRulesEndpoint, "HTTP response rules")
+}
+
+func resourceVcdAlbVirtualServiceHttpRespRulesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP response rules import initiated")
+	return resourceVcdAlbVsHttpRulesImport(d, meta)
+}
+
+func getNsxtAlbVsHttpRespRulesType(d *schema.ResourceData) (*nsxtAlbVsHttpRules[nsxtAlbVsHttpResponseRule], error) {
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpResponseRule]{Values: []nsxtAlbVsHttpResponseRule{}}
+	for _, rawRule := range d.Get("rule").([]interface{}) {
+		ruleMap := rawRule.(map[string]interface{})
+		rule := nsxtAlbVsHttpResponseRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getNsxtAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{})),
+		}
+		actions := nsxtAlbVsHttpSingleBlock(ruleMap["actions"])
+		if actions == nil {
+			return nil, fmt.Errorf("rule '%s' must have an 'actions' block", rule.Name)
+		}
+		if rewrite := nsxtAlbVsHttpSingleBlock(actions["rewrite_location_header"]); rewrite != nil {
+			rule.RewriteLocationHeaderAction = &nsxtAlbVsHttpRewriteLocationHeaderAction{
+				Protocol:  rewrite["protocol"].(string),
+				Host:      rewrite["host"].(string),
+				Path:      rewrite["path"].(string),
+				KeepQuery: rewrite["keep_query"].(bool),
+			}
+			if port := rewrite["port"].(int); port != 0 {
+				rule.RewriteLocationHeaderAction.Port = addrOf(port)
+			}
+		}
+		rule.HeaderActions = getNsxtAlbVsHttpModifyHeaderType(actions["modify_header"])
+		if rule.RewriteLocationHeaderAction == nil && len(rule.HeaderActions) == 0 {
+			return nil, fmt.Errorf("rule '%s' must have at least one action", rule.Name)
+		}
+		rules.Values = append(rules.Values, rule)
+	}
+	return rules, nil
+}
+
+func setNsxtAlbVsHttpRespRulesData(d *schema.ResourceData, rules *nsxtAlbVsHttpRules[nsxtAlbVsHttpResponseRule]) error {
+	var ruleBlocks []interface{}
+	for _, rule := range rules.Values {
+		actions := map[string]interface{}{
+			"modify_header": getNsxtAlbVsHttpModifyHeaderData(rule.HeaderActions),
+		}
+		if rewrite := rule.RewriteLocationHeaderAction; rewrite != nil {
+			rewriteBlock := map[string]interface{}{
+				"protocol":   rewrite.Protocol,
+				"host":       rewrite.Host,
+				"path":       rewrite.Path,
+				"keep_query": rewrite.KeepQuery,
+			}
+			if rewrite.Port != nil {
+				rewriteBlock["port"] = *rewrite.Port
+			}
+			actions["rewrite_location_header"] = []interface{}{rewriteBlock}
+		}
+		ruleBlocks = append(ruleBlocks, map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getNsxtAlbVsHttpMatchCriteriaData(rule.MatchCriteria, true),
+			"actions":        []interface{}{actions},
+		})
+	}
+	err := d.Set("rule", ruleBlocks)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' blocks: %s", err)
+	}
+	return nil
+}
@@ -0,0 +1,826 @@
+package vcloud
+
+import (
+	"encoding/base64"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Endpoints of the HTTP policy sets of an ALB Virtual Service (VCD 10.5+). '%s' is the Virtual Service ID
+const (
+	nsxtAlbVsHttpRequestRulesEndpoint  = "loadBalancer/virtualServices/%s/httpRequestRules"
+	nsxtAlbVsHttpResponseRulesEndpoint = "loadBalancer/virtualServices/%s/httpResponseRules"
+	nsxtAlbVsHttpSecurityRulesEndpoint = "loadBalancer/virtualServices/%s/httpSecurityRules"
+)
+
+// nsxtAlbVsHttpRules is the body of an HTTP policy set. Rules are evaluated in their order
+type nsxtAlbVsHttpRules[T any] struct {
+	Values []T `json:"values"`
+}
+
+type nsxtAlbVsHttpRequestRule struct {
+	Name             string                            `json:"name"`
+	Active           bool                              `json:"active"`
+	Logging          bool                              `json:"logging"`
+	MatchCriteria    nsxtAlbVsHttpMatchCriteria        `json:"matchCriteria"`
+	RedirectAction   *nsxtAlbVsHttpRedirectAction      `json:"redirectAction,omitempty"`
+	RewriteURLAction *nsxtAlbVsHttpRewriteURLAction    `json:"rewriteUrlAction,omitempty"`
+	HeaderActions    []nsxtAlbVsHttpModifyHeaderAction `json:"headerActions,omitempty"`
+}
+
+type nsxtAlbVsHttpResponseRule struct {
+	Name                        string                                    `json:"name"`
+	Active                      bool                                      `json:"active"`
+	Logging                     bool                                      `json:"logging"`
+	MatchCriteria               nsxtAlbVsHttpMatchCriteria                `json:"matchCriteria"`
+	RewriteLocationHeaderAction *nsxtAlbVsHttpRewriteLocationHeaderAction `json:"rewriteLocationHeaderAction,omitempty"`
+	HeaderActions               []nsxtAlbVsHttpModifyHeaderAction         `json:"headerActions,omitempty"`
+}
+
+type nsxtAlbVsHttpSecurityRule struct {
+	Name                         string                        `json:"name"`
+	Active                       bool                          `json:"active"`
+	Logging                      bool                          `json:"logging"`
+	MatchCriteria                nsxtAlbVsHttpMatchCriteria    `json:"matchCriteria"`
+	AllowOrCloseConnectionAction string                        `json:"allowOrCloseConnectionAction,omitempty"`
+	RedirectToHTTPSAction        *nsxtAlbVsHttpRedirectToHttps `json:"redirectToHTTPSAction,omitempty"`
+	SendResponseAction           *nsxtAlbVsHttpSendResponse    `json:"sendResponseAction,omitempty"`
+	RateLimitAction              *nsxtAlbVsHttpRateLimitAction `json:"rateLimitAction,omitempty"`
+}
+
+// nsxtAlbVsHttpMatchCriteria holds the conditions of a rule. The last four are only used by response rules
+type nsxtAlbVsHttpMatchCriteria struct {
+	ClientIPMatch       *nsxtAlbVsHttpStringsMatch    `json:"clientIpMatch,omitempty"`
+	ServicePortMatch    *nsxtAlbVsHttpPortsMatch      `json:"servicePortMatch,omitempty"`
+	MethodMatch         *nsxtAlbVsHttpMethodsMatch    `json:"methodMatch,omitempty"`
+	Protocol            string                        `json:"protocol,omitempty"`
+	PathMatch           *nsxtAlbVsHttpPathMatch       `json:"pathMatch,omitempty"`
+	QueryMatch          []string                      `json:"queryMatch,omitempty"`
+	HeaderMatch         []nsxtAlbVsHttpHeaderMatch    `json:"headerMatch,omitempty"`
+	CookieMatch         *nsxtAlbVsHttpCookieMatch     `json:"cookieMatch,omitempty"`
+	LocationHeaderMatch *nsxtAlbVsHttpLocationMatch   `json:"locationHeaderMatch,omitempty"`
+	RequestHeaderMatch  []nsxtAlbVsHttpHeaderMatch    `json:"requestHeaderMatch,omitempty"`
+	ResponseHeaderMatch []nsxtAlbVsHttpHeaderMatch    `json:"responseHeaderMatch,omitempty"`
+	StatusCodeMatch     *nsxtAlbVsHttpStatusCodeMatch `json:"statusCodeMatch,omitempty"`
+}
+
+type nsxtAlbVsHttpStringsMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Addresses     []string `json:"addresses"`
+}
+
+type nsxtAlbVsHttpPortsMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Ports         []int  `json:"ports"`
+}
+
+type nsxtAlbVsHttpMethodsMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Methods       []string `json:"methods"`
+}
+
+type nsxtAlbVsHttpPathMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	MatchStrings  []string `json:"matchStrings"`
+}
+
+type nsxtAlbVsHttpHeaderMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Key           string   `json:"key"`
+	Value         []string `json:"value,omitempty"`
+}
+
+type nsxtAlbVsHttpCookieMatch struct {
+	MatchCriteria string `json:"matchCriteria"`
+	Key           string `json:"key"`
+	Value         string `json:"value,omitempty"`
+}
+
+type nsxtAlbVsHttpLocationMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	Value         []string `json:"value"`
+}
+
+type nsxtAlbVsHttpStatusCodeMatch struct {
+	MatchCriteria string   `json:"matchCriteria"`
+	StatusCodes   []string `json:"statusCodes"`
+}
+
+type nsxtAlbVsHttpRedirectAction struct {
+	Protocol   string `json:"protocol"`
+	Host       string `json:"host,omitempty"`
+	Port       *int   `json:"port,omitempty"`
+	StatusCode int    `json:"statusCode"`
+	Path       string `json:"path,omitempty"`
+	KeepQuery  bool   `json:"keepQuery"`
+}
+
+type nsxtAlbVsHttpRewriteURLAction struct {
+	HostHeader   string `json:"hostHeader,omitempty"`
+	ExistingPath string `json:"existingPath,omitempty"`
+	KeepQuery    bool   `json:"keepQuery"`
+}
+
+type nsxtAlbVsHttpRewriteLocationHeaderAction struct {
+	Protocol  string `json:"protocol"`
+	Host      string `json:"host,omitempty"`
+	Port      *int   `json:"port,omitempty"`
+	Path      string `json:"path,omitempty"`
+	KeepQuery bool   `json:"keepQuery"`
+}
+
+type nsxtAlbVsHttpModifyHeaderAction struct {
+	Action string `json:"action"`
+	Name   string `json:"name"`
+	Value  string `json:"value,omitempty"`
+}
+
+type nsxtAlbVsHttpRedirectToHttps struct {
+	Port int `json:"port"`
+}
+
+// nsxtAlbVsHttpSendResponse is a local response. Content is base64 encoded in the API
+type nsxtAlbVsHttpSendResponse struct {
+	StatusCode  string `json:"statusCode"`
+	ContentType string `json:"contentType,omitempty"`
+	Content     string `json:"content,omitempty"`
+}
+
+type nsxtAlbVsHttpRateLimitAction struct {
+	Count                 int                          `json:"count"`
+	Period                int                          `json:"period"`
+	CloseConnectionAction string                       `json:"closeConnectionAction,omitempty"`
+	RedirectAction        *nsxtAlbVsHttpRedirectAction `json:"redirectAction,omitempty"`
+	LocalResponseAction   *nsxtAlbVsHttpSendResponse   `json:"localResponseAction,omitempty"`
+}
+
+var nsxtAlbVsHttpStringCriteria = []string{"BEGINS_WITH", "DOES_NOT_BEGIN_WITH", "CONTAINS", "DOES_NOT_CONTAIN",
+	"ENDS_WITH", "DOES_NOT_END_WITH", "EQUALS", "DOES_NOT_EQUAL", "REGEX_MATCH", "REGEX_DOES_NOT_MATCH"}
+
+var nsxtAlbVsHttpHeaderCriteria = append([]string{"EXISTS", "DOES_NOT_EXIST"}, nsxtAlbVsHttpStringCriteria...)
+
+var nsxtAlbVsHttpSetCriteria = []string{"IS_IN", "IS_NOT_IN"}
+
+// nsxtAlbVsHttpRuleCommonSchema returns the fields shared by the rules of all policy sets
+func nsxtAlbVsHttpRuleCommonSchema(matchCriteria, actions *schema.Resource) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the rule",
+		},
+		"active": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Defines if the rule is active",
+		},
+		"logging": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     false,
+			Description: "Defines if the requests that match the rule are logged",
+		},
+		"match_criteria": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Criteria to match the requests. All requests match when empty",
+			Elem:        matchCriteria,
+		},
+		"actions": {
+			Type:        schema.TypeList,
+			Required:    true,
+			MaxItems:    1,
+			Description: "Actions to perform on the requests that match the criteria",
+			Elem:        actions,
+		},
+	}
+}
+
+// nsxtAlbVsHttpCriteriaSchema returns a 'criteria' field accepting the given values
+func nsxtAlbVsHttpCriteriaSchema(values []string) *schema.Schema {
+	return &schema.Schema{
+		Type:         schema.TypeString,
+		Required:     true,
+		Description:  "One of " + strings.Join(values, ", "),
+		ValidateFunc: validation.StringInSlice(values, false),
+	}
+}
+
+var nsxtAlbVsHttpHeaderMatchSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpHeaderCriteria),
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the HTTP header",
+		},
+		"values": {
+			Type:        schema.TypeSet,
+			Optional:    true,
+			Description: "Values to match. Not used with 'EXISTS' and 'DOES_NOT_EXIST'",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
+	},
+}
+
+// nsxtAlbVsHttpMatchCriteriaSchema returns the match criteria of request and security rules or, when response is
+// true, of response rules
+func nsxtAlbVsHttpMatchCriteriaSchema(response bool) *schema.Resource {
+	criteria := &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"client_ip_address": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Client IP addresses to match",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpSetCriteria),
+						"ip_addresses": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "IP addresses, ranges or CIDRs",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"service_ports": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Virtual Service ports to match",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpSetCriteria),
+						"ports": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "Ports",
+							Elem: &schema.Schema{
+								Type:         schema.TypeInt,
+								ValidateFunc: validation.IsPortNumber,
+							},
+						},
+					},
+				},
+			},
+			"protocol_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Description:  "Protocol to match - 'HTTP' or 'HTTPS'",
+				ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+			},
+			"http_methods": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "HTTP methods to match",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpSetCriteria),
+						"methods": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "HTTP methods, such as 'GET', 'POST' or 'PUT'",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"path": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Request paths to match",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpStringCriteria),
+						"paths": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							Description: "Paths or path patterns",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+			"query": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Query strings to match. The request matches if its query contains any of them",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"request_headers": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Request headers to match",
+				Elem:        nsxtAlbVsHttpHeaderMatchSchema,
+			},
+			"cookie": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "Cookie to match",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpHeaderCriteria),
+						"key": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "Name of the cookie",
+						},
+						"value": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "Value of the cookie. Not used with 'EXISTS' and 'DOES_NOT_EXIST'",
+						},
+					},
+				},
+			},
+		},
+	}
+	if !response {
+		return criteria
+	}
+
+	criteria.Schema["location_header"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Location header of the response to match",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpStringCriteria),
+				"values": {
+					Type:        schema.TypeSet,
+					Required:    true,
+					Description: "Values of the location header",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	criteria.Schema["response_headers"] = &schema.Schema{
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Description: "Response headers to match",
+		Elem:        nsxtAlbVsHttpHeaderMatchSchema,
+	}
+	criteria.Schema["status_code"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "HTTP status codes of the response to match",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"criteria": nsxtAlbVsHttpCriteriaSchema(nsxtAlbVsHttpSetCriteria),
+				"http_status_codes": {
+					Type:        schema.TypeSet,
+					Required:    true,
+					Description: "Status codes or ranges, such as '200' or '500-599'",
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+			},
+		},
+	}
+	return criteria
+}
+
+var nsxtAlbVsHttpModifyHeaderSchema = &schema.Schema{
+	Type:        schema.TypeList,
+	Optional:    true,
+	Description: "HTTP headers to add, remove or replace, in order",
+	Elem: &schema.Resource{
+		Schema: map[string]*schema.Schema{
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "One of 'ADD', 'REMOVE', 'REPLACE'",
+				ValidateFunc: validation.StringInSlice([]string{"ADD", "REMOVE", "REPLACE"}, false),
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Name of the HTTP header",
+			},
+			"value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Value of the HTTP header. Not used with 'REMOVE'",
+			},
+		},
+	},
+}
+
+// nsxtAlbVsHttpRedirectSchema is a redirect, with the status codes allowed for redirects
+var nsxtAlbVsHttpRedirectSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"protocol": {
+			Type:         schema.TypeString,
+			Required:     true,
+			Description:  "Protocol of the redirect - 'HTTP' or 'HTTPS'",
+			ValidateFunc: validation.StringInSlice([]string{"HTTP", "HTTPS"}, false),
+		},
+		"host": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Host of the redirect. The host of the request is kept when empty",
+		},
+		"port": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Port of the redirect. The port of the request is kept when empty",
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"status_code": {
+			Type:         schema.TypeInt,
+			Required:     true,
+			Description:  "HTTP status code of the redirect - 301, 302 or 307",
+			ValidateFunc: validation.IntInSlice([]int{301, 302, 307}),
+		},
+		"path": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Path of the redirect. The path of the request is kept when empty",
+		},
+		"keep_query": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Default:     true,
+			Description: "Keep the query of the request",
+		},
+	},
+}
+
+// nsxtAlbVsHttpSendResponseSchema is a response sent by the load balancer, instead of the pool members
+var nsxtAlbVsHttpSendResponseSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"status_code": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "HTTP status code of the response, such as '403' or '429'",
+		},
+		"content_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "MIME type of the content, such as 'text/plain'",
+		},
+		"content": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Content of the response",
+		},
+	},
+}
+
+// nsxtAlbVsHttpRulesApiVersion returns the API version of the HTTP policy endpoints, which were added in 38.0
+func nsxtAlbVsHttpRulesApiVersion(vcdClient *VCDClient) string {
+	return vcdClient.Client.GetSpecificApiVersionOnCondition(">=38.0", "38.0")
+}
+
+// getNsxtAlbVsHttpRules retrieves an HTTP policy set of a Virtual Service
+func getNsxtAlbVsHttpRules(vcdClient *VCDClient, endpoint, virtualServiceId string, rules interface{}) error {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + fmt.Sprintf(endpoint, virtualServiceId))
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiGetItem(nsxtAlbVsHttpRulesApiVersion(vcdClient), urlRef, nil, rules, nil)
+}
+
+// updateNsxtAlbVsHttpRules replaces an HTTP policy set of a Virtual Service
+func updateNsxtAlbVsHttpRules(vcdClient *VCDClient, endpoint, virtualServiceId string, rules interface{}) error {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + fmt.Sprintf(endpoint, virtualServiceId))
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiPutItem(nsxtAlbVsHttpRulesApiVersion(vcdClient), urlRef, nil, rules, nil, nil)
+}
+
+// checkNsxtAlbVsHttpRulesSupport checks that the VCD version supports HTTP policies, and that the Virtual Service
+// exists
+func checkNsxtAlbVsHttpRulesSupport(vcdClient *VCDClient, virtualServiceId string) error {
+	if vcdClient.Client.APIVCDMaxVersionIs("< 38.0") {
+		return fmt.Errorf("ALB Virtual Service HTTP policies require VCD 10.5.0+")
+	}
+	_, err := vcdClient.GetAlbVirtualServiceById(virtualServiceId)
+	if err != nil {
+		return fmt.Errorf("could not retrieve NSX-T ALB Virtual Service: %s", err)
+	}
+	return nil
+}
+
+// resourceVcdAlbVsHttpRulesRemove removes all the rules of an HTTP policy set
+func resourceVcdAlbVsHttpRulesRemove(d *schema.ResourceData, meta interface{}, endpoint, label string) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := updateNsxtAlbVsHttpRules(vcdClient, endpoint, virtualServiceId, &nsxtAlbVsHttpRules[any]{Values: []any{}})
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] ALB Virtual Service '%s' not found while removing its %s", virtualServiceId, label)
+			return nil
+		}
+		return diag.Errorf("error removing NSX-T ALB Virtual Service %s: %s", label, err)
+	}
+	return nil
+}
+
+// resourceVcdAlbVsHttpRulesImport sets the Virtual Service ID, given as
+// org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name
+func resourceVcdAlbVsHttpRulesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	if virtualServiceId := importWholeEntityId("urn:vcloud:loadBalancerVirtualService:", d.Id()); virtualServiceId != "" {
+		albVirtualService, err := vcdClient.GetAlbVirtualServiceById(virtualServiceId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", virtualServiceId, err)
+		}
+		dSet(d, "virtual_service_id", albVirtualService.NsxtAlbVirtualService.ID)
+		d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.virtual_service_name, " +
+			"as nsxt-edge-gw-ID.virtual_service_name or as virtual service ID")
+	}
+	orgName, vdcOrVdcGroupName, edgeName, virtualServiceName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T edge gateway '%s': %s", edgeName, err)
+	}
+	albVirtualService, err := vcdClient.GetAlbVirtualServiceByName(edge.EdgeGateway.ID, virtualServiceName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T ALB Virtual Service '%s': %s", virtualServiceName, err)
+	}
+
+	dSet(d, "virtual_service_id", albVirtualService.NsxtAlbVirtualService.ID)
+	d.SetId(albVirtualService.NsxtAlbVirtualService.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getNsxtAlbVsHttpMatchCriteriaType converts a 'match_criteria' block
+func getNsxtAlbVsHttpMatchCriteriaType(blocks []interface{}) nsxtAlbVsHttpMatchCriteria {
+	result := nsxtAlbVsHttpMatchCriteria{}
+	if len(blocks) == 0 || blocks[0] == nil {
+		return result
+	}
+	criteria := blocks[0].(map[string]interface{})
+
+	if block := nsxtAlbVsHttpSingleBlock(criteria["client_ip_address"]); block != nil {
+		result.ClientIPMatch = &nsxtAlbVsHttpStringsMatch{
+			MatchCriteria: block["criteria"].(string),
+			Addresses:     convertSchemaSetToSliceOfStrings(block["ip_addresses"].(*schema.Set)),
+		}
+	}
+	if block := nsxtAlbVsHttpSingleBlock(criteria["service_ports"]); block != nil {
+		ports := &nsxtAlbVsHttpPortsMatch{MatchCriteria: block["criteria"].(string)}
+		for _, port := range block["ports"].(*schema.Set).List() {
+			ports.Ports = append(ports.Ports, port.(int))
+		}
+		result.ServicePortMatch = ports
+	}
+	result.Protocol = criteria["protocol_type"].(string)
+	if block := nsxtAlbVsHttpSingleBlock(criteria["http_methods"]); block != nil {
+		result.MethodMatch = &nsxtAlbVsHttpMethodsMatch{
+			MatchCriteria: block["criteria"].(string),
+			Methods:       convertSchemaSetToSliceOfStrings(block["methods"].(*schema.Set)),
+		}
+	}
+	if block := nsxtAlbVsHttpSingleBlock(criteria["path"]); block != nil {
+		result.PathMatch = &nsxtAlbVsHttpPathMatch{
+			MatchCriteria: block["criteria"].(string),
+			MatchStrings:  convertSchemaSetToSliceOfStrings(block["paths"].(*schema.Set)),
+		}
+	}
+	result.QueryMatch = convertSchemaSetToSliceOfStrings(criteria["query"].(*schema.Set))
+	result.HeaderMatch = getNsxtAlbVsHttpHeaderMatchType(criteria["request_headers"])
+	if block := nsxtAlbVsHttpSingleBlock(criteria["cookie"]); block != nil {
+		result.CookieMatch = &nsxtAlbVsHttpCookieMatch{
+			MatchCriteria: block["criteria"].(string),
+			Key:           block["key"].(string),
+			Value:         block["value"].(string),
+		}
+	}
+
+	// Response rules match request headers in a separate field
+	if _, isResponse := criteria["response_headers"]; isResponse {
+		result.RequestHeaderMatch, result.HeaderMatch = result.HeaderMatch, nil
+		result.ResponseHeaderMatch = getNsxtAlbVsHttpHeaderMatchType(criteria["response_headers"])
+		if block := nsxtAlbVsHttpSingleBlock(criteria["location_header"]); block != nil {
+			result.LocationHeaderMatch = &nsxtAlbVsHttpLocationMatch{
+				MatchCriteria: block["criteria"].(string),
+				Value:         convertSchemaSetToSliceOfStrings(block["values"].(*schema.Set)),
+			}
+		}
+		if block := nsxtAlbVsHttpSingleBlock(criteria["status_code"]); block != nil {
+			result.StatusCodeMatch = &nsxtAlbVsHttpStatusCodeMatch{
+				MatchCriteria: block["criteria"].(string),
+				StatusCodes:   convertSchemaSetToSliceOfStrings(block["http_status_codes"].(*schema.Set)),
+			}
+		}
+	}
+	return result
+}
+
+func getNsxtAlbVsHttpHeaderMatchType(value interface{}) []nsxtAlbVsHttpHeaderMatch {
+	var headers []nsxtAlbVsHttpHeaderMatch
+	for _, header := range value.(*schema.Set).List() {
+		headerMap := header.(map[string]interface{})
+		headers = append(headers, nsxtAlbVsHttpHeaderMatch{
+			MatchCriteria: headerMap["criteria"].(string),
+			Key:           headerMap["name"].(string),
+			Value:         convertSchemaSetToSliceOfStrings(headerMap["values"].(*schema.Set)),
+		})
+	}
+	return headers
+}
+
+// getNsxtAlbVsHttpMatchCriteriaData converts match criteria to a 'match_criteria' block. The block is left empty
+// when there are no criteria
+func getNsxtAlbVsHttpMatchCriteriaData(criteria nsxtAlbVsHttpMatchCriteria, response bool) []interface{} {
+	result := make(map[string]interface{})
+	if criteria.ClientIPMatch != nil {
+		result["client_ip_address"] = []interface{}{map[string]interface{}{
+			"criteria":     criteria.ClientIPMatch.MatchCriteria,
+			"ip_addresses": convertStringsToTypeSet(criteria.ClientIPMatch.Addresses),
+		}}
+	}
+	if criteria.ServicePortMatch != nil {
+		var ports []interface{}
+		for _, port := range criteria.ServicePortMatch.Ports {
+			ports = append(ports, port)
+		}
+		result["service_ports"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.ServicePortMatch.MatchCriteria,
+			"ports":    schema.NewSet(schema.HashInt, ports),
+		}}
+	}
+	if criteria.Protocol != "" {
+		result["protocol_type"] = criteria.Protocol
+	}
+	if criteria.MethodMatch != nil {
+		result["http_methods"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.MethodMatch.MatchCriteria,
+			"methods":  convertStringsToTypeSet(criteria.MethodMatch.Methods),
+		}}
+	}
+	if criteria.PathMatch != nil {
+		result["path"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.PathMatch.MatchCriteria,
+			"paths":    convertStringsToTypeSet(criteria.PathMatch.MatchStrings),
+		}}
+	}
+	if len(criteria.QueryMatch) > 0 {
+		result["query"] = convertStringsToTypeSet(criteria.QueryMatch)
+	}
+	requestHeaders := criteria.HeaderMatch
+	if response {
+		requestHeaders = criteria.RequestHeaderMatch
+	}
+	if len(requestHeaders) > 0 {
+		result["request_headers"] = getNsxtAlbVsHttpHeaderMatchData(requestHeaders)
+	}
+	if criteria.CookieMatch != nil {
+		result["cookie"] = []interface{}{map[string]interface{}{
+			"criteria": criteria.CookieMatch.MatchCriteria,
+			"key":      criteria.CookieMatch.Key,
+			"value":    criteria.CookieMatch.Value,
+		}}
+	}
+	if response {
+		if len(criteria.ResponseHeaderMatch) > 0 {
+			result["response_headers"] = getNsxtAlbVsHttpHeaderMatchData(criteria.ResponseHeaderMatch)
+		}
+		if criteria.LocationHeaderMatch != nil {
+			result["location_header"] = []interface{}{map[string]interface{}{
+				"criteria": criteria.LocationHeaderMatch.MatchCriteria,
+				"values":   convertStringsToTypeSet(criteria.LocationHeaderMatch.Value),
+			}}
+		}
+		if criteria.StatusCodeMatch != nil {
+			result["status_code"] = []interface{}{map[string]interface{}{
+				"criteria":          criteria.StatusCodeMatch.MatchCriteria,
+				"http_status_codes": convertStringsToTypeSet(criteria.StatusCodeMatch.StatusCodes),
+			}}
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return []interface{}{result}
+}
+
+func getNsxtAlbVsHttpHeaderMatchData(headers []nsxtAlbVsHttpHeaderMatch) []interface{} {
+	var result []interface{}
+	for _, header := range headers {
+		result = append(result, map[string]interface{}{
+			"criteria": header.MatchCriteria,
+			"name":     header.Key,
+			"values":   convertStringsToTypeSet(header.Value),
+		})
+	}
+	return result
+}
+
+func getNsxtAlbVsHttpModifyHeaderType(value interface{}) []nsxtAlbVsHttpModifyHeaderAction {
+	var headers []nsxtAlbVsHttpModifyHeaderAction
+	for _, header := range value.([]interface{}) {
+		headerMap := header.(map[string]interface{})
+		headers = append(headers, nsxtAlbVsHttpModifyHeaderAction{
+			Action: headerMap["action"].(string),
+			Name:   headerMap["name"].(string),
+			Value:  headerMap["value"].(string),
+		})
+	}
+	return headers
+}
+
+func getNsxtAlbVsHttpModifyHeaderData(headers []nsxtAlbVsHttpModifyHeaderAction) []interface{} {
+	var result []interface{}
+	for _, header := range headers {
+		result = append(result, map[string]interface{}{
+			"action": header.Action,
+			"name":   header.Name,
+			"value":  header.Value,
+		})
+	}
+	return result
+}
+
+func getNsxtAlbVsHttpRedirectType(block map[string]interface{}) *nsxtAlbVsHttpRedirectAction {
+	redirect := &nsxtAlbVsHttpRedirectAction{
+		Protocol:   block["protocol"].(string),
+		Host:       block["host"].(string),
+		StatusCode: block["status_code"].(int),
+		Path:       block["path"].(string),
+		KeepQuery:  block["keep_query"].(bool),
+	}
+	if port := block["port"].(int); port != 0 {
+		redirect.Port = addrOf(port)
+	}
+	return redirect
+}
+
+func getNsxtAlbVsHttpRedirectData(redirect *nsxtAlbVsHttpRedirectAction) []interface{} {
+	block := map[string]interface{}{
+		"protocol":    redirect.Protocol,
+		"host":        redirect.Host,
+		"status_code": redirect.StatusCode,
+		"path":        redirect.Path,
+		"keep_query":  redirect.KeepQuery,
+	}
+	if redirect.Port != nil {
+		block["port"] = *redirect.Port
+	}
+	return []interface{}{block}
+}
+
+func getNsxtAlbVsHttpSendResponseType(block map[string]interface{}) *nsxtAlbVsHttpSendResponse {
+	return &nsxtAlbVsHttpSendResponse{
+		StatusCode:  block["status_code"].(string),
+		ContentType: block["content_type"].(string),
+		Content:     base64.StdEncoding.EncodeToString([]byte(block["content"].(string))),
+	}
+}
+
+func getNsxtAlbVsHttpSendResponseData(response *nsxtAlbVsHttpSendResponse) ([]interface{}, error) {
+	content, err := base64.StdEncoding.DecodeString(response.Content)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding response content: %s", err)
+	}
+	return []interface{}{map[string]interface{}{
+		"status_code":  response.StatusCode,
+		"content_type": response.ContentType,
+		"content":      string(content),
+	}}, nil
+}
+
+// nsxtAlbVsHttpSingleBlock returns the content of a block with MaxItems 1, or nil when it is not set
+func nsxtAlbVsHttpSingleBlock(value interface{}) map[string]interface{} {
+	blocks, ok := value.([]interface{})
+	if !ok || len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	return blocks[0].(map[string]interface{})
+}
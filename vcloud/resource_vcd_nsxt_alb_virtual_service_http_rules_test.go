@@ -0,0 +1,202 @@
+//go:build nsxt || alb || ALL || functional
+
+package vcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdNsxtAlbVirtualServiceHttpRules sets request, response and security rules of a Virtual Service
+func TestAccVcdNsxtAlbVirtualServiceHttpRules(t *testing.T) {
+	preTestChecks(t)
+	skipIfNotSysAdmin(t)
+	skipNoNsxtAlbConfiguration(t)
+
+	if checkVersion(testConfig.Provider.ApiVersion, "< 38.0") {
+		t.Skipf("This test tests VCD 10.5.0+ (API V38.0+) features. Skipping.")
+	}
+
+	var params = StringMap{
+		"TestName":           t.Name(),
+		"VirtualServiceName": t.Name(),
+		"ControllerName":     t.Name(),
+		"ControllerUrl":      testConfig.Nsxt.NsxtAlbControllerUrl,
+		"ControllerUsername": testConfig.Nsxt.NsxtAlbControllerUser,
+		"ControllerPassword": testConfig.Nsxt.NsxtAlbControllerPassword,
+		"ImportableCloud":    testConfig.Nsxt.NsxtAlbImportableCloud,
+		"ReservationModel":   "DEDICATED",
+		"Org":                testConfig.VCD.Org,
+		"NsxtVdc":            testConfig.Nsxt.Vdc,
+		"EdgeGw":             testConfig.Nsxt.EdgeGateway,
+		"IsActive":           "true",
+		"RateLimit":          "100",
+		"Tags":               "nsxt alb",
+	}
+	changeSupportedFeatureSetIfVersionIsLessThan37("LicenseType", "SupportedFeatureSet", params, false)
+	testParamsNotEmpty(t, params)
+
+	params["FuncName"] = t.Name() + "step1"
+	configText1 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpRules, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "step2"
+	params["RateLimit"] = "50"
+	configText2 := templateFill(testAccVcdNsxtAlbVirtualServiceHttpRules, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdAlbVirtualServiceDestroy("vcd_nsxt_alb_virtual_service.test"),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("vcd_nsxt_alb_virtual_service_http_req_rules.test", "id", "vcd_nsxt_alb_virtual_service.test", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.name", "redirect-old-path"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.0.actions.0.redirect.0.status_code", "301"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_req_rules.test", "rule.1.actions.0.modify_header.#", "2"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_resp_rules.test", "rule.0.actions.0.modify_header.0.name", "Strict-Transport-Security"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.#", "2"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.0.actions.0.connections", "CLOSE"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.1.actions.0.rate_limit.0.count", "100"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.1.actions.0.rate_limit.0.count", "50"),
+					resource.TestCheckResourceAttr("vcd_nsxt_alb_virtual_service_http_sec_rules.test", "rule.1.actions.0.rate_limit.0.action_local_response.0.content", "Too many requests"),
+				),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_virtual_service_http_req_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+			},
+			{
+				ResourceName:      "vcd_nsxt_alb_virtual_service_http_sec_rules.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, params["VirtualServiceName"].(string)),
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtAlbVirtualServiceHttpRules = testAccVcdNsxtAlbVirtualServiceStep1 + `
+resource "vcd_nsxt_alb_virtual_service_http_req_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "redirect-old-path"
+    match_criteria {
+      path {
+        criteria = "BEGINS_WITH"
+        paths    = ["/old"]
+      }
+    }
+    actions {
+      redirect {
+        protocol    = "HTTP"
+        path        = "/new"
+        status_code = 301
+      }
+    }
+  }
+
+  rule {
+    name    = "tag-api-requests"
+    logging = true
+    match_criteria {
+      http_methods {
+        criteria = "IS_IN"
+        methods  = ["GET", "POST"]
+      }
+      request_headers {
+        criteria = "EXISTS"
+        name     = "X-Api-Key"
+      }
+    }
+    actions {
+      modify_header {
+        action = "ADD"
+        name   = "X-Forwarded-By"
+        value  = "alb"
+      }
+      modify_header {
+        action = "REMOVE"
+        name   = "X-Debug"
+      }
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_resp_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "hsts"
+    match_criteria {
+      status_code {
+        criteria          = "IS_IN"
+        http_status_codes = ["200-299"]
+      }
+    }
+    actions {
+      modify_header {
+        action = "ADD"
+        name   = "Strict-Transport-Security"
+        value  = "max-age=31536000"
+      }
+    }
+  }
+}
+
+resource "vcd_nsxt_alb_virtual_service_http_sec_rules" "test" {
+  virtual_service_id = vcd_nsxt_alb_virtual_service.test.id
+
+  rule {
+    name = "deny-blocked-network"
+    match_criteria {
+      client_ip_address {
+        criteria     = "IS_IN"
+        ip_addresses = ["198.51.100.0/24"]
+      }
+    }
+    actions {
+      connections = "CLOSE"
+    }
+  }
+
+  rule {
+    name = "limit-login"
+    match_criteria {
+      path {
+        criteria = "EQUALS"
+        paths    = ["/login"]
+      }
+    }
+    actions {
+      rate_limit {
+        count  = {{.RateLimit}}
+        period = 60
+        action_local_response {
+          status_code  = "429"
+          content_type = "text/plain"
+          content      = "Too many requests"
+        }
+      }
+    }
+  }
+}
+`
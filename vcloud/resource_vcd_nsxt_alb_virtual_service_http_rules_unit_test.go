@@ -0,0 +1,115 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func Test_nsxtAlbVsHttpSecRulesRoundTrip(t *testing.T) {
+	resourceSchema := resourceVcdAlbVirtualServiceHttpSecRules().Schema
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"virtual_service_id": "urn:vcloud:virtualservice:1",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"name": "https-only",
+				"match_criteria": []interface{}{map[string]interface{}{
+					"protocol_type": "HTTP",
+					"service_ports": []interface{}{map[string]interface{}{"criteria": "IS_IN", "ports": []interface{}{80, 8080}}},
+				}},
+				"actions": []interface{}{map[string]interface{}{"redirect_to_https": 443}},
+			},
+			map[string]interface{}{
+				"name": "limit",
+				"actions": []interface{}{map[string]interface{}{
+					"rate_limit": []interface{}{map[string]interface{}{
+						"count":  10,
+						"period": 1,
+						"action_local_response": []interface{}{map[string]interface{}{
+							"status_code": "429",
+							"content":     "slow down",
+						}},
+					}},
+				}},
+			},
+		},
+	})
+
+	rules, err := getNsxtAlbVsHttpSecRulesType(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	body, err := json.Marshal(rules)
+	if err != nil {
+		t.Fatalf("error encoding rules: %s", err)
+	}
+	for _, expected := range []string{`"redirectToHTTPSAction":{"port":443}`, `"protocol":"HTTP"`,
+		`"content":"c2xvdyBkb3du"`, `"active":true`} {
+		if !strings.Contains(string(body), expected) {
+			t.Errorf("expected %s in %s", expected, body)
+		}
+	}
+
+	// Rules read back from VCD must produce the same configuration
+	readBack := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	err = setNsxtAlbVsHttpSecRulesData(readBack, rules)
+	if err != nil {
+		t.Fatalf("error setting rules: %s", err)
+	}
+	roundTrip, err := getNsxtAlbVsHttpSecRulesType(readBack)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !reflect.DeepEqual(rules, roundTrip) {
+		t.Errorf("rules changed after a round trip:\n%+v\n%+v", rules, roundTrip)
+	}
+
+	d = schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"rule": []interface{}{map[string]interface{}{
+			"name":    "two-actions",
+			"actions": []interface{}{map[string]interface{}{"connections": "ALLOW", "redirect_to_https": 443}},
+		}},
+	})
+	if _, err = getNsxtAlbVsHttpSecRulesType(d); err == nil {
+		t.Errorf("expected an error for a security rule with two actions")
+	}
+}
+
+func Test_nsxtAlbVsHttpRespRulesHeaders(t *testing.T) {
+	resourceSchema := resourceVcdAlbVirtualServiceHttpRespRules().Schema
+	d := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{
+		"rule": []interface{}{map[string]interface{}{
+			"name": "headers",
+			"match_criteria": []interface{}{map[string]interface{}{
+				"request_headers":  []interface{}{map[string]interface{}{"criteria": "EXISTS", "name": "X-Request"}},
+				"response_headers": []interface{}{map[string]interface{}{"criteria": "EQUALS", "name": "Server", "values": []interface{}{"nginx"}}},
+			}},
+			"actions": []interface{}{map[string]interface{}{
+				"modify_header": []interface{}{map[string]interface{}{"action": "REMOVE", "name": "Server"}},
+			}},
+		}},
+	})
+	rules, err := getNsxtAlbVsHttpRespRulesType(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	criteria := rules.Values[0].MatchCriteria
+	// Response rules send request headers in a field of their own
+	if len(criteria.HeaderMatch) != 0 || len(criteria.RequestHeaderMatch) != 1 || len(criteria.ResponseHeaderMatch) != 1 {
+		t.Errorf("unexpected header criteria: %+v", criteria)
+	}
+
+	readBack := schema.TestResourceDataRaw(t, resourceSchema, map[string]interface{}{})
+	err = setNsxtAlbVsHttpRespRulesData(readBack, rules)
+	if err != nil {
+		t.Fatalf("error setting rules: %s", err)
+	}
+	if name := readBack.Get("rule.0.match_criteria.0.request_headers").(*schema.Set).List()[0].(map[string]interface{})["name"]; name != "X-Request" {
+		t.Errorf("expected request header 'X-Request', got %v", name)
+	}
+}
@@ -0,0 +1,280 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+func resourceVcdAlbVirtualServiceHttpSecRules() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		ReadContext:   resourceVcdAlbVirtualServiceHttpSecRulesRead,
+		UpdateContext: resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate,
+		DeleteContext: resourceVcdAlbVirtualServiceHttpSecRulesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdAlbVirtualServiceHttpSecRulesImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"virtual_service_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the ALB Virtual Service",
+			},
+			"rule": {
+				Type:        schema.TypeList,
+				Required:    true,
+				Description: "HTTP security rules, evaluated in their order",
+				Elem: &schema.Resource{
+					Schema: nsxtAlbVsHttpRuleCommonSchema(nsxtAlbVsHttpMatchCriteriaSchema(false), nsxtAlbVsHttpSecRuleActionsSchema),
+				},
+			},
+		},
+	}
+}
+
+// nsxtAlbVsHttpSecRuleActionsSchema holds the actions of a security rule, of which exactly one must be set
+var nsxtAlbVsHttpSecRuleActionsSchema = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"connections": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Description:  "Allow or close the connection - 'ALLOW' or 'CLOSE'",
+			ValidateFunc: validation.StringInSlice([]string{"ALLOW", "CLOSE"}, false),
+		},
+		"redirect_to_https": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			Description:  "Redirect HTTP requests to HTTPS on the given port",
+			ValidateFunc: validation.IsPortNumber,
+		},
+		"send_response": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Send a local response instead of forwarding the request to the pool",
+			Elem:        nsxtAlbVsHttpSendResponseSchema,
+		},
+		"rate_limit": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "Limit the rate of the requests that match the criteria",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"count": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Maximum number of requests in a period",
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"period": {
+						Type:         schema.TypeInt,
+						Required:     true,
+						Description:  "Period in seconds",
+						ValidateFunc: validation.IntAtLeast(1),
+					},
+					"action_close_connection": {
+						Type:        schema.TypeBool,
+						Optional:    true,
+						Description: "Close the connection of the requests above the limit",
+					},
+					"action_redirect": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Redirect the requests above the limit",
+						Elem:        nsxtAlbVsHttpRedirectSchema,
+					},
+					"action_local_response": {
+						Type:        schema.TypeList,
+						Optional:    true,
+						MaxItems:    1,
+						Description: "Send a local response to the requests above the limit",
+						Elem:        nsxtAlbVsHttpSendResponseSchema,
+					},
+				},
+			},
+		},
+	},
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	virtualServiceId := d.Get("virtual_service_id").(string)
+	vcdClient.lockById(virtualServiceId)
+	defer vcdClient.unlockById(virtualServiceId)
+
+	err := checkNsxtAlbVsHttpRulesSupport(vcdClient, virtualServiceId)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP security rules] %s", err)
+	}
+	rules, err := getNsxtAlbVsHttpSecRulesType(d)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP security rules] %s", err)
+	}
+	err = updateNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpSecurityRulesEndpoint, virtualServiceId, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP security rules] error setting rules: %s", err)
+	}
+
+	d.SetId(virtualServiceId)
+	return resourceVcdAlbVirtualServiceHttpSecRulesRead(ctx, d, meta)
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpSecurityRule]{}
+	err := getNsxtAlbVsHttpRules(vcdClient, nsxtAlbVsHttpSecurityRulesEndpoint, d.Id(), rules)
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] ALB Virtual Service '%s' not found. Removing HTTP security rules from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[ALB Virtual Service HTTP security rules] error retrieving rules: %s", err)
+	}
+
+	err = setNsxtAlbVsHttpSecRulesData(d, rules)
+	if err != nil {
+		return diag.Errorf("[ALB Virtual Service HTTP security rules] %s", err)
+	}
+	dSet(d, "virtual_service_id", d.Id())
+	return nil
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdAlbVsHttpRulesRemove(d, meta, nsxtAlbVsHttpSecurityRulesEndpoint, "HTTP security rules")
+}
+
+func resourceVcdAlbVirtualServiceHttpSecRulesImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T ALB Virtual Service HTTP security rules import initiated")
+	return resourceVcdAlbVsHttpRulesImport(d, meta)
+}
+
+func getNsxtAlbVsHttpSecRulesType(d *schema.ResourceData) (*nsxtAlbVsHttpRules[nsxtAlbVsHttpSecurityRule], error) {
+	rules := &nsxtAlbVsHttpRules[nsxtAlbVsHttpSecurityRule]{Values: []nsxtAlbVsHttpSecurityRule{}}
+	for _, rawRule := range d.Get("rule").([]interface{}) {
+		ruleMap := rawRule.(map[string]interface{})
+		rule := nsxtAlbVsHttpSecurityRule{
+			Name:          ruleMap["name"].(string),
+			Active:        ruleMap["active"].(bool),
+			Logging:       ruleMap["logging"].(bool),
+			MatchCriteria: getNsxtAlbVsHttpMatchCriteriaType(ruleMap["match_criteria"].([]interface{})),
+		}
+		actions := nsxtAlbVsHttpSingleBlock(ruleMap["actions"])
+		if actions == nil {
+			return nil, fmt.Errorf("rule '%s' must have an 'actions' block", rule.Name)
+		}
+
+		actionCount := 0
+		if connections := actions["connections"].(string); connections != "" {
+			rule.AllowOrCloseConnectionAction = connections
+			actionCount++
+		}
+		if port := actions["redirect_to_https"].(int); port != 0 {
+			rule.RedirectToHTTPSAction = &nsxtAlbVsHttpRedirectToHttps{Port: port}
+			actionCount++
+		}
+		if response := nsxtAlbVsHttpSingleBlock(actions["send_response"]); response != nil {
+			rule.SendResponseAction = getNsxtAlbVsHttpSendResponseType(response)
+			actionCount++
+		}
+		if rateLimit := nsxtAlbVsHttpSingleBlock(actions["rate_limit"]); rateLimit != nil {
+			rateLimitAction, err := getNsxtAlbVsHttpRateLimitType(rateLimit)
+			if err != nil {
+				return nil, fmt.Errorf("rule '%s': %s", rule.Name, err)
+			}
+			rule.RateLimitAction = rateLimitAction
+			actionCount++
+		}
+		if actionCount != 1 {
+			return nil, fmt.Errorf("rule '%s' must have exactly one of 'connections', 'redirect_to_https', "+
+				"'send_response' or 'rate_limit' actions", rule.Name)
+		}
+		rules.Values = append(rules.Values, rule)
+	}
+	return rules, nil
+}
+
+// getNsxtAlbVsHttpRateLimitType converts a 'rate_limit' block, which can have at most one action for the
+// requests above the limit
+func getNsxtAlbVsHttpRateLimitType(block map[string]interface{}) (*nsxtAlbVsHttpRateLimitAction, error) {
+	rateLimit := &nsxtAlbVsHttpRateLimitAction{
+		Count:  block["count"].(int),
+		Period: block["period"].(int),
+	}
+	actionCount := 0
+	if block["action_close_connection"].(bool) {
+		rateLimit.CloseConnectionAction = "CLOSE"
+		actionCount++
+	}
+	if redirect := nsxtAlbVsHttpSingleBlock(block["action_redirect"]); redirect != nil {
+		rateLimit.RedirectAction = getNsxtAlbVsHttpRedirectType(redirect)
+		actionCount++
+	}
+	if response := nsxtAlbVsHttpSingleBlock(block["action_local_response"]); response != nil {
+		rateLimit.LocalResponseAction = getNsxtAlbVsHttpSendResponseType(response)
+		actionCount++
+	}
+	if actionCount > 1 {
+		return nil, fmt.Errorf("'rate_limit' can only have one of 'action_close_connection', 'action_redirect' or 'action_local_response'")
+	}
+	return rateLimit, nil
+}
+
+func setNsxtAlbVsHttpSecRulesData(d *schema.ResourceData, rules *nsxtAlbVsHttpRules[nsxtAlbVsHttpSecurityRule]) error {
+	var ruleBlocks []interface{}
+	for _, rule := range rules.Values {
+		actions := map[string]interface{}{
+			"connections": rule.AllowOrCloseConnectionAction,
+		}
+		if rule.RedirectToHTTPSAction != nil {
+			actions["redirect_to_https"] = rule.RedirectToHTTPSAction.Port
+		}
+		if rule.SendResponseAction != nil {
+			response, err := getNsxtAlbVsHttpSendResponseData(rule.SendResponseAction)
+			if err != nil {
+				return fmt.Errorf("rule '%s': %s", rule.Name, err)
+			}
+			actions["send_response"] = response
+		}
+		if rateLimit := rule.RateLimitAction; rateLimit != nil {
+			rateLimitBlock := map[string]interface{}{
+				"count":                   rateLimit.Count,
+				"period":                  rateLimit.Period,
+				"action_close_connection": rateLimit.CloseConnectionAction != "",
+			}
+			if rateLimit.RedirectAction != nil {
+				rateLimitBlock["action_redirect"] = getNsxtAlbVsHttpRedirectData(rateLimit.RedirectAction)
+			}
+			if rateLimit.LocalResponseAction != nil {
+				response, err := getNsxtAlbVsHttpSendResponseData(rateLimit.LocalResponseAction)
+				if err != nil {
+					return fmt.Errorf("rule '%s': %s", rule.Name, err)
+				}
+				rateLimitBlock["action_local_response"] = response
+			}
+			actions["rate_limit"] = []interface{}{rateLimitBlock}
+		}
+		ruleBlocks = append(ruleBlocks, map[string]interface{}{
+			"name":           rule.Name,
+			"active":         rule.Active,
+			"logging":        rule.Logging,
+			"match_criteria": getNsxtAlbVsHttpMatchCriteriaData(rule.MatchCriteria, false),
+			"actions":        []interface{}{actions},
+		})
+	}
+	err := d.Set("rule", ruleBlocks)
+	if err != nil {
+		return fmt.Errorf("error setting 'rule' blocks: %s", err)
+	}
+	return nil
+}
@@ -216,13 +216,33 @@ func resourceVcdNsxtAppPortProfileDelete(_ context.Context, d *schema.ResourceDa
 }
 
 func resourceVcdNsxtAppPortProfileImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	if profileId := importWholeEntityId("urn:vcloud:applicationPortProfile:", d.Id()); profileId != "" {
+		var profile types.NsxtAppPortProfile
+		err := getOpenApiImportEntity(vcdClient, types.OpenApiEndpointAppPortProfiles, profileId, &profile)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find Application Port Profile: %s", err)
+		}
+		orgName := "System"
+		if profile.OrgRef != nil && profile.OrgRef.Name != "" {
+			orgName = profile.OrgRef.Name
+		}
+		if profile.Scope == types.ApplicationPortProfileScopeProvider {
+			dSet(d, "context_id", profile.ContextEntityId)
+		}
+		dSet(d, "org", orgName)
+		d.SetId(profile.ID)
+		return []*schema.ResourceData{d}, nil
+	}
 
 	// There are two paths of possible import of differently scoped NSX-T Application Port Profiles
 	// * PROVIDER (path contains 2 pieces nsxt_manager_name.app_port_profile_name)
-	// * TENANT (path contains 3 pieces org-name.vdc-or-vdc-group-name.app_port_profile_name)
-
-	vcdClient := meta.(*VCDClient)
+	// * TENANT (path contains 3 pieces org-name.vdc-or-vdc-group-name.app_port_profile_name, where
+	//   org-name.vdc-or-vdc-group-name can be replaced by the ID of the VDC or VDC Group)
+	resourceURI, err := importVdcOrVdcGroupPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	var nsxtAppPortProfile *govcd.NsxtAppPortProfile
 
@@ -276,7 +296,7 @@ func resourceVcdNsxtAppPortProfileImport(_ context.Context, d *schema.ResourceDa
 
 		// define an interface type to match VDC and VDC Groups
 		var vdcOrVdcGroup vdcOrVdcGroupHandler
-		_, vdcOrVdcGroup, err := vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
+		_, vdcOrVdcGroup, err = vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
 		if govcd.ContainsNotFound(err) {
 			adminOrg, err := vcdClient.GetAdminOrg(orgName)
 			if err != nil {
@@ -303,7 +323,8 @@ func resourceVcdNsxtAppPortProfileImport(_ context.Context, d *schema.ResourceDa
 	default:
 		return nil, fmt.Errorf("resource path must be specified in one of two formats, based on Application Port Profile scope:\n" +
 			"* PROVIDER (path contains 2 pieces nsxt_manager_name.app_port_profile_name)\n" +
-			"* TENANT (path contains 3 pieces org-name.vdc-name-or-vdc-group-name.app_port_profile_name)")
+			"* TENANT (path contains 3 pieces org-name.vdc-name-or-vdc-group-name.app_port_profile_name, or 2 pieces vdc-or-vdc-group-ID.app_port_profile_name)\n" +
+			"* the ID of the Application Port Profile")
 	}
 
 	d.SetId(nsxtAppPortProfile.NsxtAppPortProfile.ID)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -236,14 +235,18 @@ func resourceVcdNsxtDistributedFirewallDelete(_ context.Context, d *schema.Resou
 func resourceVcdNsxtDistributedFirewallImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T Distributed Firewall import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVdcGroupPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name " +
+			"or as vdc-group-ID")
 	}
 
 	orgName, vdcGroupName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[Distributed Firewall Import] error retrieving org %s: %s", orgName, err)
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -262,14 +261,18 @@ func resourceVcdNsxtDistributedFirewallRuleDelete(_ context.Context, d *schema.R
 func resourceVcdNsxtDistributedFirewallRuleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T Distributed Firewall Rule import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVdcGroupPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.fw-rule-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.fw-rule-name " +
+			"or as vdc-group-ID.fw-rule-name")
 	}
 
 	orgName, vdcGroupName, fwRuleName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[Distributed Firewall Rule Import] error retrieving org %s: %s", orgName, err)
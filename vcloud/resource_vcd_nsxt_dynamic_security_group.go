@@ -3,7 +3,6 @@ package vcloud
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -234,15 +233,18 @@ func resourceVcdDynamicSecurityGroupDelete(_ context.Context, d *schema.Resource
 }
 
 func resourceVcdDynamicSecurityGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVdcGroupPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.security_group_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name.security_group_name " +
+			"or as vdc-group-ID.security_group_name")
 	}
 	orgName, vdcGroupName, securityGroupName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
-
-	org, err := vcdClient.GetAdminOrgFromResource(d)
+	org, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[nsxt dynamic security group read] error retrieving Org: %s", err)
 	}
@@ -252,7 +254,7 @@ func resourceVcdDynamicSecurityGroupImport(_ context.Context, d *schema.Resource
 		return nil, fmt.Errorf("error retrieving VDC Group: %s", err)
 	}
 
-	securityGroup, err := vdcGroup.GetNsxtFirewallGroupByName(securityGroupName, types.FirewallGroupTypeVmCriteria)
+	securityGroup, err := importNsxtFirewallGroup(vdcGroup, securityGroupName, types.FirewallGroupTypeVmCriteria)
 	if err != nil {
 		return nil, fmt.Errorf("[nsxt dynamic security group read] error getting NSX-T dynamic security group: %s", err)
 	}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -110,6 +111,9 @@ func resourceVcdNsxtEdgeGateway() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdNsxtEdgeGatewayImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"org": {
 				Type:     schema.TypeString,
@@ -230,6 +234,7 @@ func resourceVcdNsxtEdgeGateway() *schema.Resource {
 				Computed:    true,
 				Description: "Total number of IPs allocated for this Gateway from NSX-T Segment backed External Network uplinks",
 			},
+			"metadata_entry": openApiMetadataEntryResourceSchema("NSX-T Edge Gateway"),
 		},
 	}
 }
@@ -280,7 +285,7 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("could not create NSX-T Edge Gateway type: %s", err)
 	}
 
-	createdEdgeGateway, err := adminOrg.CreateNsxtEdgeGateway(nsxtEdgeGatewayType)
+	createdEdgeGateway, err := createNsxtEdgeGateway(ctx, vcdClient, adminOrg, nsxtEdgeGatewayType)
 	if err != nil {
 		return diag.Errorf("error creating NSX-T Edge Gateway: %s", err)
 	}
@@ -300,9 +305,41 @@ func resourceVcdNsxtEdgeGatewayCreate(ctx context.Context, d *schema.ResourceDat
 		}
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, nsxtEdgeGatewayMetadata(vcdClient, createdEdgeGateway.EdgeGateway))
+	if err != nil {
+		return diag.Errorf("error adding metadata to NSX-T Edge Gateway: %s", err)
+	}
+
 	return resourceVcdNsxtEdgeGatewayRead(ctx, d, meta)
 }
 
+// createNsxtEdgeGateway works like AdminOrg.CreateNsxtEdgeGateway, but the creation task stops waiting when
+// the context is done
+func createNsxtEdgeGateway(ctx context.Context, vcdClient *VCDClient, adminOrg *govcd.AdminOrg, edgeGatewayConfig *types.OpenAPIEdgeGateway) (*govcd.NsxtEdgeGateway, error) {
+	if !vcdClient.Client.IsSysAdmin {
+		return nil, fmt.Errorf("only System Administrator can create Edge Gateway")
+	}
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + types.OpenApiEndpointEdgeGateways)
+	if err != nil {
+		return nil, err
+	}
+	// Same API version used by the SDK, which exposes 'UsingIpSpace' in the uplinks from 37.1
+	apiVersion := vcdClient.Client.GetSpecificApiVersionOnCondition(">=37.1", "37.1")
+	task, err := vcdClient.Client.OpenApiPostItemAsync(apiVersion, urlRef, nil, edgeGatewayConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Edge Gateway: %s", err)
+	}
+	err = waitForTask(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Edge Gateway: %s", err)
+	}
+	if task.Task.Owner == nil || task.Task.Owner.ID == "" {
+		return nil, fmt.Errorf("error creating Edge Gateway: the creation task has no owner")
+	}
+	return adminOrg.GetNsxtEdgeGatewayById(task.Task.Owner.ID)
+}
+
 func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[TRACE] NSX-T Edge Gateway update initiated")
 
@@ -342,6 +379,11 @@ func resourceVcdNsxtEdgeGatewayUpdate(ctx context.Context, d *schema.ResourceDat
 		return diag.Errorf("error updating NSX-T Edge Gateway with ID '%s': %s", d.Id(), err)
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, nsxtEdgeGatewayMetadata(vcdClient, edge.EdgeGateway))
+	if err != nil {
+		return diag.Errorf("error updating NSX-T Edge Gateway metadata: %s", err)
+	}
+
 	return resourceVcdNsxtEdgeGatewayRead(ctx, d, meta)
 }
 
@@ -368,7 +410,13 @@ func resourceVcdNsxtEdgeGatewayRead(_ context.Context, d *schema.ResourceData, m
 	if err != nil {
 		return diag.Errorf("error setting NSX-T Edge Gateway data: %s", err)
 	}
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_nsxt_edgegateway", nsxtEdgeGatewayMetadata(vcdClient, edge.EdgeGateway))
+}
+
+// nsxtEdgeGatewayMetadata returns the handler of the OpenAPI metadata of an NSX-T Edge Gateway
+func nsxtEdgeGatewayMetadata(vcdClient *VCDClient, edgeGateway *types.OpenAPIEdgeGateway) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointEdgeGateways,
+		"vcd_nsxt_edgegateway", edgeGateway.ID, edgeGateway.Name)
 }
 
 func resourceVcdNsxtEdgeGatewayDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -396,13 +444,23 @@ func resourceVcdNsxtEdgeGatewayDelete(_ context.Context, d *schema.ResourceData,
 func resourceVcdNsxtEdgeGatewayImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T Edge Gateway import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.nsxt-edge-gw-name or org-name.vdc-group-name.nsxt-edge-gw-name")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcOrVdcGroupName, edgeName string
+	if edgeId := importEntityId("urn:vcloud:gateway:", d.Id()); edgeId != "" {
+		var err error
+		orgName, vdcOrVdcGroupName, edgeName, err = importNsxtEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T Edge Gateway with ID '%s': %s", edgeId, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.nsxt-edge-gw-name, org-name.vdc-group-name.nsxt-edge-gw-name or as Edge Gateway ID")
+		}
+		orgName, vdcOrVdcGroupName, edgeName = resourceURI[0], resourceURI[1], resourceURI[2]
 	}
-	orgName, vdcOrVdcGroupName, edgeName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -275,13 +275,21 @@ func resourceVcdEdgeBgpNeighborDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func resourceVcdEdgeBgpNeighborImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	// The neighbor IP can contain the separator
+	if len(resourceURI) > 4 {
+		resourceURI = append(resourceURI[:3], strings.Join(resourceURI[3:], ImportSeparator))
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.edge_gateway_name.bgp_neighbor_ip, got '%s'", d.Id())
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.edge_gateway_name.bgp_neighbor_ip "+
+			"or as edge_gateway_ID.bgp_neighbor_ip, got '%s'", d.Id())
 	}
 	orgName, vdcOrVdcGroupName, edgeGatewayName, bgpNeighborIp := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,817 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	// nsxtEdgeConfigFormat identifies the documents produced by the vcloud_nsxt_edgegateway_config data source
+	nsxtEdgeConfigFormat = "vcloud_nsxt_edgegateway_config"
+	// nsxtEdgeConfigVersion is the version of the document structure. Documents with a higher version are rejected
+	nsxtEdgeConfigVersion = 1
+)
+
+// nsxtEdgeConfigSection is a part of the Edge Gateway configuration, stored in the document with the JSON returned
+// by its OpenAPI endpoint. Sections are a single document updated with PUT, or a collection of entities, which are
+// matched with the ones of the target by the value of their key field
+type nsxtEdgeConfigSection struct {
+	name       string
+	endpoint   string // '%s' is the Edge Gateway ID
+	collection bool
+	key        string
+}
+
+// nsxtEdgeConfigSections lists the sections in the order they are applied: entities referenced by other sections
+// (such as BGP prefix lists, used by BGP neighbors) come first, and the firewall comes last
+var nsxtEdgeConfigSections = []nsxtEdgeConfigSection{
+	{name: "alb_settings", endpoint: types.OpenApiEndpointAlbEdgeGateway},
+	{name: "rate_limiting", endpoint: types.OpenApiEndpointEdgeGatewayQos},
+	{name: "dhcp_forwarding", endpoint: types.OpenApiEndpointEdgeGatewayDhcpForwarder},
+	{name: "dns", endpoint: types.OpenApiEndpointEdgeGatewayDns},
+	{name: "bgp_config", endpoint: types.OpenApiEndpointEdgeBgpConfig},
+	{name: "route_advertisement", endpoint: types.OpenApiEndpointNsxtRouteAdvertisement},
+	{name: "bgp_prefix_lists", endpoint: types.OpenApiEndpointEdgeBgpConfigPrefixLists, collection: true, key: "name"},
+	{name: "bgp_neighbors", endpoint: types.OpenApiEndpointEdgeBgpNeighbor, collection: true, key: "neighborAddress"},
+	{name: "static_routes", endpoint: types.OpenApiEndpointEdgeGatewayStaticRoutes, collection: true, key: "name"},
+	{name: "nat_rules", endpoint: types.OpenApiEndpointNsxtNatRules, collection: true, key: "name"},
+	{name: "ipsec_tunnels", endpoint: types.OpenApiEndpointIpSecVpnTunnel, collection: true, key: "name"},
+	{name: "firewall", endpoint: types.OpenApiEndpointNsxtFirewallRules},
+}
+
+// nsxtEdgeConfigDocument is the portable document with the configuration of an NSX-T Edge Gateway
+type nsxtEdgeConfigDocument struct {
+	Format   string                 `json:"format"`
+	Version  int                    `json:"version"`
+	Source   nsxtEdgeConfigSource   `json:"source"`
+	Objects  nsxtEdgeConfigObjects  `json:"objects"`
+	Sections map[string]interface{} `json:"sections"`
+}
+
+// nsxtEdgeConfigSource identifies the Edge Gateway a document was exported from. Its IDs are replaced by the ones
+// of the target Edge Gateway when the document is applied
+type nsxtEdgeConfigSource struct {
+	OrgId           string `json:"org_id"`
+	EdgeGatewayId   string `json:"edge_gateway_id"`
+	EdgeGatewayName string `json:"edge_gateway_name"`
+	OwnerId         string `json:"owner_id"`
+}
+
+// nsxtEdgeConfigObjects holds the entities referenced by the sections, which get a new ID in the target
+type nsxtEdgeConfigObjects struct {
+	IpSets          []map[string]interface{}    `json:"ip_sets"`
+	AppPortProfiles []map[string]interface{}    `json:"app_port_profiles"`
+	Certificates    []nsxtEdgeConfigCertificate `json:"certificates"`
+}
+
+// nsxtEdgeConfigCertificate is a certificate used by the sections. Private keys can't be exported: certificates
+// are found by name in the certificate library of the target Org
+type nsxtEdgeConfigCertificate struct {
+	Id   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func resourceVcdNsxtEdgeGatewayConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtEdgeGatewayConfigCreateUpdate,
+		ReadContext:   resourceVcdNsxtEdgeGatewayConfigRead,
+		UpdateContext: resourceVcdNsxtEdgeGatewayConfigCreateUpdate,
+		DeleteContext: resourceVcdNsxtEdgeGatewayConfigDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtEdgeGatewayConfigImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "ID of the Edge Gateway that receives the configuration",
+			},
+			"document": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Sensitive:   true,
+				Description: "Configuration document, as exported by the 'vcloud_nsxt_edgegateway_config' data source",
+			},
+			"sections": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "Sections of the document to apply. All sections in the document are applied when empty",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"id_mapping": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "IDs used in the document (key) to be replaced by the given IDs (value), such as networks or security groups",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"pre_shared_keys": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Pre-shared keys of IPsec VPN tunnels, by tunnel name. Required for the tunnels exported without secrets",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"remapped_ids": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "IDs of the document (key) replaced by IDs of the target (value) in the last apply",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceVcdNsxtEdgeGatewayConfigCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := vcdClient.lockParentVdcGroupOrEdgeGateway(d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] %s", err)
+	}
+	defer unlock()
+
+	orgName := d.Get("org").(string)
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(orgName, d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] error retrieving Edge Gateway: %s", err)
+	}
+	document, err := parseNsxtEdgeConfigDocument(d.Get("document").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] %s", err)
+	}
+	adminOrg, err := vcdClient.GetAdminOrgFromResource(d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] error retrieving Org: %s", err)
+	}
+
+	applier := &nsxtEdgeConfigApplier{
+		vcdClient:     vcdClient,
+		adminOrg:      adminOrg,
+		target:        nsxtEdge.EdgeGateway,
+		document:      document,
+		userMapping:   convertToStringMap(d.Get("id_mapping").(map[string]interface{})),
+		preSharedKeys: convertToStringMap(d.Get("pre_shared_keys").(map[string]interface{})),
+		sections:      convertSchemaSetToSliceOfStrings(d.Get("sections").(*schema.Set)),
+	}
+	err = applier.apply()
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] %s", err)
+	}
+	err = d.Set("remapped_ids", applier.remappedIds())
+	if err != nil {
+		return diag.Errorf("[NSX-T Edge Gateway config apply] error setting 'remapped_ids': %s", err)
+	}
+	d.SetId(nsxtEdge.EdgeGateway.ID)
+	return resourceVcdNsxtEdgeGatewayConfigRead(ctx, d, meta)
+}
+
+// resourceVcdNsxtEdgeGatewayConfigRead only checks that the Edge Gateway still exists. The document is the
+// desired configuration, and is applied again when it changes
+func resourceVcdNsxtEdgeGatewayConfigRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	_, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] Edge Gateway '%s' not found. Removing configuration from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[NSX-T Edge Gateway config read] error retrieving Edge Gateway: %s", err)
+	}
+	return nil
+}
+
+// resourceVcdNsxtEdgeGatewayConfigDelete removes the resource from state, leaving the applied configuration in place
+func resourceVcdNsxtEdgeGatewayConfigDelete(_ context.Context, d *schema.ResourceData, _ interface{}) diag.Diagnostics {
+	d.SetId("")
+	return nil
+}
+
+// resourceVcdNsxtEdgeGatewayConfigImport imports the configuration of an Edge Gateway, using the path of the Edge
+// Gateway (org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name) or its ID. The document is not read back, and is applied
+// again by the first update after the import
+func resourceVcdNsxtEdgeGatewayConfigImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+
+	var orgName string
+	var edge *govcd.NsxtEdgeGateway
+	var err error
+	if edgeId := importEntityId("urn:vcloud:gateway:", d.Id()); edgeId != "" {
+		orgName, _, _, err = importNsxtEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf("[NSX-T Edge Gateway config import] error retrieving Edge Gateway '%s': %s", edgeId, err)
+		}
+		edge, err = vcdClient.GetNsxtEdgeGatewayById(orgName, edgeId)
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("[NSX-T Edge Gateway config import] resource name must be specified as " +
+				"org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name or as Edge Gateway ID")
+		}
+		orgName = resourceURI[0]
+		var vdcOrVdcGroup vdcOrVdcGroupHandler
+		vdcOrVdcGroup, err = lookupVdcOrVdcGroup(vcdClient, orgName, resourceURI[1])
+		if err != nil {
+			return nil, err
+		}
+		edge, err = vdcOrVdcGroup.GetNsxtEdgeGatewayByName(resourceURI[2])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("[NSX-T Edge Gateway config import] error retrieving Edge Gateway: %s", err)
+	}
+
+	dSet(d, "org", orgName)
+	dSet(d, "edge_gateway_id", edge.EdgeGateway.ID)
+	d.SetId(edge.EdgeGateway.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// exportNsxtEdgeConfig builds the configuration document of an Edge Gateway. Sections whose endpoint is not
+// available in the VCD version are skipped. When redactSecrets is true, the pre-shared keys of IPsec VPN tunnels are
+// left empty
+func exportNsxtEdgeConfig(vcdClient *VCDClient, edge *types.OpenAPIEdgeGateway, redactSecrets bool) (*nsxtEdgeConfigDocument, error) {
+	document := &nsxtEdgeConfigDocument{
+		Format:  nsxtEdgeConfigFormat,
+		Version: nsxtEdgeConfigVersion,
+		Source: nsxtEdgeConfigSource{
+			EdgeGatewayId:   edge.ID,
+			EdgeGatewayName: edge.Name,
+		},
+		Objects: nsxtEdgeConfigObjects{
+			IpSets:          []map[string]interface{}{},
+			AppPortProfiles: []map[string]interface{}{},
+			Certificates:    []nsxtEdgeConfigCertificate{},
+		},
+		Sections: make(map[string]interface{}),
+	}
+	if edge.Org != nil {
+		document.Source.OrgId = edge.Org.ID
+	}
+	if edge.OwnerRef != nil {
+		document.Source.OwnerId = edge.OwnerRef.ID
+	}
+
+	ipSets, err := getNsxtEdgeConfigIpSets(vcdClient, edge.ID)
+	if err != nil {
+		return nil, err
+	}
+	document.Objects.IpSets = append(document.Objects.IpSets, ipSets...)
+	if document.Source.OwnerId != "" {
+		appPortProfiles, err := getNsxtEdgeConfigItems(vcdClient, types.OpenApiEndpointAppPortProfiles,
+			"scope==TENANT;_context=="+document.Source.OwnerId)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving Application Port Profiles: %s", err)
+		}
+		document.Objects.AppPortProfiles = append(document.Objects.AppPortProfiles, appPortProfiles...)
+	}
+
+	for _, section := range nsxtEdgeConfigSections {
+		endpoint := fmt.Sprintf(section.endpoint, edge.ID)
+		var value interface{}
+		if section.collection {
+			value, err = getNsxtEdgeConfigItems(vcdClient, endpoint, "")
+		} else {
+			item := make(map[string]interface{})
+			err = getNsxtEdgeConfigItem(vcdClient, endpoint, &item)
+			value = item
+			// Only user defined rules can be applied to another Edge Gateway
+			if section.name == "firewall" {
+				rules, _ := item["userDefinedRules"].([]interface{})
+				value = map[string]interface{}{"userDefinedRules": append([]interface{}{}, rules...)}
+			}
+		}
+		if err != nil {
+			if govcd.ContainsNotFound(err) {
+				log.Printf("[DEBUG] section '%s' is not available for Edge Gateway '%s': %s", section.name, edge.Name, err)
+				continue
+			}
+			return nil, fmt.Errorf("error retrieving section '%s': %s", section.name, err)
+		}
+		document.Sections[section.name] = value
+	}
+
+	if redactSecrets {
+		tunnels, _ := document.Sections["ipsec_tunnels"].([]map[string]interface{})
+		for _, tunnel := range tunnels {
+			tunnel["preSharedKey"] = ""
+		}
+	}
+	document.Objects.Certificates = append(document.Objects.Certificates, nsxtEdgeConfigCertificates(document.Sections)...)
+	return document, nil
+}
+
+// getNsxtEdgeConfigIpSets retrieves the IP sets owned by an Edge Gateway. Each one is retrieved again by ID, as
+// the list doesn't include the IP addresses in all VCD versions
+func getNsxtEdgeConfigIpSets(vcdClient *VCDClient, edgeGatewayId string) ([]map[string]interface{}, error) {
+	items, err := getNsxtEdgeConfigItems(vcdClient, types.OpenApiEndpointFirewallGroups, "typeValue==IP_SET;_context=="+edgeGatewayId)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving IP Sets: %s", err)
+	}
+	var ipSets []map[string]interface{}
+	for _, item := range items {
+		ownerRef, _ := item["ownerRef"].(map[string]interface{})
+		if ownerRef == nil || ownerRef["id"] != edgeGatewayId {
+			continue
+		}
+		ipSet := make(map[string]interface{})
+		err = getNsxtEdgeConfigItem(vcdClient, types.OpenApiEndpointFirewallGroups+fmt.Sprint(item["id"]), &ipSet)
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving IP Set '%s': %s", item["name"], err)
+		}
+		ipSets = append(ipSets, ipSet)
+	}
+	return ipSets, nil
+}
+
+// nsxtEdgeConfigCertificates finds the certificates referenced in the sections (e.g. 'certificateRef' and
+// 'caCertificateRef' of IPsec VPN tunnels)
+func nsxtEdgeConfigCertificates(value interface{}) []nsxtEdgeConfigCertificate {
+	var certificates []nsxtEdgeConfigCertificate
+	var walk func(value interface{})
+	walk = func(value interface{}) {
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			for key, item := range typedValue {
+				reference, isReference := item.(map[string]interface{})
+				if isReference && (key == "certificateRef" || key == "caCertificateRef") && reference["id"] != nil {
+					certificate := nsxtEdgeConfigCertificate{Id: fmt.Sprint(reference["id"]), Name: fmt.Sprint(reference["name"])}
+					if !slices.Contains(certificates, certificate) {
+						certificates = append(certificates, certificate)
+					}
+					continue
+				}
+				walk(item)
+			}
+		case []interface{}:
+			for _, item := range typedValue {
+				walk(item)
+			}
+		case []map[string]interface{}:
+			for _, item := range typedValue {
+				walk(item)
+			}
+		}
+	}
+	walk(value)
+	sort.Slice(certificates, func(i, j int) bool { return certificates[i].Id < certificates[j].Id })
+	return certificates
+}
+
+// parseNsxtEdgeConfigDocument decodes a configuration document and checks its format and version
+func parseNsxtEdgeConfigDocument(text string) (*nsxtEdgeConfigDocument, error) {
+	document := &nsxtEdgeConfigDocument{}
+	err := json.Unmarshal([]byte(text), document)
+	if err != nil {
+		return nil, fmt.Errorf("error decoding configuration document: %s", err)
+	}
+	if document.Format != nsxtEdgeConfigFormat {
+		return nil, fmt.Errorf("the document is not an Edge Gateway configuration (format '%s')", document.Format)
+	}
+	if document.Version < 1 || document.Version > nsxtEdgeConfigVersion {
+		return nil, fmt.Errorf("document version %d is not supported: the highest supported version is %d",
+			document.Version, nsxtEdgeConfigVersion)
+	}
+	for name := range document.Sections {
+		if getNsxtEdgeConfigSection(name) == nil {
+			return nil, fmt.Errorf("unknown section '%s' in configuration document", name)
+		}
+	}
+	return document, nil
+}
+
+func getNsxtEdgeConfigSection(name string) *nsxtEdgeConfigSection {
+	for index := range nsxtEdgeConfigSections {
+		if nsxtEdgeConfigSections[index].name == name {
+			return &nsxtEdgeConfigSections[index]
+		}
+	}
+	return nil
+}
+
+// nsxtEdgeConfigApplier applies a configuration document to an Edge Gateway, replacing the IDs of the source with
+// the ones of the target as the referenced entities are found or created. It takes over the selected sections: the
+// entities of the target that are not in the document are removed
+type nsxtEdgeConfigApplier struct {
+	vcdClient     *VCDClient
+	adminOrg      *govcd.AdminOrg
+	target        *types.OpenAPIEdgeGateway
+	document      *nsxtEdgeConfigDocument
+	userMapping   map[string]string
+	preSharedKeys map[string]string
+	sections      []string
+	mapping       map[string]string
+}
+
+func (applier *nsxtEdgeConfigApplier) apply() error {
+	for _, name := range applier.sections {
+		if getNsxtEdgeConfigSection(name) == nil {
+			return fmt.Errorf("unknown section '%s'", name)
+		}
+	}
+
+	source := applier.document.Source
+	applier.mapping = map[string]string{source.EdgeGatewayId: applier.target.ID}
+	if source.OwnerId != "" && applier.target.OwnerRef != nil {
+		applier.mapping[source.OwnerId] = applier.target.OwnerRef.ID
+	}
+	if source.OrgId != "" && applier.target.Org != nil {
+		applier.mapping[source.OrgId] = applier.target.Org.ID
+	}
+	for sourceId, targetId := range applier.userMapping {
+		applier.mapping[sourceId] = targetId
+	}
+
+	// Missing pre-shared keys are detected before changing the target
+	if applier.isSelected("ipsec_tunnels") {
+		tunnels, _ := applier.document.Sections["ipsec_tunnels"].([]interface{})
+		for _, tunnel := range tunnels {
+			if tunnelMap, ok := applier.remap(tunnel).(map[string]interface{}); ok {
+				err := applier.setPreSharedKey(fmt.Sprint(tunnelMap["name"]), tunnelMap)
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	err := applier.applyObjects()
+	if err != nil {
+		return err
+	}
+
+	var applied []nsxtEdgeConfigSection
+	staleItems := make(map[string][]map[string]interface{})
+	for _, section := range nsxtEdgeConfigSections {
+		if !applier.isSelected(section.name) {
+			continue
+		}
+		if section.collection {
+			staleItems[section.name], err = applier.applyCollection(section)
+		} else {
+			err = applier.applyDocument(section)
+		}
+		if err != nil {
+			return err
+		}
+		applied = append(applied, section)
+	}
+
+	// Entities that are not in the document are removed once the new configuration is in place, in reverse order,
+	// so that the ones referenced by others go last
+	for index := len(applied) - 1; index >= 0; index-- {
+		err = applier.removeItems(applied[index], staleItems[applied[index].name])
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isSelected tells whether a section is in the document and among the ones chosen in 'sections'
+func (applier *nsxtEdgeConfigApplier) isSelected(name string) bool {
+	if _, found := applier.document.Sections[name]; !found {
+		return false
+	}
+	return len(applier.sections) == 0 || slices.Contains(applier.sections, name)
+}
+
+// remappedIds returns the IDs of the document replaced during the apply
+func (applier *nsxtEdgeConfigApplier) remappedIds() map[string]interface{} {
+	result := make(map[string]interface{})
+	for sourceId, targetId := range applier.mapping {
+		if sourceId != targetId {
+			result[sourceId] = targetId
+		}
+	}
+	return result
+}
+
+// applyObjects updates the IP sets and Application Port Profiles with the same name in the target, or creates
+// them, and finds the certificates by name in the certificate library of the Org
+func (applier *nsxtEdgeConfigApplier) applyObjects() error {
+	existingIpSets, err := getNsxtEdgeConfigItems(applier.vcdClient, types.OpenApiEndpointFirewallGroups,
+		"typeValue==IP_SET;_context=="+applier.target.ID)
+	if err != nil {
+		return fmt.Errorf("error retrieving IP Sets of the target Edge Gateway: %s", err)
+	}
+	for _, ipSet := range applier.document.Objects.IpSets {
+		err = applier.applyObject(types.OpenApiEndpointFirewallGroups, "IP Set", ipSet, existingIpSets)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(applier.document.Objects.AppPortProfiles) > 0 {
+		if applier.target.OwnerRef == nil {
+			return fmt.Errorf("the target Edge Gateway has no owner, which is needed for Application Port Profiles")
+		}
+		existingProfiles, err := getNsxtEdgeConfigItems(applier.vcdClient, types.OpenApiEndpointAppPortProfiles,
+			"scope==TENANT;_context=="+applier.target.OwnerRef.ID)
+		if err != nil {
+			return fmt.Errorf("error retrieving Application Port Profiles of the target: %s", err)
+		}
+		for _, profile := range applier.document.Objects.AppPortProfiles {
+			err = applier.applyObject(types.OpenApiEndpointAppPortProfiles, "Application Port Profile", profile, existingProfiles)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, certificate := range applier.document.Objects.Certificates {
+		if _, mapped := applier.mapping[certificate.Id]; mapped {
+			continue
+		}
+		found, err := applier.adminOrg.GetCertificateFromLibraryByName(certificate.Name)
+		if err != nil {
+			return fmt.Errorf("error retrieving certificate '%s' from the library of Org '%s' (it can be mapped "+
+				"in 'id_mapping'): %s", certificate.Name, applier.adminOrg.AdminOrg.Name, err)
+		}
+		applier.mapping[certificate.Id] = found.CertificateLibrary.Id
+	}
+	return nil
+}
+
+// applyObject updates the entity with the same name in the target, or creates it, and maps its ID
+func (applier *nsxtEdgeConfigApplier) applyObject(endpoint, label string, object map[string]interface{}, existing []map[string]interface{}) error {
+	sourceId := fmt.Sprint(object["id"])
+	if _, mapped := applier.userMapping[sourceId]; mapped {
+		return nil
+	}
+	payload := applier.remap(object).(map[string]interface{})
+	delete(payload, "id")
+	for _, item := range existing {
+		if item["name"] != object["name"] {
+			continue
+		}
+		payload["id"] = item["id"]
+		urlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint + fmt.Sprint(item["id"]))
+		if err != nil {
+			return err
+		}
+		err = applier.vcdClient.Client.OpenApiPutItem(applier.vcdClient.Client.APIVersion, urlRef, nil, payload, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error updating %s '%s': %s", label, object["name"], err)
+		}
+		applier.mapping[sourceId] = fmt.Sprint(item["id"])
+		return nil
+	}
+
+	urlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint)
+	if err != nil {
+		return err
+	}
+	created := make(map[string]interface{})
+	err = applier.vcdClient.Client.OpenApiPostItem(applier.vcdClient.Client.APIVersion, urlRef, nil, payload, &created, nil)
+	if err != nil {
+		return fmt.Errorf("error creating %s '%s': %s", label, object["name"], err)
+	}
+	applier.mapping[sourceId] = fmt.Sprint(created["id"])
+	return nil
+}
+
+// applyDocument replaces a single document section. The version of the target document, used by VCD to detect
+// concurrent changes, is kept
+func (applier *nsxtEdgeConfigApplier) applyDocument(section nsxtEdgeConfigSection) error {
+	urlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 +
+		fmt.Sprintf(section.endpoint, applier.target.ID))
+	if err != nil {
+		return err
+	}
+	payload, ok := applier.remap(applier.document.Sections[section.name]).(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("section '%s' must be a JSON object", section.name)
+	}
+	current := make(map[string]interface{})
+	err = applier.vcdClient.Client.OpenApiGetItem(applier.vcdClient.Client.APIVersion, urlRef, nil, &current, nil)
+	if err != nil {
+		return fmt.Errorf("error retrieving section '%s' of the target Edge Gateway: %s", section.name, err)
+	}
+	if version, found := current["version"]; found {
+		payload["version"] = version
+	}
+	// Rules get new IDs in the target
+	if section.name == "firewall" {
+		rules, _ := payload["userDefinedRules"].([]interface{})
+		for _, rule := range rules {
+			if ruleMap, ok := rule.(map[string]interface{}); ok {
+				delete(ruleMap, "id")
+			}
+		}
+	}
+	err = applier.vcdClient.Client.OpenApiPutItem(applier.vcdClient.Client.APIVersion, urlRef, nil, payload, nil, nil)
+	if err != nil {
+		return fmt.Errorf("error applying section '%s': %s", section.name, err)
+	}
+	return nil
+}
+
+// applyCollection updates the entities of the target whose key matches an item of a collection section, and creates
+// the other items. It maps the IDs of the items, so that the following sections can refer to them, and returns the
+// entities of the target that are not in the document
+func (applier *nsxtEdgeConfigApplier) applyCollection(section nsxtEdgeConfigSection) ([]map[string]interface{}, error) {
+	endpoint := fmt.Sprintf(section.endpoint, applier.target.ID)
+	urlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint)
+	if err != nil {
+		return nil, err
+	}
+	items, ok := applier.document.Sections[section.name].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("section '%s' must be a JSON list", section.name)
+	}
+	existing, err := getNsxtEdgeConfigItems(applier.vcdClient, endpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving section '%s' of the target Edge Gateway: %s", section.name, err)
+	}
+	existingByKey := make(map[string][]map[string]interface{})
+	for _, item := range existing {
+		key := fmt.Sprint(item[section.key])
+		existingByKey[key] = append(existingByKey[key], item)
+	}
+
+	updatedIds := make(map[string]bool)
+	sourceIds := make(map[string]string)
+	for _, item := range items {
+		payload, ok := applier.remap(item).(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("items of section '%s' must be JSON objects", section.name)
+		}
+		key := fmt.Sprint(payload[section.key])
+		sourceId, hasSourceId := payload["id"]
+		delete(payload, "id")
+		if section.name == "ipsec_tunnels" {
+			err = applier.setPreSharedKey(key, payload)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Each entity of the target is updated at most once, when the document has items with the same key
+		if matches := existingByKey[key]; len(matches) > 0 {
+			current := matches[0]
+			existingByKey[key] = matches[1:]
+			targetId := fmt.Sprint(current["id"])
+			payload["id"] = targetId
+			if version, found := current["version"]; found {
+				payload["version"] = version
+			}
+			itemUrlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint + targetId)
+			if err != nil {
+				return nil, err
+			}
+			err = applier.vcdClient.Client.OpenApiPutItem(applier.vcdClient.Client.APIVersion, itemUrlRef, nil, payload, nil, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error updating '%s' in section '%s': %s", key, section.name, err)
+			}
+			updatedIds[targetId] = true
+			if hasSourceId {
+				applier.mapping[fmt.Sprint(sourceId)] = targetId
+			}
+			continue
+		}
+
+		if hasSourceId {
+			sourceIds[key] = fmt.Sprint(sourceId)
+		}
+		task, err := applier.vcdClient.Client.OpenApiPostItemAsync(applier.vcdClient.Client.APIVersion, urlRef, nil, payload)
+		if err == nil {
+			err = task.WaitTaskCompletion()
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error creating '%s' in section '%s': %s", key, section.name, err)
+		}
+	}
+
+	var stale []map[string]interface{}
+	existingIds := make(map[string]bool)
+	for _, item := range existing {
+		id := fmt.Sprint(item["id"])
+		existingIds[id] = true
+		if !updatedIds[id] {
+			stale = append(stale, item)
+		}
+	}
+	if len(sourceIds) == 0 {
+		return stale, nil
+	}
+	created, err := getNsxtEdgeConfigItems(applier.vcdClient, endpoint, "")
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving section '%s' of the target Edge Gateway: %s", section.name, err)
+	}
+	for _, item := range created {
+		if existingIds[fmt.Sprint(item["id"])] {
+			continue
+		}
+		if sourceId, found := sourceIds[fmt.Sprint(item[section.key])]; found {
+			applier.mapping[sourceId] = fmt.Sprint(item["id"])
+		}
+	}
+	return stale, nil
+}
+
+// removeItems removes entities of a collection section from the target Edge Gateway
+func (applier *nsxtEdgeConfigApplier) removeItems(section nsxtEdgeConfigSection, items []map[string]interface{}) error {
+	endpoint := fmt.Sprintf(section.endpoint, applier.target.ID)
+	for _, item := range items {
+		urlRef, err := applier.vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint + fmt.Sprint(item["id"]))
+		if err != nil {
+			return err
+		}
+		err = applier.vcdClient.Client.OpenApiDeleteItem(applier.vcdClient.Client.APIVersion, urlRef, nil, nil)
+		if err != nil {
+			return fmt.Errorf("error removing '%s' from section '%s': %s", item[section.key], section.name, err)
+		}
+	}
+	return nil
+}
+
+// setPreSharedKey sets the key given in 'pre_shared_keys' for an IPsec VPN tunnel, which is required when the
+// document was exported without secrets
+func (applier *nsxtEdgeConfigApplier) setPreSharedKey(tunnelName string, tunnel map[string]interface{}) error {
+	if key, found := applier.preSharedKeys[tunnelName]; found {
+		tunnel["preSharedKey"] = key
+	}
+	if tunnel["authenticationMode"] == types.NsxtIpSecVpnAuthenticationModePSK && fmt.Sprint(tunnel["preSharedKey"]) == "" {
+		return fmt.Errorf("the pre-shared key of IPsec VPN tunnel '%s' is not in the document: it must be set in 'pre_shared_keys'", tunnelName)
+	}
+	return nil
+}
+
+// remap returns a copy of a JSON value where the strings matching a source ID are replaced by the target ID
+func (applier *nsxtEdgeConfigApplier) remap(value interface{}) interface{} {
+	switch typedValue := value.(type) {
+	case string:
+		if targetId, found := applier.mapping[typedValue]; found {
+			return targetId
+		}
+		return typedValue
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typedValue))
+		for key, item := range typedValue {
+			result[key] = applier.remap(item)
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typedValue))
+		for index, item := range typedValue {
+			result[index] = applier.remap(item)
+		}
+		return result
+	}
+	return value
+}
+
+// getNsxtEdgeConfigItem retrieves a single OpenAPI document in generic JSON format
+func getNsxtEdgeConfigItem(vcdClient *VCDClient, endpoint string, item *map[string]interface{}) error {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint)
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiGetItem(vcdClient.Client.APIVersion, urlRef, nil, item, nil)
+}
+
+// getNsxtEdgeConfigItems retrieves all the items of an OpenAPI collection in generic JSON format, with an
+// optional filter
+func getNsxtEdgeConfigItems(vcdClient *VCDClient, endpoint, filter string) ([]map[string]interface{}, error) {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 + endpoint)
+	if err != nil {
+		return nil, err
+	}
+	queryParams := url.Values{}
+	if filter != "" {
+		queryParams.Set("filter", filter)
+	}
+	items := []map[string]interface{}{}
+	err = vcdClient.Client.OpenApiGetAllItems(vcdClient.Client.APIVersion, urlRef, queryParams, &items, nil)
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
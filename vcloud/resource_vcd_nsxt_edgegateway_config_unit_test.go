@@ -0,0 +1,176 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestMockVcdNsxtEdgeGatewayConfig(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+
+	source := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	sourceId := source.Id()
+	vdcId := source.Get("owner_id").(string)
+
+	mock.Lock()
+	org := mock.fixtures.Orgs[0]
+	targetFix := &mockVcdEdgeGatewayFix{ID: mockUuid(), Name: "tf_edge_dr"}
+	mock.addEdgeGateway(org, org.Vdcs[0], targetFix)
+	mock.openApi["1.0.0/firewallGroups"] = []map[string]interface{}{
+		{"id": "urn:vcloud:firewallGroup:web", "name": "web-servers", "typeValue": "IP_SET",
+			"ownerRef": map[string]interface{}{"id": sourceId}, "ipAddresses": []interface{}{"10.10.10.11"}},
+	}
+	mock.openApi["1.0.0/applicationPortProfiles"] = []map[string]interface{}{
+		{"id": "urn:vcloud:applicationPortProfile:app", "name": "app-8443", "scope": "TENANT", "contextEntityId": vdcId},
+	}
+	for _, edgeId := range []string{sourceId, "urn:vcloud:gateway:" + targetFix.ID} {
+		mock.openApiDocs["1.0.0/edgeGateways/"+edgeId+"/dns"] = map[string]interface{}{"enabled": false}
+		mock.openApi["1.0.0/edgeGateways/"+edgeId+"/nat/rules"] = []map[string]interface{}{}
+		mock.openApi["1.0.0/edgeGateways/"+edgeId+"/ipsec/tunnels"] = []map[string]interface{}{}
+	}
+	// The target has a NAT rule with the same name as the one in the document, and one that is not in the document
+	targetId := "urn:vcloud:gateway:" + targetFix.ID
+	mock.openApi["1.0.0/edgeGateways/"+targetId+"/nat/rules"] = []map[string]interface{}{
+		{"id": "nat-target-1", "name": "web-dnat", "type": "DNAT", "externalAddresses": "192.168.200.10",
+			"internalAddresses": "10.20.20.11", "version": map[string]interface{}{"version": 4}},
+		{"id": "nat-target-2", "name": "old-rule", "type": "SNAT"},
+	}
+	mock.openApiDocs["1.0.0/edgeGateways/"+sourceId+"/dns"] = map[string]interface{}{"enabled": true, "listenerIp": "10.10.10.1", "version": 3}
+	mock.openApi["1.0.0/edgeGateways/"+sourceId+"/nat/rules"] = []map[string]interface{}{
+		{"id": "nat-1", "name": "web-dnat", "type": "DNAT", "externalAddresses": "192.168.100.10",
+			"internalAddresses": "10.10.10.11", "applicationPortProfile": map[string]interface{}{"id": "urn:vcloud:applicationPortProfile:app"}},
+	}
+	mock.openApi["1.0.0/edgeGateways/"+sourceId+"/ipsec/tunnels"] = []map[string]interface{}{
+		{"id": "tunnel-1", "name": "branch", "authenticationMode": "PSK", "preSharedKey": "secret",
+			"certificateRef": map[string]interface{}{"id": "urn:vcloud:certificateLibraryItem:old", "name": "vpn-cert"}},
+	}
+	mock.Unlock()
+
+	exported := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway_config", map[string]interface{}{
+		"edge_gateway_id": sourceId,
+		"redact_secrets":  true,
+	})
+	documentText := exported.Get("document").(string)
+	if strings.Contains(documentText, "secret") {
+		t.Errorf("pre-shared keys must not be exported when 'redact_secrets' is set")
+	}
+	document, err := parseNsxtEdgeConfigDocument(documentText)
+	if err != nil {
+		t.Fatalf("error parsing exported document: %s", err)
+	}
+	if len(document.Objects.IpSets) != 1 || len(document.Objects.Certificates) != 1 {
+		t.Errorf("expected 1 IP Set and 1 certificate, got %v", document.Objects)
+	}
+	for _, name := range []string{"dns", "nat_rules", "ipsec_tunnels", "firewall"} {
+		if !exported.Get("sections").(*schema.Set).Contains(name) {
+			t.Errorf("expected section '%s' in the document", name)
+		}
+	}
+
+	target := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge_dr"})
+	resource := Provider().ResourcesMap["vcloud_nsxt_edgegateway_config"]
+	raw := map[string]interface{}{
+		"edge_gateway_id": target.Id(),
+		"document":        documentText,
+		"id_mapping":      map[string]interface{}{"urn:vcloud:certificateLibraryItem:old": "urn:vcloud:certificateLibraryItem:new"},
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if !diags.HasError() || !strings.Contains(diags[0].Summary, "pre_shared_keys") {
+		t.Fatalf("expected an error about the missing pre-shared key, got %v", diags)
+	}
+	mock.Lock()
+	if len(mock.openApi["1.0.0/firewallGroups"]) != 1 {
+		t.Errorf("the target must not be changed when a pre-shared key is missing")
+	}
+	mock.Unlock()
+
+	var requests []string
+	transport := vcdClient.Client.Http.Transport
+	vcdClient.Client.Http.Transport = roundTripFunc(func(request *http.Request) (*http.Response, error) {
+		if request.Method == http.MethodPost || request.Method == http.MethodPut || request.Method == http.MethodDelete {
+			requests = append(requests, request.Method+" "+request.URL.Path)
+		}
+		return transport.RoundTrip(request)
+	})
+	defer func() { vcdClient.Client.Http.Transport = transport }()
+
+	raw["pre_shared_keys"] = map[string]interface{}{"branch": "new-secret"}
+	d = schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags = resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error applying configuration: %v", diags)
+	}
+	if d.Id() != target.Id() {
+		t.Errorf("expected ID '%s', got '%s'", target.Id(), d.Id())
+	}
+
+	mock.Lock()
+	defer mock.Unlock()
+	var newIpSetId string
+	for _, group := range mock.openApi["1.0.0/firewallGroups"] {
+		ownerRef := group["ownerRef"].(map[string]interface{})
+		if group["name"] == "web-servers" && ownerRef["id"] == target.Id() {
+			newIpSetId = group["id"].(string)
+		}
+	}
+	if newIpSetId == "" {
+		t.Fatalf("IP Set was not created for the target Edge Gateway")
+	}
+	remapped := d.Get("remapped_ids").(map[string]interface{})
+	if remapped["urn:vcloud:firewallGroup:web"] != newIpSetId || remapped[sourceId] != target.Id() {
+		t.Errorf("unexpected remapped IDs: %v", remapped)
+	}
+	// The profile with the same name in the same VDC is updated, and keeps its ID
+	if len(mock.openApi["1.0.0/applicationPortProfiles"]) != 1 {
+		t.Errorf("expected the existing Application Port Profile to be reused")
+	}
+
+	dns := toMockJsonMap(mock.openApiDocs["1.0.0/edgeGateways/"+target.Id()+"/dns"])
+	if dns["enabled"] != true || dns["listenerIp"] != "10.10.10.1" {
+		t.Errorf("unexpected DNS configuration of the target: %v", dns)
+	}
+	tunnels := mock.openApi["1.0.0/edgeGateways/"+target.Id()+"/ipsec/tunnels"]
+	if len(tunnels) != 1 || tunnels[0]["preSharedKey"] != "new-secret" ||
+		tunnels[0]["certificateRef"].(map[string]interface{})["id"] != "urn:vcloud:certificateLibraryItem:new" {
+		t.Errorf("unexpected IPsec VPN tunnels of the target: %v", tunnels)
+	}
+	// The NAT rule with the same name is updated in place, and the other one is removed after the new entities
+	// are created
+	natRules := mock.openApi["1.0.0/edgeGateways/"+target.Id()+"/nat/rules"]
+	if len(natRules) != 1 || natRules[0]["id"] != "nat-target-1" || remapped["nat-1"] != "nat-target-1" ||
+		natRules[0]["internalAddresses"] != "10.10.10.11" || natRules[0]["version"] == nil {
+		t.Errorf("unexpected NAT rules of the target: %v", natRules)
+	}
+	if len(requests) == 0 || !strings.HasPrefix(requests[len(requests)-1], http.MethodDelete) ||
+		!strings.HasSuffix(requests[len(requests)-1], "/nat/rules/nat-target-2") {
+		t.Errorf("expected the removal of the stale NAT rule as last request, got %v", requests)
+	}
+	for _, request := range requests {
+		if strings.HasPrefix(request, http.MethodDelete) && !strings.HasSuffix(request, "/nat/rules/nat-target-2") {
+			t.Errorf("unexpected removal: %s", request)
+		}
+	}
+	firewall := toMockJsonMap(mock.openApiDocs["1.0.0/edgeGateways/"+target.Id()+"/firewall/rules"])
+	rules := firewall["userDefinedRules"].([]interface{})
+	if len(rules) != 1 || rules[0].(map[string]interface{})["name"] != "allow-outbound" {
+		t.Errorf("unexpected firewall rules of the target: %v", rules)
+	}
+
+	// A document from a newer provider is rejected
+	var newer map[string]interface{}
+	_ = json.Unmarshal([]byte(documentText), &newer)
+	newer["version"] = nsxtEdgeConfigVersion + 1
+	contents, _ := json.Marshal(newer)
+	if _, err = parseNsxtEdgeConfigDocument(string(contents)); err == nil {
+		t.Errorf("expected an error for an unsupported document version")
+	}
+}
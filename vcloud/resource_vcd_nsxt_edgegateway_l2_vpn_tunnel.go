@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -382,15 +381,19 @@ func readL2VpnTunnelToSchema(tunnel *types.NsxtL2VpnTunnel, d *schema.ResourceDa
 func resourceVcdNsxtEdgegatewayL2VpnTunnelImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T Edge Gateway L2 VPN Tunnel import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as " +
 			"org-name.vdc-name.nsxt-edge-gw-name.l2-vpn-tunnel-name or " +
-			"org-name.vdc-group-name.nsxt-edge-gw-name.l2-vpn-tunnel-name")
+			"org-name.vdc-group-name.nsxt-edge-gw-name.l2-vpn-tunnel-name " +
+			"or nsxt-edge-gw-ID.l2-vpn-tunnel-name")
 	}
 	orgName, vdcOrVdcGroupName, edgeName, tunnelName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -222,14 +222,21 @@ func resourceVcdNsxtEdgeGatewayStaticRouteDelete(ctx context.Context, d *schema.
 }
 
 func resourceVcdNsxtEdgeGatewayStaticRouteImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.SplitN(d.Id(), ImportSeparator, 4)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	// The network CIDR can contain the separator
+	if len(resourceURI) > 4 {
+		resourceURI = append(resourceURI[:3], strings.Join(resourceURI[3:], ImportSeparator))
+	}
 	if len(resourceURI) != 4 {
 		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.edge_gateway_name.static_route_name or "+
-			"'org-name.vdc-or-vdc-group-name.edge_gateway_name.name', got '%s'", d.Id())
+			"'org-name.vdc-or-vdc-group-name.edge_gateway_name.name' or as edge_gateway_ID.static_route_name, got '%s'", d.Id())
 	}
 	orgName, vdcOrVdcGroupName, edgeGatewayName, staticRouteCidrOrName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,457 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+func resourceVcdNsxtFirewallRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdNsxtFirewallRuleCreate,
+		ReadContext:   resourceVcdNsxtFirewallRuleRead,
+		UpdateContext: resourceVcdNsxtFirewallRuleUpdate,
+		DeleteContext: resourceVcdNsxtFirewallRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdNsxtFirewallRuleImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"edge_gateway_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "Edge Gateway ID in which Firewall Rule is located",
+			},
+			"above_rule_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "An optional firewall rule ID, to put new rule above during creation",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "Firewall Rule name",
+			},
+			"direction": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IN_OUT",
+				Description:  "Direction on which Firewall Rule applies (one of 'IN', 'OUT', 'IN_OUT')",
+				ValidateFunc: validation.StringInSlice([]string{"IN", "OUT", "IN_OUT"}, false),
+			},
+			"ip_protocol": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "IPV4_IPV6",
+				Description:  "Firewall Rule Protocol (one of 'IPV4', 'IPV6', 'IPV4_IPV6')",
+				ValidateFunc: validation.StringInSlice([]string{"IPV4", "IPV6", "IPV4_IPV6"}, false),
+			},
+			"action": {
+				Type:         schema.TypeString,
+				Required:     true,
+				Description:  "Defines if the rule should 'ALLOW' or 'DROP' matching traffic",
+				ValidateFunc: validation.StringInSlice([]string{"ALLOW", "DROP"}, false),
+			},
+			"enabled": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Defined if Firewall Rule is active",
+			},
+			"logging": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Defines if matching traffic should be logged",
+			},
+			"source_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Source Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"destination_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Destination Firewall Group IDs (IP Sets or Security Groups). Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"app_port_profile_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "A set of Application Port Profile IDs. Leaving it empty means 'Any'",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// nsxtFirewallRulesRaw holds the user defined rules of an Edge Gateway firewall as raw JSON, so that the rules
+// managed elsewhere are sent back exactly as they were retrieved, including fields unknown to the SDK
+type nsxtFirewallRulesRaw struct {
+	UserDefinedRules []json.RawMessage `json:"userDefinedRules"`
+}
+
+// nsxtFirewallRuleId is used to read the ID of a rule in raw JSON format
+type nsxtFirewallRuleId struct {
+	ID string `json:"id"`
+}
+
+func resourceVcdNsxtFirewallRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := vcdClient.lockParentVdcGroupOrEdgeGateway(d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] %s", err)
+	}
+	defer unlock()
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] error retrieving Edge Gateway: %s", err)
+	}
+
+	rules, err := getNsxtFirewallRulesRaw(vcdClient, nsxtEdge)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] %s", err)
+	}
+	existingIds, err := nsxtFirewallRawRuleIds(rules)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] %s", err)
+	}
+
+	newRule, err := json.Marshal(getNsxtFirewallRuleType(d))
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] error converting Firewall Rule to JSON: %s", err)
+	}
+
+	// By default the new rule goes to the bottom of the list. When 'above_rule_id' is set, it is inserted at the
+	// position of that rule, which moves one place down
+	position := len(rules)
+	aboveRuleId := d.Get("above_rule_id").(string)
+	if aboveRuleId != "" {
+		position = slices.Index(existingIds, aboveRuleId)
+		if position < 0 {
+			return diag.Errorf("[NSX-T Firewall Rule create] rule with ID '%s' specified in 'above_rule_id' not found", aboveRuleId)
+		}
+	}
+	rules = append(rules[:position], append([]json.RawMessage{newRule}, rules[position:]...)...)
+
+	updatedRules, err := updateNsxtFirewallRulesRaw(vcdClient, nsxtEdge, rules)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] %s", err)
+	}
+	updatedIds, err := nsxtFirewallRawRuleIds(updatedRules)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule create] %s", err)
+	}
+
+	// The new rule is the only one with an ID that did not exist before the update
+	for _, id := range updatedIds {
+		if !slices.Contains(existingIds, id) {
+			d.SetId(id)
+			break
+		}
+	}
+	if d.Id() == "" {
+		return diag.Errorf("[NSX-T Firewall Rule create] could not find the ID of the created Firewall Rule")
+	}
+
+	return resourceVcdNsxtFirewallRuleRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtFirewallRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := vcdClient.lockParentVdcGroupOrEdgeGateway(d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] %s", err)
+	}
+	defer unlock()
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] error retrieving Edge Gateway: %s", err)
+	}
+
+	rules, err := getNsxtFirewallRulesRaw(vcdClient, nsxtEdge)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] %s", err)
+	}
+	ids, err := nsxtFirewallRawRuleIds(rules)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] %s", err)
+	}
+	position := slices.Index(ids, d.Id())
+	if position < 0 {
+		return diag.Errorf("[NSX-T Firewall Rule update] Firewall Rule with ID '%s' not found", d.Id())
+	}
+
+	// The rule keeps its ID and its position in the list
+	ruleType := getNsxtFirewallRuleType(d)
+	ruleType.ID = d.Id()
+	rules[position], err = json.Marshal(ruleType)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] error converting Firewall Rule to JSON: %s", err)
+	}
+
+	_, err = updateNsxtFirewallRulesRaw(vcdClient, nsxtEdge, rules)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule update] %s", err)
+	}
+
+	return resourceVcdNsxtFirewallRuleRead(ctx, d, meta)
+}
+
+func resourceVcdNsxtFirewallRuleRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Get("edge_gateway_id").(string))
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[NSX-T Firewall Rule read] error retrieving Edge Gateway: %s", err)
+	}
+
+	rule, err := getNsxtFirewallRuleById(nsxtEdge, d.Id())
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] NSX-T Firewall Rule '%s' not found. Removing from state", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[NSX-T Firewall Rule read] %s", err)
+	}
+
+	err = setNsxtFirewallRuleData(rule, d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule read] error storing data to state: %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtFirewallRuleDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+	unlock, err := vcdClient.lockParentVdcGroupOrEdgeGateway(d)
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule delete] %s", err)
+	}
+	defer unlock()
+
+	nsxtEdge, err := vcdClient.GetNsxtEdgeGatewayById(d.Get("org").(string), d.Get("edge_gateway_id").(string))
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule delete] error retrieving Edge Gateway: %s", err)
+	}
+
+	firewall, err := nsxtEdge.GetNsxtFirewall()
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule delete] error retrieving NSX-T Firewall Rules: %s", err)
+	}
+
+	err = firewall.DeleteRuleById(d.Id())
+	if err != nil {
+		return diag.Errorf("[NSX-T Firewall Rule delete] %s", err)
+	}
+
+	return nil
+}
+
+func resourceVcdNsxtFirewallRuleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	log.Printf("[TRACE] NSX-T Edge Gateway Firewall Rule import initiated")
+
+	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcOrVdcGroupName, edgeName, ruleName string
+	switch {
+	case len(resourceURI) == 2 && importEntityId("urn:vcloud:gateway:", resourceURI[0]) != "":
+		edgeId := importEntityId("urn:vcloud:gateway:", resourceURI[0])
+		var err error
+		orgName, vdcOrVdcGroupName, edgeName, err = importNsxtEdgeGatewayNames(vcdClient, edgeId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve NSX-T edge gateway '%s': %s", edgeId, err)
+		}
+		ruleName = resourceURI[1]
+	case len(resourceURI) == 4:
+		orgName, vdcOrVdcGroupName, edgeName, ruleName = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+	default:
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-or-vdc-group-name.nsxt-edge-gw-name.fw-rule-name-or-ID " +
+			"or as nsxt-edge-gw-ID.fw-rule-name-or-ID")
+	}
+
+	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
+	if err != nil {
+		return nil, err
+	}
+
+	edge, err := vdcOrVdcGroup.GetNsxtEdgeGatewayByName(edgeName)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve NSX-T edge gateway '%s': %s", edgeName, err)
+	}
+
+	firewall, err := edge.GetNsxtFirewall()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall Rules: %s", err)
+	}
+
+	// The API does not enforce unique names, therefore the import is refused when the name is ambiguous
+	var foundIds []string
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		if rule.Name == ruleName || rule.ID == ruleName {
+			foundIds = append(foundIds, rule.ID)
+		}
+	}
+	switch len(foundIds) {
+	case 0:
+		return nil, fmt.Errorf("could not find NSX-T Firewall Rule '%s' in Edge Gateway '%s'", ruleName, edgeName)
+	case 1:
+	default:
+		return nil, fmt.Errorf("found %d NSX-T Firewall Rules named '%s' in Edge Gateway '%s' (IDs %s)",
+			len(foundIds), ruleName, edgeName, strings.Join(foundIds, ", "))
+	}
+
+	d.SetId(foundIds[0])
+	dSet(d, "org", orgName)
+	dSet(d, "edge_gateway_id", edge.EdgeGateway.ID)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// getNsxtFirewallRuleById retrieves a single user defined rule of an Edge Gateway firewall
+func getNsxtFirewallRuleById(nsxtEdge *govcd.NsxtEdgeGateway, id string) (*types.NsxtFirewallRule, error) {
+	firewall, err := nsxtEdge.GetNsxtFirewall()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall Rules: %s", err)
+	}
+	for _, rule := range firewall.NsxtFirewallRuleContainer.UserDefinedRules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: NSX-T Firewall Rule with ID '%s'", govcd.ErrorEntityNotFound, id)
+}
+
+// getNsxtFirewallRulesRaw retrieves the user defined rules of an Edge Gateway firewall, in raw JSON format
+func getNsxtFirewallRulesRaw(vcdClient *VCDClient, nsxtEdge *govcd.NsxtEdgeGateway) ([]json.RawMessage, error) {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 +
+		fmt.Sprintf(types.OpenApiEndpointNsxtFirewallRules, nsxtEdge.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	rules := &nsxtFirewallRulesRaw{}
+	err = vcdClient.Client.OpenApiGetItem(vcdClient.Client.APIVersion, urlRef, nil, rules, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving NSX-T Firewall Rules: %s", err)
+	}
+	return rules.UserDefinedRules, nil
+}
+
+// updateNsxtFirewallRulesRaw replaces the user defined rules of an Edge Gateway firewall. The API has no endpoint
+// to create a single rule, therefore all rules are sent in their new order. It returns the rules after the update
+func updateNsxtFirewallRulesRaw(vcdClient *VCDClient, nsxtEdge *govcd.NsxtEdgeGateway, rules []json.RawMessage) ([]json.RawMessage, error) {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(types.OpenApiPathVersion1_0_0 +
+		fmt.Sprintf(types.OpenApiEndpointNsxtFirewallRules, nsxtEdge.EdgeGateway.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	updatedRules := &nsxtFirewallRulesRaw{}
+	err = vcdClient.Client.OpenApiPutItem(vcdClient.Client.APIVersion, urlRef, nil,
+		&nsxtFirewallRulesRaw{UserDefinedRules: rules}, updatedRules, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error updating NSX-T Firewall Rules: %s", err)
+	}
+	return updatedRules.UserDefinedRules, nil
+}
+
+// nsxtFirewallRawRuleIds returns the IDs of rules in raw JSON format, in the same order
+func nsxtFirewallRawRuleIds(rules []json.RawMessage) ([]string, error) {
+	ids := make([]string, len(rules))
+	for index, rule := range rules {
+		ruleId := nsxtFirewallRuleId{}
+		err := json.Unmarshal(rule, &ruleId)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ID of NSX-T Firewall Rule: %s", err)
+		}
+		ids[index] = ruleId.ID
+	}
+	return ids, nil
+}
+
+func getNsxtFirewallRuleType(d *schema.ResourceData) *types.NsxtFirewallRule {
+	rule := &types.NsxtFirewallRule{
+		Name:       d.Get("name").(string),
+		Action:     d.Get("action").(string),
+		Enabled:    d.Get("enabled").(bool),
+		IpProtocol: d.Get("ip_protocol").(string),
+		Logging:    d.Get("logging").(bool),
+		Direction:  d.Get("direction").(string),
+		Version:    nil,
+	}
+
+	sourceGroupIds := convertSchemaSetToSliceOfStrings(d.Get("source_ids").(*schema.Set))
+	rule.SourceFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(sourceGroupIds)
+
+	destinationGroupIds := convertSchemaSetToSliceOfStrings(d.Get("destination_ids").(*schema.Set))
+	rule.DestinationFirewallGroups = convertSliceOfStringsToOpenApiReferenceIds(destinationGroupIds)
+
+	appPortProfileIds := convertSchemaSetToSliceOfStrings(d.Get("app_port_profile_ids").(*schema.Set))
+	rule.ApplicationPortProfiles = convertSliceOfStringsToOpenApiReferenceIds(appPortProfileIds)
+
+	return rule
+}
+
+func setNsxtFirewallRuleData(rule *types.NsxtFirewallRule, d *schema.ResourceData) error {
+	dSet(d, "name", rule.Name)
+	dSet(d, "action", rule.Action)
+	dSet(d, "enabled", rule.Enabled)
+	dSet(d, "ip_protocol", rule.IpProtocol)
+	dSet(d, "direction", rule.Direction)
+	dSet(d, "logging", rule.Logging)
+
+	sourceSet := convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.SourceFirewallGroups))
+	err := d.Set("source_ids", sourceSet)
+	if err != nil {
+		return fmt.Errorf("error storing 'source_ids': %s", err)
+	}
+
+	destinationSet := convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.DestinationFirewallGroups))
+	err = d.Set("destination_ids", destinationSet)
+	if err != nil {
+		return fmt.Errorf("error storing 'destination_ids': %s", err)
+	}
+
+	appPortProfileSet := convertStringsToTypeSet(extractIdsFromOpenApiReferences(rule.ApplicationPortProfiles))
+	err = d.Set("app_port_profile_ids", appPortProfileSet)
+	if err != nil {
+		return fmt.Errorf("error storing 'app_port_profile_ids': %s", err)
+	}
+
+	return nil
+}
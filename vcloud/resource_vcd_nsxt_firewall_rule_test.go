@@ -0,0 +1,101 @@
+//go:build network || nsxt || ALL || functional
+
+package vcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+// TestAccVcdNsxtFirewallRule creates rules as separate resources and checks that 'above_rule_id' places a rule
+// above another one
+func TestAccVcdNsxtFirewallRule(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":     testConfig.VCD.Org,
+		"NsxtVdc": testConfig.Nsxt.Vdc,
+		"EdgeGw":  testConfig.Nsxt.EdgeGateway,
+		"Action":  "ALLOW",
+		"Tags":    "network nsxt",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText1 := templateFill(testAccVcdNsxtFirewallRule, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 1: %s", configText1)
+
+	params["FuncName"] = t.Name() + "-step2"
+	params["Action"] = "DROP"
+	configText2 := templateFill(testAccVcdNsxtFirewallRule, params)
+	debugPrintf("#[DEBUG] CONFIGURATION for step 2: %s", configText2)
+
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckNsxtFirewallRulesDestroy(testConfig.Nsxt.Vdc, testConfig.Nsxt.EdgeGateway),
+		Steps: []resource.TestStep{
+			{
+				Config: configText1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("vcd_nsxt_firewall_rule.bottom", "id"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.bottom", "direction", "IN_OUT"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.bottom", "ip_protocol", "IPV4_IPV6"),
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.top", "action", "ALLOW"),
+					// The data source lists rules in their order: 'top' was created above 'bottom'
+					resource.TestCheckResourceAttr("data.vcd_nsxt_firewall.all", "rule.#", "2"),
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_firewall.all", "rule.0.id", "vcd_nsxt_firewall_rule.top", "id"),
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_firewall.all", "rule.1.id", "vcd_nsxt_firewall_rule.bottom", "id"),
+				),
+			},
+			{
+				Config: configText2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("vcd_nsxt_firewall_rule.top", "action", "DROP"),
+					resource.TestCheckResourceAttrPair("data.vcd_nsxt_firewall.all", "rule.0.id", "vcd_nsxt_firewall_rule.top", "id"),
+				),
+			},
+			{
+				ResourceName:            "vcd_nsxt_firewall_rule.top",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdNsxtEdgeGatewayObject(testConfig.Nsxt.EdgeGateway, "top-rule"),
+				ImportStateVerifyIgnore: []string{"above_rule_id"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdNsxtFirewallRule = testAccVcdNsxtFirewallPrereqs + `
+resource "vcd_nsxt_firewall_rule" "bottom" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  name   = "bottom-rule"
+  action = "ALLOW"
+}
+
+resource "vcd_nsxt_firewall_rule" "top" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+  above_rule_id   = vcd_nsxt_firewall_rule.bottom.id
+
+  name        = "top-rule"
+  action      = "{{.Action}}"
+  direction   = "IN"
+  ip_protocol = "IPV4"
+}
+
+# skip-binary-test: cannot define resource and datasource in the same file
+data "vcd_nsxt_firewall" "all" {
+  org             = "{{.Org}}"
+  edge_gateway_id = data.vcd_nsxt_edgegateway.testing.id
+
+  depends_on = [vcd_nsxt_firewall_rule.top]
+}
+`
@@ -0,0 +1,118 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mockFirewallRules returns the user defined firewall rules of an Edge Gateway in the mock, in their order
+func mockFirewallRules(mock *mockVcd, edgeGatewayId string) []map[string]interface{} {
+	mock.Lock()
+	defer mock.Unlock()
+	doc := toMockJsonMap(mock.openApiDocs["1.0.0/edgeGateways/"+edgeGatewayId+"/firewall/rules"])
+	var rules []map[string]interface{}
+	for _, rule := range doc["userDefinedRules"].([]interface{}) {
+		rules = append(rules, rule.(map[string]interface{}))
+	}
+	return rules
+}
+
+func mockFirewallRuleNames(mock *mockVcd, edgeGatewayId string) []string {
+	var names []string
+	for _, rule := range mockFirewallRules(mock, edgeGatewayId) {
+		names = append(names, rule["name"].(string))
+	}
+	return names
+}
+
+func TestMockVcdNsxtFirewallRuleLifecycle(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+
+	edge := readMockDataSource(t, vcdClient, "vcloud_nsxt_edgegateway", map[string]interface{}{"name": "tf_edge"})
+	docPath := "1.0.0/edgeGateways/" + edge.Id() + "/firewall/rules"
+
+	// A field set by another tool on an existing rule must survive the updates made by this resource
+	mock.Lock()
+	doc := toMockJsonMap(mock.openApiDocs[docPath])
+	doc["userDefinedRules"].([]interface{})[0].(map[string]interface{})["comments"] = "owned by platform team"
+	mock.openApiDocs[docPath] = doc
+	mock.Unlock()
+
+	resource := Provider().ResourcesMap["vcloud_nsxt_firewall_rule"]
+	bottom := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"edge_gateway_id": edge.Id(),
+		"name":            "app-https",
+		"action":          "ALLOW",
+		"direction":       "IN",
+	})
+	diags := resource.CreateContext(ctx, bottom, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating firewall rule: %v", diags)
+	}
+	if bottom.Id() == "" || bottom.Get("ip_protocol").(string) != "IPV4_IPV6" {
+		t.Errorf("unexpected state after creation: ID '%s', ip_protocol '%s'", bottom.Id(), bottom.Get("ip_protocol"))
+	}
+
+	above := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"edge_gateway_id": edge.Id(),
+		"above_rule_id":   bottom.Id(),
+		"name":            "app-deny-admin",
+		"action":          "DROP",
+	})
+	diags = resource.CreateContext(ctx, above, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating firewall rule above another: %v", diags)
+	}
+	expected := []string{"allow-outbound", "app-deny-admin", "app-https"}
+	if names := mockFirewallRuleNames(mock, edge.Id()); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected rules %v, got %v", expected, names)
+	}
+	if comments := mockFirewallRules(mock, edge.Id())[0]["comments"]; comments != "owned by platform team" {
+		t.Errorf("expected field of a foreign rule to be kept, got %v", comments)
+	}
+
+	// An update keeps the position of the rule
+	dSet(bottom, "name", "app-https-renamed")
+	diags = resource.UpdateContext(ctx, bottom, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error updating firewall rule: %v", diags)
+	}
+	expected = []string{"allow-outbound", "app-deny-admin", "app-https-renamed"}
+	if names := mockFirewallRuleNames(mock, edge.Id()); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected rules %v after update, got %v", expected, names)
+	}
+
+	imported := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{})
+	imported.SetId("tf_org.tf_vdc.tf_edge.app-deny-admin")
+	_, err := resource.Importer.StateContext(ctx, imported, vcdClient)
+	if err != nil {
+		t.Fatalf("error importing firewall rule: %s", err)
+	}
+	if imported.Id() != above.Id() || imported.Get("edge_gateway_id").(string) != edge.Id() {
+		t.Errorf("unexpected import result: ID '%s', edge_gateway_id '%s'", imported.Id(), imported.Get("edge_gateway_id"))
+	}
+
+	diags = resource.DeleteContext(ctx, above, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting firewall rule: %v", diags)
+	}
+	expected = []string{"allow-outbound", "app-https-renamed"}
+	if names := mockFirewallRuleNames(mock, edge.Id()); !reflect.DeepEqual(names, expected) {
+		t.Errorf("expected rules %v after deletion, got %v", expected, names)
+	}
+
+	// A rule removed outside of Terraform is removed from state
+	diags = resource.ReadContext(ctx, above, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading deleted firewall rule: %v", diags)
+	}
+	if above.Id() != "" {
+		t.Errorf("expected empty ID for deleted firewall rule, got %s", above.Id())
+	}
+}
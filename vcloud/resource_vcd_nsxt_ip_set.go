@@ -3,7 +3,6 @@ package vcloud
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -216,19 +215,22 @@ func resourceVcdNsxtIpSetDelete(_ context.Context, d *schema.ResourceData, meta
 }
 
 func resourceVcdNsxtIpSetImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.ip_set_name or" +
-			"as org-name.vdc-group-name.edge_gateway_name.ip_set_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.ip_set_name or " +
+			"as org-name.vdc-group-name.edge_gateway_name.ip_set_name or as edge-gateway-ID.ip_set_name, where the name " +
+			"can also be the ID of the IP Set")
 	}
 	orgName, vdcOrVdcGroupName, edgeGatewayName, ipSetName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
-
 	// define an interface type to match VDC and VDC Groups
 	var vdcOrVdcGroup vdcOrVdcGroupHandler
 	var adminOrg *govcd.AdminOrg
-	_, vdcOrVdcGroup, err := vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
+	_, vdcOrVdcGroup, err = vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
 	if govcd.ContainsNotFound(err) {
 		adminOrg, err = vcdClient.GetAdminOrg(orgName)
 		if err != nil {
@@ -255,12 +257,12 @@ func resourceVcdNsxtIpSetImport(_ context.Context, d *schema.ResourceData, meta
 			return nil, fmt.Errorf("[nsxt ip set resource import] error finding VDC Group by ID '%s': %s", parentEdgeGatewayOwnerId, err)
 		}
 
-		ipSet, err = vdcGroup.GetNsxtFirewallGroupByName(ipSetName, types.FirewallGroupTypeIpSet)
+		ipSet, err = importNsxtFirewallGroup(vdcGroup, ipSetName, types.FirewallGroupTypeIpSet)
 		if err != nil {
 			return nil, fmt.Errorf("[nsxt ip set resource import] error getting NSX-T IP Set '%s': %s", ipSetName, err)
 		}
 	} else {
-		ipSet, err = nsxtEdgeGateway.GetNsxtFirewallGroupByName(ipSetName, types.FirewallGroupTypeIpSet)
+		ipSet, err = importNsxtFirewallGroup(nsxtEdgeGateway, ipSetName, types.FirewallGroupTypeIpSet)
 		if err != nil {
 			return nil, fmt.Errorf("[nsxt ip set resource import] unable to find IP Set '%s': %s", ipSetName, err)
 		}
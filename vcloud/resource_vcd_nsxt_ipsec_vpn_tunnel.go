@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 	"log"
-	"strings"
 	"text/tabwriter"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
@@ -442,13 +441,17 @@ func resourceVcdNsxtIpSecVpnTunnelDelete(_ context.Context, d *schema.ResourceDa
 func resourceVcdNsxtIpSecVpnTunnelImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] NSX-T IPsec VPN Tunnel Import started")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.ipsec_tunnel_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.ipsec_tunnel_name " +
+			"or as edge_gateway_ID.ipsec_tunnel_name")
 	}
 	orgName, vdcOrVdcGroupName, edgeGatewayName, ipSecVpnTunnelIdentifier := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
 	vdcOrVdcGroup, err := lookupVdcOrVdcGroup(vcdClient, orgName, vdcOrVdcGroupName)
 	if err != nil {
 		return nil, err
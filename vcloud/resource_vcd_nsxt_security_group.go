@@ -285,19 +285,22 @@ func resourceVcdSecurityGroupDelete(_ context.Context, d *schema.ResourceData, m
 }
 
 func resourceVcdSecurityGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importNsxtEdgeGatewayPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 4 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.security_group_name or" +
-			"as org-name.vdc-group-name.edge_gateway_name.security_group_name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-name.edge_gateway_name.security_group_name or " +
+			"as org-name.vdc-group-name.edge_gateway_name.security_group_name or as edge-gateway-ID.security_group_name, where the name " +
+			"can also be the ID of the Security Group")
 	}
 	orgName, vdcOrVdcGroupName, edgeGatewayName, securityGroupName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 
-	vcdClient := meta.(*VCDClient)
-
 	// define an interface type to match VDC and VDC Groups
 	var vdcOrVdcGroup vdcOrVdcGroupHandler
 	var adminOrg *govcd.AdminOrg
-	_, vdcOrVdcGroup, err := vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
+	_, vdcOrVdcGroup, err = vcdClient.GetOrgAndVdc(orgName, vdcOrVdcGroupName)
 	if govcd.ContainsNotFound(err) {
 		adminOrg, err = vcdClient.GetAdminOrg(orgName)
 		if err != nil {
@@ -324,12 +327,12 @@ func resourceVcdSecurityGroupImport(_ context.Context, d *schema.ResourceData, m
 			return nil, fmt.Errorf("[nsxt security group resource import] error finding VDC Group by ID '%s': %s", parentEdgeGatewayOwnerId, err)
 		}
 
-		securityGroup, err = vdcGroup.GetNsxtFirewallGroupByName(securityGroupName, types.FirewallGroupTypeSecurityGroup)
+		securityGroup, err = importNsxtFirewallGroup(vdcGroup, securityGroupName, types.FirewallGroupTypeSecurityGroup)
 		if err != nil {
 			return nil, fmt.Errorf("[nsxt security group resource import] error getting NSX-T Security Group '%s': %s", securityGroupName, err)
 		}
 	} else {
-		securityGroup, err = nsxtEdgeGateway.GetNsxtFirewallGroupByName(securityGroupName, types.FirewallGroupTypeSecurityGroup)
+		securityGroup, err = importNsxtFirewallGroup(nsxtEdgeGateway, securityGroupName, types.FirewallGroupTypeSecurityGroup)
 		if err != nil {
 			return nil, fmt.Errorf("[nsxt security group resource import] unable to find NSX-T Security Group '%s': %s", securityGroupName, err)
 		}
@@ -136,9 +136,15 @@ func resourceVcdSegmentProfileTemplateImport(_ context.Context, d *schema.Resour
 	vcdClient := meta.(*VCDClient)
 
 	resourceURI := d.Id()
-	spt, err := vcdClient.GetSegmentProfileTemplateByName(resourceURI)
+	var spt *govcd.NsxtSegmentProfileTemplate
+	var err error
+	if id := importWholeEntityId("urn:vcloud:segmentProfileTemplate:", resourceURI); id != "" {
+		spt, err = vcdClient.GetSegmentProfileTemplateById(id)
+	} else {
+		spt, err = vcdClient.GetSegmentProfileTemplateByName(resourceURI)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("error finding NSX-T Segment Profile Template with Name '%s': %s", d.Id(), err)
+		return nil, fmt.Errorf("error finding NSX-T Segment Profile Template '%s': %s", d.Id(), err)
 	}
 
 	d.SetId(spt.NsxtSegmentProfileTemplate.ID)
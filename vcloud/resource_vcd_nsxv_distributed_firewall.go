@@ -609,6 +609,9 @@ func dfwRulesToResource(rules []types.NsxvDistributedFirewallRule, d *schema.Res
 // terraform import vcd_nsxv_distributed_firewall.identifier org-name.vdc-name
 func resourceVcdNsxvDistributedFirewallImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	if vdcId := importWholeEntityId("urn:vcloud:vdc:", d.Id()); vdcId != "" {
+		resourceURI = []string{vdcId}
+	}
 
 	vcdClient := meta.(*VCDClient)
 	var dfw *govcd.NsxvDistributedFirewall
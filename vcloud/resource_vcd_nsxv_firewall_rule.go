@@ -392,40 +392,47 @@ func resourceVcdNsxvFirewallRuleDelete(d *schema.ResourceData, meta interface{})
 // Example import by UI ID path (_the_id_string_): org.vdc.edge-gw.ui-no.2
 // Example list path (_the_id_string_): list@org.vdc.edge-gw
 func resourceVcdNsxvFirewallRuleImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	var commandOrgName, orgName, vdcName, edgeName, firewallRuleId, uiId string
+	var orgName, vdcName, edgeName, firewallRuleId, uiId string
 	var listRules bool
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	helpError := fmt.Errorf(`resource id must be specified in one of these formats:
 'org-name.vdc-name.edge-gw-name.real-firewall-rule-id' to import by rule id
 'org-name.vdc-name.edge-gw-name.ui-no.X' where X is the firewall rule number shown in UI
-'list@org-name.vdc-name.edge-gw-name' to get a list of rules with their respective UI numbers and real IDs`)
+'list@org-name.vdc-name.edge-gw-name' to get a list of rules with their respective UI numbers and real IDs
+In all formats, 'org-name.vdc-name.edge-gw-name' can be replaced by the Edge Gateway ID`)
 
 	log.Printf("[DEBUG] importing vcd_nsxv_firewall_rule resource with provided id %s", d.Id())
 
-	switch len(resourceURI) {
-	case 3:
-		commandOrgName, vdcName, edgeName = resourceURI[0], resourceURI[1], resourceURI[2]
-		commandOrgNameSplit := strings.Split(commandOrgName, "@")
-		if len(commandOrgNameSplit) != 2 {
+	// The list command is prefixed to the path, which can start with the Edge Gateway ID
+	identifier := d.Id()
+	if command, path, found := strings.Cut(identifier, "@"); found {
+		if command == "" || strings.Contains(command, ImportSeparator) {
 			return nil, helpError
 		}
-		orgName = commandOrgNameSplit[1]
+		identifier = path
 		listRules = true
-	case 4:
+	}
+
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importEdgeGatewayPath(vcdClient, identifier)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case listRules && len(resourceURI) == 3:
+		orgName, vdcName, edgeName = resourceURI[0], resourceURI[1], resourceURI[2]
+	case !listRules && len(resourceURI) == 4:
 		orgName, vdcName, edgeName, firewallRuleId = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
-		listRules = false
-	case 5:
+	case !listRules && len(resourceURI) == 5:
 		if resourceURI[3] != "ui-no" {
 			return nil, helpError
 		}
 		orgName, vdcName, edgeName, uiId = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[4]
-		listRules = false
 	default:
 		return nil, helpError
 	}
 
-	vcdClient := meta.(*VCDClient)
 	edgeGateway, err := vcdClient.GetEdgeGateway(orgName, vdcName, edgeName)
 	if err != nil {
 		return nil, fmt.Errorf(errorUnableToFindEdgeGateway, err)
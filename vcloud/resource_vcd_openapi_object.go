@@ -0,0 +1,351 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// resourceVcdOpenApiObject manages any object of the VCD OpenAPI (/cloudapi) that follows the usual pattern of
+// creating items with a POST to their collection, and reading, updating and deleting them on their own endpoint.
+// It is meant for the features that are not covered by a specific resource yet.
+func resourceVcdOpenApiObject() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdOpenApiObjectCreate,
+		ReadContext:   resourceVcdOpenApiObjectRead,
+		UpdateContext: resourceVcdOpenApiObjectUpdate,
+		DeleteContext: resourceVcdOpenApiObjectDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdOpenApiObjectImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"endpoint": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+				Description:  "OpenAPI endpoint of the collection where the object is created, relative to '/cloudapi/' (e.g. '1.0.0/ipSpaces/')",
+			},
+			"item_endpoint": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "OpenAPI endpoint of the object, relative to '/cloudapi/', with '{id}' as placeholder of its ID. " +
+					"Defaults to the collection endpoint followed by the ID",
+			},
+			"api_version": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "API version used in the requests. Defaults to the version negotiated by the provider",
+			},
+			"body": {
+				Type:                  schema.TypeString,
+				Required:              true,
+				ValidateFunc:          validation.StringIsJSON,
+				DiffSuppressFunc:      hasJsonValueChanged,
+				DiffSuppressOnRefresh: true,
+				Description:           "JSON body used to create and update the object. Only the fields present in the body are checked for drift",
+			},
+			"id_path": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "id",
+				Description: "JSON path of the ID in the object returned by VCD after the creation (e.g. 'id' or 'entity.id')",
+			},
+			"ignored_fields": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "JSON paths of the fields of 'body' that are not checked for drift, such as secrets that VCD does not return",
+			},
+			"response": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "JSON of the object, as returned by VCD",
+			},
+		},
+	}
+}
+
+func resourceVcdOpenApiObjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	var payload interface{}
+	err := json.Unmarshal([]byte(d.Get("body").(string)), &payload)
+	if err != nil {
+		return diag.Errorf("error reading 'body': %s", err)
+	}
+
+	endpoint := normalizeOpenApiObjectEndpoint(d.Get("endpoint").(string), true)
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(endpoint)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	// Asynchronous creations are tracked until their task finishes, and the object is retrieved
+	// with the ID of the task owner
+	var created interface{}
+	err = vcdClient.Client.OpenApiPostItem(getOpenApiObjectApiVersion(d, vcdClient), urlRef, nil, payload, &created, nil)
+	if err != nil {
+		return diag.Errorf("error creating OpenAPI object in %s: %s", endpoint, err)
+	}
+
+	id, err := getOpenApiObjectId(created, d.Get("id_path").(string))
+	if err != nil {
+		return diag.Errorf("error getting the ID of the OpenAPI object created in %s: %s", endpoint, err)
+	}
+	d.SetId(id)
+
+	return resourceVcdOpenApiObjectRead(ctx, d, meta)
+}
+
+func resourceVcdOpenApiObjectRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	var current interface{}
+	err := getOpenApiObjectItem(d, vcdClient, &current)
+	if govcd.ContainsNotFound(err) {
+		log.Printf("[DEBUG] OpenAPI object %s no longer exists. Removing from tfstate", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("error reading OpenAPI object %s: %s", d.Id(), err)
+	}
+
+	response, err := json.Marshal(current)
+	if err != nil {
+		return diag.Errorf("error encoding OpenAPI object %s: %s", d.Id(), err)
+	}
+	dSet(d, "response", string(response))
+
+	// After an import, the whole object is the body
+	if d.Get("body").(string) == "" {
+		dSet(d, "body", string(response))
+		return nil
+	}
+
+	var configured interface{}
+	err = json.Unmarshal([]byte(d.Get("body").(string)), &configured)
+	if err != nil {
+		return diag.Errorf("error reading 'body': %s", err)
+	}
+	ignoredFields := map[string]bool{}
+	for _, field := range convertSchemaSetToSliceOfStrings(d.Get("ignored_fields").(*schema.Set)) {
+		ignoredFields[normalizeOpenApiObjectPath(field)] = true
+	}
+	observed, err := json.Marshal(getOpenApiObjectObservedValue(configured, current, "", ignoredFields))
+	if err != nil {
+		return diag.Errorf("error encoding OpenAPI object %s: %s", d.Id(), err)
+	}
+	areEqual, err := areMarshaledJsonEqual([]byte(d.Get("body").(string)), observed)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !areEqual {
+		dSet(d, "body", string(observed))
+	}
+	return nil
+}
+
+func resourceVcdOpenApiObjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	if d.HasChange("body") {
+		var configured interface{}
+		err := json.Unmarshal([]byte(d.Get("body").(string)), &configured)
+		if err != nil {
+			return diag.Errorf("error reading 'body': %s", err)
+		}
+
+		// The body is merged into the current object, as updates usually require all the fields, including the
+		// ones added by VCD, such as the version used for optimistic locking
+		var current interface{}
+		err = getOpenApiObjectItem(d, vcdClient, &current)
+		if err != nil {
+			return diag.Errorf("error reading OpenAPI object %s before updating it: %s", d.Id(), err)
+		}
+		urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(getOpenApiObjectItemEndpoint(d))
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		err = vcdClient.Client.OpenApiPutItem(getOpenApiObjectApiVersion(d, vcdClient), urlRef, nil,
+			mergeOpenApiObjectValue(current, configured), nil, nil)
+		if err != nil {
+			return diag.Errorf("error updating OpenAPI object %s: %s", d.Id(), err)
+		}
+	}
+
+	return resourceVcdOpenApiObjectRead(ctx, d, meta)
+}
+
+func resourceVcdOpenApiObjectDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(getOpenApiObjectItemEndpoint(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	err = vcdClient.Client.OpenApiDeleteItem(getOpenApiObjectApiVersion(d, vcdClient), urlRef, nil, nil)
+	if err != nil && !govcd.ContainsNotFound(err) {
+		return diag.Errorf("error deleting OpenAPI object %s: %s", d.Id(), err)
+	}
+	return nil
+}
+
+// resourceVcdOpenApiObjectImport imports an OpenAPI object with the collection endpoint and the ID of the object, such as
+// 1.0.0/ipSpaces/.urn:vcloud:ipSpace:8bd5dd6c-3e89-4a74-9ea9-9aef4b5e4b3a
+func resourceVcdOpenApiObjectImport(_ context.Context, d *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	separatorIndex := strings.LastIndex(d.Id(), ImportSeparator)
+	if separatorIndex <= 0 || separatorIndex == len(d.Id())-len(ImportSeparator) {
+		return nil, fmt.Errorf("resource name must be specified as endpoint%sid", ImportSeparator)
+	}
+	endpoint, id := d.Id()[:separatorIndex], d.Id()[separatorIndex+len(ImportSeparator):]
+
+	dSet(d, "endpoint", normalizeOpenApiObjectEndpoint(endpoint, true))
+	dSet(d, "id_path", "id")
+	d.SetId(id)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getOpenApiObjectItem retrieves the OpenAPI object of the resource in generic JSON format
+func getOpenApiObjectItem(d *schema.ResourceData, vcdClient *VCDClient, item *interface{}) error {
+	urlRef, err := vcdClient.Client.OpenApiBuildEndpoint(getOpenApiObjectItemEndpoint(d))
+	if err != nil {
+		return err
+	}
+	return vcdClient.Client.OpenApiGetItem(getOpenApiObjectApiVersion(d, vcdClient), urlRef, nil, item, nil)
+}
+
+// getOpenApiObjectItemEndpoint returns the endpoint of the object managed by the resource
+func getOpenApiObjectItemEndpoint(d *schema.ResourceData) string {
+	if itemEndpoint := d.Get("item_endpoint").(string); itemEndpoint != "" {
+		return normalizeOpenApiObjectEndpoint(strings.ReplaceAll(itemEndpoint, "{id}", d.Id()), false)
+	}
+	return normalizeOpenApiObjectEndpoint(d.Get("endpoint").(string), true) + d.Id()
+}
+
+// getOpenApiObjectApiVersion returns the API version set in the configuration, or the one negotiated by the provider
+func getOpenApiObjectApiVersion(d *schema.ResourceData, vcdClient *VCDClient) string {
+	return firstNonEmpty(d.Get("api_version").(string), vcdClient.Client.APIVersion)
+}
+
+// normalizeOpenApiObjectEndpoint removes the '/cloudapi/' prefix from an endpoint, which is added by the client,
+// and adds a trailing slash to the endpoints of collections
+func normalizeOpenApiObjectEndpoint(endpoint string, isCollection bool) string {
+	endpoint = strings.TrimPrefix(strings.TrimSpace(endpoint), "/")
+	endpoint = strings.TrimPrefix(endpoint, "cloudapi/")
+	if isCollection && !strings.HasSuffix(endpoint, "/") {
+		endpoint += "/"
+	}
+	return endpoint
+}
+
+var reOpenApiObjectPathIndex = regexp.MustCompile(`\[(\d+)]`)
+
+// normalizeOpenApiObjectPath converts a JSON path such as '$.entity.items[0].id' to the dot notation used internally
+// ('entity.items.0.id')
+func normalizeOpenApiObjectPath(path string) string {
+	path = strings.TrimPrefix(strings.TrimSpace(path), "$")
+	path = reOpenApiObjectPathIndex.ReplaceAllString(path, ".$1")
+	return strings.Trim(path, ".")
+}
+
+// getOpenApiObjectField returns the value found in the given JSON path of a generic JSON value
+func getOpenApiObjectField(value interface{}, path string) (interface{}, bool) {
+	path = normalizeOpenApiObjectPath(path)
+	if path == "" {
+		return value, true
+	}
+	for _, field := range strings.Split(path, ".") {
+		switch typedValue := value.(type) {
+		case map[string]interface{}:
+			fieldValue, found := typedValue[field]
+			if !found {
+				return nil, false
+			}
+			value = fieldValue
+		case []interface{}:
+			index, err := strconv.Atoi(field)
+			if err != nil || index < 0 || index >= len(typedValue) {
+				return nil, false
+			}
+			value = typedValue[index]
+		default:
+			return nil, false
+		}
+	}
+	return value, true
+}
+
+// getOpenApiObjectId returns the ID found in the given JSON path of an OpenAPI object
+func getOpenApiObjectId(object interface{}, idPath string) (string, error) {
+	id, found := getOpenApiObjectField(object, idPath)
+	if !found || id == nil {
+		return "", fmt.Errorf("the object does not contain the field '%s'", idPath)
+	}
+	switch typedId := id.(type) {
+	case string:
+		if typedId == "" {
+			return "", fmt.Errorf("the field '%s' of the object is empty", idPath)
+		}
+		return typedId, nil
+	case float64, bool:
+		return fmt.Sprint(typedId), nil
+	}
+	return "", fmt.Errorf("the field '%s' of the object is not a string or a number", idPath)
+}
+
+// getOpenApiObjectObservedValue returns the configured JSON value with the values of the remote object, so that the
+// changes made outside Terraform show up as a difference. The fields of JSON objects are compared one by one, to skip
+// the ones that VCD adds, while lists and values are compared as a whole. Ignored fields keep their configured value
+func getOpenApiObjectObservedValue(configured, remote interface{}, path string, ignoredFields map[string]bool) interface{} {
+	if ignoredFields[path] {
+		return configured
+	}
+	configuredObject, isConfiguredObject := configured.(map[string]interface{})
+	remoteObject, isRemoteObject := remote.(map[string]interface{})
+	if !isConfiguredObject || !isRemoteObject {
+		return remote
+	}
+	observed := map[string]interface{}{}
+	for field, configuredValue := range configuredObject {
+		fieldPath := field
+		if path != "" {
+			fieldPath = path + "." + field
+		}
+		remoteValue, found := remoteObject[field]
+		if !found && !ignoredFields[fieldPath] {
+			continue
+		}
+		observed[field] = getOpenApiObjectObservedValue(configuredValue, remoteValue, fieldPath, ignoredFields)
+	}
+	return observed
+}
+
+// mergeOpenApiObjectValue returns the current JSON value of an object with the configured fields. Nested objects are
+// merged field by field, while lists and values are replaced
+func mergeOpenApiObjectValue(current, configured interface{}) interface{} {
+	currentObject, isCurrentObject := current.(map[string]interface{})
+	configuredObject, isConfiguredObject := configured.(map[string]interface{})
+	if !isCurrentObject || !isConfiguredObject {
+		return configured
+	}
+	merged := make(map[string]interface{}, len(currentObject))
+	for field, value := range currentObject {
+		merged[field] = value
+	}
+	for field, value := range configuredObject {
+		merged[field] = mergeOpenApiObjectValue(currentObject[field], value)
+	}
+	return merged
+}
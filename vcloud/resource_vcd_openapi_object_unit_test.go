@@ -0,0 +1,159 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// mockOpenApiTestObject returns the object with the given ID from the collection used in the tests of vcloud_openapi_object
+func mockOpenApiTestObject(mock *mockVcd, id string) map[string]interface{} {
+	mock.Lock()
+	defer mock.Unlock()
+	for _, item := range mock.openApi["1.0.0/testObjects"] {
+		if item["id"] == id {
+			return item
+		}
+	}
+	return nil
+}
+
+func TestMockVcdOpenApiObjectLifecycle(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+	mock.Lock()
+	mock.openApi["1.0.0/testObjects"] = []map[string]interface{}{}
+	mock.Unlock()
+
+	resource := Provider().ResourcesMap["vcloud_openapi_object"]
+	body := `{"name": "object-1", "settings": {"enabled": true}, "password": "secret"}`
+	d := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"endpoint":       "/cloudapi/1.0.0/testObjects",
+		"body":           body,
+		"ignored_fields": []interface{}{"$.password"},
+	})
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating OpenAPI object: %v", diags)
+	}
+	if mockOpenApiTestObject(mock, d.Id()) == nil {
+		t.Fatalf("OpenAPI object %s not found in VCD", d.Id())
+	}
+
+	// Changes made outside Terraform show up in the body, except for the fields that VCD adds and the ignored ones
+	object := mockOpenApiTestObject(mock, d.Id())
+	mock.Lock()
+	object["settings"] = map[string]interface{}{"enabled": false}
+	object["status"] = "REALIZED"
+	delete(object, "password")
+	mock.Unlock()
+	diags = resource.ReadContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading OpenAPI object: %v", diags)
+	}
+	areEqual, err := areMarshaledJsonEqual([]byte(d.Get("body").(string)), []byte(`{"name": "object-1", "settings": {"enabled": false}, "password": "secret"}`))
+	if err != nil || !areEqual {
+		t.Errorf("unexpected body after drift: %s", d.Get("body"))
+	}
+
+	// Updates keep the fields added by VCD
+	updated := schema.TestResourceDataRaw(t, resource.Schema, map[string]interface{}{
+		"endpoint":       "1.0.0/testObjects/",
+		"body":           body,
+		"ignored_fields": []interface{}{"password"},
+	})
+	updated.SetId(d.Id())
+	diags = resource.UpdateContext(ctx, updated, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error updating OpenAPI object: %v", diags)
+	}
+	object = mockOpenApiTestObject(mock, d.Id())
+	if object["status"] != "REALIZED" || !reflect.DeepEqual(object["settings"], map[string]interface{}{"enabled": true}) {
+		t.Errorf("unexpected object after update: %v", object)
+	}
+
+	// Data sources find the object by its endpoint or by filter
+	byEndpoint := readMockDataSource(t, vcdClient, "vcloud_openapi_object", map[string]interface{}{
+		"endpoint": "1.0.0/testObjects/" + d.Id(),
+	})
+	byFilter := readMockDataSource(t, vcdClient, "vcloud_openapi_object", map[string]interface{}{
+		"endpoint": "1.0.0/testObjects",
+		"filter":   "name==object-1",
+	})
+	if byEndpoint.Id() != d.Id() || byFilter.Id() != d.Id() {
+		t.Errorf("expected data sources with ID %s, got %s and %s", d.Id(), byEndpoint.Id(), byFilter.Id())
+	}
+
+	diags = resource.DeleteContext(ctx, updated, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting OpenAPI object: %v", diags)
+	}
+	if mockOpenApiTestObject(mock, d.Id()) != nil {
+		t.Errorf("OpenAPI object %s still exists after deletion", d.Id())
+	}
+	diags = resource.ReadContext(ctx, updated, vcdClient)
+	if diags.HasError() || updated.Id() != "" {
+		t.Errorf("expected a deleted object to be removed from state, got ID '%s' and %v", updated.Id(), diags)
+	}
+}
+
+func TestGetOpenApiObjectId(t *testing.T) {
+	var object interface{}
+	err := json.Unmarshal([]byte(`{"id": "urn:vcloud:ipSpace:1", "entity": {"id": 42}, "items": [{"id": "a"}, {"id": "b"}], "empty": ""}`), &object)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		path    string
+		want    string
+		wantErr bool
+	}{
+		{path: "id", want: "urn:vcloud:ipSpace:1"},
+		{path: "$.id", want: "urn:vcloud:ipSpace:1"},
+		{path: "entity.id", want: "42"},
+		{path: "$.items[1].id", want: "b"},
+		{path: "items.0.id", want: "a"},
+		{path: "items[2].id", wantErr: true},
+		{path: "entity.name", wantErr: true},
+		{path: "empty", wantErr: true},
+		{path: "items", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got, err := getOpenApiObjectId(object, tt.path)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("getOpenApiObjectId() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("getOpenApiObjectId() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMergeOpenApiObjectValue(t *testing.T) {
+	current := map[string]interface{}{
+		"id":       "urn:vcloud:test:1",
+		"version":  map[string]interface{}{"version": float64(3)},
+		"settings": map[string]interface{}{"enabled": false, "mode": "AUTO"},
+		"tags":     []interface{}{"a", "b"},
+	}
+	configured := map[string]interface{}{
+		"settings": map[string]interface{}{"enabled": true},
+		"tags":     []interface{}{"c"},
+	}
+	want := map[string]interface{}{
+		"id":       "urn:vcloud:test:1",
+		"version":  map[string]interface{}{"version": float64(3)},
+		"settings": map[string]interface{}{"enabled": true, "mode": "AUTO"},
+		"tags":     []interface{}{"c"},
+	}
+	if got := mergeOpenApiObjectValue(current, configured); !reflect.DeepEqual(got, want) {
+		t.Errorf("mergeOpenApiObjectValue() = %v, want %v", got, want)
+	}
+}
@@ -32,6 +32,7 @@ func resourceOrg() *schema.Resource {
 		ReadContext:   resourceOrgRead,
 		UpdateContext: resourceOrgUpdate,
 		DeleteContext: resourceOrgDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_org", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdOrgImport,
 		},
@@ -610,14 +611,19 @@ func resourceOrgRead(_ context.Context, d *schema.ResourceData, m interface{}) d
 // The d.ID() field as being passed from `terraform import _resource_name_ _the_id_string_ requires
 // a name based dot-formatted path to the object to lookup the object and sets the id of object.
 // `terraform import` automatically performs `refresh` operation which loads up all other fields.
-// For this resource, the import path is just the org name.
+// For this resource, the import path is just the org name, or the Org ID (URN, HREF or UUID).
 //
 // Example import path (id): orgName
 func resourceVcdOrgImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	orgName := d.Id()
-
 	vcdClient := meta.(*VCDClient)
-	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
+
+	var adminOrg *govcd.AdminOrg
+	var err error
+	if orgId := importEntityId("urn:vcloud:org:", d.Id()); orgId != "" {
+		adminOrg, err = vcdClient.GetAdminOrgById(orgId)
+	} else {
+		adminOrg, err = vcdClient.GetAdminOrgByName(d.Id())
+	}
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, err)
 	}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"fmt"
 	"log"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
@@ -196,19 +195,22 @@ func resourceVcdOrgGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 // Example import path (id): my-org.my-group
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.org_group")
+		return nil, fmt.Errorf("resource name must be specified as org.org_group or as org-ID.org_group, where org_group can also be the group ID")
 	}
 	orgName, groupName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
 	}
 
-	group, err := adminOrg.GetGroupByName(groupName, false)
+	group, err := adminOrg.GetGroupByNameOrId(groupName, false)
 	if err != nil {
 		return nil, fmt.Errorf("[group import] error retrieving group %s: %s", groupName, err)
 	}
@@ -417,6 +417,9 @@ func fillLdapSettings(d *schema.ResourceData) (*types.OrgLdapSettingsType, error
 // Example import path (id): orgName
 func resourceVcdOrgLdapImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	orgName := d.Id()
+	if orgId := importEntityId("urn:vcloud:org:", d.Id()); orgId != "" {
+		orgName = orgId
+	}
 
 	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgName)
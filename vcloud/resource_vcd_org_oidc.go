@@ -480,6 +480,9 @@ func resourceVcdOrgOidcDelete(_ context.Context, d *schema.ResourceData, meta in
 // The only parameter needed is the Org identifier, which could be either the Org name or its ID
 func resourceVcdOrgOidcImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	orgNameOrId := d.Id()
+	if orgId := importEntityId("urn:vcloud:org:", d.Id()); orgId != "" {
+		orgNameOrId = orgId
+	}
 
 	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgNameOrId)
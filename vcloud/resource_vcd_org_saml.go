@@ -207,6 +207,9 @@ func resourceVcdOrgSamlDelete(ctx context.Context, d *schema.ResourceData, meta
 // The only parameter needed is the Org identifier, which could be either the Org name or its ID
 func resourceVcdOrgSamlImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	orgNameOrId := d.Id()
+	if orgId := importEntityId("urn:vcloud:org:", d.Id()); orgId != "" {
+		orgNameOrId = orgId
+	}
 
 	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByNameOrId(orgNameOrId)
@@ -256,7 +256,7 @@ func resourceToOrgUser(d *schema.ResourceData, meta interface{}) (*govcd.OrgUser
 		return nil, nil, fmt.Errorf("[resourceToOrgUser ] error retrieving org %s", d.Get("org").(string))
 	}
 	userName := d.Get("name").(string)
-	orgUser, err := adminOrg.GetUserByName(userName, false)
+	orgUser, err := adminOrg.GetUserByNameOrId(userName, false)
 	if err != nil {
 		return nil, nil, fmt.Errorf("[resourceToOrgUser] error retrieving user %s: %s", userName, err)
 	}
@@ -372,13 +372,16 @@ func resourceVcdOrgUserUpdate(ctx context.Context, d *schema.ResourceData, meta
 // Example import path (id): my-org.my-user-admin
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgUserImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.org_user")
+		return nil, fmt.Errorf("resource name must be specified as org.org_user or as org-ID.org_user, where org_user can also be the user ID")
 	}
 	orgName, userName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
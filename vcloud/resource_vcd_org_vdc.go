@@ -53,6 +53,7 @@ func resourceVcdOrgVdc() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceVcdVdcCreate,
 		DeleteContext: resourceVcdVdcDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_org_vdc", "metadata"),
 		ReadContext:   resourceVcdVdcRead,
 		UpdateContext: resourceVcdVdcUpdate,
 		Importer: &schema.ResourceImporter{
@@ -1395,14 +1396,23 @@ func getVcdVdcInput(d *schema.ResourceData, vcdClient *VCDClient) (*types.VdcCon
 // Example import path (_the_id_string_): org.my_existing_vdc
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdOrgVdcImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.my_existing_vdc")
-	}
-	orgName, vdcName := resourceURI[0], resourceURI[1]
-
 	vcdClient := meta.(*VCDClient)
 
+	var orgName, vdcName string
+	if vdcId := importEntityId("urn:vcloud:vdc:", d.Id()); vdcId != "" {
+		var err error
+		orgName, vdcName, err = importVdcNames(vcdClient, vdcId)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find VDC %s: %s", vdcId, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 2 {
+			return nil, fmt.Errorf("resource name must be specified as org.my_existing_vdc or as VDC ID")
+		}
+		orgName, vdcName = resourceURI[0], resourceURI[1]
+	}
+
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, err)
@@ -62,6 +62,7 @@ func resourceVcdProviderVdc() *schema.Resource {
 		ReadContext:   resourceVcdProviderVdcRead,
 		UpdateContext: resourceVcdProviderVdcUpdate,
 		DeleteContext: resourceVcdProviderVdcDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_provider_vdc", ""),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceProviderVdcImport,
 		},
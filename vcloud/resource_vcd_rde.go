@@ -150,7 +150,7 @@ func resourceVcdRdeCreate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	err = createOrUpdateOpenApiMetadataEntryInVcd(d, rde)
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, govcdOpenApiMetadata{rde})
 	if err != nil {
 		return diag.Errorf("could not create metadata for the Runtime Defined Entity: %s", err)
 	}
@@ -234,7 +234,7 @@ func resourceVcdRdeRead(_ context.Context, d *schema.ResourceData, meta interfac
 		dSet(d, "entity_in_sync", areJsonEqual)
 	}
 
-	diags := updateOpenApiMetadataInState(d, vcdClient, "vcd_rde", rde)
+	diags := updateOpenApiMetadataInState(d, vcdClient, "vcd_rde", govcdOpenApiMetadata{rde})
 	if diags != nil && diags.HasError() {
 		return diags
 	}
@@ -338,7 +338,7 @@ func resourceVcdRdeUpdate(ctx context.Context, d *schema.ResourceData, meta inte
 		}
 	}
 
-	err = createOrUpdateOpenApiMetadataEntryInVcd(d, rde)
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, govcdOpenApiMetadata{rde})
 	if err != nil {
 		return diag.Errorf("could not create metadata for the Runtime Defined Entity: %s", err)
 	}
@@ -383,7 +383,7 @@ func resourceVcdRdeDelete(_ context.Context, d *schema.ResourceData, meta interf
 func resourceVcdRdeImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	vcdClient := meta.(*VCDClient)
 	helpError := fmt.Errorf(`resource id must be specified in one of these formats:
-'rde-id' to import by RDE id
+'rde-id' to import by RDE id, given as URN or HREF
 'vendor.nss.version.name.position' where position is the RDE number as returned by VCD, starting on 1
 'list@vendor.nss.version.name' to get a list of RDEs with their respective positions and real IDs`)
 
@@ -419,30 +419,38 @@ func resourceVcdRdeImport(_ context.Context, d *schema.ResourceData, meta interf
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	var rde *govcd.DefinedEntity
 	var err error
-	switch len(resourceURI) {
-	case 1: // ie: urn:vcloud:entity:vendor:nss:a074f9e9-5d76-4f1e-8c37-f4e8b28e51ff
+	// The URN of an RDE contains its vendor and nss, so it can't be built from a bare UUID. When it is found in an
+	// HREF, it is used before the separator splits the host name
+	rdeUrnIndex := strings.Index(d.Id(), "urn:vcloud:entity:")
+	switch {
+	case rdeUrnIndex >= 0 && extractUuid(d.Id()) != "": // ie: https://vcd.example.com/cloudapi/1.0.0/entities/urn:vcloud:entity:vendor:nss:a074f9e9-5d76-4f1e-8c37-f4e8b28e51ff
+		rde, err = vcdClient.VCDClient.GetRdeById(d.Id()[rdeUrnIndex:])
+		if err != nil {
+			return nil, err
+		}
+	case len(resourceURI) == 1: // ie: urn:vcloud:entity:vendor:nss:a074f9e9-5d76-4f1e-8c37-f4e8b28e51ff
 		rde, err = vcdClient.VCDClient.GetRdeById(resourceURI[0])
 		if err != nil {
 			return nil, err
 		}
-	case 4: // ie: VCD_IMPORT_SEPARATOR="_" list@vendor_nss_1.2.3_name
+	case len(resourceURI) == 4: // ie: VCD_IMPORT_SEPARATOR="_" list@vendor_nss_1.2.3_name
 		listAndVendorSplit := strings.Split(resourceURI[0], "@")
 		if len(listAndVendorSplit) != 2 {
 			return nil, helpError
 		}
 		return nil, printList(listAndVendorSplit[1], resourceURI[1], resourceURI[2], resourceURI[3])
-	case 5: // ie: VCD_IMPORT_SEPARATOR="_" vendor_nss_1.2.3_name_1
+	case len(resourceURI) == 5: // ie: VCD_IMPORT_SEPARATOR="_" vendor_nss_1.2.3_name_1
 		rde, err = getRdeInPosition(resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3], resourceURI[4])
 		if err != nil {
 			return nil, err
 		}
-	case 6: // ie: list@vendor.nss.1.2.3.name
+	case len(resourceURI) == 6: // ie: list@vendor.nss.1.2.3.name
 		listAndVendorSplit := strings.Split(resourceURI[0], "@")
 		if len(listAndVendorSplit) != 2 {
 			return nil, helpError
 		}
 		return nil, printList(listAndVendorSplit[1], resourceURI[1], fmt.Sprintf("%s.%s.%s", resourceURI[2], resourceURI[3], resourceURI[4]), resourceURI[5])
-	case 7: // ie: vendor.nss.1.2.3.name.1
+	case len(resourceURI) == 7: // ie: vendor.nss.1.2.3.name.1
 		rde, err = getRdeInPosition(resourceURI[0], resourceURI[1], fmt.Sprintf("%s.%s.%s", resourceURI[2], resourceURI[3], resourceURI[4]), resourceURI[5], resourceURI[6])
 		if err != nil {
 			return nil, err
@@ -159,13 +159,23 @@ func resourceVcdRdeInterfaceDelete(_ context.Context, d *schema.ResourceData, me
 // Example import path (_the_id_string_): vmware.kubernetes.1.0.0
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdRdeInterfaceImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	// The Runtime Defined Entity Interface URN is made of its vendor, nss and version, not of a UUID
+	if strings.HasPrefix(d.Id(), "urn:vcloud:interface:") {
+		di, err := vcdClient.GetDefinedInterfaceById(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("error finding Runtime Defined Entity Interface with ID %s: %s", d.Id(), err)
+		}
+		d.SetId(di.DefinedInterface.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) < 3 {
-		return nil, fmt.Errorf("resource identifier must be specified as vendor.nss.version")
+		return nil, fmt.Errorf("resource identifier must be specified as vendor.nss.version or as urn:vcloud:interface:vendor:nss:version")
 	}
 	vendor, nss, version := resourceURI[0], resourceURI[1], strings.Join(resourceURI[2:], ".")
 
-	vcdClient := meta.(*VCDClient)
 	di, err := vcdClient.GetDefinedInterface(vendor, nss, version)
 	if err != nil {
 		return nil, fmt.Errorf("error finding Runtime Defined Entity Interface with vendor %s, nss %s and version %s: %s", vendor, nss, version, err)
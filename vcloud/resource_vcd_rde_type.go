@@ -387,13 +387,23 @@ func resourceVcdRdeTypeDelete(_ context.Context, d *schema.ResourceData, meta in
 // Example import path (_the_id_string_): vmware.kubernetes.1.0.0
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdRdeTypeImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	// The Runtime Defined Entity Type URN is made of its vendor, nss and version, not of a UUID
+	if strings.HasPrefix(d.Id(), "urn:vcloud:type:") {
+		rdeType, err := vcdClient.GetRdeTypeById(d.Id())
+		if err != nil {
+			return nil, fmt.Errorf("error finding Runtime Defined Entity Type with ID %s: %s", d.Id(), err)
+		}
+		d.SetId(rdeType.DefinedEntityType.ID)
+		return []*schema.ResourceData{d}, nil
+	}
+
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) < 3 {
-		return nil, fmt.Errorf("resource identifier must be specified as vendor.nss.version")
+		return nil, fmt.Errorf("resource identifier must be specified as vendor.nss.version or as urn:vcloud:type:vendor:nss:version")
 	}
 	vendor, nss, version := resourceURI[0], resourceURI[1], strings.Join(resourceURI[2:], ".")
 
-	vcdClient := meta.(*VCDClient)
 	rdeType, err := vcdClient.GetRdeType(vendor, nss, version)
 	if err != nil {
 		return nil, fmt.Errorf("error finding Runtime Defined Entity Type with vendor %s, nss %s and version %s: %s", vendor, nss, version, err)
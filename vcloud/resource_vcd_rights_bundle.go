@@ -287,17 +287,23 @@ func resourceRightsBundleDelete(_ context.Context, d *schema.ResourceData, meta
 func resourceVcdRightsBundleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 	if len(resourceURI) != 1 {
-		return nil, fmt.Errorf("resource name must be specified as rightsBundle-name")
+		return nil, fmt.Errorf("resource name must be specified as rightsBundle-name or as rightsBundle-ID")
 	}
 	rightsBundleName := resourceURI[0]
 
 	vcdClient := meta.(*VCDClient)
 
-	rightsBundle, err := vcdClient.Client.GetRightsBundleByName(rightsBundleName)
+	var rightsBundle *govcd.RightsBundle
+	var err error
+	if id := importWholeEntityId("urn:vcloud:rightsBundle:", d.Id()); id != "" {
+		rightsBundle, err = vcdClient.Client.GetRightsBundleById(id)
+	} else {
+		rightsBundle, err = vcdClient.Client.GetRightsBundleByName(rightsBundleName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("[rights bundle import] error retrieving rights bundle %s: %s", rightsBundleName, err)
 	}
-	dSet(d, "name", rightsBundleName)
+	dSet(d, "name", rightsBundle.RightsBundle.Name)
 	dSet(d, "description", rightsBundle.RightsBundle.Description)
 	dSet(d, "bundle_key", rightsBundle.RightsBundle.BundleKey)
 
@@ -3,7 +3,6 @@ package vcloud
 import (
 	"context"
 	"fmt"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -272,25 +271,32 @@ func getRights(client *VCDClient, org *govcd.AdminOrg, label string, d *schema.R
 }
 
 func resourceVcdRoleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.role-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.role-name or as org-ID.role-name, where role-name can also be the role ID")
 	}
 	orgName, roleName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
-
 	org, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[role import] error retrieving org %s: %s", orgName, err)
 	}
 
-	role, err := org.GetRoleByName(roleName)
+	var role *govcd.Role
+	if roleId := importEntityId("urn:vcloud:role:", roleName); roleId != "" {
+		role, err = org.GetRoleById(roleId)
+	} else {
+		role, err = org.GetRoleByName(roleName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("[role import] error retrieving role %s: %s", roleName, err)
 	}
 	dSet(d, "org", orgName)
-	dSet(d, "name", roleName)
+	dSet(d, "name", role.Role.Name)
 	dSet(d, "description", role.Role.Description)
 	dSet(d, "bundle_key", role.Role.BundleKey)
 	d.SetId(role.Role.ID)
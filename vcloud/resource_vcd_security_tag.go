@@ -8,7 +8,6 @@ import (
 	"github.com/vmware/go-vcloud-director/v2/govcd"
 	"github.com/vmware/go-vcloud-director/v2/types/v56"
 	"log"
-	"strings"
 )
 
 func resourceVcdSecurityTag() *schema.Resource {
@@ -127,13 +126,16 @@ func resourceVcdOpenApiSecurityTagDelete(_ context.Context, d *schema.ResourceDa
 }
 
 func resourceVcdOpenApiSecurityTagImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org.catalog")
+		return nil, fmt.Errorf("resource name must be specified as org.security-tag or as org-ID.security-tag")
 	}
 
 	orgName, securityTag := resourceURI[0], resourceURI[1]
-	vcdClient := meta.(*VCDClient)
 
 	org, err := vcdClient.GetOrgByName(orgName)
 	if err != nil {
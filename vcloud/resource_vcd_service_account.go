@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"log"
 	"net/url"
-	"strings"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -320,20 +319,29 @@ func resourceVcdServiceAccountDelete(ctx context.Context, d *schema.ResourceData
 func resourceVcdServiceAccountImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	log.Printf("[TRACE] API token import initiated")
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importOrgPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.service-account-name")
+		return nil, fmt.Errorf("resource name must be specified as org-name.service-account-name or as org-ID.service-account-name, " +
+			"where service-account-name can also be the service account ID")
 	}
 	orgName := resourceURI[0]
 	saName := resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	org, err := vcdClient.GetOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving org: %s", err)
 	}
 
-	sa, err := org.GetServiceAccountByName(saName)
+	var sa *govcd.ServiceAccount
+	if saId := importEntityId("urn:vcloud:serviceAccount:", saName); saId != "" {
+		sa, err = org.GetServiceAccountById(saId)
+	} else {
+		sa, err = org.GetServiceAccountByName(saName)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error retrieving service account: %s", err)
 	}
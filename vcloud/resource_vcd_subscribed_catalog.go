@@ -468,13 +468,17 @@ func resourceVcdSubscribedCatalogDelete(ctx context.Context, d *schema.ResourceD
 // terraform import vcd_subscribed_catalog.catalog-name  org-name.catalog-id
 func resourceVcdSubscribedCatalogImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	util.Logger.Println("[TRACE] entering resourceVcdSubscribedCatalogImport")
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importCatalogPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.catalog-name or org-name.catalog-ID")
+		return nil, fmt.Errorf("resource name must be specified as org-name.catalog-name or org-name.catalog-ID " +
+			"or as catalog-ID")
 	}
 	orgName, catalogIdentifier := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 
 	if err != nil {
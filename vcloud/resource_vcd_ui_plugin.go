@@ -302,19 +302,29 @@ func resourceVcdUIPluginDelete(_ context.Context, d *schema.ResourceData, meta i
 // Example import path (_the_id_string_): VMware."Customize Portal".3.1.4
 // Note: the separator can be changed using Provider.import_separator or variable VCD_IMPORT_SEPARATOR
 func resourceVcdUIPluginImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) < 3 {
-		return nil, fmt.Errorf("resource identifier must be specified as vendor.pluginName.version")
-	}
-	vendor, name, version := resourceURI[0], resourceURI[1], strings.Join(resourceURI[2:], ".")
-
 	vcdClient := meta.(*VCDClient)
-	uiPlugin, err := vcdClient.GetUIPlugin(vendor, name, version)
-	if err != nil {
-		return nil, fmt.Errorf("error finding UI Plugin with vendor %s, nss %s and version %s: %s", vendor, name, version, err)
+	var uiPlugin *govcd.UIPlugin
+	if id := importWholeEntityId("urn:vcloud:uiPlugin:", d.Id()); id != "" {
+		var err error
+		uiPlugin, err = vcdClient.GetUIPluginById(id)
+		if err != nil {
+			return nil, fmt.Errorf("error finding UI Plugin with ID %s: %s", id, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) < 3 {
+			return nil, fmt.Errorf("resource identifier must be specified as vendor.pluginName.version or as UI Plugin ID")
+		}
+		vendor, name, version := resourceURI[0], resourceURI[1], strings.Join(resourceURI[2:], ".")
+
+		var err error
+		uiPlugin, err = vcdClient.GetUIPlugin(vendor, name, version)
+		if err != nil {
+			return nil, fmt.Errorf("error finding UI Plugin with vendor %s, nss %s and version %s: %s", vendor, name, version, err)
+		}
 	}
 
-	err = setUIPluginTenantIds(uiPlugin, d)
+	err := setUIPluginTenantIds(uiPlugin, d)
 	if err != nil {
 		return nil, err
 	}
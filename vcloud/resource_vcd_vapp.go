@@ -6,11 +6,13 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
 )
 
 const vAppUnknownStatus = "-unknown-status-"
@@ -21,9 +23,15 @@ func resourceVcdVApp() *schema.Resource {
 		UpdateContext: resourceVcdVAppUpdate,
 		ReadContext:   resourceVcdVAppRead,
 		DeleteContext: resourceVcdVAppDelete,
+		CustomizeDiff: defaultMetadataCustomizeDiff("vcd_vapp", "metadata"),
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdVappImport,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(30 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -235,7 +243,7 @@ func resourceVcdVAppUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			if err != nil {
 				return diag.Errorf("error Powering On: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.Errorf("error completing tasks: %s", err)
 			}
@@ -246,7 +254,7 @@ func resourceVcdVAppUpdate(ctx context.Context, d *schema.ResourceData, meta int
 			if err != nil {
 				return diag.Errorf("error Powering Off: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.Errorf("error completing tasks: %s", err)
 			}
@@ -334,7 +342,7 @@ func genericVcdVAppRead(d *schema.ResourceData, meta interface{}, origin string)
 	return nil
 }
 
-func resourceVcdVAppDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	vcdClient := meta.(*VCDClient)
 
 	vcdClient.lockVapp(d)
@@ -355,12 +363,12 @@ func resourceVcdVAppDelete(_ context.Context, d *schema.ResourceData, meta inter
 	if err != nil {
 		return diag.Errorf("error with networking change: %#v", err)
 	}
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, task)
 	if err != nil {
 		return diag.Errorf("error changing network: %#v", err)
 	}
 
-	err = tryUndeploy(*vapp)
+	err = tryUndeploy(ctx, *vapp)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -370,7 +378,7 @@ func resourceVcdVAppDelete(_ context.Context, d *schema.ResourceData, meta inter
 		return diag.Errorf("error deleting: %#v", err)
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, task)
 	if err != nil {
 		return diag.Errorf("error with deleting vApp task: %#v", err)
 	}
@@ -382,7 +390,7 @@ func resourceVcdVAppDelete(_ context.Context, d *schema.ResourceData, meta inter
 // Very often the vApp is powered off at this point and Undeploy() would fail with error:
 // "The requested operation could not be executed since vApp vApp_name is not running"
 // So, if the error matches we just ignore it and the caller may fast forward to vapp.Delete()
-func tryUndeploy(vapp govcd.VApp) error {
+func tryUndeploy(ctx context.Context, vapp govcd.VApp) error {
 	task, err := vapp.Undeploy()
 	var reErr = regexp.MustCompile(`.*The requested operation could not be executed since vApp.*is not running.*`)
 	if err != nil && reErr.MatchString(err.Error()) {
@@ -392,7 +400,7 @@ func tryUndeploy(vapp govcd.VApp) error {
 		return fmt.Errorf("error undeploying vApp: %#v", err)
 	}
 
-	err = task.WaitTaskCompletion()
+	err = waitForTask(ctx, task)
 	if err != nil {
 		return fmt.Errorf("error undeploying vApp: %#v", err)
 	}
@@ -411,24 +419,40 @@ func tryUndeploy(vapp govcd.VApp) error {
 //
 // Example resource name (_resource_name_): vcd_vapp.vapp_name
 // Example import path (_the_id_string_): org-name.vdc-name.vapp-name
+// or
+// Example import path (_the_id_string_): vApp URN, HREF or UUID
 func resourceVcdVappImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[vapp import] resource name must be specified as org-name.vdc-name.vapp-name")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcName, vappIdentifier string
+	if vappId := importEntityId("urn:vcloud:vapp:", d.Id()); vappId != "" {
+		record, err := queryImportRecord(vcdClient, types.QtVapp, "/vApp/vapp-", vappId)
+		if err != nil {
+			return nil, fmt.Errorf("[vapp import] %s", err)
+		}
+		orgName, vdcName, err = importVdcNames(vcdClient, record["vdc"])
+		if err != nil {
+			return nil, fmt.Errorf("[vapp import] error retrieving VDC of vApp %s: %s", vappId, err)
+		}
+		vappIdentifier = vappId
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 3 {
+			return nil, fmt.Errorf("[vapp import] resource name must be specified as org-name.vdc-name.vapp-name or as vApp ID")
+		}
+		orgName, vdcName, vappIdentifier = resourceURI[0], resourceURI[1], resourceURI[2]
 	}
-	orgName, vdcName, vappName := resourceURI[0], resourceURI[1], resourceURI[2]
 
-	vcdClient := meta.(*VCDClient)
 	_, vdc, err := vcdClient.GetOrgAndVdc(orgName, vdcName)
 	if err != nil {
 		return nil, fmt.Errorf("[vapp import] unable to find VDC %s: %s ", vdcName, err)
 	}
 
-	vapp, err := vdc.GetVAppByName(vappName, false)
+	vapp, err := vdc.GetVAppByNameOrId(vappIdentifier, false)
 	if err != nil {
-		return nil, fmt.Errorf("[vapp import] error retrieving vapp %s: %s", vappName, err)
+		return nil, fmt.Errorf("[vapp import] error retrieving vapp %s: %s", vappIdentifier, err)
 	}
-	dSet(d, "name", vappName)
+	dSet(d, "name", vapp.VApp.Name)
 	dSet(d, "org", orgName)
 	dSet(d, "vdc", vdcName)
 	d.SetId(vapp.VApp.ID)
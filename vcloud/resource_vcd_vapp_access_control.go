@@ -228,9 +228,14 @@ func resourceAccessControlVappDelete(_ context.Context, d *schema.ResourceData,
 }
 
 func accessControlVappImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVAppPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[vApp access control import] resource identifier must be specified as org.vdc.my-vapp")
+		return nil, fmt.Errorf("[vApp access control import] resource identifier must be specified as org.vdc.my-vapp " +
+			"or as vapp-ID")
 	}
 	listRequested := false
 	orgName, vdcName, vappIdentifier := resourceURI[0], resourceURI[1], resourceURI[2]
@@ -252,7 +257,6 @@ func accessControlVappImport(_ context.Context, d *schema.ResourceData, meta int
 		return nil, fmt.Errorf("[vApp access control import] empty vApp access control identifier provided")
 	}
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
@@ -360,7 +360,7 @@ func expandVappFirewallRules(d *schema.ResourceData, vapp *govcd.VApp) ([]*types
 }
 
 var errHelpVappNetworkRulesImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'org-name.vdc-name.vapp-name.network_name', 'org.vdc-name.vapp-id.network-id' or 
+'org-name.vdc-name.vapp-name.network_name', 'org.vdc-name.vapp-id.network-id', 'vapp-id.network-id' or 
 'list@org-name.vdc-name.vapp-name' to get a list of vapp networks with their IDs`)
 
 // vappFirewallRulesImport is responsible for importing the resource.
@@ -382,7 +382,10 @@ func vappFirewallRulesImport(_ context.Context, d *schema.ResourceData, meta int
 }
 func vappNetworkRuleImport(d *schema.ResourceData, meta interface{}, resourceType string) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName string
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI, err := importVAppPath(meta.(*VCDClient), d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing %s resource with provided id %s", resourceType, d.Id())
 
@@ -78,7 +78,19 @@ func resourceVcdVAppVm() *schema.Resource {
 		Importer: &schema.ResourceImporter{
 			StateContext: resourceVcdVappVmImport,
 		},
-		Schema: vmSchemaFunc(vappVmType),
+		Timeouts:      vmTimeouts(),
+		CustomizeDiff: vmCustomizeDiff,
+		Schema:        vmSchemaFunc(vappVmType),
+	}
+}
+
+// vmTimeouts defines the default timeouts of both VM resources. They apply to the VCD tasks that create,
+// power, and remove the VM
+func vmTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(60 * time.Minute),
+		Update: schema.DefaultTimeout(60 * time.Minute),
+		Delete: schema.DefaultTimeout(30 * time.Minute),
 	}
 }
 
@@ -311,6 +323,8 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Description: "Optional number of seconds to try and wait for DHCP IP (valid for " +
 				"'network' block only)",
 		},
+		"wait_for_guest": vmWaitForGuestSchema(),
+		"cloud_init":     vmCloudInitSchema(),
 		"network": {
 			Optional:    true,
 			Type:        schema.TypeList,
@@ -726,6 +740,12 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 			Default:     false,
 			Description: "True if the update of resource should fail when virtual machine power off needed.",
 		},
+		"power_off_required_by": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Changed attributes that require the VM to be powered off during update, as computed in the latest plan with changes",
+			Elem:        &schema.Schema{Type: schema.TypeString},
+		},
 		"sizing_policy_id": {
 			Type:        schema.TypeString,
 			Optional:    true,
@@ -765,7 +785,7 @@ func vmSchemaFunc(vmType typeOfVm) map[string]*schema.Schema {
 
 // resourceVcdVAppVmCreate is an entry function for VM within vApp creation. It locks parent vApp and cascades down the
 // other functions that need to be run
-func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	startTime := time.Now()
 
 	vappName := d.Get("vapp_name").(string)
@@ -824,7 +844,7 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	diags := genericResourceVmCreate(d, meta, vappVmType)
+	diags := genericResourceVmCreate(ctx, d, meta, vappVmType)
 	// We need to check if there were errors, as genericResourceVmCreate can also return a warning
 	if diags.HasError() {
 		return diags
@@ -842,7 +862,7 @@ func resourceVcdVAppVmCreate(_ context.Context, d *schema.ResourceData, meta int
 // genericResourceVmCreate does the following:
 // * Executes VM create functions based on the type of VM (standalone or vApp member)
 // * Runs additional customization functions which are common for all 4 types of VMs
-func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+func genericResourceVmCreate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	vcdClient := meta.(*VCDClient)
 
@@ -863,19 +883,19 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	switch {
 	case isVmFromTemplateDeprecated || isVmFromTemplate:
 		util.Logger.Printf("[DEBUG] [VM create] creating VM from template")
-		vm, err = createVmFromImage(d, meta, vmType, vmSourceCatalogTemplate)
+		vm, err = createVmFromImage(ctx, d, meta, vmType, vmSourceCatalogTemplate)
 		if err != nil {
 			return diag.Errorf("error creating VM from template: %s", err)
 		}
 	case isVmCopy:
 		util.Logger.Printf("[DEBUG] [VM create] creating VM copy")
-		vm, err = createVmFromImage(d, meta, vmType, vmSourceVmCopy)
+		vm, err = createVmFromImage(ctx, d, meta, vmType, vmSourceVmCopy)
 		if err != nil {
 			return diag.Errorf("error creating VM copy: %s", err)
 		}
 	case isEmptyVm:
 		util.Logger.Printf("[DEBUG] [VM create] creating empty VM")
-		vm, err = createVmEmpty(d, meta, vmType)
+		vm, err = createVmEmpty(ctx, d, meta, vmType)
 		if err != nil {
 			return diag.Errorf("error creating empty VM: %s", err)
 		}
@@ -993,6 +1013,14 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 		return diag.Errorf("error refreshing VM: %s", err)
 	}
 
+	// Handle cloud-init data. The VM is refreshed inside `applyCloudInit`
+	// Such schema fields are processed:
+	// * cloud_init
+	err = applyCloudInit(ctx, d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("error setting cloud-init data during creation: %s", err)
+	}
+
 	// Explicitly setting CPU and Memory Hot Add settings
 	// Note. VM Creation bodies allow specifying these values, but they are ignored therefore using
 	// an explicit "/vmCapabilities" API endpoint
@@ -1068,7 +1096,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 			if err != nil {
 				return diag.Errorf("error powering on: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.Errorf(errorCompletingTask, err)
 			}
@@ -1079,6 +1107,13 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 	// VM power on handling was the last step, no other VM adjustment operations should be performed
 	////////////////////////////////////////////////////////////////////////////////////////////////
 
+	// Such schema fields are processed:
+	// * wait_for_guest
+	err = waitForGuest(ctx, d, vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM create] error waiting for guest: %s", err)
+	}
+
 	// Read function is called in wrapper functions `resourceVcdVAppVmCreate` and
 	// `resourceVcdStandaloneVmCreate`
 	if len(diags) != 0 {
@@ -1107,7 +1142,7 @@ func genericResourceVmCreate(d *schema.ResourceData, meta interface{}, vmType ty
 // 3. Perform additional operations which are common for both types of VMs
 //
 // Note. VM Power ON (if it wasn't disabled in HCL configuration) occurs as last step after all configuration is done.
-func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm, sourceImageType vmImageSource) (*govcd.VM, error) {
+func createVmFromImage(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm, sourceImageType vmImageSource) (*govcd.VM, error) {
 	vcdClient := meta.(*VCDClient)
 
 	// Step 1 - lookup common information
@@ -1199,7 +1234,13 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 		}
 
 		util.Logger.Printf("%# v", pretty.Formatter(standaloneVmParams))
-		vm, err = vdc.CreateStandaloneVMFromTemplate(&standaloneVmParams)
+		task, err := vdc.CreateStandaloneVMFromTemplateAsync(&standaloneVmParams)
+		if err == nil {
+			err = waitForTask(ctx, task)
+		}
+		if err == nil {
+			vm, err = standaloneVmFromTask(vcdClient, vdc, task, vmName)
+		}
 		if err != nil {
 			d.SetId("")
 			return nil, fmt.Errorf("[VM creation] error creating standalone VM from template %s : %s", vmName, err)
@@ -1256,7 +1297,7 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 			},
 		}
 
-		vm, err = vapp.AddRawVM(vappVmParams)
+		vm, err = addRawVmToVapp(ctx, vcdClient, vapp, vappVmParams)
 		if err != nil {
 			d.SetId("")
 			return nil, fmt.Errorf("[VM creation] error getting VM %s : %s", vmName, err)
@@ -1401,7 +1442,7 @@ func createVmFromImage(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 // 3. Perform additional operations which are common for both types of VMs
 //
 // Note. VM Power ON (if it wasn't disabled in HCL configuration) occurs as last step after all configuration is done.
-func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*govcd.VM, error) {
+func createVmEmpty(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*govcd.VM, error) {
 	util.Logger.Printf("[TRACE] Creating empty VM: %s", d.Get("name").(string))
 
 	vcdClient := meta.(*VCDClient)
@@ -1613,7 +1654,15 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 			Media: mediaReference,
 		}
 
-		newVm, err = vdc.CreateStandaloneVm(&params)
+		task, err := vdc.CreateStandaloneVmAsync(&params)
+		if err != nil {
+			return nil, err
+		}
+		err = waitForTask(ctx, task)
+		if err != nil {
+			return nil, err
+		}
+		newVm, err = standaloneVmFromTask(vcdClient, vdc, task, vmName)
 		if err != nil {
 			return nil, err
 		}
@@ -1663,7 +1712,7 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 		}
 
 		util.Logger.Printf("[VM create - add empty VM] recomposeVAppParamsForEmptyVm %# v", pretty.Formatter(recomposeVAppParamsForEmptyVm))
-		newVm, err = vapp.AddEmptyVm(recomposeVAppParamsForEmptyVm)
+		newVm, err = addEmptyVmToVapp(ctx, vapp, recomposeVAppParamsForEmptyVm)
 		if err != nil {
 			return nil, fmt.Errorf("[VM creation] error creating VM %s : %s", vmName, err)
 		}
@@ -1719,11 +1768,11 @@ func createVmEmpty(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*
 	return newVm, nil
 }
 
-func resourceVcdVAppVmUpdate(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	return genericResourceVcdVmUpdate(d, meta, vappVmType)
+func resourceVcdVAppVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return genericResourceVcdVmUpdate(ctx, d, meta, vappVmType)
 }
 
-func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
+func genericResourceVcdVmUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
 	log.Printf("[DEBUG] [VM update] started with lock")
 	vcdClient := meta.(*VCDClient)
 
@@ -1743,13 +1792,18 @@ func genericResourceVcdVmUpdate(d *schema.ResourceData, meta interface{}, vmType
 		log.Printf("[DEBUG] [VM update] exiting early because only 'network_dhcp_wait_seconds' has change")
 		return genericVcdVmRead(d, meta, "resource")
 	}
+	// The same applies to "wait_for_guest", which is only used when the VM is powered on
+	if onlyHasChange("wait_for_guest", vmSchemaFunc(vmType), d) {
+		log.Printf("[DEBUG] [VM update] exiting early because only 'wait_for_guest' has change")
+		return genericVcdVmRead(d, meta, "resource")
+	}
 
 	err := resourceVmHotUpdate(d, meta, vmType)
 	if err != nil {
 		return err
 	}
 
-	return resourceVcdVAppVmUpdateExecute(d, meta, "update", vmType, nil)
+	return resourceVcdVAppVmUpdateExecute(ctx, d, meta, "update", vmType, nil)
 }
 
 func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOfVm) diag.Diagnostics {
@@ -1853,7 +1907,7 @@ func resourceVmHotUpdate(d *schema.ResourceData, meta interface{}, vmType typeOf
 	return nil
 }
 
-func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
+func resourceVcdVAppVmUpdateExecute(ctx context.Context, d *schema.ResourceData, meta interface{}, executionType string, vmType typeOfVm, computePolicy *types.VdcComputePolicy) diag.Diagnostics {
 	diags := diag.Diagnostics{}
 	log.Printf("[DEBUG] [VM update] started without lock")
 
@@ -1906,12 +1960,13 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 	if executionType == "create" && len(d.Get("network").([]interface{})) > 0 {
 		networksNeedsColdChange = true
 	}
+	// The network configuration of cloud-init is rendered with the NICs of the VM
+	cloudInit := getCloudInitConfig(d.Get("cloud_init"))
+	cloudInitNeedsUpdate := cloudInitHasChange(d) || (d.HasChange("network") && cloudInit != nil && cloudInit.networkConfig != "")
 	log.Printf("[TRACE] VM %s requires cold changes: memory(%t), cpu(%t), network(%t)", vm.VM.Name, memoryNeedsColdChange, cpusNeedsColdChange, networksNeedsColdChange)
 
 	// this represents fields which have to be changed in cold (with VM power off)
-	if d.HasChanges("cpu_cores", "power_on", "disk", "expose_hardware_virtualization", "boot_image",
-		"hardware_version", "os_type", "description", "cpu_hot_add_enabled",
-		"memory_hot_add_enabled", "firmware", "boot_options.0.efi_secure_boot") || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange {
+	if d.HasChanges(append([]string{"power_on"}, vmPowerOffFields...)...) || memoryNeedsColdChange || cpusNeedsColdChange || networksNeedsColdChange || cloudInitNeedsUpdate {
 
 		log.Printf("[TRACE] VM %s has changes: memory(%t), cpus(%t), cpu_cores(%t),"+
 			"power_on(%t), disk(%t), expose_hardware_virtualization(%t),"+
@@ -1935,7 +1990,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
 			}
@@ -1954,6 +2009,13 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 		}
 
+		if cloudInitNeedsUpdate {
+			err = applyCloudInit(ctx, d, vcd, vm)
+			if err != nil {
+				return diag.Errorf("error updating cloud-init data: %s", err)
+			}
+		}
+
 		if memoryNeedsColdChange || executionType == "create" {
 			memory, isMemorySet := d.GetOk("memory")
 			isMemoryComingFromSizingPolicy := computePolicy != nil && (computePolicy.Memory != nil && !isMemorySet)
@@ -2013,7 +2075,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 				return diag.Errorf("error changing hardware assisted virtualization: %s", err)
 			}
 
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.FromErr(err)
 			}
@@ -2175,7 +2237,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			if err != nil {
 				return diag.Errorf("error powering on: %s", err)
 			}
-			err = task.WaitTaskCompletion()
+			err = waitForTask(ctx, task)
 			if err != nil {
 				return diag.Errorf(errorCompletingTask, err)
 			}
@@ -2193,7 +2255,7 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 				if err != nil {
 					return diag.Errorf("error triggering undeploy for VM %s: %s", vm.VM.Name, err)
 				}
-				err = task.WaitTaskCompletion()
+				err = waitForTask(ctx, task)
 				if err != nil {
 					return diag.Errorf("error waiting for undeploy task for VM %s: %s", vm.VM.Name, err)
 				}
@@ -2206,6 +2268,10 @@ func resourceVcdVAppVmUpdateExecute(d *schema.ResourceData, meta interface{}, ex
 			}
 		}
 
+		err = waitForGuest(ctx, d, vcd, vm)
+		if err != nil {
+			return diag.Errorf("[VM update] error waiting for guest: %s", err)
+		}
 	}
 
 	log.Printf("[DEBUG] [VM update] finished")
@@ -2446,7 +2512,7 @@ func genericVcdVmRead(d *schema.ResourceData, meta interface{}, origin string) d
 	return nil
 }
 
-func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+func resourceVcdVAppVmDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("[DEBUG] [VM delete] started")
 
 	vcdClient := meta.(*VCDClient)
@@ -2479,13 +2545,15 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("[VM delete] error getting VM %s : %s", identifier, err)
 	}
 
+	vmUuid := extractUuid(vm.VM.ID)
+
 	// If it is a standalone VM, we remove it in one go
 	if vapp.VApp.IsAutoNature {
 		err = vm.Delete()
 		if err != nil {
 			return diag.FromErr(err)
 		}
-		return nil
+		return cloudInitDeleteDiagnostics(ctx, d, vcdClient, vmUuid)
 	}
 	util.Logger.Printf("[VM delete] vApp before deletion %# v", pretty.Formatter(vapp.VApp))
 	util.Logger.Printf("[VM delete] VM before deletion %# v", pretty.Formatter(vm.VM))
@@ -2502,7 +2570,7 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("error Undeploying: %s", err)
 		}
 
-		err = task.WaitTaskCompletion()
+		err = waitForTask(ctx, task)
 		if err != nil {
 			return diag.Errorf("error Undeploying VM: %s", err)
 		}
@@ -2522,7 +2590,7 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 		if err != nil {
 			return diag.Errorf("error detaching disk `%s`: %s", existingDiskHref, err)
 		}
-		err = task.WaitTaskCompletion()
+		err = waitForTask(ctx, task)
 		if err != nil {
 			return diag.Errorf("error waiting detaching disk task to finish`%s`: %s", existingDiskHref, err)
 		}
@@ -2534,6 +2602,19 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 		return diag.Errorf("error deleting: %s", err)
 	}
 	log.Printf("[DEBUG] [VM delete] finished")
+	return cloudInitDeleteDiagnostics(ctx, d, vcdClient, vmUuid)
+}
+
+// cloudInitDeleteDiagnostics removes the cloud-init seed media of a deleted VM. Failures are reported as
+// warnings, as the VM is already gone
+func cloudInitDeleteDiagnostics(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vmUuid string) diag.Diagnostics {
+	err := deleteCloudInitSeeds(ctx, d, vcdClient, vmUuid)
+	if err != nil {
+		return diag.Diagnostics{{
+			Severity: diag.Warning,
+			Summary:  fmt.Sprintf("the cloud-init seed media of the VM could not be removed: %s", err),
+		}}
+	}
 	return nil
 }
 
@@ -2552,6 +2633,8 @@ func resourceVcdVAppVmDelete(_ context.Context, d *schema.ResourceData, meta int
 // Example import path for standalone VM (_the_id_string_): org-name.vdc-name.vm-name
 // or
 // Example import path for standalone VM (_the_id_string_): org-name.vdc-name.vm-ID
+// or
+// Example import path for any VM (_the_id_string_): VM URN, HREF or UUID
 //
 // The VM identifier can be either the VM name or its ID
 // If we are dealing with standalone VMs, the name can retrieve duplicates. When that happens, the import fails
@@ -2568,15 +2651,31 @@ func resourceVcdVappVmImport(_ context.Context, d *schema.ResourceData, meta int
 	var vmIdentifier string
 	standaloneVm := false
 
-	// With three arguments, we expect a standalone VM
-	if len(resourceURI) == 3 {
-		// standalone VM
+	vmId := importEntityId("urn:vcloud:vm:", d.Id())
+	switch {
+	case vmId != "":
+		// With an ID, the location of the VM is taken from its query record
+		record, err := queryImportRecord(vcdClient, types.QtVm, "/vApp/vm-", vmId)
+		if err != nil {
+			return nil, fmt.Errorf("[VM import] %s", err)
+		}
+		orgName, vdcName, err = importVdcNames(vcdClient, record["vdc"])
+		if err != nil {
+			return nil, fmt.Errorf("[VM import] error retrieving VDC of VM %s: %s", vmId, err)
+		}
+		standaloneVm = record["isAutoNature"] == "true"
+		if !standaloneVm {
+			vappName = record["containerName"]
+		}
+		vmIdentifier = vmId
+	case len(resourceURI) == 3:
+		// With three arguments, we expect a standalone VM
 		orgName, vdcName, vmIdentifier = resourceURI[0], resourceURI[1], resourceURI[2]
 		standaloneVm = true
-	} else {
+	default:
 		// With 4 arguments, it's a VM within a vApp
 		if len(resourceURI) != 4 {
-			return nil, fmt.Errorf("[VM import] resource name must be specified as org-name.vdc-name.vapp-name.vm-name-or-ID")
+			return nil, fmt.Errorf("[VM import] resource name must be specified as org-name.vdc-name.vapp-name.vm-name-or-ID or as VM ID")
 		}
 		orgName, vdcName, vappName, vmIdentifier = resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
 	}
@@ -2612,7 +2711,7 @@ func resourceVcdVappVmImport(_ context.Context, d *schema.ResourceData, meta int
 		}
 	}
 
-	dSet(d, "name", vmIdentifier)
+	dSet(d, "name", vm.VM.Name)
 	dSet(d, "org", orgName)
 	dSet(d, "vdc", vdcName)
 	dSet(d, "vapp_name", vappName)
@@ -0,0 +1,478 @@
+package vcloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+const (
+	cloudInitDeliveryGuestinfo = "guestinfo"
+	cloudInitDeliverySeedIso   = "seed_iso"
+	// cloudInitSeedPrefix starts the name of the seed media items, followed by the VM UUID and a checksum
+	cloudInitSeedPrefix = "cidata-"
+)
+
+// cloudInitGuestinfoKeys are the extra configuration keys read by the VMware datasource of cloud-init
+var cloudInitGuestinfoKeys = []string{
+	"guestinfo.userdata", "guestinfo.userdata.encoding", "guestinfo.metadata", "guestinfo.metadata.encoding",
+}
+
+// vmCloudInitSchema defines the 'cloud_init' block of VM resources
+func vmCloudInitSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Cloud-init data delivered to the guest OS. Changes power off the VM and run cloud-init again at next boot",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"user_data": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Sensitive:   true,
+					Description: "Cloud-init user data, such as a '#cloud-config' document or a script",
+				},
+				"meta_data": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Cloud-init meta data. When not set, it contains the instance ID and the computer name. " +
+						"The instance ID is added when missing",
+				},
+				"network_config": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "Cloud-init network configuration. It is a Go template that can use the NICs of the VM, " +
+						"such as '{{ range .Nics }}{{ .Mac }}{{ end }}'",
+				},
+				"delivery": {
+					Type:         schema.TypeString,
+					Optional:     true,
+					Default:      cloudInitDeliveryGuestinfo,
+					ValidateFunc: validation.StringInSlice([]string{cloudInitDeliveryGuestinfo, cloudInitDeliverySeedIso}, false),
+					Description:  "How the data is delivered: 'guestinfo' (VM extra configuration) or 'seed_iso' (NoCloud ISO inserted in the VM)",
+				},
+				"seed_catalog_id": {
+					Type:     schema.TypeString,
+					Optional: true,
+					Description: "ID of a catalog of the VM Org, where the seed ISO is uploaded. Required when 'delivery' " +
+						"is 'seed_iso'",
+				},
+				"eject_force": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     true,
+					Description: "When ejecting the previous seed ISO, answers automatically yes to the question of the guest OS",
+				},
+			},
+		},
+	}
+}
+
+// cloudInitConfig holds the settings of the 'cloud_init' block
+type cloudInitConfig struct {
+	userData      string
+	metaData      string
+	networkConfig string
+	delivery      string
+	seedCatalogId string
+	ejectForce    bool
+}
+
+// getCloudInitConfig converts a value of the 'cloud_init' block. It returns nil when the block is not set
+func getCloudInitConfig(raw interface{}) *cloudInitConfig {
+	blocks, ok := raw.([]interface{})
+	if !ok || len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	return &cloudInitConfig{
+		userData:      block["user_data"].(string),
+		metaData:      block["meta_data"].(string),
+		networkConfig: block["network_config"].(string),
+		delivery:      block["delivery"].(string),
+		seedCatalogId: block["seed_catalog_id"].(string),
+		ejectForce:    block["eject_force"].(bool),
+	}
+}
+
+// cloudInitHasChange returns true when the 'cloud_init' block changes in a way that requires delivering the data
+// again. A change of 'eject_force' alone is only recorded in the state
+func cloudInitHasChange(d changeGetter) bool {
+	oldRaw, newRaw := d.GetChange("cloud_init")
+	oldConfig, newConfig := getCloudInitConfig(oldRaw), getCloudInitConfig(newRaw)
+	if oldConfig == nil || newConfig == nil {
+		return (oldConfig == nil) != (newConfig == nil)
+	}
+	oldConfig.ejectForce = newConfig.ejectForce
+	return *oldConfig != *newConfig
+}
+
+// cloudInitNic describes a NIC of the VM in the 'network_config' template
+type cloudInitNic struct {
+	Index          int
+	Network        string
+	Mac            string
+	Ip             string
+	AllocationMode string
+	Primary        bool
+	Connected      bool
+	// Gateway, PrefixLength and Dns come from the Org VDC network, when the NIC is connected to one
+	Gateway      string
+	PrefixLength int
+	Dns          []string
+	DnsSuffix    string
+}
+
+// cloudInitTemplateData is the data available in the 'network_config' template
+type cloudInitTemplateData struct {
+	Hostname string
+	Nics     []cloudInitNic
+}
+
+// cloudInitData is the rendered content delivered to the guest
+type cloudInitData struct {
+	instanceId    string
+	userData      string
+	metaData      string
+	networkConfig string
+}
+
+// renderCloudInit builds the data delivered to the guest. The instance ID changes with the content, so that
+// cloud-init runs again after each change
+func renderCloudInit(config *cloudInitConfig, vmUuid string, templateData cloudInitTemplateData) (*cloudInitData, error) {
+	data := &cloudInitData{userData: config.userData}
+	if config.networkConfig != "" {
+		networkTemplate, err := template.New("network_config").Option("missingkey=error").Parse(config.networkConfig)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing 'network_config' template: %s", err)
+		}
+		rendered := bytes.Buffer{}
+		err = networkTemplate.Execute(&rendered, templateData)
+		if err != nil {
+			return nil, fmt.Errorf("error rendering 'network_config' template: %s", err)
+		}
+		data.networkConfig = rendered.String()
+	}
+
+	checksum := sha256.Sum256([]byte(config.userData + "\x00" + config.metaData + "\x00" + data.networkConfig))
+	data.instanceId = fmt.Sprintf("%s-%x", vmUuid, checksum[:4])
+
+	data.metaData = config.metaData
+	if data.metaData == "" {
+		data.metaData = fmt.Sprintf("local-hostname: %s\n", templateData.Hostname)
+	}
+	if !cloudInitHasKey(data.metaData, "instance-id") {
+		data.metaData = fmt.Sprintf("instance-id: %s\n", data.instanceId) + data.metaData
+	}
+	return data, nil
+}
+
+// cloudInitHasKey returns true when a top-level key is defined in a YAML document
+func cloudInitHasKey(document, key string) bool {
+	for _, line := range strings.Split(document, "\n") {
+		if strings.HasPrefix(line, key+":") {
+			return true
+		}
+	}
+	return false
+}
+
+// guestinfoExtraConfig returns the extra configuration items read by the VMware datasource of cloud-init. The
+// network configuration is part of the meta data
+func (data *cloudInitData) guestinfoExtraConfig() []*types.ExtraConfigMarshal {
+	metaData := data.metaData
+	if data.networkConfig != "" {
+		if !strings.HasSuffix(metaData, "\n") {
+			metaData += "\n"
+		}
+		metaData += fmt.Sprintf("network: %s\nnetwork.encoding: base64\n", base64.StdEncoding.EncodeToString([]byte(data.networkConfig)))
+	}
+	return []*types.ExtraConfigMarshal{
+		{Key: "guestinfo.metadata", Value: base64.StdEncoding.EncodeToString([]byte(metaData))},
+		{Key: "guestinfo.metadata.encoding", Value: "base64"},
+		{Key: "guestinfo.userdata", Value: base64.StdEncoding.EncodeToString([]byte(data.userData))},
+		{Key: "guestinfo.userdata.encoding", Value: "base64"},
+	}
+}
+
+// seedIso returns the NoCloud seed image, with volume label 'cidata'
+func (data *cloudInitData) seedIso() ([]byte, error) {
+	files := map[string][]byte{
+		"meta-data": []byte(data.metaData),
+		"user-data": []byte(data.userData),
+	}
+	if data.networkConfig != "" {
+		files["network-config"] = []byte(data.networkConfig)
+	}
+	return buildSeedIso("cidata", files, time.Now())
+}
+
+// applyCloudInit delivers the data of the 'cloud_init' block to a powered off VM, removing what was delivered
+// before. Such schema fields are processed:
+// * cloud_init
+func applyCloudInit(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	oldRaw, newRaw := d.GetChange("cloud_init")
+	oldConfig := getCloudInitConfig(oldRaw)
+	newConfig := getCloudInitConfig(newRaw)
+	if oldConfig == nil && newConfig == nil {
+		return nil
+	}
+	vmUuid := extractUuid(vm.VM.ID)
+
+	if oldConfig != nil && (newConfig == nil || oldConfig.delivery != newConfig.delivery) {
+		err := removeCloudInit(ctx, d, vcdClient, vm, oldConfig, vmUuid)
+		if err != nil {
+			return err
+		}
+	}
+	if newConfig == nil {
+		return nil
+	}
+
+	err := vm.Refresh()
+	if err != nil {
+		return fmt.Errorf("error refreshing VM: %s", err)
+	}
+	templateData := cloudInitVmTemplateData(d, vcdClient, vm)
+	data, err := renderCloudInit(newConfig, vmUuid, templateData)
+	if err != nil {
+		return err
+	}
+
+	switch newConfig.delivery {
+	case cloudInitDeliverySeedIso:
+		err = deliverCloudInitSeedIso(ctx, d, vcdClient, vm, newConfig, data, vmUuid)
+	default:
+		log.Printf("[DEBUG] [VM cloud-init] setting guestinfo of VM %s (instance %s)", vm.VM.Name, data.instanceId)
+		_, err = vm.UpdateExtraConfig(data.guestinfoExtraConfig())
+	}
+	if err != nil {
+		return fmt.Errorf("error delivering cloud-init data with '%s': %s", newConfig.delivery, err)
+	}
+	return vm.Refresh()
+}
+
+// cloudInitVmTemplateData collects the NICs of the VM for the 'network_config' template. The subnet settings
+// are looked up in the Org VDC networks, when possible
+func cloudInitVmTemplateData(d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) cloudInitTemplateData {
+	data := cloudInitTemplateData{Hostname: d.Get("computer_name").(string)}
+	if data.Hostname == "" {
+		data.Hostname = vm.VM.Name
+	}
+	section := vm.VM.NetworkConnectionSection
+	if section == nil {
+		return data
+	}
+
+	_, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
+	if err != nil {
+		log.Printf("[DEBUG] [VM cloud-init] unable to retrieve VDC for network lookup: %s", err)
+	}
+	for _, connection := range section.NetworkConnection {
+		if connection == nil {
+			continue
+		}
+		nic := cloudInitNic{
+			Index:          connection.NetworkConnectionIndex,
+			Network:        connection.Network,
+			Mac:            connection.MACAddress,
+			Ip:             connection.IPAddress,
+			AllocationMode: connection.IPAddressAllocationMode,
+			Primary:        connection.NetworkConnectionIndex == section.PrimaryNetworkConnectionIndex,
+			Connected:      connection.IsConnected,
+		}
+		if vdc != nil && connection.Network != "" && connection.Network != "none" {
+			network, err := vdc.GetOpenApiOrgVdcNetworkByName(connection.Network)
+			if err == nil && len(network.OpenApiOrgVdcNetwork.Subnets.Values) > 0 {
+				subnet := network.OpenApiOrgVdcNetwork.Subnets.Values[0]
+				nic.Gateway = subnet.Gateway
+				nic.PrefixLength = subnet.PrefixLength
+				nic.DnsSuffix = subnet.DNSSuffix
+				for _, dns := range []string{subnet.DNSServer1, subnet.DNSServer2} {
+					if dns != "" {
+						nic.Dns = append(nic.Dns, dns)
+					}
+				}
+			} else if err != nil {
+				log.Printf("[DEBUG] [VM cloud-init] network %s is not an Org VDC network: %s", connection.Network, err)
+			}
+		}
+		data.Nics = append(data.Nics, nic)
+	}
+	return data
+}
+
+// deliverCloudInitSeedIso uploads the seed ISO to the catalog, if it is not there yet, and inserts it in the VM
+// in place of the previous one. The media is ejected and inserted with the functions of vcloud_inserted_media
+func deliverCloudInitSeedIso(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM, config *cloudInitConfig, data *cloudInitData, vmUuid string) error {
+	if config.seedCatalogId == "" {
+		return fmt.Errorf("'seed_catalog_id' is required when 'delivery' is '%s'", cloudInitDeliverySeedIso)
+	}
+	catalog, err := vcdClient.Client.GetCatalogById(config.seedCatalogId)
+	if err != nil {
+		return fmt.Errorf("error retrieving seed catalog %s: %s", config.seedCatalogId, err)
+	}
+	mediaName := cloudInitSeedPrefix + data.instanceId
+
+	_, err = catalog.GetMediaByName(mediaName, false)
+	if govcd.ContainsNotFound(err) {
+		_, err = uploadCloudInitSeedIso(ctx, catalog, mediaName, data)
+	}
+	if err != nil {
+		return fmt.Errorf("error retrieving seed media %s: %s", mediaName, err)
+	}
+
+	seedMedia, err := cloudInitSeedMedia(d, vm, catalog, config)
+	if err != nil {
+		return err
+	}
+	err = removeCloudInitSeeds(ctx, catalog, vmUuid, mediaName, seedMedia, vcdClient)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] [VM cloud-init] inserting seed media %s in VM %s", mediaName, vm.VM.Name)
+	err = insertVmMedia(seedMedia(mediaName), vcdClient)
+	if err != nil {
+		return fmt.Errorf("error inserting seed media %s: %s", mediaName, err)
+	}
+	return nil
+}
+
+// cloudInitSeedMedia returns a function that describes a seed media of the VM with the schema of
+// vcloud_inserted_media, so that it can be inserted and ejected in the same way
+func cloudInitSeedMedia(d *schema.ResourceData, vm *govcd.VM, catalog *govcd.Catalog, config *cloudInitConfig) (func(mediaName string) *schema.ResourceData, error) {
+	vapp, err := vm.GetParentVApp()
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving vApp of VM %s: %s", vm.VM.Name, err)
+	}
+	return func(mediaName string) *schema.ResourceData {
+		media := resourceVcdInsertedMedia().Data(nil)
+		dSet(media, "org", d.Get("org").(string))
+		dSet(media, "vdc", d.Get("vdc").(string))
+		dSet(media, "vapp_name", vapp.VApp.Name)
+		dSet(media, "vm_name", vm.VM.Name)
+		dSet(media, "catalog", catalog.Catalog.Name)
+		dSet(media, "name", mediaName)
+		dSet(media, "eject_force", config.ejectForce)
+		return media
+	}, nil
+}
+
+func uploadCloudInitSeedIso(ctx context.Context, catalog *govcd.Catalog, mediaName string, data *cloudInitData) (*govcd.Media, error) {
+	image, err := data.seedIso()
+	if err != nil {
+		return nil, err
+	}
+	file, err := os.CreateTemp("", mediaName+"-*.iso")
+	if err != nil {
+		return nil, fmt.Errorf("error creating temporary seed ISO: %s", err)
+	}
+	defer func() {
+		_ = os.Remove(file.Name())
+	}()
+	_, err = file.Write(image)
+	safeClose(file)
+	if err != nil {
+		return nil, fmt.Errorf("error writing temporary seed ISO: %s", err)
+	}
+
+	log.Printf("[DEBUG] [VM cloud-init] uploading seed media %s to catalog %s", mediaName, catalog.Catalog.Name)
+	uploadTask, err := catalog.UploadMediaImage(mediaName, "cloud-init seed created by Terraform", file.Name(), 1024*1024)
+	if err != nil {
+		return nil, fmt.Errorf("error uploading seed media %s: %s", mediaName, err)
+	}
+	err = waitForTask(ctx, *uploadTask.Task)
+	if err != nil {
+		return nil, fmt.Errorf("error waiting for the upload of seed media %s: %s", mediaName, err)
+	}
+	return catalog.GetMediaByName(mediaName, true)
+}
+
+// removeCloudInit removes the data delivered to the VM with the given configuration
+func removeCloudInit(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM, config *cloudInitConfig, vmUuid string) error {
+	if config.delivery != cloudInitDeliverySeedIso {
+		var items []*types.ExtraConfigMarshal
+		for _, key := range cloudInitGuestinfoKeys {
+			items = append(items, &types.ExtraConfigMarshal{Key: key})
+		}
+		_, err := vm.DeleteExtraConfig(items)
+		if err != nil {
+			return fmt.Errorf("error removing cloud-init guestinfo: %s", err)
+		}
+		return nil
+	}
+	if config.seedCatalogId == "" {
+		return nil
+	}
+	catalog, err := vcdClient.Client.GetCatalogById(config.seedCatalogId)
+	if err != nil {
+		return fmt.Errorf("error retrieving seed catalog %s: %s", config.seedCatalogId, err)
+	}
+	seedMedia, err := cloudInitSeedMedia(d, vm, catalog, config)
+	if err != nil {
+		return err
+	}
+	return removeCloudInitSeeds(ctx, catalog, vmUuid, "", seedMedia, vcdClient)
+}
+
+// removeCloudInitSeeds ejects from the VM and deletes the seed media items created for it, except keepMedia.
+// seedMedia is nil when the VM was already deleted
+func removeCloudInitSeeds(ctx context.Context, catalog *govcd.Catalog, vmUuid, keepMedia string, seedMedia func(string) *schema.ResourceData, vcdClient *VCDClient) error {
+	mediaList, err := catalog.QueryMediaList()
+	if err != nil {
+		return fmt.Errorf("error listing media of catalog %s: %s", catalog.Catalog.Name, err)
+	}
+	for _, record := range mediaList {
+		if !strings.HasPrefix(record.Name, cloudInitSeedPrefix+vmUuid+"-") || record.Name == keepMedia {
+			continue
+		}
+		media, err := catalog.GetMediaByName(record.Name, false)
+		if err != nil {
+			return fmt.Errorf("error retrieving seed media %s: %s", record.Name, err)
+		}
+		if seedMedia != nil {
+			err = ejectVmMedia(seedMedia(record.Name), vcdClient)
+			if err != nil {
+				// The media may not be inserted in the VM
+				log.Printf("[DEBUG] [VM cloud-init] unable to eject seed media %s: %s", record.Name, err)
+			}
+		}
+		log.Printf("[DEBUG] [VM cloud-init] deleting seed media %s", record.Name)
+		task, err := media.Delete()
+		if err != nil {
+			return fmt.Errorf("error deleting seed media %s: %s", record.Name, err)
+		}
+		err = waitForTask(ctx, task)
+		if err != nil {
+			return fmt.Errorf("error deleting seed media %s: %s", record.Name, err)
+		}
+	}
+	return nil
+}
+
+// deleteCloudInitSeeds deletes the seed media items of a VM that was removed
+func deleteCloudInitSeeds(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vmUuid string) error {
+	config := getCloudInitConfig(d.Get("cloud_init"))
+	if config == nil || config.delivery != cloudInitDeliverySeedIso || config.seedCatalogId == "" {
+		return nil
+	}
+	catalog, err := vcdClient.Client.GetCatalogById(config.seedCatalogId)
+	if err != nil {
+		return fmt.Errorf("error retrieving seed catalog %s: %s", config.seedCatalogId, err)
+	}
+	return removeCloudInitSeeds(ctx, catalog, vmUuid, "", nil, vcdClient)
+}
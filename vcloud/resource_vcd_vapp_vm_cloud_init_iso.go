@@ -0,0 +1,312 @@
+package vcloud
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf16"
+)
+
+const (
+	// isoSectorSize is the size of the logical blocks of an ISO 9660 image
+	isoSectorSize = 2048
+	// isoPaddingSectors are added at the end of the image, as genisoimage does, because some readers (including
+	// the read-ahead of Linux) fail on images that end right after the last file
+	isoPaddingSectors = 150
+	// isoDCharacters are the characters allowed in the file identifiers of ISO 9660 level 1
+	isoDCharacters = "ABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789_"
+
+	isoRockRidgeId          = "RRIP_1991A"
+	isoRockRidgeDescription = "THE ROCK RIDGE INTERCHANGE PROTOCOL PROVIDES SUPPORT FOR POSIX FILE SYSTEM SEMANTICS"
+	isoRockRidgeSource      = "PLEASE CONTACT DISC PUBLISHER FOR SPECIFICATION SOURCE.  SEE PUBLISHER IDENTIFIER IN " +
+		"PRIMARY VOLUME DESCRIPTOR FOR CONTACT INFORMATION."
+)
+
+// isoFile is a file in the root directory of an ISO 9660 image
+type isoFile struct {
+	// name is the original name, recorded with the Rock Ridge and Joliet extensions
+	name string
+	// level1Name is the 8.3 name of the primary volume
+	level1Name string
+	contents   []byte
+	extent     uint32
+}
+
+// buildSeedIso creates an ISO 9660 image with the given files in its root directory, and the given volume label.
+// It is the format of the NoCloud seed used by cloud-init (label 'cidata'). The names of the files, such as
+// 'meta-data', are not valid in plain ISO 9660, which only allows 8.3 upper case names: they are recorded with
+// the Rock Ridge extension, read by Linux and other Unix systems, and in a Joliet volume, read by Windows (and
+// cloudbase-init). The primary volume contains the 8.3 names.
+//
+// Layout: system area (sectors 0-15), primary volume descriptor (16), Joliet volume descriptor (17), terminator
+// (18), path tables of the primary volume (19, 20) and of the Joliet volume (21, 22), root directory of the primary
+// volume (23) and of the Joliet volume (24), Rock Ridge continuation area (25), file contents (26 onwards), padding.
+// The continuation area follows the directory, as some readers (such as libarchive) only read forward
+func buildSeedIso(label string, files map[string][]byte, created time.Time) ([]byte, error) {
+	if len(label) > 16 {
+		return nil, fmt.Errorf("ISO volume label '%s' is longer than 16 characters", label)
+	}
+	var sortedFiles []*isoFile
+	for name, contents := range files {
+		sortedFiles = append(sortedFiles, &isoFile{name: name, contents: contents})
+	}
+	sort.Slice(sortedFiles, func(i, j int) bool { return sortedFiles[i].name < sortedFiles[j].name })
+	usedNames := make(map[string]bool)
+	for _, file := range sortedFiles {
+		file.level1Name = isoLevel1Name(file.name, usedNames)
+	}
+
+	const (
+		rootExtent         = 23
+		jolietRootExtent   = 24
+		continuationExtent = 25
+	)
+	nextExtent := uint32(continuationExtent + 1)
+	for _, file := range sortedFiles {
+		file.extent = nextExtent
+		nextExtent += isoSectors(len(file.contents))
+	}
+	totalSectors := nextExtent + isoPaddingSectors
+
+	// The Rock Ridge extension is announced by the SP and ER entries of the first record of the root directory.
+	// ER doesn't fit in the record, and is written in a continuation area
+	extensionRecord := isoRockRidgeExtensionRecord()
+	root := bytes.Buffer{}
+	root.Write(isoDirectoryRecord("\x00", rootExtent, isoSectorSize, true, created, isoSystemUse(
+		[]byte{'S', 'P', 7, 1, 0xBE, 0xEF, 0},
+		isoRockRidgePosixAttributes(true),
+		isoContinuationEntry(continuationExtent, uint32(len(extensionRecord))),
+	)))
+	root.Write(isoDirectoryRecord("\x01", rootExtent, isoSectorSize, true, created, isoRockRidgePosixAttributes(true)))
+	jolietRoot := bytes.Buffer{}
+	jolietRoot.Write(isoDirectoryRecord("\x00", jolietRootExtent, isoSectorSize, true, created, nil))
+	jolietRoot.Write(isoDirectoryRecord("\x01", jolietRootExtent, isoSectorSize, true, created, nil))
+
+	// The records of a directory are sorted by identifier
+	sort.Slice(sortedFiles, func(i, j int) bool { return sortedFiles[i].level1Name < sortedFiles[j].level1Name })
+	for _, file := range sortedFiles {
+		root.Write(isoDirectoryRecord(file.level1Name, file.extent, uint32(len(file.contents)), false, created, isoSystemUse(
+			isoRockRidgePosixAttributes(false),
+			isoRockRidgeName(file.name),
+		)))
+	}
+	sort.Slice(sortedFiles, func(i, j int) bool { return sortedFiles[i].name < sortedFiles[j].name })
+	for _, file := range sortedFiles {
+		jolietRoot.Write(isoDirectoryRecord(isoUcs2(file.name+";1"), file.extent, uint32(len(file.contents)), false, created, nil))
+	}
+	if root.Len() > isoSectorSize || jolietRoot.Len() > isoSectorSize {
+		return nil, fmt.Errorf("too many files for an ISO seed image")
+	}
+
+	image := make([]byte, int(totalSectors)*isoSectorSize)
+	copy(image[16*isoSectorSize:], isoVolumeDescriptor(false, label, totalSectors, rootExtent, 19, created))
+	copy(image[17*isoSectorSize:], isoVolumeDescriptor(true, label, totalSectors, jolietRootExtent, 21, created))
+	terminator := image[18*isoSectorSize:]
+	terminator[0] = 255
+	copy(terminator[1:6], "CD001")
+	terminator[6] = 1
+	copy(image[19*isoSectorSize:], isoPathTable(rootExtent, binary.LittleEndian))
+	copy(image[20*isoSectorSize:], isoPathTable(rootExtent, binary.BigEndian))
+	copy(image[21*isoSectorSize:], isoPathTable(jolietRootExtent, binary.LittleEndian))
+	copy(image[22*isoSectorSize:], isoPathTable(jolietRootExtent, binary.BigEndian))
+	copy(image[continuationExtent*isoSectorSize:], extensionRecord)
+	copy(image[rootExtent*isoSectorSize:], root.Bytes())
+	copy(image[jolietRootExtent*isoSectorSize:], jolietRoot.Bytes())
+	for _, file := range sortedFiles {
+		copy(image[int(file.extent)*isoSectorSize:], file.contents)
+	}
+	return image, nil
+}
+
+// isoLevel1Name converts a file name into an ISO 9660 level 1 file identifier: up to 8 d-characters, a dot, up to
+// 3 d-characters of extension, and the version. Names that become equal to a previous one get a numeric suffix
+func isoLevel1Name(name string, used map[string]bool) string {
+	base, extension := name, ""
+	if dot := strings.LastIndex(name, "."); dot > 0 {
+		base, extension = name[:dot], name[dot+1:]
+	}
+	base = isoDString(base, 8)
+	extension = isoDString(extension, 3)
+	identifier := base + "." + extension + ";1"
+	for n := 1; used[identifier]; n++ {
+		suffix := fmt.Sprintf("%d", n)
+		if len(base)+len(suffix) > 8 {
+			base = base[:8-len(suffix)]
+		}
+		identifier = base + suffix + "." + extension + ";1"
+	}
+	used[identifier] = true
+	return identifier
+}
+
+// isoDString converts a text to upper case d-characters, replacing the other characters with '_'
+func isoDString(text string, maxLength int) string {
+	result := strings.Builder{}
+	for _, r := range strings.ToUpper(text) {
+		if result.Len() == maxLength {
+			break
+		}
+		if strings.ContainsRune(isoDCharacters, r) {
+			result.WriteRune(r)
+		} else {
+			result.WriteByte('_')
+		}
+	}
+	return result.String()
+}
+
+// isoUcs2 encodes a text in big-endian UCS-2, as required by Joliet
+func isoUcs2(text string) string {
+	result := bytes.Buffer{}
+	for _, unit := range utf16.Encode([]rune(text)) {
+		result.WriteByte(byte(unit >> 8))
+		result.WriteByte(byte(unit))
+	}
+	return result.String()
+}
+
+func isoSectors(size int) uint32 {
+	sectors := (size + isoSectorSize - 1) / isoSectorSize
+	if sectors == 0 {
+		sectors = 1
+	}
+	return uint32(sectors)
+}
+
+// isoBothEndian32 encodes a number in the "both-byte orders" format of ISO 9660 (little-endian, then big-endian)
+func isoBothEndian32(value uint32) []byte {
+	result := make([]byte, 8)
+	binary.LittleEndian.PutUint32(result[0:4], value)
+	binary.BigEndian.PutUint32(result[4:8], value)
+	return result
+}
+
+func isoBothEndian16(value uint16) []byte {
+	result := make([]byte, 4)
+	binary.LittleEndian.PutUint16(result[0:2], value)
+	binary.BigEndian.PutUint16(result[2:4], value)
+	return result
+}
+
+// isoDirectoryRecord encodes the entry of a file or directory, followed by its System Use entries. The names
+// "\x00" and "\x01" are the current and parent directory
+func isoDirectoryRecord(name string, extent, size uint32, directory bool, recorded time.Time, systemUse []byte) []byte {
+	nameLength := 33 + len(name)
+	if len(name)%2 == 0 {
+		nameLength++
+	}
+	length := nameLength + len(systemUse)
+	if length%2 == 1 {
+		length++
+	}
+	record := make([]byte, length)
+	record[0] = byte(length)
+	copy(record[2:10], isoBothEndian32(extent))
+	copy(record[10:18], isoBothEndian32(size))
+	recorded = recorded.UTC()
+	copy(record[18:25], []byte{byte(recorded.Year() - 1900), byte(recorded.Month()), byte(recorded.Day()),
+		byte(recorded.Hour()), byte(recorded.Minute()), byte(recorded.Second()), 0})
+	if directory {
+		record[25] = 2
+	}
+	copy(record[28:32], isoBothEndian16(1))
+	record[32] = byte(len(name))
+	copy(record[33:], name)
+	copy(record[nameLength:], systemUse)
+	return record
+}
+
+func isoSystemUse(entries ...[]byte) []byte {
+	return bytes.Join(entries, nil)
+}
+
+// isoRockRidgePosixAttributes encodes the PX entry, with read-only permissions for everybody
+func isoRockRidgePosixAttributes(directory bool) []byte {
+	mode, links := uint32(0100444), uint32(1)
+	if directory {
+		mode, links = 040555, 2
+	}
+	entry := []byte{'P', 'X', 36, 1}
+	entry = append(entry, isoBothEndian32(mode)...)
+	entry = append(entry, isoBothEndian32(links)...)
+	entry = append(entry, isoBothEndian32(0)...)
+	return append(entry, isoBothEndian32(0)...)
+}
+
+// isoRockRidgeName encodes the NM entry, which holds the original name of a file
+func isoRockRidgeName(name string) []byte {
+	return append([]byte{'N', 'M', byte(5 + len(name)), 1, 0}, name...)
+}
+
+// isoContinuationEntry encodes the CE entry, which points to the System Use entries that continue in another sector
+func isoContinuationEntry(extent, length uint32) []byte {
+	entry := []byte{'C', 'E', 28, 1}
+	entry = append(entry, isoBothEndian32(extent)...)
+	entry = append(entry, isoBothEndian32(0)...)
+	return append(entry, isoBothEndian32(length)...)
+}
+
+// isoRockRidgeExtensionRecord encodes the ER entry, which identifies the Rock Ridge extension
+func isoRockRidgeExtensionRecord() []byte {
+	entry := []byte{'E', 'R', byte(8 + len(isoRockRidgeId) + len(isoRockRidgeDescription) + len(isoRockRidgeSource)), 1,
+		byte(len(isoRockRidgeId)), byte(len(isoRockRidgeDescription)), byte(len(isoRockRidgeSource)), 1}
+	entry = append(entry, isoRockRidgeId...)
+	entry = append(entry, isoRockRidgeDescription...)
+	return append(entry, isoRockRidgeSource...)
+}
+
+// isoPathTable encodes the path table, which contains only the root directory
+func isoPathTable(rootExtent uint32, order binary.ByteOrder) []byte {
+	table := make([]byte, 10)
+	table[0] = 1
+	order.PutUint32(table[2:6], rootExtent)
+	order.PutUint16(table[6:8], 1)
+	return table
+}
+
+// isoVolumeDescriptor encodes the primary volume descriptor or, when joliet is true, the supplementary volume
+// descriptor of the Joliet volume, whose identifiers are in UCS-2. The path tables are in the sectors starting
+// from pathTableExtent (little-endian, then big-endian)
+func isoVolumeDescriptor(joliet bool, label string, totalSectors, rootExtent, pathTableExtent uint32, created time.Time) []byte {
+	padded := func(text string, length int) string {
+		return isoPadded(text, length)
+	}
+	descriptor := make([]byte, isoSectorSize)
+	descriptor[0] = 1
+	if joliet {
+		descriptor[0] = 2
+		padded = func(text string, length int) string {
+			return isoUcs2(isoPadded(text, length/2))
+		}
+		// UCS-2 level 3
+		copy(descriptor[88:91], "%/E")
+	}
+	copy(descriptor[1:6], "CD001")
+	descriptor[6] = 1
+	copy(descriptor[8:40], padded("", 32))
+	copy(descriptor[40:72], padded(label, 32))
+	copy(descriptor[80:88], isoBothEndian32(totalSectors))
+	copy(descriptor[120:124], isoBothEndian16(1))
+	copy(descriptor[124:128], isoBothEndian16(1))
+	copy(descriptor[128:132], isoBothEndian16(isoSectorSize))
+	copy(descriptor[132:140], isoBothEndian32(10))
+	binary.LittleEndian.PutUint32(descriptor[140:144], pathTableExtent)
+	binary.BigEndian.PutUint32(descriptor[148:152], pathTableExtent+1)
+	copy(descriptor[156:190], isoDirectoryRecord("\x00", rootExtent, isoSectorSize, true, created, nil))
+	copy(descriptor[190:702], padded("", 512))
+	copy(descriptor[702:813], isoPadded("", 111))
+	date := created.UTC().Format("20060102150405") + "00"
+	copy(descriptor[813:830], date+"\x00")
+	copy(descriptor[830:847], date+"\x00")
+	copy(descriptor[847:864], "0000000000000000\x00")
+	copy(descriptor[864:881], date+"\x00")
+	descriptor[881] = 1
+	return descriptor
+}
+
+func isoPadded(text string, length int) string {
+	return text + strings.Repeat(" ", length-len(text))
+}
@@ -0,0 +1,282 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf16"
+)
+
+// isoSystemUseEntries splits the System Use area of a directory record into its entries, by signature
+func isoSystemUseEntries(t *testing.T, systemUse []byte) map[string][]byte {
+	entries := make(map[string][]byte)
+	for offset := 0; offset+4 <= len(systemUse) && systemUse[offset] != 0; offset += int(systemUse[offset+2]) {
+		length := int(systemUse[offset+2])
+		if length < 4 || offset+length > len(systemUse) {
+			t.Fatalf("invalid System Use entry at offset %d", offset)
+		}
+		entries[string(systemUse[offset:offset+2])] = systemUse[offset : offset+length]
+	}
+	return entries
+}
+
+// readRockRidgeFiles reads the label and the files in the root directory of the primary volume of an image created
+// by buildSeedIso, using the names of the Rock Ridge extension, as Linux does. It checks that the extension is
+// announced with the SP entry, and identified by the ER entry in the continuation area
+func readRockRidgeFiles(t *testing.T, image []byte) (string, map[string]string) {
+	descriptor := image[16*isoSectorSize:]
+	if string(descriptor[1:6]) != "CD001" || descriptor[0] != 1 {
+		t.Fatalf("primary volume descriptor not found")
+	}
+	label := strings.TrimRight(string(descriptor[40:72]), " ")
+	rootExtent := binary.LittleEndian.Uint32(descriptor[156+2 : 156+6])
+	directory := image[rootExtent*isoSectorSize : (rootExtent+1)*isoSectorSize]
+	files := make(map[string]string)
+	for offset := 0; offset < len(directory) && directory[offset] != 0; offset += int(directory[offset]) {
+		record := directory[offset : offset+int(directory[offset])]
+		identifier := record[33 : 33+int(record[32])]
+		systemUseOffset := 33 + len(identifier)
+		if len(identifier)%2 == 0 {
+			systemUseOffset++
+		}
+		entries := isoSystemUseEntries(t, record[systemUseOffset:])
+		if offset == 0 {
+			if sp, found := entries["SP"]; !found || sp[4] != 0xBE || sp[5] != 0xEF {
+				t.Fatalf("Rock Ridge SP entry not found in the root directory")
+			}
+			ce, found := entries["CE"]
+			if !found {
+				t.Fatalf("Rock Ridge CE entry not found in the root directory")
+			}
+			continuation := binary.LittleEndian.Uint32(ce[4:8])*isoSectorSize + binary.LittleEndian.Uint32(ce[12:16])
+			er := isoSystemUseEntries(t, image[continuation:continuation+binary.LittleEndian.Uint32(ce[20:24])])["ER"]
+			if er == nil || string(er[8:8+int(er[4])]) != isoRockRidgeId {
+				t.Fatalf("Rock Ridge ER entry not found in the continuation area")
+			}
+		}
+		if len(identifier) == 1 {
+			continue
+		}
+		nm, found := entries["NM"]
+		if !found {
+			t.Fatalf("Rock Ridge name not found for %s", identifier)
+		}
+		extent := binary.LittleEndian.Uint32(record[2:6])
+		size := binary.LittleEndian.Uint32(record[10:14])
+		files[string(nm[5:])] = string(image[extent*isoSectorSize : extent*isoSectorSize+size])
+	}
+	return label, files
+}
+
+// readJolietFiles reads the files in the root directory of the Joliet volume of an image created by buildSeedIso
+func readJolietFiles(t *testing.T, image []byte) map[string]string {
+	descriptor := image[17*isoSectorSize:]
+	if string(descriptor[1:6]) != "CD001" || descriptor[0] != 2 || string(descriptor[88:91]) != "%/E" {
+		t.Fatalf("Joliet volume descriptor not found")
+	}
+	rootExtent := binary.LittleEndian.Uint32(descriptor[156+2 : 156+6])
+	directory := image[rootExtent*isoSectorSize : (rootExtent+1)*isoSectorSize]
+	files := make(map[string]string)
+	for offset := 0; offset < len(directory) && directory[offset] != 0; offset += int(directory[offset]) {
+		record := directory[offset:]
+		identifier := record[33 : 33+int(record[32])]
+		if len(identifier) == 1 {
+			continue
+		}
+		var name []uint16
+		for i := 0; i+1 < len(identifier); i += 2 {
+			name = append(name, binary.BigEndian.Uint16(identifier[i:i+2]))
+		}
+		extent := binary.LittleEndian.Uint32(record[2:6])
+		size := binary.LittleEndian.Uint32(record[10:14])
+		if binary.BigEndian.Uint32(record[6:10]) != extent || binary.BigEndian.Uint32(record[14:18]) != size {
+			t.Errorf("both-endian fields of %s don't match", string(utf16.Decode(name)))
+		}
+		files[string(utf16.Decode(name))] = string(image[extent*isoSectorSize : extent*isoSectorSize+size])
+	}
+	return files
+}
+
+func Test_buildSeedIso(t *testing.T) {
+	userData := "#cloud-config\n" + strings.Repeat("# padding to use more than one sector\n", 100)
+	image, err := buildSeedIso("cidata", map[string][]byte{
+		"user-data":      []byte(userData),
+		"meta-data":      []byte("instance-id: i-1\n"),
+		"network-config": []byte("version: 2\n"),
+	}, time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(image)%isoSectorSize != 0 {
+		t.Errorf("image size %d is not a multiple of the sector size", len(image))
+	}
+	expected := map[string]string{
+		"user-data":      userData,
+		"meta-data":      "instance-id: i-1\n",
+		"network-config": "version: 2\n",
+	}
+
+	// The Rock Ridge names, as read by Linux
+	label, files := readRockRidgeFiles(t, image)
+	if label != "cidata" {
+		t.Errorf("expected label 'cidata', got '%s'", label)
+	}
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Rock Ridge files: got %v, want %v", files, expected)
+	}
+
+	// The Joliet names, as read by Windows
+	expected = map[string]string{
+		"user-data;1":      userData,
+		"meta-data;1":      "instance-id: i-1\n",
+		"network-config;1": "version: 2\n",
+	}
+	files = readJolietFiles(t, image)
+	if !reflect.DeepEqual(files, expected) {
+		t.Errorf("Joliet files: got %v, want %v", files, expected)
+	}
+
+	_, err = buildSeedIso(strings.Repeat("x", 33), nil, time.Now())
+	if err == nil {
+		t.Errorf("expected error for a long label")
+	}
+}
+
+func Test_isoLevel1Name(t *testing.T) {
+	used := make(map[string]bool)
+	for _, test := range []struct {
+		name     string
+		expected string
+	}{
+		{"meta-data", "META_DAT.;1"},
+		{"network-config", "NETWORK_.;1"},
+		{"network-configuration", "NETWORK1.;1"},
+		{"seed.yaml", "SEED.YAM;1"},
+	} {
+		got := isoLevel1Name(test.name, used)
+		if got != test.expected {
+			t.Errorf("%s: got %s, want %s", test.name, got, test.expected)
+		}
+	}
+}
+
+func Test_renderCloudInit(t *testing.T) {
+	templateData := cloudInitTemplateData{
+		Hostname: "web-01",
+		Nics: []cloudInitNic{
+			{Index: 0, Mac: "00:50:56:01:02:03", Ip: "10.0.0.10", AllocationMode: "POOL", Primary: true, Gateway: "10.0.0.1", PrefixLength: 24, Dns: []string{"8.8.8.8"}},
+			{Index: 1, Mac: "00:50:56:01:02:04", AllocationMode: "DHCP"},
+		},
+	}
+	config := &cloudInitConfig{
+		userData: "#cloud-config\npackages: [nginx]\n",
+		networkConfig: `version: 2
+ethernets:
+{{- range .Nics }}
+  nic{{ .Index }}:
+    match:
+      macaddress: "{{ .Mac }}"
+{{- if eq .AllocationMode "DHCP" }}
+    dhcp4: true
+{{- else }}
+    addresses: ["{{ .Ip }}/{{ .PrefixLength }}"]
+    gateway4: {{ .Gateway }}
+{{- end }}
+{{- end }}
+`,
+	}
+	data, err := renderCloudInit(config, "5c6bdc1b-e0de-4c43-a1fd-00d34c2b2bd6", templateData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	for _, expected := range []string{`macaddress: "00:50:56:01:02:03"`, `addresses: ["10.0.0.10/24"]`, "gateway4: 10.0.0.1", "nic1:", "dhcp4: true"} {
+		if !strings.Contains(data.networkConfig, expected) {
+			t.Errorf("network config doesn't contain '%s':\n%s", expected, data.networkConfig)
+		}
+	}
+	if !strings.HasPrefix(data.metaData, "instance-id: "+data.instanceId+"\n") || !strings.Contains(data.metaData, "local-hostname: web-01") {
+		t.Errorf("unexpected meta data:\n%s", data.metaData)
+	}
+
+	// A change of the content changes the instance ID, so that cloud-init runs again
+	config.userData += "runcmd: [reboot]\n"
+	changed, err := renderCloudInit(config, "5c6bdc1b-e0de-4c43-a1fd-00d34c2b2bd6", templateData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if changed.instanceId == data.instanceId {
+		t.Errorf("expected a new instance ID after a change of user data")
+	}
+
+	// The instance ID given in the meta data is kept
+	config.metaData = "instance-id: fixed\nlocal-hostname: other\n"
+	fixed, err := renderCloudInit(config, "5c6bdc1b-e0de-4c43-a1fd-00d34c2b2bd6", templateData)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if fixed.metaData != config.metaData {
+		t.Errorf("expected meta data to be unchanged, got:\n%s", fixed.metaData)
+	}
+
+	extraConfig := fixed.guestinfoExtraConfig()
+	if len(extraConfig) != len(cloudInitGuestinfoKeys) {
+		t.Fatalf("expected %d guestinfo keys, got %d", len(cloudInitGuestinfoKeys), len(extraConfig))
+	}
+	metaData, err := base64.StdEncoding.DecodeString(extraConfig[0].Value)
+	if err != nil {
+		t.Fatalf("guestinfo.metadata is not base64: %s", err)
+	}
+	if !strings.Contains(string(metaData), "network.encoding: base64") {
+		t.Errorf("guestinfo meta data doesn't contain the network configuration:\n%s", metaData)
+	}
+
+	config.networkConfig = "{{ .Missing }}"
+	_, err = renderCloudInit(config, "5c6bdc1b-e0de-4c43-a1fd-00d34c2b2bd6", templateData)
+	if err == nil {
+		t.Errorf("expected error for an invalid template field")
+	}
+}
+
+// cloudInitChange implements changeGetter with the old and new value of the 'cloud_init' block
+type cloudInitChange [2]interface{}
+
+func (c cloudInitChange) GetChange(string) (interface{}, interface{}) {
+	return c[0], c[1]
+}
+
+// Test_cloudInitHasChange checks which changes of the 'cloud_init' block deliver the data again
+func Test_cloudInitHasChange(t *testing.T) {
+	block := func(userData string, ejectForce bool) []interface{} {
+		return []interface{}{map[string]interface{}{
+			"user_data":       userData,
+			"meta_data":       "",
+			"network_config":  "",
+			"delivery":        cloudInitDeliverySeedIso,
+			"seed_catalog_id": "urn:vcloud:catalog:1",
+			"eject_force":     ejectForce,
+		}}
+	}
+	tests := []struct {
+		name   string
+		change cloudInitChange
+		want   bool
+	}{
+		{name: "no block", change: cloudInitChange{[]interface{}{}, []interface{}{}}, want: false},
+		{name: "block added", change: cloudInitChange{[]interface{}{}, block("#cloud-config", true)}, want: true},
+		{name: "block removed", change: cloudInitChange{block("#cloud-config", true), []interface{}{}}, want: true},
+		{name: "user data changed", change: cloudInitChange{block("#cloud-config", true), block("#!/bin/sh", true)}, want: true},
+		{name: "only eject_force changed", change: cloudInitChange{block("#cloud-config", true), block("#cloud-config", false)}, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cloudInitHasChange(tt.change); got != tt.want {
+				t.Errorf("cloudInitHasChange() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
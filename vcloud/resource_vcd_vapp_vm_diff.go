@@ -0,0 +1,221 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// vmPowerOffFields lists the attributes that can only be changed while the VM is powered off.
+// Besides these, `memory` and `cpus` need a power off when hot add is not enabled, `network`
+// needs it when the primary NIC is removed, and `cloud_init` when its data changes (see
+// cloudInitHasChange). (See resourceVcdVAppVmUpdateExecute)
+var vmPowerOffFields = []string{
+	"cpu_cores", "disk", "expose_hardware_virtualization", "boot_image", "hardware_version", "os_type",
+	"description", "cpu_hot_add_enabled", "memory_hot_add_enabled", "firmware", "boot_options.0.efi_secure_boot",
+}
+
+// vmCustomizeDiff validates, at plan time, the combinations of fields that would otherwise fail (or be
+// silently ignored) during creation or update of `vcloud_vapp_vm` and `vcloud_vm`.
+// It also computes `power_off_required_by`, so that the plan shows which changes will power off the VM
+func vmCustomizeDiff(_ context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	rawConfig := d.GetRawConfig()
+	if rawConfig.IsNull() || !rawConfig.IsKnown() {
+		return nil
+	}
+
+	err := validateVmNetworkManualIp(rawConfig)
+	if err != nil {
+		return err
+	}
+
+	err = validateVmOverrideTemplateDisks(rawConfig)
+	if err != nil {
+		return err
+	}
+
+	err = validateVmSizingPolicyCompute(d, rawConfig, meta)
+	if err != nil {
+		return err
+	}
+
+	err = setDefaultMetadataInDiff(d, meta, "vcd_vapp_vm", "metadata")
+	if err != nil {
+		return err
+	}
+
+	return setVmPowerOffRequiredBy(d)
+}
+
+// validateVmNetworkManualIp checks that every `network` block with `ip_allocation_mode = "MANUAL"` also defines an IP.
+// Blocks in which the IP is not known yet (e.g. coming from another resource) are checked during apply
+func validateVmNetworkManualIp(rawConfig cty.Value) error {
+	for index, network := range getRawConfigBlocks(rawConfig, "network") {
+		mode := network.GetAttr("ip_allocation_mode")
+		if !mode.IsKnown() || mode.IsNull() || mode.AsString() != "MANUAL" {
+			continue
+		}
+		ip := network.GetAttr("ip")
+		if !ip.IsKnown() {
+			continue
+		}
+		if ip.IsNull() || strings.TrimSpace(ip.AsString()) == "" {
+			return fmt.Errorf("network block #%d: 'ip' is required when 'ip_allocation_mode' is MANUAL", index+1)
+		}
+	}
+	return nil
+}
+
+// validateVmOverrideTemplateDisks checks that `override_template_disk` blocks refer to different disks.
+// Disks are matched by bus type, bus number and unit number
+func validateVmOverrideTemplateDisks(rawConfig cty.Value) error {
+	seen := make(map[string]bool)
+	for _, disk := range getRawConfigBlocks(rawConfig, "override_template_disk") {
+		busType := disk.GetAttr("bus_type")
+		busNumber := disk.GetAttr("bus_number")
+		unitNumber := disk.GetAttr("unit_number")
+		if !busType.IsKnown() || !busNumber.IsKnown() || !unitNumber.IsKnown() ||
+			busType.IsNull() || busNumber.IsNull() || unitNumber.IsNull() {
+			continue
+		}
+		key := fmt.Sprintf("%s:%s:%s", busType.AsString(), busNumber.AsBigFloat().String(), unitNumber.AsBigFloat().String())
+		if seen[key] {
+			return fmt.Errorf("more than one 'override_template_disk' block uses bus_type '%s', bus_number %s and unit_number %s",
+				busType.AsString(), busNumber.AsBigFloat().String(), unitNumber.AsBigFloat().String())
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+// validateVmSizingPolicyCompute checks that `cpus`, `cpu_cores` and `memory`, when set, do not contradict the values
+// defined in the sizing policy, as the policy ones would take precedence during creation and produce a permanent diff.
+// The policy is only retrieved when any of the involved fields has changed
+func validateVmSizingPolicyCompute(d *schema.ResourceDiff, rawConfig cty.Value, meta interface{}) error {
+	if !d.HasChanges("sizing_policy_id", "cpus", "cpu_cores", "memory") {
+		return nil
+	}
+	policyIdValue := rawConfig.GetAttr("sizing_policy_id")
+	if !policyIdValue.IsKnown() || policyIdValue.IsNull() || policyIdValue.AsString() == "" {
+		return nil
+	}
+	configured := make(map[string]int)
+	for _, field := range []string{"cpus", "cpu_cores", "memory"} {
+		value := rawConfig.GetAttr(field)
+		if value.IsKnown() && !value.IsNull() {
+			intValue, _ := value.AsBigFloat().Int64()
+			configured[field] = int(intValue)
+		}
+	}
+	if len(configured) == 0 {
+		return nil
+	}
+
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return nil
+	}
+	policyId := policyIdValue.AsString()
+	policy, err := vcdClient.GetVdcComputePolicyV2ById(policyId)
+	if err != nil {
+		return fmt.Errorf("error retrieving sizing policy %s: %s", policyId, err)
+	}
+
+	policyValues := map[string]*int{
+		"cpus":      policy.VdcComputePolicyV2.CPUCount,
+		"cpu_cores": policy.VdcComputePolicyV2.CoresPerSocket,
+		"memory":    policy.VdcComputePolicyV2.Memory,
+	}
+	var conflicts []string
+	for _, field := range []string{"cpus", "cpu_cores", "memory"} {
+		value, isSet := configured[field]
+		if isSet && policyValues[field] != nil && *policyValues[field] != value {
+			conflicts = append(conflicts, fmt.Sprintf("'%s' is %d, but sizing policy '%s' defines %d",
+				field, value, policy.VdcComputePolicyV2.Name, *policyValues[field]))
+		}
+	}
+	if len(conflicts) > 0 {
+		return fmt.Errorf("conflicting VM sizing: %s. Remove the attributes or make them match the sizing policy",
+			strings.Join(conflicts, "; "))
+	}
+	return nil
+}
+
+// setVmPowerOffRequiredBy stores in `power_off_required_by` the changed attributes that can't be applied with a hot
+// update (see resourceVmHotUpdate) and will power off the VM during update. The value is only refreshed when the
+// VM has other changes, to avoid a plan containing only this computed field.
+// When `prevent_update_power_off` is set on a powered on VM, such changes are rejected at plan time.
+func setVmPowerOffRequiredBy(d *schema.ResourceDiff) error {
+	if d.Id() == "" {
+		return d.SetNew("power_off_required_by", []string{})
+	}
+	hasChanges := false
+	for _, key := range d.GetChangedKeysPrefix("") {
+		// 'network_dhcp_wait_seconds' alone does not trigger a VM update (see genericResourceVcdVmUpdate)
+		if key != "network_dhcp_wait_seconds" && key != "power_off_required_by" {
+			hasChanges = true
+			break
+		}
+	}
+	if !hasChanges {
+		return nil
+	}
+
+	var requiredBy []string
+	for _, field := range vmPowerOffFields {
+		if d.HasChange(field) {
+			requiredBy = append(requiredBy, field)
+		}
+	}
+	if !d.Get("memory_hot_add_enabled").(bool) && d.HasChange("memory") {
+		requiredBy = append(requiredBy, "memory")
+	}
+	if !d.Get("cpu_hot_add_enabled").(bool) && d.HasChange("cpus") {
+		requiredBy = append(requiredBy, "cpus")
+	}
+	if d.HasChange("network") && isPrimaryNicRemoved(d) {
+		requiredBy = append(requiredBy, "network")
+	}
+	// The network configuration of cloud-init is rendered again with the new NICs
+	if cloudInit := getCloudInitConfig(d.Get("cloud_init")); cloudInitHasChange(d) ||
+		(d.HasChange("network") && cloudInit != nil && cloudInit.networkConfig != "") {
+		requiredBy = append(requiredBy, "cloud_init")
+	}
+	sort.Strings(requiredBy)
+
+	oldPowerOn, newPowerOn := d.GetChange("power_on")
+	if len(requiredBy) > 0 && d.Get("prevent_update_power_off").(bool) && oldPowerOn.(bool) && newPowerOn.(bool) {
+		return fmt.Errorf("changes to %s require the VM to power off, but 'prevent_update_power_off' is true",
+			strings.Join(requiredBy, ", "))
+	}
+
+	old := convertTypeListToSliceOfStrings(d.Get("power_off_required_by").([]interface{}))
+	if strings.Join(old, ",") == strings.Join(requiredBy, ",") {
+		return nil
+	}
+	if requiredBy == nil {
+		requiredBy = []string{}
+	}
+	return d.SetNew("power_off_required_by", requiredBy)
+}
+
+// getRawConfigBlocks returns the known elements of a list or set of blocks from raw configuration
+func getRawConfigBlocks(rawConfig cty.Value, blockName string) []cty.Value {
+	blocks := rawConfig.GetAttr(blockName)
+	if blocks.IsNull() || !blocks.IsKnown() || !blocks.CanIterateElements() {
+		return nil
+	}
+	var result []cty.Value
+	for iterator := blocks.ElementIterator(); iterator.Next(); {
+		_, block := iterator.Element()
+		if block.IsNull() || !block.IsKnown() {
+			continue
+		}
+		result = append(result, block)
+	}
+	return result
+}
@@ -0,0 +1,121 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func testVmNetworkBlock(mode, ip cty.Value) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"ip_allocation_mode": mode,
+		"ip":                 ip,
+	})
+}
+
+func testVmOverrideDiskBlock(busType string, busNumber, unitNumber int64) cty.Value {
+	return cty.ObjectVal(map[string]cty.Value{
+		"bus_type":    cty.StringVal(busType),
+		"bus_number":  cty.NumberIntVal(busNumber),
+		"unit_number": cty.NumberIntVal(unitNumber),
+	})
+}
+
+// Test_validateVmNetworkManualIp checks that MANUAL network blocks without an IP are rejected at plan time
+func Test_validateVmNetworkManualIp(t *testing.T) {
+	tests := []struct {
+		name    string
+		network []cty.Value
+		wantErr bool
+	}{
+		{
+			name:    "manual with IP",
+			network: []cty.Value{testVmNetworkBlock(cty.StringVal("MANUAL"), cty.StringVal("192.168.1.10"))},
+			wantErr: false,
+		},
+		{
+			name:    "manual without IP",
+			network: []cty.Value{testVmNetworkBlock(cty.StringVal("MANUAL"), cty.NullVal(cty.String))},
+			wantErr: true,
+		},
+		{
+			name:    "manual with empty IP",
+			network: []cty.Value{testVmNetworkBlock(cty.StringVal("MANUAL"), cty.StringVal(""))},
+			wantErr: true,
+		},
+		{
+			name:    "manual with IP known after apply",
+			network: []cty.Value{testVmNetworkBlock(cty.StringVal("MANUAL"), cty.UnknownVal(cty.String))},
+			wantErr: false,
+		},
+		{
+			name: "second block manual without IP",
+			network: []cty.Value{
+				testVmNetworkBlock(cty.StringVal("POOL"), cty.NullVal(cty.String)),
+				testVmNetworkBlock(cty.StringVal("MANUAL"), cty.NullVal(cty.String)),
+			},
+			wantErr: true,
+		},
+		{
+			name:    "DHCP without IP",
+			network: []cty.Value{testVmNetworkBlock(cty.StringVal("DHCP"), cty.NullVal(cty.String))},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rawConfig := cty.ObjectVal(map[string]cty.Value{"network": cty.ListVal(tt.network)})
+			err := validateVmNetworkManualIp(rawConfig)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVmNetworkManualIp() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_validateVmOverrideTemplateDisks checks that two override_template_disk blocks can't refer to the same disk
+func Test_validateVmOverrideTemplateDisks(t *testing.T) {
+	tests := []struct {
+		name    string
+		disks   []cty.Value
+		wantErr bool
+	}{
+		{
+			name: "different disks",
+			disks: []cty.Value{
+				testVmOverrideDiskBlock("paravirtual", 0, 0),
+				testVmOverrideDiskBlock("paravirtual", 0, 1),
+			},
+			wantErr: false,
+		},
+		{
+			name: "same position on different bus types",
+			disks: []cty.Value{
+				testVmOverrideDiskBlock("paravirtual", 0, 0),
+				testVmOverrideDiskBlock("ide", 0, 0),
+			},
+			wantErr: false,
+		},
+		{
+			name: "duplicate disk",
+			disks: []cty.Value{
+				testVmOverrideDiskBlock("paravirtual", 0, 0),
+				testVmOverrideDiskBlock("paravirtual", 1, 0),
+				testVmOverrideDiskBlock("paravirtual", 0, 0),
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Disks in a set that differ only in size would be separate elements, so a list is equivalent here
+			rawConfig := cty.ObjectVal(map[string]cty.Value{"override_template_disk": cty.ListVal(tt.disks)})
+			err := validateVmOverrideTemplateDisks(rawConfig)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateVmOverrideTemplateDisks() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
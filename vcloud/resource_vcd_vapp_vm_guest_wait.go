@@ -0,0 +1,268 @@
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// guestWaitPollInterval is the default number of seconds between two checks of the guest conditions
+const guestWaitPollInterval = 10
+
+// vmWaitForGuestSchema defines the 'wait_for_guest' block of VM resources
+func vmWaitForGuestSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		MaxItems: 1,
+		Description: "Blocks create and update operations, after the VM is powered on, until the guest OS reaches the " +
+			"given conditions",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"customization_complete": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Wait until guest customization reaches GC_COMPLETE. Fails when it reaches GC_FAILED",
+				},
+				"tools_running": {
+					Type:        schema.TypeBool,
+					Optional:    true,
+					Default:     false,
+					Description: "Wait until VMware Tools report that they are running",
+				},
+				"guest_property": {
+					Type:        schema.TypeString,
+					Optional:    true,
+					Description: "Wait until the guest property with this key has a value",
+				},
+				"nic_ip_indexes": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Wait until the NICs with these indexes report an IP address",
+					Elem: &schema.Schema{
+						Type:         schema.TypeInt,
+						ValidateFunc: validation.IntAtLeast(0),
+					},
+				},
+				"poll_interval": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      guestWaitPollInterval,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Number of seconds between two checks of the conditions",
+				},
+			},
+		},
+	}
+}
+
+// guestWaitConditions holds the settings of the 'wait_for_guest' block
+type guestWaitConditions struct {
+	customizationComplete bool
+	toolsRunning          bool
+	guestProperty         string
+	nicIpIndexes          []int
+	pollInterval          time.Duration
+}
+
+// getGuestWaitConditions converts the 'wait_for_guest' block. It returns nil when the block is not set
+func getGuestWaitConditions(d *schema.ResourceData) *guestWaitConditions {
+	blocks := d.Get("wait_for_guest").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return nil
+	}
+	block := blocks[0].(map[string]interface{})
+	conditions := &guestWaitConditions{
+		customizationComplete: block["customization_complete"].(bool),
+		toolsRunning:          block["tools_running"].(bool),
+		guestProperty:         block["guest_property"].(string),
+		pollInterval:          time.Duration(block["poll_interval"].(int)) * time.Second,
+	}
+	for _, index := range block["nic_ip_indexes"].([]interface{}) {
+		conditions.nicIpIndexes = append(conditions.nicIpIndexes, index.(int))
+	}
+	return conditions
+}
+
+// waitForGuest blocks until the guest of a powered on VM satisfies the conditions of the 'wait_for_guest' block,
+// or until the context expires. It fails immediately when guest customization fails
+func waitForGuest(ctx context.Context, d *schema.ResourceData, vcdClient *VCDClient, vm *govcd.VM) error {
+	conditions := getGuestWaitConditions(d)
+	if conditions == nil {
+		return nil
+	}
+	if !d.Get("power_on").(bool) {
+		log.Printf("[DEBUG] [VM wait for guest] VM %s is not powered on. Skipping 'wait_for_guest'", vm.VM.Name)
+		return nil
+	}
+
+	start := time.Now()
+	ticker := time.NewTicker(conditions.pollInterval)
+	defer ticker.Stop()
+	for {
+		pending, err := guestPendingConditions(vcdClient, vm, conditions)
+		if err != nil {
+			return err
+		}
+		if len(pending) == 0 {
+			log.Printf("[DEBUG] [VM wait for guest] VM %s guest ready after %s", vm.VM.Name, time.Since(start))
+			return nil
+		}
+		log.Printf("[DEBUG] [VM wait for guest] VM %s waiting for: %s", vm.VM.Name, strings.Join(pending, ", "))
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for the guest of VM %s: %s",
+				time.Since(start).Round(time.Second), vm.VM.Name, strings.Join(pending, ", "))
+		case <-ticker.C:
+		}
+	}
+}
+
+// guestPendingConditions returns the description of the conditions that the guest doesn't satisfy yet
+func guestPendingConditions(vcdClient *VCDClient, vm *govcd.VM, conditions *guestWaitConditions) ([]string, error) {
+	var pending []string
+
+	if conditions.customizationComplete {
+		status, err := vm.GetGuestCustomizationStatus()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving guest customization status of VM %s: %s", vm.VM.Name, err)
+		}
+		switch status {
+		case types.GuestCustStatusComplete:
+		case types.GuestCustStatusFailed:
+			return nil, fmt.Errorf("guest customization of VM %s failed%s", vm.VM.Name, guestCustomizationLog(vm))
+		default:
+			pending = append(pending, fmt.Sprintf("guest customization to complete (status %s)", status))
+		}
+	}
+
+	if conditions.toolsRunning {
+		status, err := queryVmToolsStatus(vcdClient, vm)
+		if err != nil {
+			return nil, err
+		}
+		if !isVmToolsRunning(status) {
+			pending = append(pending, fmt.Sprintf("VMware Tools to run (status '%s')", status))
+		}
+	}
+
+	if conditions.guestProperty != "" {
+		properties, err := vm.GetProductSectionList()
+		if err != nil {
+			return nil, fmt.Errorf("error retrieving guest properties of VM %s: %s", vm.VM.Name, err)
+		}
+		if guestPropertyValue(properties, conditions.guestProperty) == "" {
+			pending = append(pending, fmt.Sprintf("guest property '%s' to have a value", conditions.guestProperty))
+		}
+	}
+
+	if len(conditions.nicIpIndexes) > 0 {
+		err := vm.Refresh()
+		if err != nil {
+			return nil, fmt.Errorf("error refreshing VM %s: %s", vm.VM.Name, err)
+		}
+		for _, index := range conditions.nicIpIndexes {
+			if vmNicIp(vm.VM.NetworkConnectionSection, index) == "" {
+				pending = append(pending, fmt.Sprintf("NIC %d to report an IP address", index))
+			}
+		}
+	}
+	return pending, nil
+}
+
+// queryVmToolsStatus returns the VMware Tools status of a VM, which is only available in the query service
+func queryVmToolsStatus(vcdClient *VCDClient, vm *govcd.VM) (string, error) {
+	queryType := types.QtVm
+	if vcdClient.Client.IsSysAdmin {
+		queryType = types.QtAdminVm
+	}
+	results, err := vcdClient.QueryWithNotEncodedParams(nil, map[string]string{
+		"type":          queryType,
+		"filter":        "href==" + url.QueryEscape(vm.VM.HREF),
+		"filterEncoded": "true",
+	})
+	if err != nil {
+		return "", fmt.Errorf("error retrieving VMware Tools status of VM %s: %s", vm.VM.Name, err)
+	}
+	records := results.Results.VMRecord
+	if vcdClient.Client.IsSysAdmin {
+		records = results.Results.AdminVMRecord
+	}
+	if len(records) != 1 {
+		return "", fmt.Errorf("error retrieving VMware Tools status of VM %s: expected 1 record, found %d", vm.VM.Name, len(records))
+	}
+	return records[0].VmToolsStatus, nil
+}
+
+// isVmToolsRunning returns true for the VMware Tools status values reported while the tools are running, even
+// when an upgrade is available
+func isVmToolsRunning(status string) bool {
+	switch strings.ToLower(status) {
+	case "toolsok", "toolsold", "guesttoolsrunning":
+		return true
+	}
+	return false
+}
+
+// guestPropertyValue returns the value of a guest property, or an empty string when it is not set
+func guestPropertyValue(properties *types.ProductSectionList, key string) string {
+	if properties == nil || properties.ProductSection == nil {
+		return ""
+	}
+	for _, property := range properties.ProductSection.Property {
+		if property == nil || property.Key != key {
+			continue
+		}
+		if property.Value != nil && property.Value.Value != "" {
+			return property.Value.Value
+		}
+		return property.DefaultValue
+	}
+	return ""
+}
+
+// vmNicIp returns the IP address reported for the NIC with the given index
+func vmNicIp(section *types.NetworkConnectionSection, index int) string {
+	if section == nil {
+		return ""
+	}
+	for _, connection := range section.NetworkConnection {
+		if connection != nil && connection.NetworkConnectionIndex == index && connection.IPAddress != "any" {
+			return connection.IPAddress
+		}
+	}
+	return ""
+}
+
+// guestCustomizationLog returns the customization results that VMware Tools write in the extra configuration of
+// the VM, to be appended to an error message
+func guestCustomizationLog(vm *govcd.VM) string {
+	extraConfig, err := vm.GetExtraConfig()
+	if err != nil {
+		log.Printf("[DEBUG] [VM wait for guest] unable to retrieve extra configuration of VM %s: %s", vm.VM.Name, err)
+		return ""
+	}
+	var lines []string
+	for _, item := range extraConfig {
+		key := strings.ToLower(item.Key)
+		if strings.HasPrefix(key, "guestinfo.gc.") || strings.HasPrefix(key, "guestinfo.toolsdeploypkg") {
+			lines = append(lines, fmt.Sprintf("%s = %s", item.Key, item.Value))
+		}
+	}
+	if len(lines) == 0 {
+		return ""
+	}
+	sort.Strings(lines)
+	return ". Guest log:\n" + strings.Join(lines, "\n")
+}
@@ -0,0 +1,121 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// mockGuestWaitVm returns the mock state and the govcd VM of tf_vm1
+func mockGuestWaitVm(t *testing.T) (*mockVcd, *VCDClient, *mockVcdVmFix, *govcd.VM) {
+	mock, vcdClient := mockVcdTestClient(t)
+	_, vdc, err := vcdClient.GetOrgAndVdc("tf_org", "tf_vdc")
+	if err != nil {
+		t.Fatalf("error retrieving VDC: %s", err)
+	}
+	vapp, err := vdc.GetVAppByName("tf_vapp", false)
+	if err != nil {
+		t.Fatalf("error retrieving vApp: %s", err)
+	}
+	vm, err := vapp.GetVMByName("tf_vm1", false)
+	if err != nil {
+		t.Fatalf("error retrieving VM: %s", err)
+	}
+	var fixture *mockVcdVmFix
+	for _, vappFixture := range mock.allVApps() {
+		for _, vmFixture := range vappFixture.Vms {
+			if vmFixture.Name == "tf_vm1" {
+				fixture = vmFixture
+			}
+		}
+	}
+	return mock, vcdClient, fixture, vm
+}
+
+func guestWaitResourceData(t *testing.T, block map[string]interface{}) *schema.ResourceData {
+	return schema.TestResourceDataRaw(t, Provider().ResourcesMap["vcloud_vapp_vm"].Schema, map[string]interface{}{
+		"vapp_name":      "tf_vapp",
+		"name":           "tf_vm1",
+		"power_on":       true,
+		"wait_for_guest": []interface{}{block},
+	})
+}
+
+func TestMockVcdWaitForGuestConditions(t *testing.T) {
+	mock, vcdClient, fixture, vm := mockGuestWaitVm(t)
+	d := guestWaitResourceData(t, map[string]interface{}{
+		"customization_complete": true,
+		"tools_running":          true,
+		"guest_property":         "guestinfo.ready",
+		"nic_ip_indexes":         []interface{}{0},
+	})
+	conditions := getGuestWaitConditions(d)
+
+	mock.Lock()
+	fixture.gcStatus = types.GuestCustStatusPending
+	fixture.toolsStatus = "toolsNotRunning"
+	mock.Unlock()
+	pending, err := guestPendingConditions(vcdClient, vm, conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pending) != 4 {
+		t.Errorf("expected 4 pending conditions, got %v", pending)
+	}
+
+	mock.Lock()
+	fixture.gcStatus = types.GuestCustStatusComplete
+	fixture.toolsStatus = "toolsOk"
+	fixture.nicIp = "192.168.1.10"
+	fixture.guestProperties = map[string]string{"guestinfo.ready": "yes"}
+	mock.Unlock()
+	pending, err = guestPendingConditions(vcdClient, vm, conditions)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(pending) != 0 {
+		t.Errorf("expected no pending conditions, got %v", pending)
+	}
+	err = waitForGuest(context.Background(), d, vcdClient, vm)
+	if err != nil {
+		t.Errorf("unexpected error waiting for a ready guest: %s", err)
+	}
+}
+
+func TestMockVcdWaitForGuestFailure(t *testing.T) {
+	mock, vcdClient, fixture, vm := mockGuestWaitVm(t)
+
+	mock.Lock()
+	fixture.gcStatus = types.GuestCustStatusFailed
+	mock.Unlock()
+	d := guestWaitResourceData(t, map[string]interface{}{"customization_complete": true})
+	err := waitForGuest(context.Background(), d, vcdClient, vm)
+	if err == nil || !strings.Contains(err.Error(), "guest customization of VM tf_vm1 failed") {
+		t.Errorf("expected a customization failure, got %v", err)
+	}
+
+	mock.Lock()
+	fixture.gcStatus = types.GuestCustStatusPending
+	mock.Unlock()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err = waitForGuest(ctx, d, vcdClient, vm)
+	if err == nil || !strings.Contains(err.Error(), "timed out") || !strings.Contains(err.Error(), "GC_PENDING") {
+		t.Errorf("expected a timeout listing the pending customization, got %v", err)
+	}
+
+	// Nothing to wait for when the VM is not powered on
+	d = guestWaitResourceData(t, map[string]interface{}{"customization_complete": true})
+	dSet(d, "power_on", false)
+	err = waitForGuest(ctx, d, vcdClient, vm)
+	if err != nil {
+		t.Errorf("unexpected error for a powered off VM: %s", err)
+	}
+}
@@ -9,9 +9,11 @@ package vcloud
 // More information in https://github.com/hashicorp/terraform-plugin-sdk/issues/817
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"net"
+	"net/http"
 	"sort"
 	"strconv"
 	"strings"
@@ -406,8 +408,13 @@ func addRemoveGuestProperties(d *schema.ResourceData, vm *govcd.VM) error {
 	return nil
 }
 
+// changeGetter is implemented by both *schema.ResourceData and *schema.ResourceDiff
+type changeGetter interface {
+	GetChange(key string) (interface{}, interface{})
+}
+
 // isPrimaryNicRemoved checks if the updated schema has a primary NIC at all
-func isPrimaryNicRemoved(d *schema.ResourceData) bool {
+func isPrimaryNicRemoved(d changeGetter) bool {
 	_, newNetworkRaw := d.GetChange("network")
 	newNetworks := newNetworkRaw.([]interface{})
 
@@ -459,6 +466,16 @@ func updateVmSpecSection(vmSpecSection *types.VmSpecSection, vm *govcd.VM, descr
 // * vApp
 // * VM
 func getVmFromResource(d *schema.ResourceData, meta interface{}, vmType typeOfVm) (*VCDClient, *govcd.Org, *govcd.Vdc, *govcd.VApp, string, *govcd.VM, error) {
+	identifier := d.Id()
+	if identifier == "" {
+		identifier = d.Get("name").(string)
+	}
+	return getVmFromResourceByIdentifier(d, meta, vmType, identifier)
+}
+
+// getVmFromResourceByIdentifier works like getVmFromResource, but uses the given VM name or ID instead of the
+// resource ID or name. It is needed by resources that refer to a VM without being the VM (e.g. vcd_vm_snapshot)
+func getVmFromResourceByIdentifier(d *schema.ResourceData, meta interface{}, vmType typeOfVm, identifier string) (*VCDClient, *govcd.Org, *govcd.Vdc, *govcd.VApp, string, *govcd.VM, error) {
 	vcdClient := meta.(*VCDClient)
 
 	org, vdc, err := vcdClient.GetOrgAndVdcFromResource(d)
@@ -480,10 +497,6 @@ func getVmFromResource(d *schema.ResourceData, meta interface{}, vmType typeOfVm
 		return nil, nil, nil, nil, "", nil, fmt.Errorf("[getVmFromResource] error finding vApp '%s': %s%s", vappName, err, additionalMessage)
 	}
 
-	identifier := d.Id()
-	if identifier == "" {
-		identifier = d.Get("name").(string)
-	}
 	if identifier == "" {
 		return nil, nil, nil, nil, "", nil, fmt.Errorf("[VM update] neither name or ID was set")
 	}
@@ -1318,3 +1331,64 @@ func createOrUpdateVmSecurityTags(d *schema.ResourceData, vm *govcd.VM) error {
 
 	return nil
 }
+
+// addRawVmToVapp works like VApp.AddRawVM, but the recomposition task stops waiting when the context is done
+func addRawVmToVapp(ctx context.Context, vcdClient *VCDClient, vapp *govcd.VApp, params *types.ReComposeVAppParams) (*govcd.VM, error) {
+	task, err := vcdClient.Client.ExecuteTaskRequestWithApiVersion(vapp.VApp.HREF+"/action/recomposeVApp", http.MethodPost,
+		types.MimeRecomposeVappParams, "error instantiating a new VM: %s", params,
+		vcdClient.Client.GetSpecificApiVersionOnCondition(">=37.1", "37.1"))
+	if err != nil {
+		return nil, err
+	}
+	err = waitForTask(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("VM creation task failed: %s", err)
+	}
+
+	// The recomposition task does not return any reference to the VM, which must be looked up by name
+	var vmName string
+	if params.SourcedItem != nil && params.SourcedItem.Source != nil {
+		vmName = params.SourcedItem.Source.Name
+	}
+	vm, err := vapp.GetVMByName(vmName, true)
+	if err != nil {
+		return nil, fmt.Errorf("error finding VM %s in vApp %s after creation: %s", vmName, vapp.VApp.Name, err)
+	}
+	return vm, nil
+}
+
+// addEmptyVmToVapp works like VApp.AddEmptyVm, but the recomposition task stops waiting when the context is done
+func addEmptyVmToVapp(ctx context.Context, vapp *govcd.VApp, params *types.RecomposeVAppParamsForEmptyVm) (*govcd.VM, error) {
+	task, err := vapp.AddEmptyVmAsync(params)
+	if err != nil {
+		return nil, err
+	}
+	err = waitForTask(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	err = vapp.Refresh()
+	if err != nil {
+		return nil, fmt.Errorf("error refreshing vApp %s after VM creation: %s", vapp.VApp.Name, err)
+	}
+	return vapp.GetVMByName(params.CreateItem.Name, false)
+}
+
+// standaloneVmFromTask retrieves the VM created by a standalone VM creation task. The task is owned by
+// the vApp that VCD creates to host the VM, which contains only that VM
+func standaloneVmFromTask(vcdClient *VCDClient, vdc *govcd.Vdc, task govcd.Task, vmName string) (*govcd.VM, error) {
+	if task.Task.Owner == nil || task.Task.Owner.HREF == "" {
+		return nil, fmt.Errorf("task owner is empty for VM %s", vmName)
+	}
+	vapp, err := vdc.GetVAppByHref(task.Task.Owner.HREF)
+	if err != nil {
+		return nil, err
+	}
+	if vapp.VApp.Children == nil || len(vapp.VApp.Children.VM) == 0 {
+		return nil, fmt.Errorf("vApp %s contains no VMs: %s", vapp.VApp.Name, govcd.ErrorEntityNotFound)
+	}
+	if len(vapp.VApp.Children.VM) > 1 {
+		return nil, fmt.Errorf("vApp %s contains more than one VM", vapp.VApp.Name)
+	}
+	return vcdClient.Client.GetVMByHref(vapp.VApp.Children.VM[0].HREF)
+}
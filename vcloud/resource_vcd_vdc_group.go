@@ -182,6 +182,7 @@ func resourceVdcGroup() *schema.Resource {
 				Default:     false,
 				Description: "Forces deletion of VDC Group during destroy",
 			},
+			"metadata_entry": openApiMetadataEntryResourceSchema("VDC Group"),
 		},
 	}
 }
@@ -233,6 +234,11 @@ func resourceVcdVdcGroupCreate(ctx context.Context, d *schema.ResourceData, meta
 	}
 
 	d.SetId(createdVdcGroup.VdcGroup.Id)
+
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, vdcGroupMetadata(vcdClient, createdVdcGroup.VdcGroup))
+	if err != nil {
+		return diag.Errorf("error adding metadata to VDC group: %s", err)
+	}
 	return resourceVcdVdcGroupRead(ctx, d, meta)
 }
 
@@ -314,6 +320,11 @@ func resourceVcdVdcGroupUpdate(ctx context.Context, d *schema.ResourceData, meta
 		}
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, vdcGroupMetadata(vcdClient, vdcGroup.VdcGroup))
+	if err != nil {
+		return diag.Errorf("[VDC group update] error updating metadata: %s", err)
+	}
+
 	return resourceVcdVdcGroupRead(ctx, d, meta)
 }
 
@@ -379,7 +390,13 @@ func resourceVcdVdcGroupRead(_ context.Context, d *schema.ResourceData, meta int
 			return diag.Errorf("[VDC group read] could not set participating_vdc_ids block: %s", err)
 		}
 	}
-	return nil
+	return updateOpenApiMetadataInState(d, vcdClient, "vcd_vdc_group", vdcGroupMetadata(vcdClient, vdcGroup.VdcGroup))
+}
+
+// vdcGroupMetadata returns the handler of the OpenAPI metadata of a VDC Group
+func vdcGroupMetadata(vcdClient *VCDClient, vdcGroup *types.VdcGroup) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointVdcGroups,
+		"vcd_vdc_group", vdcGroup.Id, vdcGroup.Name)
 }
 
 func getDefaultPolicyStatus(vdcGroup *govcd.VdcGroup) (*bool, error) {
@@ -469,13 +486,23 @@ func resourceVcdVdcGroupDelete(_ context.Context, d *schema.ResourceData, meta i
 }
 
 func resourceVdcGroupImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
-	if len(resourceURI) != 2 {
-		return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name")
+	vcdClient := meta.(*VCDClient)
+
+	var orgName, vdcGroupName string
+	if vdcGroupId := importEntityId("urn:vcloud:vdcGroup:", d.Id()); vdcGroupId != "" {
+		var err error
+		orgName, vdcGroupName, err = importOwnerNames(vcdClient, &types.OpenApiReference{ID: vdcGroupId})
+		if err != nil {
+			return nil, fmt.Errorf("[VDC group import] error retrieving VDC group %s: %s", vdcGroupId, err)
+		}
+	} else {
+		resourceURI := strings.Split(d.Id(), ImportSeparator)
+		if len(resourceURI) != 2 {
+			return nil, fmt.Errorf("resource name must be specified as org-name.vdc-group-name or as VDC group ID")
+		}
+		orgName, vdcGroupName = resourceURI[0], resourceURI[1]
 	}
-	orgName, vdcGroupName := resourceURI[0], resourceURI[1]
 
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrg(orgName)
 	if err != nil {
 		return nil, fmt.Errorf("[VDC group import] error retrieving org %s: %s", orgName, err)
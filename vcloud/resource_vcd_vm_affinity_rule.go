@@ -325,9 +325,14 @@ func resourceVcdVmAffinityRuleDelete(_ context.Context, d *schema.ResourceData,
 //
 // Returns an error with all the VM affinity rules (name + ID for each)
 func resourceVcdVmAffinityRuleImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVdcPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
 	if len(resourceURI) != 3 {
-		return nil, fmt.Errorf("[VM affinity rule import] resource identifier must be specified as org.vdc.my-affinity-rule")
+		return nil, fmt.Errorf("[VM affinity rule import] resource identifier must be specified as org.vdc.my-affinity-rule " +
+			"or as vdc-ID.my-affinity-rule")
 	}
 	listRequested := false
 	orgName, vdcName, affinityRuleIdentifier := resourceURI[0], resourceURI[1], resourceURI[2]
@@ -350,7 +355,6 @@ func resourceVcdVmAffinityRuleImport(_ context.Context, d *schema.ResourceData,
 	}
 
 	lookingForId := govcd.IsUuid(affinityRuleIdentifier)
-	vcdClient := meta.(*VCDClient)
 	adminOrg, err := vcdClient.GetAdminOrgByName(orgName)
 	if err != nil {
 		return nil, fmt.Errorf(errorRetrievingOrg, orgName)
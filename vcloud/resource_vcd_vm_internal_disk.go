@@ -407,6 +407,7 @@ func resourceVmInternalDiskRead(_ context.Context, d *schema.ResourceData, m int
 
 var errHelpInternalDiskImport = fmt.Errorf(`resource id must be specified in one of these formats:
 'org-name.vdc-name.vapp-name.vm-name.my-internal-disk-id' to import by rule id
+'vm-id.my-internal-disk-id' to import by disk id from the VM with the given ID
 'list@org-name.vdc-name.vapp-name.vm-name' to get a list of internal disks with their IDs`)
 
 // resourceVcdVmInternalDiskImport is responsible for importing the resource.
@@ -428,7 +429,10 @@ var errHelpInternalDiskImport = fmt.Errorf(`resource id must be specified in one
 func resourceVcdVmInternalDiskImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	var commandOrgName, orgName, vdcName, vappName, vmName, diskId string
 
-	resourceURI := strings.Split(d.Id(), ImportSeparator)
+	resourceURI, err := importVmPath(meta.(*VCDClient), d.Id())
+	if err != nil {
+		return nil, err
+	}
 
 	log.Printf("[DEBUG] importing vcd_vm_internal_disk resource with provided id %s", d.Id())
 
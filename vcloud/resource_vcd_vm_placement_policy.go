@@ -259,7 +259,7 @@ func resourceVmPlacementPolicyDelete(_ context.Context, d *schema.ResourceData,
 }
 
 var errHelpVmPlacementPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'vm-placement-policy-name', 'vm-placement-policy-id' or 'list@' to get a list of VM placement policies with their IDs`)
+'vm-placement-policy-name', 'vm-placement-policy-id' (URN, HREF or UUID) or 'list@' to get a list of VM placement policies with their IDs`)
 
 // resourceVmPlacementPolicyImport is responsible for importing the resource.
 // The following steps happen as part of import
@@ -280,6 +280,10 @@ func resourceVmPlacementPolicyImport(_ context.Context, d *schema.ResourceData,
 
 	log.Printf("[DEBUG] importing VM Placement Policy resource with provided id %s", d.Id())
 
+	if policyId := importWholeEntityId("urn:vcloud:vdcComputePolicy:", d.Id()); policyId != "" {
+		return getVmPlacementPolicy(d, meta, policyId)
+	}
+
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmPlacementPolicyImport
 	}
@@ -57,6 +57,7 @@ func resourceVcdVmSizingPolicy() *schema.Resource {
 				Type:     schema.TypeList,
 				Elem:     sizingPolicyMemory,
 			},
+			"metadata_entry": openApiMetadataEntryResourceSchema("VM sizing policy"),
 		},
 	}
 }
@@ -166,6 +167,11 @@ func resourceVmSizingPolicyCreate(ctx context.Context, d *schema.ResourceData, m
 	d.SetId(createdVmSizingPolicy.VdcComputePolicy.ID)
 	log.Printf("[TRACE] VM sizing policy created: %#v", createdVmSizingPolicy.VdcComputePolicy)
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, vmSizingPolicyMetadata(vcdClient, createdVmSizingPolicy.VdcComputePolicy))
+	if err != nil {
+		return diag.Errorf("error adding metadata to VM sizing policy: %s", err)
+	}
+
 	return resourceVmSizingPolicyRead(ctx, d, meta)
 }
 
@@ -195,11 +201,30 @@ func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, m
 		}
 	}
 
-	// The secondary method of retrieval is from name
-	if d.Id() == "" {
-		if policyName == "" {
+	// Data sources can also find the policy by metadata
+	if d.Id() == "" && policyName == "" {
+		if _, ok := d.GetOk("metadata_entry_filter"); !ok {
 			return diag.Errorf("both name and ID are empty")
 		}
+		method = "metadata"
+		queryParams := url.Values{}
+		queryParams.Add("filter", "isSizingOnly==true")
+		allPolicies, err := vcdClient.Client.GetAllVdcComputePolicies(queryParams)
+		if err != nil {
+			return diag.Errorf("unable to retrieve VM sizing policies: %s", err)
+		}
+		policy, err = getOpenApiEntityByMetadataFilter(d, "metadata_entry_filter", "VM sizing policy", allPolicies,
+			func(candidate *govcd.VdcComputePolicy) openApiMetadataHandler {
+				return vmSizingPolicyMetadata(vcdClient, candidate.VdcComputePolicy)
+			})
+		if err != nil {
+			return diag.Errorf("unable to find VM sizing policy: %s", err)
+		}
+		d.SetId(policy.VdcComputePolicy.ID)
+	}
+
+	// The secondary method of retrieval is from name
+	if d.Id() == "" {
 		method = "name"
 		queryParams := url.Values{}
 		queryParams.Add("filter", fmt.Sprintf("name==%s;isSizingOnly==true", policyName))
@@ -223,7 +248,17 @@ func genericVcdVmSizingPolicyRead(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("[genericVcdVmSizingPolicyRead] error defining sizing policy")
 	}
 	util.Logger.Printf("[TRACE] [get VM sizing policy] Retrieved by %s\n", method)
-	return setVmSizingPolicy(ctx, d, *policy.VdcComputePolicy)
+	diags := setVmSizingPolicy(ctx, d, *policy.VdcComputePolicy)
+	if diags.HasError() {
+		return diags
+	}
+	return append(diags, updateOpenApiMetadataInState(d, vcdClient, "vcd_vm_sizing_policy", vmSizingPolicyMetadata(vcdClient, policy.VdcComputePolicy))...)
+}
+
+// vmSizingPolicyMetadata returns the handler of the OpenAPI metadata of a VM sizing policy
+func vmSizingPolicyMetadata(vcdClient *VCDClient, policy *types.VdcComputePolicy) openApiMetadataHandler {
+	return newOpenApiEntityMetadata(vcdClient, types.OpenApiPathVersion1_0_0+types.OpenApiEndpointVdcComputePolicies,
+		"vcd_vm_sizing_policy", policy.ID, policy.Name)
 }
 
 // setVmSizingPolicy sets object state from *govcd.VdcComputePolicy
@@ -327,6 +362,11 @@ func resourceVmSizingPolicyUpdate(ctx context.Context, d *schema.ResourceData, m
 		return diag.Errorf("error updating VM sizing policy %s, err: %s", policyName, err)
 	}
 
+	err = createOrUpdateOpenApiMetadataEntryInVcd(d, vmSizingPolicyMetadata(vcdClient, policy.VdcComputePolicy))
+	if err != nil {
+		return diag.Errorf("error updating metadata of VM sizing policy %s: %s", policyName, err)
+	}
+
 	log.Printf("[TRACE] VM sizing policy update completed: %s", policyName)
 	return resourceVmSizingPolicyRead(ctx, d, meta)
 }
@@ -500,7 +540,7 @@ func getMemoryInput(memoryPart []interface{}, params *types.VdcComputePolicy) (*
 }
 
 var errHelpVmSizingPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'vm-sizing-policy-name', 'vm-sizing-policy-id' or 'list@' to get a list of VM sizing policies with their IDs`)
+'vm-sizing-policy-name', 'vm-sizing-policy-id' (URN, HREF or UUID) or 'list@' to get a list of VM sizing policies with their IDs`)
 
 // resourceVmSizingPolicyImport is responsible for importing the resource.
 // The following steps happen as part of import
@@ -521,6 +561,10 @@ func resourceVmSizingPolicyImport(_ context.Context, d *schema.ResourceData, met
 
 	log.Printf("[DEBUG] importing VM sizing policy resource with provided id %s", d.Id())
 
+	if policyId := importWholeEntityId("urn:vcloud:vdcComputePolicy:", d.Id()); policyId != "" {
+		return getVmSizingPolicy(d, meta, policyId)
+	}
+
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmSizingPolicyImport
 	}
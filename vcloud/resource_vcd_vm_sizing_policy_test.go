@@ -415,3 +415,28 @@ output "memory_reservation_guarantee" {
 	value = data.vcd_vm_sizing_policy.vcd_vm_sizing_policy_by_name.memory[0].reservation_guarantee
 }
 `
+
+func TestAccVcdVmSizingPolicyMetadata(t *testing.T) {
+	skipIfNotSysAdmin(t)
+	if checkVersion(testConfig.Provider.ApiVersion, "< 38.0") {
+		t.Skip("OpenAPI metadata of VM sizing policies requires Vcloud 10.5.0+")
+	}
+	testOpenApiMetadataEntryCRUD(t,
+		testAccCheckVcdVmSizingPolicyMetadata, "vcd_vm_sizing_policy.test-policy",
+		testAccCheckVcdVmSizingPolicyMetadataDatasource, "data.vcd_vm_sizing_policy.test-policy-ds",
+		StringMap{})
+}
+
+const testAccCheckVcdVmSizingPolicyMetadata = `
+resource "vcd_vm_sizing_policy" "test-policy" {
+  name        = "{{.Name}}"
+  description = "{{.Name}}"
+  {{.Metadata}}
+}
+`
+
+const testAccCheckVcdVmSizingPolicyMetadataDatasource = `
+data "vcd_vm_sizing_policy" "test-policy-ds" {
+  name = vcd_vm_sizing_policy.test-policy.name
+}
+`
@@ -0,0 +1,304 @@
+package vcloud
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// vmSnapshotCreateParams is the payload of the VM "createSnapshot" action
+type vmSnapshotCreateParams struct {
+	XMLName     xml.Name `xml:"CreateSnapshotParams"`
+	Xmlns       string   `xml:"xmlns,attr"`
+	Name        string   `xml:"name,attr,omitempty"`
+	Memory      bool     `xml:"memory,attr"`
+	Quiesce     bool     `xml:"quiesce,attr"`
+	Description string   `xml:"Description,omitempty"`
+}
+
+const mimeCreateSnapshotParams = "application/vnd.vmware.vcloud.createSnapshotParams+xml"
+
+func resourceVcdVmSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVcdVmSnapshotCreate,
+		ReadContext:   resourceVcdVmSnapshotRead,
+		UpdateContext: resourceVcdVmSnapshotUpdate,
+		DeleteContext: resourceVcdVmSnapshotDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVcdVmSnapshotImport,
+		},
+		// Snapshots with memory, and the consolidation of disks when they are removed, can take long for big VMs
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"org": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+				Description: "The name of organization to use, optional if defined at provider " +
+					"level. Useful when connected as sysadmin working across different organizations",
+			},
+			"vdc": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The name of VDC to use, optional if defined at provider level",
+			},
+			"vapp_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The vApp that contains the VM. For standalone VMs, use the 'vapp_name' attribute of 'vcd_vm'",
+			},
+			"vm_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the VM to snapshot",
+			},
+			// VCD doesn't return the name and the description of a snapshot, so they stay empty after import
+			"name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressUnreadableAfterImport(),
+				Description:      "The name of the snapshot",
+			},
+			"description": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressUnreadableAfterImport(),
+				Description:      "The description of the snapshot",
+			},
+			"memory": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to include the VM memory in the snapshot. Only effective when the VM is powered on",
+			},
+			"quiesce": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     false,
+				Description: "Whether to quiesce the guest file system before taking the snapshot. Requires VMware Tools",
+			},
+			"revert_on_destroy": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether to revert the VM to the snapshot before removing it, when the resource is destroyed",
+			},
+			"vm_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The ID of the VM",
+			},
+			"created": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The date and time when the snapshot was created",
+			},
+			"powered_on": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the VM was powered on when the snapshot was taken",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The size of the snapshot, in bytes",
+			},
+		},
+	}
+}
+
+func resourceVcdVmSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVapp(d)
+	defer vcdClient.unLockParentVapp(d)
+
+	_, _, _, _, _, vm, err := getVmFromResourceByIdentifier(d, meta, vappVmType, d.Get("vm_name").(string))
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+
+	// VCD keeps a single snapshot per VM: creating a new one would silently replace the existing one,
+	// which may be managed elsewhere
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+	if snapshot != nil {
+		return diag.Errorf("[VM snapshot create] VM '%s' already has a snapshot (created %s). A VM can only have one snapshot: "+
+			"import it or remove it before creating a new one", vm.VM.Name, snapshot.Created)
+	}
+
+	params := &vmSnapshotCreateParams{
+		Xmlns:       types.XMLNamespaceVCloud,
+		Name:        d.Get("name").(string),
+		Memory:      d.Get("memory").(bool),
+		Quiesce:     d.Get("quiesce").(bool),
+		Description: d.Get("description").(string),
+	}
+	err = vmSnapshotAction(ctx, vcdClient, vm, "createSnapshot", mimeCreateSnapshotParams, params)
+	if err != nil {
+		return diag.Errorf("[VM snapshot create] %s", err)
+	}
+
+	d.SetId(vm.VM.ID)
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotRead(_ context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	_, _, _, _, _, vm, err := getVmFromResourceByIdentifier(d, meta, vappVmType, d.Get("vm_name").(string))
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			log.Printf("[DEBUG] VM '%s' not found. Removing snapshot from state", d.Get("vm_name").(string))
+			d.SetId("")
+			return nil
+		}
+		return diag.Errorf("[VM snapshot read] %s", err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot read] %s", err)
+	}
+	if snapshot == nil {
+		log.Printf("[DEBUG] VM '%s' has no snapshot. Removing from state", vm.VM.Name)
+		d.SetId("")
+		return nil
+	}
+
+	d.SetId(vm.VM.ID)
+	setVmSnapshotData(d, vm, snapshot)
+	return nil
+}
+
+// resourceVcdVmSnapshotUpdate only handles "revert_on_destroy", as all the other fields force a new snapshot
+func resourceVcdVmSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceVcdVmSnapshotRead(ctx, d, meta)
+}
+
+func resourceVcdVmSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	vcdClient := meta.(*VCDClient)
+
+	vcdClient.lockParentVapp(d)
+	defer vcdClient.unLockParentVapp(d)
+
+	_, _, _, _, _, vm, err := getVmFromResourceByIdentifier(d, meta, vappVmType, d.Get("vm_name").(string))
+	if err != nil {
+		if govcd.ContainsNotFound(err) {
+			return nil
+		}
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+	if snapshot == nil {
+		return nil
+	}
+
+	if d.Get("revert_on_destroy").(bool) {
+		err = vmSnapshotAction(ctx, vcdClient, vm, "revertToCurrentSnapshot", "", nil)
+		if err != nil {
+			return diag.Errorf("[VM snapshot delete] error reverting VM '%s' to snapshot: %s", vm.VM.Name, err)
+		}
+	}
+	err = vmSnapshotAction(ctx, vcdClient, vm, "removeAllSnapshots", "", nil)
+	if err != nil {
+		return diag.Errorf("[VM snapshot delete] %s", err)
+	}
+	return nil
+}
+
+// resourceVcdVmSnapshotImport imports the snapshot of a VM using a path like org-name.vdc-name.vapp-name.vm-name,
+// or the ID of the VM
+func resourceVcdVmSnapshotImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	vcdClient := meta.(*VCDClient)
+	resourceURI, err := importVmPath(vcdClient, d.Id())
+	if err != nil {
+		return nil, err
+	}
+	if len(resourceURI) != 4 {
+		return nil, fmt.Errorf("[VM snapshot import] resource name must be specified as org-name.vdc-name.vapp-name.vm-name " +
+			"or as vm-ID")
+	}
+	orgName, vdcName, vappName, vmName := resourceURI[0], resourceURI[1], resourceURI[2], resourceURI[3]
+
+	dSet(d, "org", orgName)
+	dSet(d, "vdc", vdcName)
+	dSet(d, "vapp_name", vappName)
+	dSet(d, "vm_name", vmName)
+	dSet(d, "memory", false)
+	dSet(d, "quiesce", false)
+	dSet(d, "revert_on_destroy", false)
+
+	_, _, _, _, _, vm, err := getVmFromResourceByIdentifier(d, meta, vappVmType, vmName)
+	if err != nil {
+		return nil, fmt.Errorf("[VM snapshot import] %s", err)
+	}
+	snapshot, err := getVmSnapshot(vcdClient, vm)
+	if err != nil {
+		return nil, fmt.Errorf("[VM snapshot import] %s", err)
+	}
+	if snapshot == nil {
+		return nil, fmt.Errorf("[VM snapshot import] VM '%s' has no snapshot", vmName)
+	}
+	d.SetId(vm.VM.ID)
+	return []*schema.ResourceData{d}, nil
+}
+
+// getVmSnapshot returns the snapshot of a VM, or nil if the VM has no snapshot
+func getVmSnapshot(vcdClient *VCDClient, vm *govcd.VM) (*types.SnapshotItem, error) {
+	var snapshotSection types.SnapshotSection
+	_, err := vcdClient.Client.ExecuteRequest(vm.VM.HREF+"/snapshotSection", http.MethodGet,
+		"", "error retrieving snapshots of VM "+vm.VM.Name+": %s", nil, &snapshotSection)
+	if err != nil {
+		return nil, err
+	}
+	if len(snapshotSection.Snapshot) == 0 {
+		return nil, nil
+	}
+	return snapshotSection.Snapshot[0], nil
+}
+
+// vmSnapshotAction runs one of the snapshot actions of a VM ("createSnapshot", "revertToCurrentSnapshot",
+// "removeAllSnapshots") and waits for its task to complete
+func vmSnapshotAction(ctx context.Context, vcdClient *VCDClient, vm *govcd.VM, action, contentType string, payload interface{}) error {
+	task, err := vcdClient.Client.ExecuteTaskRequest(vm.VM.HREF+"/action/"+action, http.MethodPost,
+		contentType, "error running "+action+" on VM "+vm.VM.Name+": %s", payload)
+	if err != nil {
+		return err
+	}
+	return waitForTask(ctx, task)
+}
+
+func setVmSnapshotData(d *schema.ResourceData, vm *govcd.VM, snapshot *types.SnapshotItem) {
+	dSet(d, "vm_id", vm.VM.ID)
+	dSet(d, "created", snapshot.Created)
+	dSet(d, "powered_on", snapshot.PoweredOn)
+	dSet(d, "size", snapshot.Size)
+}
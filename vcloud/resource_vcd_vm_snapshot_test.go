@@ -0,0 +1,104 @@
+//go:build vapp || vm || ALL || functional
+
+package vcloud
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccVcdVmSnapshot(t *testing.T) {
+	preTestChecks(t)
+
+	var params = StringMap{
+		"Org":         testConfig.VCD.Org,
+		"Vdc":         testConfig.Nsxt.Vdc,
+		"Catalog":     testSuiteCatalogName,
+		"CatalogItem": testSuiteCatalogOVAItem,
+		"VappName":    t.Name(),
+		"VmName":      t.Name() + "-vm",
+		"Description": t.Name() + " snapshot",
+		"Tags":        "vapp vm",
+	}
+	testParamsNotEmpty(t, params)
+
+	configText := templateFill(testAccVcdVmSnapshot, params)
+	params["FuncName"] = t.Name() + "-step2"
+	configTextDS := templateFill(testAccVcdVmSnapshot+testAccVcdVmSnapshotDS, params)
+	if vcdShortTest {
+		t.Skip(acceptanceTestsSkipped)
+		return
+	}
+	debugPrintf("#[DEBUG] CONFIGURATION: %s", configText)
+
+	resourceName := "vcd_vm_snapshot.snapshot"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: testAccProviders,
+		CheckDestroy:      testAccCheckVcdNsxtVAppVmDestroy(t.Name()),
+		Steps: []resource.TestStep{
+			{
+				Config: configText,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(resourceName, "id", "vcd_vapp_vm.vm", "id"),
+					resource.TestCheckResourceAttrPair(resourceName, "vm_id", "vcd_vapp_vm.vm", "id"),
+					resource.TestCheckResourceAttrSet(resourceName, "created"),
+					resource.TestCheckResourceAttr(resourceName, "powered_on", "false"),
+				),
+			},
+			{
+				Config: configTextDS,
+				Check: resource.ComposeTestCheckFunc(
+					resourceFieldsEqual(resourceName, "data.vcd_vm_snapshot.snapshot",
+						[]string{"memory", "quiesce", "revert_on_destroy", "name", "description"}),
+				),
+			},
+			{
+				ResourceName:            resourceName,
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateIdFunc:       importStateIdVappObject(t.Name(), t.Name()+"-vm", testConfig.Nsxt.Vdc),
+				ImportStateVerifyIgnore: []string{"name", "description"},
+			},
+		},
+	})
+	postTestChecks(t)
+}
+
+const testAccVcdVmSnapshot = `
+resource "vcd_vapp" "vapp" {
+  org  = "{{.Org}}"
+  vdc  = "{{.Vdc}}"
+  name = "{{.VappName}}"
+}
+
+resource "vcd_vapp_vm" "vm" {
+  org           = "{{.Org}}"
+  vdc           = "{{.Vdc}}"
+  vapp_name     = vcd_vapp.vapp.name
+  name          = "{{.VmName}}"
+  catalog_name  = "{{.Catalog}}"
+  template_name = "{{.CatalogItem}}"
+  memory        = 1024
+  cpus          = 1
+  power_on      = false
+}
+
+resource "vcd_vm_snapshot" "snapshot" {
+  org         = "{{.Org}}"
+  vdc         = "{{.Vdc}}"
+  vapp_name   = vcd_vapp.vapp.name
+  vm_name     = vcd_vapp_vm.vm.name
+  name        = "before-upgrade"
+  description = "{{.Description}}"
+}
+`
+
+const testAccVcdVmSnapshotDS = `
+data "vcd_vm_snapshot" "snapshot" {
+  org       = "{{.Org}}"
+  vdc       = "{{.Vdc}}"
+  vapp_name = vcd_vm_snapshot.snapshot.vapp_name
+  vm_name   = vcd_vm_snapshot.snapshot.vm_name
+}
+`
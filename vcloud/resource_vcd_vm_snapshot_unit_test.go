@@ -0,0 +1,96 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+// TestMockVcdVmSnapshotLifecycle creates, reads, imports, and removes a VM snapshot in the mock VCD
+func TestMockVcdVmSnapshotLifecycle(t *testing.T) {
+	_, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+	resource := Provider().ResourcesMap["vcloud_vm_snapshot"]
+
+	raw := map[string]interface{}{
+		"vapp_name":         "tf_vapp",
+		"vm_name":           "tf_vm1",
+		"name":              "before-upgrade",
+		"memory":            true,
+		"revert_on_destroy": true,
+	}
+	d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating VM snapshot: %v", diags)
+	}
+	if d.Id() == "" || d.Id() != d.Get("vm_id").(string) {
+		t.Fatalf("expected snapshot ID to be the VM ID, got '%s' and '%s'", d.Id(), d.Get("vm_id"))
+	}
+	if d.Get("created").(string) == "" || !d.Get("powered_on").(bool) {
+		t.Errorf("unexpected snapshot data: created '%s', powered on %v", d.Get("created"), d.Get("powered_on"))
+	}
+
+	// A VM can only have one snapshot
+	second := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags = resource.CreateContext(ctx, second, vcdClient)
+	if !diags.HasError() {
+		t.Errorf("expected error when creating a second snapshot for the same VM")
+	}
+
+	dataSource := readMockDataSource(t, vcdClient, "vcloud_vm_snapshot", map[string]interface{}{
+		"vapp_name": "tf_vapp",
+		"vm_name":   "tf_vm1",
+	})
+	if dataSource.Get("created").(string) != d.Get("created").(string) {
+		t.Errorf("data source and resource differ: created '%s' and '%s'", dataSource.Get("created"), d.Get("created"))
+	}
+
+	imported := resource.Data(nil)
+	imported.SetId("tf_org.tf_vdc.tf_vapp.tf_vm1")
+	importedList, err := resource.Importer.StateContext(ctx, imported, vcdClient)
+	if err != nil {
+		t.Fatalf("error importing VM snapshot: %s", err)
+	}
+	if importedList[0].Id() != d.Id() {
+		t.Errorf("expected imported ID %s, got %s", d.Id(), importedList[0].Id())
+	}
+
+	// VCD doesn't return the name and the description, so the plan after import must not replace the snapshot
+	// because of the values in the configuration
+	diags = resource.ReadContext(ctx, importedList[0], vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading imported VM snapshot: %v", diags)
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"org":         "tf_org",
+		"vdc":         "tf_vdc",
+		"vapp_name":   "tf_vapp",
+		"vm_name":     "tf_vm1",
+		"name":        "before-upgrade",
+		"description": "Taken before the OS upgrade",
+	})
+	diff, err := resource.Diff(ctx, importedList[0].State(), config, vcdClient)
+	if err != nil {
+		t.Fatalf("error planning imported VM snapshot: %s", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Errorf("expected no changes after import, got %s", diff.GoString())
+	}
+
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting VM snapshot: %v", diags)
+	}
+	diags = resource.ReadContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error reading deleted VM snapshot: %v", diags)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected empty ID for deleted snapshot, got %s", d.Id())
+	}
+}
@@ -438,13 +438,17 @@ func getVgpuClustersAndVmGroups(d *schema.ResourceData, vcdClient *VCDClient) ([
 }
 
 var errHelpVmVgpuPolicyImport = fmt.Errorf(`resource id must be specified in one of these formats:
-'vm-vgpu-policy-name', 'vm-vgpu-policy-id' or 'list@' to get a list of VM vgpu policies with their IDs`)
+'vm-vgpu-policy-name', 'vm-vgpu-policy-id' (URN, HREF or UUID) or 'list@' to get a list of VM vgpu policies with their IDs`)
 
 func resourceVcdVmVgpuPolicyImport(_ context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	resourceURI := strings.Split(d.Id(), ImportSeparator)
 
 	log.Printf("[DEBUG] importing VM vGPU policy resource with provided id %s", d.Id())
 
+	if policyId := importWholeEntityId("urn:vcloud:vdcComputePolicy:", d.Id()); policyId != "" {
+		return getVmVgpuPolicy(d, meta, policyId)
+	}
+
 	if len(resourceURI) != 1 {
 		return nil, errHelpVmVgpuPolicyImport
 	}
@@ -0,0 +1,486 @@
+package vcloud
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// retryErrorClass identifies a family of transient VCD errors that can be retried
+type retryErrorClass string
+
+const (
+	// retryBusyEntity covers the BUSY_ENTITY errors returned when an entity is locked by another operation
+	retryBusyEntity retryErrorClass = "busy_entity"
+	// retryServerError covers HTTP 5xx responses
+	retryServerError retryErrorClass = "server_error"
+	// retryTaskConflict covers tasks that fail because a concurrent operation is modifying the same entity
+	retryTaskConflict retryErrorClass = "task_conflict"
+	// retryConnection covers network failures between the provider and VCD
+	retryConnection retryErrorClass = "connection"
+)
+
+// retryErrorClasses lists all the classes that can be used in the provider 'retry' block
+var retryErrorClasses = []string{
+	string(retryBusyEntity),
+	string(retryServerError),
+	string(retryTaskConflict),
+	string(retryConnection),
+}
+
+// retryDefaultErrorClasses are the classes retried when 'retryable_errors' is not set
+var retryDefaultErrorClasses = []retryErrorClass{retryBusyEntity, retryServerError, retryTaskConflict}
+
+// retryErrorPatterns classify the errors returned by go-vcloud-director. Most errors reach the provider as
+// plain strings, as both the SDK and the resources wrap them with fmt.Errorf("%s"), so the message is the only
+// reliable source of information
+var retryErrorPatterns = []struct {
+	class   retryErrorClass
+	pattern *regexp.Regexp
+}{
+	{retryBusyEntity, regexp.MustCompile(`BUSY_ENTITY|(?i)\bis busy\b|busy completing an operation`)},
+	{retryTaskConflict, regexp.MustCompile(`(?i)another (task|operation) is (already )?(in progress|running)|` +
+		`concurrent(ly)? modifi|CONCURRENT_MODIFICATION|optimistic ?lock|operation already in progress|` +
+		`was modified by another`)},
+	{retryServerError, regexp.MustCompile(`API Error: 5\d\d\b|INTERNAL_SERVER_ERROR|` +
+		`(?i)\b(internal server error|bad gateway|service unavailable|gateway timeout)\b`)},
+	{retryConnection, regexp.MustCompile(`(?i)connection reset by peer|connection refused|broken pipe|i/o timeout|` +
+		`TLS handshake timeout|unexpected EOF|: EOF$|no such host`)},
+}
+
+// classifyRetryError returns the class of a transient error, or an empty string when the error is not transient
+func classifyRetryError(err error) retryErrorClass {
+	if err == nil {
+		return ""
+	}
+
+	// Structured errors, when they were not flattened on the way up
+	var apiError *types.Error
+	if errors.As(err, &apiError) {
+		if apiError.MinorErrorCode == "BUSY_ENTITY" {
+			return retryBusyEntity
+		}
+		if apiError.MajorErrorCode >= 500 {
+			return retryServerError
+		}
+	}
+	var openApiError *types.OpenApiError
+	if errors.As(err, &openApiError) {
+		switch openApiError.MinorErrorCode {
+		case "BUSY_ENTITY":
+			return retryBusyEntity
+		case "INTERNAL_SERVER_ERROR":
+			return retryServerError
+		}
+	}
+
+	message := err.Error()
+	for _, p := range retryErrorPatterns {
+		if p.pattern.MatchString(message) {
+			return p.class
+		}
+	}
+	return ""
+}
+
+// retryPolicy defines how many times and how often a failed CRUD operation, or one of its requests, is attempted again
+type retryPolicy struct {
+	maxAttempts     int
+	initialBackoff  time.Duration
+	maxBackoff      time.Duration
+	multiplier      float64
+	retryableErrors map[retryErrorClass]bool
+	// overrides holds the policies defined for specific resource types
+	overrides map[string]*retryPolicy
+}
+
+// noRetryPolicy is used when the provider has no 'retry' block: every operation is attempted only once
+var noRetryPolicy = &retryPolicy{maxAttempts: 1}
+
+// forResource returns the policy that applies to the given resource type
+func (p *retryPolicy) forResource(resourceType string) *retryPolicy {
+	if p == nil {
+		return noRetryPolicy
+	}
+	if override, ok := p.overrides[resourceType]; ok {
+		return override
+	}
+	return p
+}
+
+// String returns a representation of the policy and of its overrides, used to tell apart the cached connections
+func (p *retryPolicy) String() string {
+	if p == nil {
+		return ""
+	}
+	classes := make([]string, 0, len(p.retryableErrors))
+	for class, enabled := range p.retryableErrors {
+		if enabled {
+			classes = append(classes, string(class))
+		}
+	}
+	sort.Strings(classes)
+	result := fmt.Sprintf("%d:%s:%s:%g:%s", p.maxAttempts, p.initialBackoff, p.maxBackoff, p.multiplier,
+		strings.Join(classes, ","))
+
+	resourceTypes := make([]string, 0, len(p.overrides))
+	for resourceType := range p.overrides {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+	for _, resourceType := range resourceTypes {
+		result += fmt.Sprintf(";%s=%s", resourceType, p.overrides[resourceType])
+	}
+	return result
+}
+
+// backoff returns the time to wait after the given failed attempt (starting from 1). It grows exponentially
+// up to maxBackoff, with up to 20% of random jitter, so that parallel operations don't retry in lockstep
+func (p *retryPolicy) backoff(attempt int) time.Duration {
+	wait := float64(p.initialBackoff) * math.Pow(p.multiplier, float64(attempt-1))
+	if p.maxBackoff > 0 && wait > float64(p.maxBackoff) {
+		wait = float64(p.maxBackoff)
+	}
+	wait += wait * 0.2 * rand.Float64()
+	return time.Duration(wait)
+}
+
+// wholeOperationRetries lists the resource operations that are repeated as a whole when they fail. They are safe
+// to repeat, as a read doesn't change VCD and a delete brings it to the same final state. Creations and updates may
+// have changed VCD before failing, and only the requests that VCD didn't apply are repeated (see roundTrip)
+var wholeOperationRetries = map[string]bool{"read": true, "delete": true}
+
+// run invokes 'attempt' until it succeeds, fails with a non retryable error, or the attempts are exhausted
+func (p *retryPolicy) run(ctx context.Context, resourceType, operation string, attempt func() error) error {
+	for n := 1; ; n++ {
+		err := attempt()
+		if err == nil || n >= p.maxAttempts {
+			return err
+		}
+		class := classifyRetryError(err)
+		if class == "" || !p.retryableErrors[class] {
+			return err
+		}
+
+		if !p.wait(ctx, resourceType, operation, n, class, err) {
+			return err
+		}
+	}
+}
+
+// wait logs a failed attempt and sleeps for its backoff. It returns false when the context ends before
+func (p *retryPolicy) wait(ctx context.Context, resourceType, operation string, attempt int, class retryErrorClass, err error) bool {
+	wait := p.backoff(attempt)
+	log.Printf("[WARN] %s %s: attempt %d of %d failed with a retryable '%s' error. Retrying in %s: %s",
+		resourceType, operation, attempt, p.maxAttempts, class, wait.Round(time.Millisecond), err)
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// roundTrip sends a request of the operation, and repeats it while it fails with a retryable error that shows
+// that VCD didn't apply it: BUSY_ENTITY and task conflicts are rejections of the request, while server and
+// connection errors are only retried for requests that don't change VCD (GET and HEAD), or when the connection
+// could not be opened. The failures of tasks, which are only known after VCD started the change, are never retried
+// here. Requests of the operations that are repeated as a whole are sent only once
+func (op *crudOperation) roundTrip(base http.RoundTripper, request *http.Request) (*http.Response, error) {
+	policy := op.retry
+	if policy == nil || policy.maxAttempts <= 1 || (!op.dataSource && wholeOperationRetries[op.operation]) ||
+		(request.Body != nil && request.GetBody == nil) {
+		return base.RoundTrip(request)
+	}
+	for n := 1; ; n++ {
+		attempt := request
+		if n > 1 {
+			attempt = request.Clone(request.Context())
+			if request.GetBody != nil {
+				body, err := request.GetBody()
+				if err != nil {
+					return nil, err
+				}
+				attempt.Body = body
+			}
+		}
+		response, err := base.RoundTrip(attempt)
+		if n >= policy.maxAttempts {
+			return response, err
+		}
+		class, cause := requestRetryClass(request, response, err)
+		if class == "" || !policy.retryableErrors[class] {
+			return response, err
+		}
+
+		if !policy.wait(op.ctx, op.resourceType, op.operation, n, class, cause) {
+			return response, err
+		}
+		if response != nil {
+			_ = response.Body.Close()
+		}
+	}
+}
+
+// requestRetryClass returns the class of the transient error of a request that can be sent again, together with
+// the error itself. The body of a failed response is read to classify it, and is restored for the caller
+func requestRetryClass(request *http.Request, response *http.Response, err error) (retryErrorClass, error) {
+	safe := request.Method == http.MethodGet || request.Method == http.MethodHead
+	if err != nil {
+		var opError *net.OpError
+		if safe || (errors.As(err, &opError) && opError.Op == "dial") {
+			return classifyRetryError(err), err
+		}
+		return "", err
+	}
+	if response.StatusCode < http.StatusBadRequest {
+		return "", nil
+	}
+
+	contents, _ := io.ReadAll(io.LimitReader(response.Body, 64*1024))
+	response.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(contents), response.Body), response.Body}
+	cause := fmt.Errorf("API Error: %d: %s", response.StatusCode, contents)
+	class := classifyRetryError(cause)
+	if !safe && class != retryBusyEntity && class != retryTaskConflict {
+		return "", cause
+	}
+	return class, cause
+}
+
+// retryPolicyFromMeta returns the policy of the given resource type stored in the provider client
+func retryPolicyFromMeta(meta interface{}, resourceType string) *retryPolicy {
+	vcdClient, ok := meta.(*VCDClient)
+	if !ok || vcdClient == nil {
+		return noRetryPolicy
+	}
+	return vcdClient.RetryPolicy.forResource(resourceType)
+}
+
+// diagnosticsError returns the errors contained in a list of diagnostics as a single error, or nil if there are none
+func diagnosticsError(diags diag.Diagnostics) error {
+	var messages []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		message := d.Summary
+		if d.Detail != "" {
+			message += ": " + d.Detail
+		}
+		messages = append(messages, message)
+	}
+	if len(messages) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(messages, "; "))
+}
+
+type crudContextFunc = func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+type crudFunc = func(*schema.ResourceData, interface{}) error
+
+// retryCrudContext wraps a context-aware CRUD function with the retry policy of the resource type
+func retryCrudContext(resourceType, operation string, f crudContextFunc) crudContextFunc {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		var diags diag.Diagnostics
+		_ = retryPolicyFromMeta(meta, resourceType).run(ctx, resourceType, operation, func() error {
+			diags = f(ctx, d, meta)
+			return diagnosticsError(diags)
+		})
+		return diags
+	}
+}
+
+// retryCrud wraps a legacy CRUD function with the retry policy of the resource type. The retries end with the
+// timeout of the operation, as for the context-aware functions
+func retryCrud(resourceType, operation string, f crudFunc) crudFunc {
+	if f == nil {
+		return nil
+	}
+	return func(d *schema.ResourceData, meta interface{}) error {
+		ctx, cancel := context.WithTimeout(context.Background(), crudOperationTimeout(d, operation))
+		defer cancel()
+		return retryPolicyFromMeta(meta, resourceType).run(ctx, resourceType, operation, func() error {
+			return f(d, meta)
+		})
+	}
+}
+
+// withRetryPolicy returns a copy of the given resources in which the read and delete functions are repeated as a
+// whole according to the retry policy defined in the provider. The requests of the other operations are retried
+// one by one by crudOperationTransport. The original map is left untouched, so that every call of Provider() wraps
+// the functions only once
+func withRetryPolicy(resources map[string]*schema.Resource) map[string]*schema.Resource {
+	wrapped := make(map[string]*schema.Resource, len(resources))
+	for resourceType, resource := range resources {
+		r := *resource
+		r.ReadContext = retryCrudContext(resourceType, "read", r.ReadContext)
+		r.DeleteContext = retryCrudContext(resourceType, "delete", r.DeleteContext)
+		r.Read = retryCrud(resourceType, "read", r.Read)       //nolint:staticcheck
+		r.Delete = retryCrud(resourceType, "delete", r.Delete) //nolint:staticcheck
+		wrapped[resourceType] = &r
+	}
+	return wrapped
+}
+
+// retrySchema defines the 'retry' block of the provider
+func retrySchema() *schema.Schema {
+	resourceTypes := make([]string, 0, len(globalResourceMap))
+	for resourceType := range globalResourceMap {
+		resourceTypes = append(resourceTypes, resourceType)
+	}
+	sort.Strings(resourceTypes)
+
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Description: "Defines how CRUD operations that fail with transient VCD errors are retried",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"max_attempts": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      5,
+					ValidateFunc: validation.IntAtLeast(1),
+					Description:  "Maximum number of attempts for each operation, including the first one (defaults to 5)",
+				},
+				"initial_backoff": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      2,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Seconds to wait after the first failed attempt (defaults to 2)",
+				},
+				"max_backoff": {
+					Type:         schema.TypeInt,
+					Optional:     true,
+					Default:      30,
+					ValidateFunc: validation.IntAtLeast(0),
+					Description:  "Maximum number of seconds to wait between attempts (defaults to 30)",
+				},
+				"backoff_multiplier": {
+					Type:         schema.TypeFloat,
+					Optional:     true,
+					Default:      2.0,
+					ValidateFunc: validation.FloatAtLeast(1),
+					Description:  "Factor by which the wait grows after each failed attempt (defaults to 2)",
+				},
+				"retryable_errors": retryableErrorsSchema(fmt.Sprintf("Classes of errors that are retried. "+
+					"One or more of '%s' (defaults to all except 'connection')", strings.Join(retryErrorClasses, "', '"))),
+				"resource_override": {
+					Type:        schema.TypeList,
+					Optional:    true,
+					Description: "Overrides the retry settings for a specific resource type",
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"resource_type": {
+								Type:         schema.TypeString,
+								Required:     true,
+								ValidateFunc: validation.StringInSlice(resourceTypes, false),
+								Description:  "The resource type the override applies to, such as 'vcloud_vapp_vm'",
+							},
+							"max_attempts": {
+								Type:         schema.TypeInt,
+								Optional:     true,
+								ValidateFunc: validation.IntAtLeast(1),
+								Description:  "Maximum number of attempts for this resource type. Set it to 1 to disable retries",
+							},
+							"retryable_errors": retryableErrorsSchema("Classes of errors that are retried for this " +
+								"resource type. Inherited from the 'retry' block when empty"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func retryableErrorsSchema(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeSet,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(retryErrorClasses, false),
+		},
+		Description: description,
+	}
+}
+
+// getRetryPolicy builds the retry policy from the 'retry' block of the provider
+func getRetryPolicy(d *schema.ResourceData) (*retryPolicy, error) {
+	blocks := d.Get("retry").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		return noRetryPolicy, nil
+	}
+	block := blocks[0].(map[string]interface{})
+
+	policy := &retryPolicy{
+		maxAttempts:     block["max_attempts"].(int),
+		initialBackoff:  time.Duration(block["initial_backoff"].(int)) * time.Second,
+		maxBackoff:      time.Duration(block["max_backoff"].(int)) * time.Second,
+		multiplier:      block["backoff_multiplier"].(float64),
+		retryableErrors: retryErrorClassSet(block["retryable_errors"].(*schema.Set), retryDefaultErrorClasses),
+		overrides:       make(map[string]*retryPolicy),
+	}
+	if policy.maxBackoff < policy.initialBackoff {
+		return nil, fmt.Errorf("'max_backoff' (%d) must not be lower than 'initial_backoff' (%d)",
+			block["max_backoff"].(int), block["initial_backoff"].(int))
+	}
+
+	for _, raw := range block["resource_override"].([]interface{}) {
+		override := raw.(map[string]interface{})
+		resourceType := override["resource_type"].(string)
+		if _, found := policy.overrides[resourceType]; found {
+			return nil, fmt.Errorf("more than one 'resource_override' for resource type '%s'", resourceType)
+		}
+		resourcePolicy := *policy
+		resourcePolicy.overrides = nil
+		if maxAttempts := override["max_attempts"].(int); maxAttempts > 0 {
+			resourcePolicy.maxAttempts = maxAttempts
+		}
+		if classes := override["retryable_errors"].(*schema.Set); classes.Len() > 0 {
+			resourcePolicy.retryableErrors = retryErrorClassSet(classes, nil)
+		}
+		policy.overrides[resourceType] = &resourcePolicy
+	}
+	return policy, nil
+}
+
+// retryErrorClassSet converts a set of error class names, using the given defaults when it is empty
+func retryErrorClassSet(classes *schema.Set, defaults []retryErrorClass) map[retryErrorClass]bool {
+	result := make(map[retryErrorClass]bool)
+	for _, class := range convertSchemaSetToSliceOfStrings(classes) {
+		result[retryErrorClass(class)] = true
+	}
+	if len(result) == 0 {
+		for _, class := range defaults {
+			result[class] = true
+		}
+	}
+	return result
+}
@@ -0,0 +1,386 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/vmware/go-vcloud-director/v2/types/v56"
+)
+
+// Test_classifyRetryError checks the classification of the errors returned by go-vcloud-director
+func Test_classifyRetryError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want retryErrorClass
+	}{
+		{
+			name: "nil",
+			err:  nil,
+			want: "",
+		},
+		{
+			name: "busy entity XML error",
+			err: fmt.Errorf("error running createSnapshot on VM vm1: API Error: 400: The entity vm1 (urn:vcloud:vm:1) " +
+				"is busy completing an operation CREATE_SNAPSHOT"),
+			want: retryBusyEntity,
+		},
+		{
+			name: "busy entity OpenAPI error",
+			err:  fmt.Errorf("error in HTTP PUT request: BUSY_ENTITY - [ 1234 ] The entity is busy"),
+			want: retryBusyEntity,
+		},
+		{
+			name: "structured busy entity",
+			err:  fmt.Errorf("wrapped: %w", &types.Error{MajorErrorCode: 400, MinorErrorCode: "BUSY_ENTITY", Message: "locked"}),
+			want: retryBusyEntity,
+		},
+		{
+			name: "server error",
+			err:  fmt.Errorf("error retrieving vApp: API Error: 503: Service Unavailable"),
+			want: retryServerError,
+		},
+		{
+			name: "structured server error",
+			err:  fmt.Errorf("wrapped: %w", &types.Error{MajorErrorCode: 500, Message: "unexpected"}),
+			want: retryServerError,
+		},
+		{
+			name: "task conflict",
+			err:  fmt.Errorf("error completing tasks: task did not complete successfully: Another task is already running on this entity"),
+			want: retryTaskConflict,
+		},
+		{
+			name: "connection",
+			err:  fmt.Errorf("Get \"https://vcd.example.com/api/vApp/1\": read tcp 10.0.0.1:443: connection reset by peer"),
+			want: retryConnection,
+		},
+		{
+			name: "not found",
+			err:  fmt.Errorf("[ENF] entity not found"),
+			want: "",
+		},
+		{
+			name: "bad request",
+			err:  fmt.Errorf("API Error: 400: The name must be unique"),
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := classifyRetryError(tt.err); got != tt.want {
+				t.Errorf("classifyRetryError() = '%s', want '%s'", got, tt.want)
+			}
+		})
+	}
+}
+
+// Test_retryPolicyRun checks the number of attempts made by a policy
+func Test_retryPolicyRun(t *testing.T) {
+	policy := &retryPolicy{
+		maxAttempts:     3,
+		multiplier:      2,
+		retryableErrors: map[retryErrorClass]bool{retryBusyEntity: true},
+	}
+	busyError := fmt.Errorf("BUSY_ENTITY - the entity is busy")
+
+	tests := []struct {
+		name         string
+		operation    string
+		errors       []error
+		wantAttempts int
+		wantErr      bool
+	}{
+		{
+			name:         "success at first attempt",
+			operation:    "read",
+			errors:       []error{nil},
+			wantAttempts: 1,
+		},
+		{
+			name:         "success after retries",
+			operation:    "read",
+			errors:       []error{busyError, busyError, nil},
+			wantAttempts: 3,
+		},
+		{
+			name:         "attempts exhausted",
+			operation:    "read",
+			errors:       []error{busyError, busyError, busyError, nil},
+			wantAttempts: 3,
+			wantErr:      true,
+		},
+		{
+			name:         "class not retryable",
+			operation:    "read",
+			errors:       []error{fmt.Errorf("API Error: 500: unexpected"), nil},
+			wantAttempts: 1,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			attempts := 0
+			err := policy.run(context.Background(), "vcloud_vm", tt.operation, func() error {
+				attempts++
+				return tt.errors[attempts-1]
+			})
+			if (err != nil) != tt.wantErr {
+				t.Errorf("run() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if attempts != tt.wantAttempts {
+				t.Errorf("run() made %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+// Test_retryPolicyBackoff checks that the wait grows exponentially, within the jitter, up to the maximum
+func Test_retryPolicyBackoff(t *testing.T) {
+	policy := &retryPolicy{initialBackoff: time.Second, maxBackoff: 5 * time.Second, multiplier: 2}
+	for attempt, want := range map[int]time.Duration{1: time.Second, 2: 2 * time.Second, 3: 4 * time.Second, 4: 5 * time.Second} {
+		got := policy.backoff(attempt)
+		if got < want || got > want+want/5 {
+			t.Errorf("backoff(%d) = %s, want between %s and %s", attempt, got, want, want+want/5)
+		}
+	}
+}
+
+// Test_getRetryPolicy checks how the provider 'retry' block is converted into a policy
+func Test_getRetryPolicy(t *testing.T) {
+	providerSchema := Provider().Schema
+
+	d := schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{})
+	policy, err := getRetryPolicy(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if policy.forResource("vcloud_vm").maxAttempts != 1 {
+		t.Errorf("expected no retries without a 'retry' block")
+	}
+
+	d = schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"max_attempts": 4,
+				"resource_override": []interface{}{
+					map[string]interface{}{
+						"resource_type":    "vcloud_vapp_vm",
+						"max_attempts":     8,
+						"retryable_errors": []interface{}{"connection"},
+					},
+				},
+			},
+		},
+	})
+	policy, err = getRetryPolicy(d)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	vmPolicy := policy.forResource("vcloud_vm")
+	if vmPolicy.maxAttempts != 4 || vmPolicy.initialBackoff != 2*time.Second || vmPolicy.maxBackoff != 30*time.Second {
+		t.Errorf("unexpected policy for vcloud_vm: %+v", vmPolicy)
+	}
+	for _, class := range retryDefaultErrorClasses {
+		if !vmPolicy.retryableErrors[class] {
+			t.Errorf("expected '%s' to be retried by default", class)
+		}
+	}
+	vappVmPolicy := policy.forResource("vcloud_vapp_vm")
+	if vappVmPolicy.maxAttempts != 8 || vappVmPolicy.initialBackoff != 2*time.Second {
+		t.Errorf("unexpected policy for vcloud_vapp_vm: %+v", vappVmPolicy)
+	}
+	if !vappVmPolicy.retryableErrors[retryConnection] || vappVmPolicy.retryableErrors[retryBusyEntity] {
+		t.Errorf("unexpected retryable errors for vcloud_vapp_vm: %v", vappVmPolicy.retryableErrors)
+	}
+
+	d = schema.TestResourceDataRaw(t, providerSchema, map[string]interface{}{
+		"retry": []interface{}{
+			map[string]interface{}{
+				"initial_backoff": 10,
+				"max_backoff":     5,
+			},
+		},
+	})
+	_, err = getRetryPolicy(d)
+	if err == nil {
+		t.Errorf("expected error when 'max_backoff' is lower than 'initial_backoff'")
+	}
+}
+
+// Test_retryPolicyString checks that the representation of a policy, used to tell apart the cached connections,
+// is stable and changes with every setting
+func Test_retryPolicyString(t *testing.T) {
+	newPolicy := func() *retryPolicy {
+		return &retryPolicy{
+			maxAttempts:     4,
+			initialBackoff:  2 * time.Second,
+			maxBackoff:      30 * time.Second,
+			multiplier:      2,
+			retryableErrors: map[retryErrorClass]bool{retryBusyEntity: true, retryServerError: true},
+			overrides: map[string]*retryPolicy{
+				"vcloud_vapp_vm": {maxAttempts: 8, retryableErrors: map[retryErrorClass]bool{retryConnection: true}},
+				"vcloud_vm":      {maxAttempts: 2},
+			},
+		}
+	}
+	base := newPolicy().String()
+	if base != newPolicy().String() {
+		t.Errorf("expected the same representation for equal policies")
+	}
+	if (*retryPolicy)(nil).String() != "" || noRetryPolicy.String() == base {
+		t.Errorf("unexpected representation for the policies without retries")
+	}
+
+	changes := map[string]func(p *retryPolicy){
+		"max attempts":     func(p *retryPolicy) { p.maxAttempts = 5 },
+		"initial backoff":  func(p *retryPolicy) { p.initialBackoff = time.Second },
+		"max backoff":      func(p *retryPolicy) { p.maxBackoff = time.Minute },
+		"multiplier":       func(p *retryPolicy) { p.multiplier = 1.5 },
+		"retryable errors": func(p *retryPolicy) { p.retryableErrors[retryTaskConflict] = true },
+		"override":         func(p *retryPolicy) { p.overrides["vcloud_vapp_vm"].maxAttempts = 9 },
+		"new override":     func(p *retryPolicy) { p.overrides["vcloud_vapp"] = &retryPolicy{maxAttempts: 3} },
+	}
+	for name, change := range changes {
+		policy := newPolicy()
+		change(policy)
+		if policy.String() == base {
+			t.Errorf("expected a different representation after changing the %s", name)
+		}
+	}
+}
+
+// TestMockVcdRetryBusyEntity checks that the resources registered in the provider retry operations that
+// fail while the VM is busy
+func TestMockVcdRetryBusyEntity(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	ctx := context.Background()
+	resource := Provider().ResourcesMap["vcloud_vm_snapshot"]
+	raw := map[string]interface{}{
+		"vapp_name": "tf_vapp",
+		"vm_name":   "tf_vm1",
+	}
+	busyMessage := "The entity tf_vm1 is busy completing an operation VAPP_UPDATE_VM"
+
+	// Without a retry policy, the failure reaches the user
+	vcdClient.RetryPolicy = noRetryPolicy
+	mock.failNext(http.MethodPost, "/action/createSnapshot", http.StatusBadRequest, "BUSY_ENTITY", busyMessage, 1)
+	d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if !diags.HasError() {
+		t.Fatalf("expected a busy entity error without retry policy")
+	}
+
+	vcdClient.RetryPolicy = &retryPolicy{
+		maxAttempts:     3,
+		initialBackoff:  time.Millisecond,
+		maxBackoff:      time.Millisecond,
+		multiplier:      1,
+		retryableErrors: map[retryErrorClass]bool{retryBusyEntity: true},
+	}
+	mock.failNext(http.MethodPost, "/action/createSnapshot", http.StatusBadRequest, "BUSY_ENTITY", busyMessage, 2)
+	d = schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags = resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating VM snapshot with retry policy: %v", diags)
+	}
+
+	// A creation is never repeated after VCD accepted a change: a server error on the request that starts it
+	// reaches the user, while the same error on a request that only reads data is retried
+	vcdClient.RetryPolicy.retryableErrors[retryServerError] = true
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting VM snapshot: %v", diags)
+	}
+	mock.failNext(http.MethodPost, "/action/createSnapshot", http.StatusGatewayTimeout, "", "Gateway Timeout", 1)
+	d = schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags = resource.CreateContext(ctx, d, vcdClient)
+	if !diags.HasError() {
+		t.Fatalf("expected the server error of the creation request not to be retried")
+	}
+	mock.failNext(http.MethodGet, "/snapshotSection", http.StatusServiceUnavailable, "", "Service Unavailable", 2)
+	d = schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags = resource.CreateContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error creating VM snapshot with retried reads: %v", diags)
+	}
+
+	mock.failNext(http.MethodPost, "/action/removeAllSnapshots", http.StatusBadRequest, "BUSY_ENTITY", busyMessage, 3)
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if !diags.HasError() {
+		t.Fatalf("expected a busy entity error after the attempts are exhausted")
+	}
+	diags = resource.DeleteContext(ctx, d, vcdClient)
+	if diags.HasError() {
+		t.Fatalf("error deleting VM snapshot: %v", diags)
+	}
+}
+
+// Test_requestRetryClass checks which failed requests can be sent again
+func Test_requestRetryClass(t *testing.T) {
+	busyBody := `<Error minorErrorCode="BUSY_ENTITY" message="The entity vm1 is busy completing an operation"/>`
+	tests := []struct {
+		name   string
+		method string
+		status int
+		body   string
+		err    error
+		want   retryErrorClass
+	}{
+		{name: "success", method: http.MethodPost, status: http.StatusAccepted, want: ""},
+		{name: "busy entity on POST", method: http.MethodPost, status: http.StatusBadRequest, body: busyBody, want: retryBusyEntity},
+		{name: "server error on GET", method: http.MethodGet, status: http.StatusBadGateway, body: "Bad Gateway", want: retryServerError},
+		{name: "server error on POST", method: http.MethodPost, status: http.StatusGatewayTimeout, body: "Gateway Timeout", want: ""},
+		{name: "server error on PUT", method: http.MethodPut, status: http.StatusInternalServerError, body: "INTERNAL_SERVER_ERROR", want: ""},
+		{name: "not found", method: http.MethodGet, status: http.StatusNotFound, body: "RESOURCE_NOT_FOUND", want: ""},
+		{
+			name:   "connection refused on POST",
+			method: http.MethodPost,
+			err:    &net.OpError{Op: "dial", Net: "tcp", Err: fmt.Errorf("connect: connection refused")},
+			want:   retryConnection,
+		},
+		{
+			name:   "connection reset on POST",
+			method: http.MethodPost,
+			err:    &net.OpError{Op: "read", Net: "tcp", Err: fmt.Errorf("read: connection reset by peer")},
+			want:   "",
+		},
+		{
+			name:   "connection reset on GET",
+			method: http.MethodGet,
+			err:    &net.OpError{Op: "read", Net: "tcp", Err: fmt.Errorf("read: connection reset by peer")},
+			want:   retryConnection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request, err := http.NewRequest(tt.method, "https://vcd.example.com/api/vApp/vm-1", nil)
+			if err != nil {
+				t.Fatalf("error creating request: %s", err)
+			}
+			var response *http.Response
+			if tt.err == nil {
+				response = &http.Response{StatusCode: tt.status, Body: io.NopCloser(strings.NewReader(tt.body))}
+			}
+			got, _ := requestRetryClass(request, response, tt.err)
+			if got != tt.want {
+				t.Errorf("requestRetryClass() = '%s', want '%s'", got, tt.want)
+			}
+			if response != nil {
+				body, _ := io.ReadAll(response.Body)
+				if string(body) != tt.body {
+					t.Errorf("response body not restored: got '%s', want '%s'", body, tt.body)
+				}
+			}
+		})
+	}
+}
@@ -158,6 +158,43 @@ func normalizeId(prefix, id string) string {
 	return prefix + id
 }
 
+// importEntityId checks whether an import identifier refers to an entity by ID instead of by name path.
+// A URN is returned as it is, while an HREF or a bare UUID is converted to a URN using the given prefix.
+// An empty string is returned when the identifier is a name path
+func importEntityId(prefix, identifier string) string {
+	uuid := extractUuid(identifier)
+	switch {
+	case uuid == "":
+		return ""
+	case strings.HasPrefix(identifier, "urn:"):
+		return identifier
+	case identifier == uuid || strings.HasPrefix(identifier, "http"):
+		return normalizeId(prefix, uuid)
+	}
+	return ""
+}
+
+// splitImportEntityId splits an import identifier that starts with the ID of an entity (URN, HREF or bare UUID),
+// optionally followed by other names separated by ImportSeparator. The ID is returned as importEntityId does, with the
+// remaining names. An empty ID is returned when the identifier doesn't start with an ID
+func splitImportEntityId(prefix, identifier string) (string, []string) {
+	location := getUuidRegex("", "").FindStringIndex(identifier)
+	if location == nil {
+		return "", nil
+	}
+	id := importEntityId(prefix, identifier[:location[1]])
+	rest := identifier[location[1]:]
+	switch {
+	case id == "":
+		return "", nil
+	case rest == "":
+		return id, []string{}
+	case !strings.HasPrefix(rest, ImportSeparator):
+		return "", nil
+	}
+	return id, strings.Split(strings.TrimPrefix(rest, ImportSeparator), ImportSeparator)
+}
+
 // haveSameUuid compares two IDs (or HREF)
 // and returns true if the UUID part of the two input strings are the same.
 // This is useful when comparing a HREF to a ID, or a HREF from an admin path
@@ -366,3 +403,61 @@ func firstNonEmpty(args ...string) string {
 	}
 	return ""
 }
+
+// computedSchemaMap returns a copy of the given schema where all the fields, including the nested ones, are computed.
+// It is used to build the elements of the data sources that return a list of the objects of another data source.
+// Deprecated fields and the fields in skipFields, such as the search arguments of that data source, are not included
+func computedSchemaMap(input map[string]*schema.Schema, skipFields ...string) map[string]*schema.Schema {
+	output := make(map[string]*schema.Schema, len(input))
+	for key, value := range input {
+		if value.Deprecated != "" || contains(skipFields, key) {
+			continue
+		}
+		output[key] = computedSchema(value)
+	}
+	return output
+}
+
+// computedSchema returns a computed copy of a single schema field. All the properties that only apply to the
+// fields set in the configuration are removed
+func computedSchema(input *schema.Schema) *schema.Schema {
+	output := *input
+	output.Required = false
+	output.Optional = false
+	output.Computed = true
+	output.ForceNew = false
+	output.Default = nil
+	output.DefaultFunc = nil
+	output.StateFunc = nil
+	output.ValidateFunc = nil
+	output.ValidateDiagFunc = nil
+	output.DiffSuppressFunc = nil
+	output.DiffSuppressOnRefresh = false
+	output.ConflictsWith = nil
+	output.ExactlyOneOf = nil
+	output.AtLeastOneOf = nil
+	output.RequiredWith = nil
+	output.MinItems = 0
+	output.MaxItems = 0
+	output.ConfigMode = schema.SchemaConfigModeAuto
+
+	// The elements of lists, sets and maps of primitive types have only their type set, and are kept as they are
+	if elem, ok := input.Elem.(*schema.Resource); ok {
+		output.Elem = &schema.Resource{Schema: computedSchemaMap(elem.Schema)}
+	}
+	return &output
+}
+
+// resourceDataToMap returns the values of the given fields of a ResourceData as a map, which can be stored as an
+// element of a list of objects. The field "id" gets the ID of the ResourceData
+func resourceDataToMap(d *schema.ResourceData, fields map[string]*schema.Schema) map[string]interface{} {
+	result := make(map[string]interface{}, len(fields))
+	for key := range fields {
+		if key == "id" {
+			result[key] = d.Id()
+			continue
+		}
+		result[key] = d.Get(key)
+	}
+	return result
+}
@@ -3,7 +3,11 @@
 package vcloud
 
 import (
+	"reflect"
 	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 // Test_jsonToCompactString checks that an unmarshaled JSON is correctly converted into a compact string.
@@ -164,3 +168,122 @@ func Test_areMarshaledJsonEqual(t *testing.T) {
 		})
 	}
 }
+
+// Test_computedSchemaMap checks that the copy of a schema has only computed fields, and that the original is unchanged
+func Test_computedSchemaMap(t *testing.T) {
+	input := map[string]*schema.Schema{
+		"name": {
+			Type:         schema.TypeString,
+			Required:     true,
+			ValidateFunc: validation.StringIsNotEmpty,
+		},
+		"filter": {
+			Type:     schema.TypeString,
+			Optional: true,
+		},
+		"old_name": {
+			Type:       schema.TypeString,
+			Computed:   true,
+			Deprecated: "Use name instead",
+		},
+		"rule": {
+			Type:     schema.TypeList,
+			Optional: true,
+			MaxItems: 1,
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"port": {
+						Type:          schema.TypeInt,
+						Optional:      true,
+						Default:       80,
+						ConflictsWith: []string{"rule.0.ports"},
+					},
+					"ports": {
+						Type:     schema.TypeSet,
+						Optional: true,
+						Elem:     &schema.Schema{Type: schema.TypeString},
+					},
+				},
+			},
+		},
+	}
+
+	output := computedSchemaMap(input, "filter")
+	if len(output) != 2 || output["filter"] != nil || output["old_name"] != nil {
+		t.Fatalf("unexpected fields in computed schema: %v", output)
+	}
+	name := output["name"]
+	if name.Required || !name.Computed || name.ValidateFunc != nil {
+		t.Errorf("field 'name' is not computed only: %#v", name)
+	}
+	rule := output["rule"]
+	if rule.Optional || !rule.Computed || rule.MaxItems != 0 {
+		t.Errorf("field 'rule' is not computed only: %#v", rule)
+	}
+	port := rule.Elem.(*schema.Resource).Schema["port"]
+	if port.Optional || !port.Computed || port.Default != nil || port.ConflictsWith != nil {
+		t.Errorf("nested field 'port' is not computed only: %#v", port)
+	}
+	if ports := rule.Elem.(*schema.Resource).Schema["ports"]; ports.Elem.(*schema.Schema).Computed {
+		t.Errorf("the elements of nested field 'ports' must not be computed")
+	}
+	if !input["name"].Required || !input["rule"].Elem.(*schema.Resource).Schema["port"].Optional {
+		t.Errorf("the original schema was modified")
+	}
+}
+
+func Test_importEntityId(t *testing.T) {
+	uuid := "7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d"
+	tests := []struct {
+		name       string
+		identifier string
+		want       string
+	}{
+		{"URN", "urn:vcloud:vapp:" + uuid, "urn:vcloud:vapp:" + uuid},
+		{"bare UUID", uuid, "urn:vcloud:vapp:" + uuid},
+		{"HREF", "https://vcd.example.com/api/vApp/vapp-" + uuid, "urn:vcloud:vapp:" + uuid},
+		{"name path", "my-org.my-vdc.my-vapp", ""},
+		{"name path ending with a UUID", "my-org.my-vdc." + uuid, ""},
+		{"empty", "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := importEntityId("urn:vcloud:vapp:", tt.identifier)
+			if got != tt.want {
+				t.Errorf("importEntityId() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_splitImportEntityId(t *testing.T) {
+	uuid := "7a9b2d3e-1c4f-4b5e-9d6a-0e1f2a3b4c5d"
+	otherUuid := "5e3a1b2c-7d4f-4a6e-8b9c-0d1e2f3a4b5c"
+	tests := []struct {
+		name       string
+		identifier string
+		wantId     string
+		wantNames  []string
+	}{
+		{"URN", "urn:vcloud:gateway:" + uuid, "urn:vcloud:gateway:" + uuid, []string{}},
+		{"bare UUID", uuid, "urn:vcloud:gateway:" + uuid, []string{}},
+		{"URN and names", "urn:vcloud:gateway:" + uuid + ".my-rule", "urn:vcloud:gateway:" + uuid, []string{"my-rule"}},
+		{"bare UUID and names", uuid + ".ui-no.3", "urn:vcloud:gateway:" + uuid, []string{"ui-no", "3"}},
+		{"HREF and names", "https://vcd.example.com/api/admin/edgeGateway/" + uuid + ".my-rule",
+			"urn:vcloud:gateway:" + uuid, []string{"my-rule"}},
+		{"URN and entity ID", "urn:vcloud:gateway:" + uuid + ".urn:vcloud:firewallGroup:" + otherUuid,
+			"urn:vcloud:gateway:" + uuid, []string{"urn:vcloud:firewallGroup:" + otherUuid}},
+		{"name path", "my-org.my-vdc.my-edge", "", nil},
+		{"name path ending with a UUID", "my-org.my-vdc." + uuid, "", nil},
+		{"UUID followed by text", uuid + "-suffix", "", nil},
+		{"empty", "", "", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotId, gotNames := splitImportEntityId("urn:vcloud:gateway:", tt.identifier)
+			if gotId != tt.wantId || !reflect.DeepEqual(gotNames, tt.wantNames) {
+				t.Errorf("splitImportEntityId() = %s, %v, want %s, %v", gotId, gotNames, tt.wantId, tt.wantNames)
+			}
+		})
+	}
+}
@@ -34,6 +34,14 @@ func suppressNetworkUpgradedInterface() schema.SchemaDiffSuppressFunc {
 	}
 }
 
+// suppressUnreadableAfterImport suppresses the change of a property that VCD doesn't return, when an existing
+// resource has no value for it, as it happens after import. The property is only compared when both values are known
+func suppressUnreadableAfterImport() schema.SchemaDiffSuppressFunc {
+	return func(k string, old string, new string, d *schema.ResourceData) bool {
+		return old == "" && d.Id() != ""
+	}
+}
+
 // falseBoolSuppress suppresses change if value is set to false or is empty
 func falseBoolSuppress() schema.SchemaDiffSuppressFunc {
 	return func(k string, old string, new string, d *schema.ResourceData) bool {
@@ -0,0 +1,86 @@
+package vcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/vmware/go-vcloud-director/v2/govcd"
+)
+
+// taskPollingInterval is how often waitForTask checks the status of a task. It is the same interval
+// used by Task.WaitTaskCompletion
+var taskPollingInterval = 3 * time.Second
+
+// waitForTask waits for a task to complete, like Task.WaitTaskCompletion, but it also stops when the
+// context is done. This happens when the operation exceeds the value set in the 'timeouts' block of the
+// resource. In that case the task is cancelled in VCD, so that it doesn't keep on changing the entity
+// after Terraform has given up on it
+func waitForTask(ctx context.Context, task govcd.Task) error {
+	if task.Task == nil {
+		return fmt.Errorf("cannot wait for an empty task")
+	}
+	start := time.Now()
+	for {
+		err := task.Refresh()
+		if err != nil {
+			return fmt.Errorf("error retrieving task: %s", err)
+		}
+		switch task.Task.Status {
+		case "success":
+			return nil
+		case "aborted", "canceled":
+			return fmt.Errorf("task '%s' (%s) was %s", task.Task.OperationName, task.Task.ID, task.Task.Status)
+		case "error":
+			message := ""
+			if task.Task.Error != nil {
+				message = task.Task.Error.Error()
+			}
+			return fmt.Errorf("task did not complete successfully: %s", message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return cancelTimedOutTask(ctx, task, time.Since(start))
+		case <-time.After(taskPollingInterval):
+		}
+	}
+}
+
+// cancelTimedOutTask cancels a task that was still running when the context expired and returns
+// the error that reports it
+func cancelTimedOutTask(ctx context.Context, task govcd.Task, elapsed time.Duration) error {
+	reason := "the operation was interrupted"
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		reason = "the operation timed out (see the 'timeouts' block of the resource)"
+	}
+	taskDescription := fmt.Sprintf("task '%s' (%s)", task.Task.OperationName, task.Task.ID)
+
+	log.Printf("[WARN] %s: %s after %s. Cancelling it", taskDescription, reason, elapsed.Round(time.Second))
+	err := task.CancelTask()
+	if err != nil {
+		return fmt.Errorf("%s: %s after %s and the task could not be cancelled: %s",
+			taskDescription, reason, elapsed.Round(time.Second), err)
+	}
+	return fmt.Errorf("%s: %s after %s. The task was cancelled", taskDescription, reason, elapsed.Round(time.Second))
+}
+
+// timeoutFromContext returns the time left before the deadline of the context, capped by the given
+// limit. A zero limit means that only the context deadline applies. The result is never lower than one
+// second, as some SDK functions interpret a zero timeout as "wait forever"
+func timeoutFromContext(ctx context.Context, limit time.Duration) time.Duration {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return limit
+	}
+	left := time.Until(deadline)
+	if left < time.Second {
+		left = time.Second
+	}
+	if limit > 0 && limit < left {
+		return limit
+	}
+	return left
+}
@@ -0,0 +1,84 @@
+//go:build unit || ALL
+
+package vcloud
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// Test_timeoutFromContext checks how the timeout of an operation is limited by the context deadline
+func Test_timeoutFromContext(t *testing.T) {
+	if got := timeoutFromContext(context.Background(), time.Hour); got != time.Hour {
+		t.Errorf("without deadline: got %s, want %s", got, time.Hour)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+	defer cancel()
+	if got := timeoutFromContext(ctx, time.Hour); got > 10*time.Minute || got < 9*time.Minute {
+		t.Errorf("limit above deadline: got %s, want about %s", got, 10*time.Minute)
+	}
+	if got := timeoutFromContext(ctx, time.Minute); got != time.Minute {
+		t.Errorf("limit below deadline: got %s, want %s", got, time.Minute)
+	}
+	if got := timeoutFromContext(ctx, 0); got > 10*time.Minute || got < 9*time.Minute {
+		t.Errorf("no limit: got %s, want about %s", got, 10*time.Minute)
+	}
+
+	expired, cancelExpired := context.WithTimeout(context.Background(), -time.Minute)
+	defer cancelExpired()
+	if got := timeoutFromContext(expired, 0); got != time.Second {
+		t.Errorf("expired deadline: got %s, want %s", got, time.Second)
+	}
+}
+
+// TestMockVcdTaskTimeout checks that a resource gives up on a task when its timeout expires, and that the
+// task is cancelled in VCD
+func TestMockVcdTaskTimeout(t *testing.T) {
+	mock, vcdClient := mockVcdTestClient(t)
+	vcdClient.RetryPolicy = noRetryPolicy
+
+	previousInterval := taskPollingInterval
+	taskPollingInterval = 10 * time.Millisecond
+	defer func() { taskPollingInterval = previousInterval }()
+
+	resource := Provider().ResourcesMap["vcloud_vm_snapshot"]
+	raw := map[string]interface{}{
+		"vapp_name": "tf_vapp",
+		"vm_name":   "tf_vm1",
+	}
+
+	mock.Lock()
+	mock.holdTasks = true
+	mock.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	d := schema.TestResourceDataRaw(t, resource.Schema, raw)
+	diags := resource.CreateContext(ctx, d, vcdClient)
+	if !diags.HasError() {
+		t.Fatalf("expected a timeout error")
+	}
+	if !strings.Contains(diags[0].Summary, "timed out") || !strings.Contains(diags[0].Summary, "was cancelled") {
+		t.Errorf("unexpected error: %s", diags[0].Summary)
+	}
+	if d.Id() != "" {
+		t.Errorf("expected no ID after a timed out creation, got '%s'", d.Id())
+	}
+
+	mock.Lock()
+	defer mock.Unlock()
+	aborted := 0
+	for _, task := range mock.tasks {
+		if task.OperationName == "vmCreateSnapshot" && task.Status == "aborted" {
+			aborted++
+		}
+	}
+	if aborted != 1 {
+		t.Errorf("expected the snapshot task to be cancelled, found %d aborted tasks", aborted)
+	}
+}